@@ -11,6 +11,8 @@ import (
 	"strconv"
 	"testing"
 
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/fakearm"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/recording"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/features"
 )
 
@@ -55,6 +57,12 @@ type TestData struct {
 
 	// resourceLabel is the local used for the resource - generally "test""
 	resourceLabel string
+
+	// rand is the source of random values when the Test is being recorded or replayed, and nil otherwise
+	rand *rand.Rand
+
+	// fakeResourceManager is the Fake Resource Manager this Test is running against, if any
+	fakeResourceManager *fakearm.Server
 }
 
 // BuildTestData generates some test data for the given resource
@@ -85,6 +93,10 @@ func BuildTestData(t *testing.T, resourceType string, resourceLabel string) Test
 		Secondary: os.Getenv("ARM_TEST_SUBSCRIPTION_ID_ALT"),
 	}
 
+	if recording.Enabled() {
+		testData.useCassette(t)
+	}
+
 	return testData
 }
 
@@ -123,7 +135,21 @@ func (td *TestData) RandomStringOfLength(len int) string {
 		panic("Invalid Test: RandomStringOfLength: length argument must be between 1 and 1024 characters")
 	}
 
-	return randString(len)
+	return td.randString(len)
+}
+
+// randString generates a random alphanumeric string of the length specified, using
+// the Cassette's source of random values when the Test is being recorded or replayed
+func (td *TestData) randString(strlen int) string {
+	if td.rand == nil {
+		return randString(strlen)
+	}
+
+	result := make([]byte, strlen)
+	for i := 0; i < strlen; i++ {
+		result[i] = charSetAlphaNum[td.rand.Intn(len(charSetAlphaNum))]
+	}
+	return string(result)
 }
 
 // randString generates a random alphanumeric string of the length specified
@@ -0,0 +1,60 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package acceptance
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/fakearm"
+)
+
+// UseFakeResourceManager starts a Fake Resource Manager for the current Test and configures the Provider
+// (and the Test Client used to check the Resources) to send requests to it rather than to Azure - which is
+// useful to exercise a Resource's Create/Read/Update/Delete functions without an Azure Subscription.
+//
+// The Fake Resource Manager only supports the generic Resource Manager operations, as such this should be
+// called right after BuildTestData and is only suitable for Resources which don't depend on data-plane APIs.
+func (td *TestData) UseFakeResourceManager(t *testing.T, options fakearm.Options) *fakearm.Server {
+	if td.fakeResourceManager != nil {
+		t.Fatalf("a Fake Resource Manager is already configured for %q", t.Name())
+		return nil
+	}
+
+	server := fakearm.New(options)
+	t.Cleanup(server.Close)
+
+	td.fakeResourceManager = server
+	td.MetadataURL = server.MetadataHost()
+	td.Subscriptions = Subscriptions{
+		Primary:   fakearm.SubscriptionId,
+		Secondary: fakearm.SubscriptionId,
+	}
+
+	// any Azure Region can be used, since the Server doesn't validate these
+	if td.Locations.Primary == "" {
+		td.Locations = Regions{
+			Primary:   "westeurope",
+			Secondary: "northeurope",
+			Ternary:   "eastus2",
+		}
+	}
+
+	// the Provider is configured from the Environment, which needs to point to the Server
+	variables := map[string]string{
+		"ARM_CLIENT_ID":                  fakearm.ClientId,
+		"ARM_CLIENT_SECRET":              "fake",
+		"ARM_METADATA_HOSTNAME":          server.MetadataHost(),
+		"ARM_SKIP_PROVIDER_REGISTRATION": "true",
+		"ARM_SUBSCRIPTION_ID":            fakearm.SubscriptionId,
+		"ARM_TENANT_ID":                  fakearm.TenantId,
+		"ARM_TEST_LOCATION":              td.Locations.Primary,
+		"ARM_TEST_LOCATION_ALT":          td.Locations.Secondary,
+		"ARM_TEST_LOCATION_ALT2":         td.Locations.Ternary,
+	}
+	for key, value := range variables {
+		t.Setenv(key, value)
+	}
+
+	return server
+}
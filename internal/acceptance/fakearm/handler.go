@@ -0,0 +1,438 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package fakearm
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type operation struct {
+	id        string
+	remaining int
+	done      bool
+
+	// complete is called (whilst the Server is locked) once the operation has completed
+	complete func()
+}
+
+// poll returns whether the operation has completed, completing it once it's been polled enough times
+func (o *operation) poll() bool {
+	if o.done {
+		return true
+	}
+	if o.remaining > 0 {
+		o.remaining--
+		return false
+	}
+
+	o.done = true
+	o.complete()
+	return true
+}
+
+// newOperation registers a long-running operation, the caller must hold the lock
+func (s *Server) newOperation(complete func()) *operation {
+	s.nextOperationId++
+	op := &operation{
+		id:        strconv.Itoa(s.nextOperationId),
+		remaining: s.options.PollsUntilComplete,
+		complete:  complete,
+	}
+	s.operations[op.id] = op
+	return op
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	segments := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+
+	switch {
+	case r.Method == http.MethodGet && strings.EqualFold(r.URL.Path, "/metadata/endpoints"):
+		s.metadata(w)
+		return
+
+	case r.Method == http.MethodGet && len(segments) == 2 && segments[0] == "operations":
+		s.operationStatus(w, segments[1])
+		return
+
+	case r.Method == http.MethodGet && len(segments) == 2 && segments[0] == "operationResults":
+		s.operationResult(w, segments[1])
+		return
+	}
+
+	// all Resource Manager operations require the `api-version` querystring
+	if r.URL.Query().Get("api-version") == "" {
+		writeError(w, http.StatusBadRequest, "MissingApiVersionParameter", "The api-version query parameter (?api-version=) is required for all requests.")
+		return
+	}
+
+	path := parseResourcePath(r.URL.Path)
+	switch r.Method {
+	case http.MethodGet:
+		s.get(w, path)
+	case http.MethodHead:
+		s.head(w, path)
+	case http.MethodPut:
+		s.put(w, r, path)
+	case http.MethodPatch:
+		s.patch(w, r, path)
+	case http.MethodDelete:
+		s.delete(w, r, path)
+	case http.MethodPost:
+		s.post(w, r, path)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "MethodNotAllowed", fmt.Sprintf("The method %q is not supported.", r.Method))
+	}
+}
+
+func (s *Server) get(w http.ResponseWriter, path resourcePath) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if existing, ok := s.resources[path.key()]; ok {
+		if existing.deletion != nil && existing.deletion.poll() {
+			s.notFound(w, path)
+			return
+		}
+		writeJson(w, http.StatusOK, existing.body)
+		return
+	}
+
+	if path.isCollection {
+		s.list(w, path)
+		return
+	}
+
+	// Subscriptions always exist
+	if len(path.segments) == 2 && strings.EqualFold(path.segments[0], "subscriptions") {
+		writeJson(w, http.StatusOK, map[string]interface{}{
+			"id":             path.id,
+			"subscriptionId": path.segments[1],
+			"displayName":    "Fake Resource Manager",
+			"state":          "Enabled",
+			"tenantId":       TenantId,
+		})
+		return
+	}
+
+	s.notFound(w, path)
+}
+
+func (s *Server) head(w http.ResponseWriter, path resourcePath) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if _, ok := s.resources[path.key()]; ok {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	w.WriteHeader(http.StatusNotFound)
+}
+
+func (s *Server) list(w http.ResponseWriter, path resourcePath) {
+	parentKey := path.parent().key()
+
+	// `{resourceGroup}/resources` lists all the top-level Resources within the Resource Group
+	listAll := path.parent().isResourceGroup() && strings.EqualFold(path.name(), "resources")
+
+	values := make([]interface{}, 0)
+	keys := make([]string, 0)
+	for key, existing := range s.resources {
+		if existing.path.parent().key() != parentKey {
+			continue
+		}
+		if !listAll && !strings.EqualFold(existing.path.resourceType(), path.resourceType()) {
+			continue
+		}
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		values = append(values, s.resources[key].body)
+	}
+
+	writeJson(w, http.StatusOK, map[string]interface{}{
+		"value": values,
+	})
+}
+
+func (s *Server) put(w http.ResponseWriter, r *http.Request, path resourcePath) {
+	if path.isCollection || len(path.segments) < 2 {
+		writeError(w, http.StatusBadRequest, "InvalidResourceId", fmt.Sprintf("The Resource ID %q is invalid.", path.id))
+		return
+	}
+
+	body, ok := readBody(w, r)
+	if !ok {
+		return
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if !s.checkParent(w, path) {
+		return
+	}
+
+	statusCode := http.StatusCreated
+	provisioningState := "Creating"
+	existing, exists := s.resources[path.key()]
+	if exists {
+		if existing.deletion != nil && !existing.deletion.done {
+			writeError(w, http.StatusConflict, "AnotherOperationInProgress", fmt.Sprintf("The Resource %q is being deleted.", path.id))
+			return
+		}
+
+		// the casing of the ID is retained from when the Resource was created
+		path.id = existing.path.id
+		statusCode = http.StatusOK
+		provisioningState = "Updating"
+	}
+
+	s.write(w, r, path, body, statusCode, provisioningState)
+}
+
+func (s *Server) patch(w http.ResponseWriter, r *http.Request, path resourcePath) {
+	body, ok := readBody(w, r)
+	if !ok {
+		return
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	existing, exists := s.resources[path.key()]
+	if !exists || (existing.deletion != nil && existing.deletion.done) {
+		s.notFound(w, path)
+		return
+	}
+
+	s.write(w, r, existing.path, mergePatch(existing.body, body), http.StatusOK, "Updating")
+}
+
+// write stores the Resource and returns it along with the long-running operation headers, the caller must hold the lock
+func (s *Server) write(w http.ResponseWriter, r *http.Request, path resourcePath, body map[string]interface{}, statusCode int, provisioningState string) {
+	if s.options.PollsUntilComplete == 0 {
+		provisioningState = "Succeeded"
+	} else {
+		// Swagger only documents a 202 for long-running operations, so the generated SDKs reject a 201 here
+		statusCode = http.StatusAccepted
+	}
+
+	stored := &resource{
+		path: path,
+		body: withResourceFields(path, body, provisioningState),
+	}
+	s.resources[path.key()] = stored
+
+	op := s.newOperation(func() {
+		stored.body = withResourceFields(path, stored.body, "Succeeded")
+	})
+
+	// since the Resource is returned the provisioningState could be polled instead, however an explicit
+	// operation allows the Retry-After header to be honoured (meaning polling completes immediately)
+	w.Header().Set("Azure-AsyncOperation", fmt.Sprintf("%s/operations/%s?api-version=%s", s.server.URL, op.id, r.URL.Query().Get("api-version")))
+	w.Header().Set("Retry-After", "0")
+	writeJson(w, statusCode, stored.body)
+}
+
+func (s *Server) delete(w http.ResponseWriter, r *http.Request, path resourcePath) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	existing, exists := s.resources[path.key()]
+	if !exists {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	if existing.deletion != nil {
+		w.WriteHeader(http.StatusAccepted)
+		return
+	}
+
+	remove := func() {
+		// removing a Resource also removes any Resources nested within it
+		prefix := path.key() + "/"
+		for key := range s.resources {
+			if key == path.key() || strings.HasPrefix(key, prefix) {
+				delete(s.resources, key)
+			}
+		}
+	}
+
+	if s.options.PollsUntilComplete == 0 {
+		remove()
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	existing.deletion = s.newOperation(remove)
+	existing.body = withResourceFields(existing.path, existing.body, "Deleting")
+
+	w.Header().Set("Location", fmt.Sprintf("%s/operationResults/%s?api-version=%s", s.server.URL, existing.deletion.id, r.URL.Query().Get("api-version")))
+	w.Header().Set("Retry-After", "0")
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func (s *Server) post(w http.ResponseWriter, r *http.Request, path resourcePath) {
+	s.lock.Lock()
+	handler, ok := s.actions[strings.ToLower(path.name())]
+	s.lock.Unlock()
+
+	if !ok {
+		writeError(w, http.StatusBadRequest, "UnsupportedAction", fmt.Sprintf("The action %q is not supported by the Fake Resource Manager - use `HandleAction` to register a handler.", path.name()))
+		return
+	}
+	handler(w, r)
+}
+
+func (s *Server) operationStatus(w http.ResponseWriter, id string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	op, ok := s.operations[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, "OperationNotFound", fmt.Sprintf("The operation %q was not found.", id))
+		return
+	}
+
+	status := "InProgress"
+	if op.poll() {
+		status = "Succeeded"
+	}
+
+	w.Header().Set("Retry-After", "0")
+	writeJson(w, http.StatusOK, map[string]interface{}{
+		"name":   id,
+		"status": status,
+	})
+}
+
+func (s *Server) operationResult(w http.ResponseWriter, id string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	op, ok := s.operations[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, "OperationNotFound", fmt.Sprintf("The operation %q was not found.", id))
+		return
+	}
+
+	if !op.poll() {
+		w.Header().Set("Location", fmt.Sprintf("%s/operationResults/%s", s.server.URL, id))
+		w.Header().Set("Retry-After", "0")
+		w.WriteHeader(http.StatusAccepted)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) metadata(w http.ResponseWriter) {
+	writeJson(w, http.StatusOK, map[string]interface{}{
+		"name":            "FakeResourceManager",
+		"resourceManager": s.server.URL,
+		"authentication": map[string]interface{}{
+			"loginEndpoint": "https://login.microsoftonline.com",
+			"audiences":     []string{s.server.URL},
+			"tenant":        "common",
+		},
+		"microsoftGraphResourceId": "https://graph.microsoft.com/",
+		"suffixes":                 map[string]interface{}{},
+	})
+}
+
+// checkParent ensures that the Resource Group (or parent Resource) exists, the caller must hold the lock
+func (s *Server) checkParent(w http.ResponseWriter, path resourcePath) bool {
+	if !path.requiresParent() {
+		return true
+	}
+
+	parent := path.parent()
+	if existing, ok := s.resources[parent.key()]; ok && existing.deletion == nil {
+		return true
+	}
+
+	if parent.isResourceGroup() {
+		writeError(w, http.StatusNotFound, "ResourceGroupNotFound", fmt.Sprintf("Resource group '%s' could not be found.", parent.name()))
+		return false
+	}
+	writeError(w, http.StatusNotFound, "ParentResourceNotFound", fmt.Sprintf("Can not perform requested operation on nested resource. Parent resource '%s' not found.", parent.id))
+	return false
+}
+
+// notFound returns the 404 for the Resource, the caller must hold the lock
+func (s *Server) notFound(w http.ResponseWriter, path resourcePath) {
+	if path.isResourceGroup() {
+		writeError(w, http.StatusNotFound, "ResourceGroupNotFound", fmt.Sprintf("Resource group '%s' could not be found.", path.name()))
+		return
+	}
+
+	if path.requiresParent() {
+		resourceGroup := parseResourcePath(strings.Join(path.segments[0:4], "/"))
+		if _, ok := s.resources[resourceGroup.key()]; !ok {
+			writeError(w, http.StatusNotFound, "ResourceGroupNotFound", fmt.Sprintf("Resource group '%s' could not be found.", resourceGroup.name()))
+			return
+		}
+	}
+
+	writeError(w, http.StatusNotFound, "ResourceNotFound", fmt.Sprintf("The Resource '%s' under resource group was not found.", path.resourceType()+"/"+path.name()))
+}
+
+func readBody(w http.ResponseWriter, r *http.Request) (map[string]interface{}, bool) {
+	contents, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "InvalidRequestContent", fmt.Sprintf("reading request body: %+v", err))
+		return nil, false
+	}
+
+	body := map[string]interface{}{}
+	if len(contents) == 0 {
+		return body, true
+	}
+	if err := json.Unmarshal(contents, &body); err != nil {
+		writeError(w, http.StatusBadRequest, "InvalidRequestContent", fmt.Sprintf("The request content was invalid and could not be deserialized: %+v", err))
+		return nil, false
+	}
+	return body, true
+}
+
+// mergePatch applies the patch to the input as a JSON Merge Patch (RFC 7396)
+func mergePatch(input map[string]interface{}, patch map[string]interface{}) map[string]interface{} {
+	output := deepCopy(input)
+	for key, value := range patch {
+		if value == nil {
+			delete(output, key)
+			continue
+		}
+
+		if patchMap, ok := value.(map[string]interface{}); ok {
+			if existing, ok := output[key].(map[string]interface{}); ok {
+				output[key] = mergePatch(existing, patchMap)
+				continue
+			}
+		}
+		output[key] = value
+	}
+	return output
+}
+
+func writeError(w http.ResponseWriter, statusCode int, code, message string) {
+	writeJson(w, statusCode, map[string]interface{}{
+		"error": map[string]interface{}{
+			"code":    code,
+			"message": message,
+		},
+	})
+}
+
+func writeJson(w http.ResponseWriter, statusCode int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(statusCode)
+	_ = json.NewEncoder(w).Encode(body)
+}
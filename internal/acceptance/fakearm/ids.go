@@ -0,0 +1,113 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package fakearm
+
+import (
+	"strings"
+)
+
+// resourcePath describes a Resource Manager URI, which either refers to a Resource or a collection of Resources
+type resourcePath struct {
+	// id is the path with any leading/trailing slashes normalized, in the casing it was requested
+	id string
+
+	segments []string
+
+	// isCollection is true when the path ends with a Resource Type rather than a Resource Name
+	isCollection bool
+
+	// namespace is the Resource Provider Namespace (e.g. `Microsoft.Web`), which is empty for Resource Groups
+	namespace string
+
+	// types are the Resource Types within the namespace (e.g. `sites` and `config`)
+	types []string
+}
+
+func parseResourcePath(path string) resourcePath {
+	trimmed := strings.Trim(path, "/")
+	segments := make([]string, 0)
+	if trimmed != "" {
+		segments = strings.Split(trimmed, "/")
+	}
+
+	result := resourcePath{
+		id:       "/" + trimmed,
+		segments: segments,
+		types:    make([]string, 0),
+	}
+
+	expectName := false
+	for i := 0; i < len(segments); i++ {
+		// `providers/{namespace}` resets the types, which also handles extension resources scoped to another resource
+		if !expectName && strings.EqualFold(segments[i], "providers") && i+1 < len(segments) {
+			result.namespace = segments[i+1]
+			result.types = make([]string, 0)
+			i++
+			continue
+		}
+
+		if !expectName {
+			result.types = append(result.types, segments[i])
+		}
+		expectName = !expectName
+	}
+	result.isCollection = expectName
+
+	return result
+}
+
+// key returns the case-insensitive key used to store this Resource
+func (p resourcePath) key() string {
+	return strings.ToLower(p.id)
+}
+
+// name returns the name of this Resource
+func (p resourcePath) name() string {
+	if len(p.segments) == 0 {
+		return ""
+	}
+	return p.segments[len(p.segments)-1]
+}
+
+// resourceType returns the fully qualified Resource Type, e.g. `Microsoft.Web/sites/config`
+func (p resourcePath) resourceType() string {
+	if p.namespace != "" {
+		return p.namespace + "/" + strings.Join(p.types, "/")
+	}
+
+	// Subscriptions and Resource Groups are exposed by `Microsoft.Resources`
+	types := p.types
+	if len(types) > 1 && strings.EqualFold(types[0], "subscriptions") {
+		types = types[1:]
+	}
+	return "Microsoft.Resources/" + strings.Join(types, "/")
+}
+
+// parent returns the path to the Resource (or scope) this Resource is nested within
+func (p resourcePath) parent() resourcePath {
+	segments := p.segments
+	if p.isCollection {
+		segments = segments[:len(segments)-1]
+	} else if len(segments) >= 2 {
+		segments = segments[:len(segments)-2]
+	}
+
+	// e.g. `{scope}/providers/Microsoft.Web/sites/{name}` is nested within `{scope}`
+	if len(segments) >= 2 && strings.EqualFold(segments[len(segments)-2], "providers") {
+		segments = segments[:len(segments)-2]
+	}
+
+	return parseResourcePath(strings.Join(segments, "/"))
+}
+
+// requiresParent returns whether the parent of this Resource must exist - which is the case for everything within a Resource Group
+func (p resourcePath) requiresParent() bool {
+	parent := p.parent()
+	return len(parent.segments) >= 4 && strings.EqualFold(parent.segments[0], "subscriptions") && strings.EqualFold(parent.segments[2], "resourceGroups")
+}
+
+// isResourceGroup returns whether this path refers to a Resource Group
+func (p resourcePath) isResourceGroup() bool {
+	return !p.isCollection && len(p.segments) == 4 && strings.EqualFold(p.segments[0], "subscriptions") && strings.EqualFold(p.segments[2], "resourceGroups")
+}
@@ -0,0 +1,101 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package fakearm
+
+import (
+	"testing"
+)
+
+func TestParseResourcePath(t *testing.T) {
+	testData := []struct {
+		Input          string
+		IsCollection   bool
+		Name           string
+		ResourceType   string
+		Parent         string
+		RequiresParent bool
+		ResourceGroup  bool
+	}{
+		{
+			Input:        "/subscriptions/00000000-0000-0000-0000-000000000000",
+			Name:         "00000000-0000-0000-0000-000000000000",
+			ResourceType: "Microsoft.Resources/subscriptions",
+			Parent:       "/",
+		},
+		{
+			Input:         "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example",
+			Name:          "example",
+			ResourceType:  "Microsoft.Resources/resourceGroups",
+			Parent:        "/subscriptions/00000000-0000-0000-0000-000000000000",
+			ResourceGroup: true,
+		},
+		{
+			Input:        "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/",
+			IsCollection: true,
+			Name:         "resourceGroups",
+			ResourceType: "Microsoft.Resources/resourceGroups",
+			Parent:       "/subscriptions/00000000-0000-0000-0000-000000000000",
+		},
+		{
+			Input:          "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example/providers/Microsoft.Web/sites/site1",
+			Name:           "site1",
+			ResourceType:   "Microsoft.Web/sites",
+			Parent:         "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example",
+			RequiresParent: true,
+		},
+		{
+			Input:          "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example/providers/Microsoft.Web/sites/site1/config/web",
+			Name:           "web",
+			ResourceType:   "Microsoft.Web/sites/config",
+			Parent:         "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example/providers/Microsoft.Web/sites/site1",
+			RequiresParent: true,
+		},
+		{
+			Input:          "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example/providers/Microsoft.Web/sites",
+			IsCollection:   true,
+			Name:           "sites",
+			ResourceType:   "Microsoft.Web/sites",
+			Parent:         "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example",
+			RequiresParent: true,
+		},
+		{
+			// extension resources are nested within the Resource they're scoped to
+			Input:          "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example/providers/Microsoft.Web/sites/site1/providers/Microsoft.Insights/diagnosticSettings/diag1",
+			Name:           "diag1",
+			ResourceType:   "Microsoft.Insights/diagnosticSettings",
+			Parent:         "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example/providers/Microsoft.Web/sites/site1",
+			RequiresParent: true,
+		},
+		{
+			Input:        "/subscriptions/00000000-0000-0000-0000-000000000000/providers/Microsoft.Authorization/roleDefinitions/def1",
+			Name:         "def1",
+			ResourceType: "Microsoft.Authorization/roleDefinitions",
+			Parent:       "/subscriptions/00000000-0000-0000-0000-000000000000",
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Input)
+
+		actual := parseResourcePath(v.Input)
+		if actual.isCollection != v.IsCollection {
+			t.Fatalf("expected isCollection to be %t but got %t", v.IsCollection, actual.isCollection)
+		}
+		if actual.name() != v.Name {
+			t.Fatalf("expected name to be %q but got %q", v.Name, actual.name())
+		}
+		if actual.resourceType() != v.ResourceType {
+			t.Fatalf("expected resourceType to be %q but got %q", v.ResourceType, actual.resourceType())
+		}
+		if parent := actual.parent().id; parent != v.Parent {
+			t.Fatalf("expected parent to be %q but got %q", v.Parent, parent)
+		}
+		if actual.requiresParent() != v.RequiresParent {
+			t.Fatalf("expected requiresParent to be %t but got %t", v.RequiresParent, actual.requiresParent())
+		}
+		if actual.isResourceGroup() != v.ResourceGroup {
+			t.Fatalf("expected isResourceGroup to be %t but got %t", v.ResourceGroup, actual.isResourceGroup())
+		}
+	}
+}
@@ -0,0 +1,49 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package fakearm
+
+import (
+	"net/http"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/hashicorp/go-azure-sdk/sdk/client"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/common"
+)
+
+// Recorder returns the HTTP Recorder which should be used to build Clients for this Server, which
+// ensures that no authentication takes place (since the requests are served by this Server)
+func (s *Server) Recorder() common.HTTPRecorder {
+	return offlineRecorder{}
+}
+
+var _ common.HTTPRecorder = offlineRecorder{}
+
+// offlineRecorder doesn't need to redirect any requests, since the Environment for the Server already points to it
+type offlineRecorder struct{}
+
+func (offlineRecorder) RequestMiddleware() client.RequestMiddleware {
+	return func(req *http.Request) (*http.Request, error) {
+		return req, nil
+	}
+}
+
+func (offlineRecorder) ResponseMiddleware() client.ResponseMiddleware {
+	return func(_ *http.Request, resp *http.Response) (*http.Response, error) {
+		return resp, nil
+	}
+}
+
+func (offlineRecorder) WrapSender(sender autorest.Sender) autorest.Sender {
+	return sender
+}
+
+func (offlineRecorder) Offline() bool {
+	return true
+}
+
+func (offlineRecorder) OfflineIdentity() (clientId, objectId, tenantId string) {
+	return ClientId, ObjectId, TenantId
+}
+
+func (offlineRecorder) SanitizeIdentity(_, _, _ string) {}
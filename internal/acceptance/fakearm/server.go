@@ -0,0 +1,191 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package fakearm
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/go-azure-sdk/sdk/environments"
+)
+
+const (
+	// SubscriptionId is the Subscription ID which is exposed to the Provider when using the Fake Resource Manager,
+	// however Resources can be created within any Subscription
+	SubscriptionId = "00000000-0000-0000-0000-000000000000"
+
+	TenantId = "00000000-0000-0000-0000-000000000001"
+	ClientId = "00000000-0000-0000-0000-000000000002"
+	ObjectId = "00000000-0000-0000-0000-000000000003"
+)
+
+type Options struct {
+	// PollsUntilComplete is the number of times that each long-running operation must be polled before it
+	// completes, during which time the `provisioningState` of the Resource is `Creating`/`Updating`/`Deleting`
+	// and the initial response is a `202 Accepted`. Defaults to `0`, where operations complete immediately - which
+	// is required for the (synchronous) Resource Group and Subscription APIs.
+	PollsUntilComplete int
+}
+
+// Server is an in-process stand-in for the Azure Resource Manager API, which supports the generic PUT, GET,
+// PATCH and DELETE operations on Resource IDs - allowing Resources to be exercised without access to Azure.
+type Server struct {
+	options Options
+	server  *httptest.Server
+
+	lock            sync.Mutex
+	resources       map[string]*resource
+	operations      map[string]*operation
+	actions         map[string]http.HandlerFunc
+	nextOperationId int
+}
+
+type resource struct {
+	path resourcePath
+	body map[string]interface{}
+
+	// deletion is the in-progress operation deleting this Resource, if any
+	deletion *operation
+}
+
+var (
+	serversLock sync.Mutex
+
+	// servers is a map of the Metadata Host to the running Server, allowing the Provider to be pointed at a Server
+	servers = map[string]*Server{}
+)
+
+// New starts a new Fake Resource Manager, which must be closed once it's no longer needed
+func New(options Options) *Server {
+	s := &Server{
+		options:    options,
+		resources:  map[string]*resource{},
+		operations: map[string]*operation{},
+		actions:    map[string]http.HandlerFunc{},
+	}
+	s.server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+
+	serversLock.Lock()
+	servers[strings.ToLower(s.MetadataHost())] = s
+	serversLock.Unlock()
+
+	return s
+}
+
+// ForMetadataHost returns the running Server for the specified Metadata Host, or nil if there isn't one
+func ForMetadataHost(metadataHost string) *Server {
+	if metadataHost == "" {
+		return nil
+	}
+
+	serversLock.Lock()
+	defer serversLock.Unlock()
+	return servers[strings.ToLower(metadataHost)]
+}
+
+// Close shuts down this Server
+func (s *Server) Close() {
+	serversLock.Lock()
+	delete(servers, strings.ToLower(s.MetadataHost()))
+	serversLock.Unlock()
+
+	s.server.Close()
+}
+
+// URL returns the Resource Manager endpoint for this Server, e.g. `http://127.0.0.1:1234`
+func (s *Server) URL() string {
+	return s.server.URL
+}
+
+// MetadataHost returns the host (and port) for this Server, which can be used as the Provider's `metadata_host`
+func (s *Server) MetadataHost() string {
+	u, err := url.Parse(s.server.URL)
+	if err != nil {
+		return ""
+	}
+	return u.Host
+}
+
+// Environment returns the Azure Environment which sends Resource Manager requests to this Server
+func (s *Server) Environment() *environments.Environment {
+	env := environments.AzurePublic()
+	env.Name = "FakeResourceManager"
+	env.ResourceManager = environments.ResourceManagerAPI(s.server.URL)
+	return env
+}
+
+// Seed creates (or replaces) the Resource with the specified ID, for example to test importing an existing Resource
+func (s *Server) Seed(id string, body map[string]interface{}) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	path := parseResourcePath(id)
+	s.resources[path.key()] = &resource{
+		path: path,
+		body: withResourceFields(path, body, "Succeeded"),
+	}
+}
+
+// Resource returns the current representation of the Resource with the specified ID, if it exists
+func (s *Server) Resource(id string) (map[string]interface{}, bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	existing, ok := s.resources[parseResourcePath(id).key()]
+	if !ok {
+		return nil, false
+	}
+	return deepCopy(existing.body), true
+}
+
+// HandleAction registers the handler used for POST requests to the specified action (e.g. `listKeys`), which
+// otherwise aren't supported by this Server
+func (s *Server) HandleAction(action string, handler http.HandlerFunc) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.actions[strings.ToLower(action)] = handler
+}
+
+// withResourceFields returns the body with the `id`, `name`, `type` and `properties.provisioningState` fields populated
+func withResourceFields(path resourcePath, body map[string]interface{}, provisioningState string) map[string]interface{} {
+	output := deepCopy(body)
+	output["id"] = path.id
+	output["name"] = path.name()
+	output["type"] = path.resourceType()
+
+	properties, ok := output["properties"].(map[string]interface{})
+	if _, exists := output["properties"]; !exists {
+		properties = map[string]interface{}{}
+		output["properties"] = properties
+		ok = true
+	}
+	if ok {
+		properties["provisioningState"] = provisioningState
+	}
+
+	return output
+}
+
+func deepCopy(input map[string]interface{}) map[string]interface{} {
+	if input == nil {
+		return map[string]interface{}{}
+	}
+
+	// values are only ever JSON values, so round-tripping them is sufficient
+	contents, err := json.Marshal(input)
+	if err != nil {
+		panic(fmt.Sprintf("copying resource: %+v", err))
+	}
+	output := map[string]interface{}{}
+	if err := json.Unmarshal(contents, &output); err != nil {
+		panic(fmt.Sprintf("copying resource: %+v", err))
+	}
+	return output
+}
@@ -0,0 +1,236 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package fakearm
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/mysql/2017-12-01/configurations"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/resources/2023-07-01/resourcegroups"
+	"github.com/hashicorp/go-azure-sdk/sdk/auth"
+)
+
+var _ auth.Authorizer = testAuthorizer{}
+
+// testAuthorizer returns a static access token, since the Server doesn't validate these
+type testAuthorizer struct{}
+
+func (testAuthorizer) Token(_ context.Context, _ *http.Request) (*oauth2.Token, error) {
+	return &oauth2.Token{
+		AccessToken: "fake",
+		TokenType:   "Bearer",
+		Expiry:      time.Now().Add(time.Hour),
+	}, nil
+}
+
+func (testAuthorizer) AuxiliaryTokens(_ context.Context, _ *http.Request) ([]*oauth2.Token, error) {
+	return []*oauth2.Token{}, nil
+}
+
+func TestResourceLifecycle(t *testing.T) {
+	server := New(Options{})
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	client, err := resourcegroups.NewResourceGroupsClientWithBaseURI(server.Environment().ResourceManager)
+	if err != nil {
+		t.Fatalf("building client: %+v", err)
+	}
+	client.Client.SetAuthorizer(testAuthorizer{})
+
+	id := commonids.NewResourceGroupID(SubscriptionId, "acctestRG")
+	existing, err := client.Get(ctx, id)
+	if !response.WasNotFound(existing.HttpResponse) {
+		t.Fatalf("expected a 404 for a Resource Group which doesn't exist but got %+v", err)
+	}
+
+	created, err := client.CreateOrUpdate(ctx, id, resourcegroups.ResourceGroup{
+		Location: "westeurope",
+		Tags: pointer.To(map[string]string{
+			"env": "test",
+		}),
+	})
+	if err != nil {
+		t.Fatalf("creating %s: %+v", id, err)
+	}
+	if created.HttpResponse.StatusCode != http.StatusCreated {
+		t.Fatalf("expected a 201 when creating but got %d", created.HttpResponse.StatusCode)
+	}
+
+	// the casing of the ID shouldn't matter
+	retrieved, err := client.Get(ctx, commonids.NewResourceGroupID(SubscriptionId, "ACCTESTRG"))
+	if err != nil {
+		t.Fatalf("retrieving %s: %+v", id, err)
+	}
+	model := retrieved.Model
+	if model == nil || pointer.From(model.Id) != id.ID() || pointer.From(model.Name) != "acctestRG" || model.Location != "westeurope" {
+		t.Fatalf("unexpected model %+v", model)
+	}
+	if model.Properties == nil || pointer.From(model.Properties.ProvisioningState) != "Succeeded" {
+		t.Fatalf("expected the provisioningState to be `Succeeded`")
+	}
+
+	if _, err := client.Update(ctx, id, resourcegroups.ResourceGroupPatchable{
+		Tags: pointer.To(map[string]string{
+			"env": "prod",
+		}),
+	}); err != nil {
+		t.Fatalf("updating %s: %+v", id, err)
+	}
+	retrieved, err = client.Get(ctx, id)
+	if err != nil {
+		t.Fatalf("retrieving %s: %+v", id, err)
+	}
+	if tags := pointer.From(retrieved.Model.Tags); tags["env"] != "prod" || retrieved.Model.Location != "westeurope" {
+		t.Fatalf("expected the update to be merged into %+v", retrieved.Model)
+	}
+
+	if _, err := client.Delete(ctx, id, resourcegroups.DefaultDeleteOperationOptions()); err != nil {
+		t.Fatalf("deleting %s: %+v", id, err)
+	}
+	existing, err = client.Get(ctx, id)
+	if !response.WasNotFound(existing.HttpResponse) {
+		t.Fatalf("expected a 404 once deleted but got %+v", err)
+	}
+}
+
+func TestLongRunningOperations(t *testing.T) {
+	server := New(Options{
+		PollsUntilComplete: 2,
+	})
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	client, err := configurations.NewConfigurationsClientWithBaseURI(server.Environment().ResourceManager)
+	if err != nil {
+		t.Fatalf("building client: %+v", err)
+	}
+	client.Client.SetAuthorizer(testAuthorizer{})
+
+	id := configurations.NewConfigurationID(SubscriptionId, "acctestRG", "acctestserver", "event_scheduler")
+	input := configurations.Configuration{
+		Properties: &configurations.ConfigurationProperties{
+			Value: pointer.To("ON"),
+		},
+	}
+
+	// nested Resources require the Resource Group and parent Resource to exist
+	resp, err := client.CreateOrUpdate(ctx, id, input)
+	if err == nil || resp.HttpResponse == nil || resp.HttpResponse.StatusCode != http.StatusNotFound {
+		t.Fatalf("expected a 404 when the Resource Group doesn't exist but got %+v", err)
+	}
+	server.Seed(commonids.NewResourceGroupID(SubscriptionId, "acctestRG").ID(), map[string]interface{}{"location": "westeurope"})
+	resp, err = client.CreateOrUpdate(ctx, id, input)
+	if err == nil || resp.HttpResponse == nil || resp.HttpResponse.StatusCode != http.StatusNotFound {
+		t.Fatalf("expected a 404 when the parent Resource doesn't exist but got %+v", err)
+	}
+	server.Seed(configurations.NewServerID(SubscriptionId, "acctestRG", "acctestserver").ID(), map[string]interface{}{"location": "westeurope"})
+
+	if err := client.CreateOrUpdateThenPoll(ctx, id, input); err != nil {
+		t.Fatalf("creating %s: %+v", id, err)
+	}
+
+	body, ok := server.Resource(id.ID())
+	if !ok {
+		t.Fatalf("expected %s to exist", id)
+	}
+	if state := body["properties"].(map[string]interface{})["provisioningState"]; state != "Succeeded" {
+		t.Fatalf("expected the provisioningState to be `Succeeded` once polled but got %q", state)
+	}
+	if resourceType := body["type"]; resourceType != "Microsoft.DBforMySQL/servers/configurations" {
+		t.Fatalf("expected the type to be `Microsoft.DBforMySQL/servers/configurations` but got %q", resourceType)
+	}
+
+	list, err := client.ListByServer(ctx, configurations.NewServerID(SubscriptionId, "acctestRG", "acctestserver"))
+	if err != nil {
+		t.Fatalf("listing configurations: %+v", err)
+	}
+	if list.Model == nil || list.Model.Value == nil || len(*list.Model.Value) != 1 {
+		t.Fatalf("expected 1 configuration to be listed but got %+v", list.Model)
+	}
+}
+
+func TestLongRunningDelete(t *testing.T) {
+	server := New(Options{
+		PollsUntilComplete: 1,
+	})
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	client, err := resourcegroups.NewResourceGroupsClientWithBaseURI(server.Environment().ResourceManager)
+	if err != nil {
+		t.Fatalf("building client: %+v", err)
+	}
+	client.Client.SetAuthorizer(testAuthorizer{})
+
+	id := commonids.NewResourceGroupID(SubscriptionId, "acctestRG")
+	server.Seed(id.ID(), map[string]interface{}{"location": "westeurope"})
+	server.Seed(id.ID()+"/providers/Microsoft.Web/sites/acctestsite", map[string]interface{}{"location": "westeurope"})
+
+	resp, err := client.Delete(ctx, id, resourcegroups.DefaultDeleteOperationOptions())
+	if err != nil {
+		t.Fatalf("deleting %s: %+v", id, err)
+	}
+	if resp.HttpResponse.StatusCode != http.StatusAccepted || resp.HttpResponse.Header.Get("Location") == "" {
+		t.Fatalf("expected a 202 with a Location header but got %d", resp.HttpResponse.StatusCode)
+	}
+
+	retrieved, err := client.Get(ctx, id)
+	if err != nil {
+		t.Fatalf("expected %s to exist whilst being deleted: %+v", id, err)
+	}
+	if state := pointer.From(retrieved.Model.Properties.ProvisioningState); state != "Deleting" {
+		t.Fatalf("expected the provisioningState to be `Deleting` but got %q", state)
+	}
+
+	existing, _ := client.Get(ctx, id)
+	if !response.WasNotFound(existing.HttpResponse) {
+		t.Fatalf("expected a 404 once the deletion completed")
+	}
+	if _, ok := server.Resource(id.ID() + "/providers/Microsoft.Web/sites/acctestsite"); ok {
+		t.Fatalf("expected the nested Resources to be deleted alongside the Resource Group")
+	}
+}
+
+func TestMissingApiVersion(t *testing.T) {
+	server := New(Options{})
+	defer server.Close()
+
+	resp, err := http.Get(server.URL() + "/subscriptions/" + SubscriptionId + "/resourceGroups/acctestRG")
+	if err != nil {
+		t.Fatalf("sending request: %+v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("expected a 400 when the `api-version` is missing but got %d", resp.StatusCode)
+	}
+}
+
+func TestForMetadataHost(t *testing.T) {
+	server := New(Options{})
+
+	if ForMetadataHost(server.MetadataHost()) != server {
+		t.Fatalf("expected the Server to be registered for %q", server.MetadataHost())
+	}
+
+	server.Close()
+	if ForMetadataHost(server.MetadataHost()) != nil {
+		t.Fatalf("expected the Server to be unregistered once closed")
+	}
+}
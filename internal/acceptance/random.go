@@ -4,6 +4,8 @@
 package acceptance
 
 import (
+	"fmt"
+	"math/rand"
 	"strconv"
 	"strings"
 	"time"
@@ -29,6 +31,20 @@ func RandTimeInt() int {
 	return i
 }
 
+// randTimeIntFrom returns a value in the same format as RandTimeInt, using the specified
+// time and source of random values so that the value can be reproduced
+func randTimeIntFrom(t time.Time, r *rand.Rand) int {
+	timeStr := strings.Replace(t.UTC().Format("060102150405.00"), ".", "", 1)
+	postfix := fmt.Sprintf("%04d", r.Intn(10000))
+
+	i, err := strconv.Atoi(timeStr + postfix)
+	if err != nil {
+		panic(err)
+	}
+
+	return i
+}
+
 // RandString generates a random alphanumeric string of the length specified
 func RandString(strlen int) string {
 	return acctest.RandString(strlen)
@@ -3,7 +3,12 @@
 
 package acceptance
 
-import "testing"
+import (
+	"math/rand"
+	"strconv"
+	"testing"
+	"time"
+)
 
 func TestAccRandTimeInt(t *testing.T) {
 	t.Run("Rand Date int", func(t *testing.T) {
@@ -18,3 +23,17 @@ func TestAccRandTimeInt(t *testing.T) {
 		}
 	})
 }
+
+func TestAccRandTimeIntFrom(t *testing.T) {
+	recordedAt := time.Date(2024, 1, 2, 3, 4, 5, 60000000, time.UTC)
+
+	first := randTimeIntFrom(recordedAt, rand.New(rand.NewSource(42)))
+	second := randTimeIntFrom(recordedAt, rand.New(rand.NewSource(42)))
+	if first != second {
+		t.Fatalf("expected randTimeIntFrom to return the same value for the same seed but got %d and %d", first, second)
+	}
+
+	if prefix := strconv.Itoa(first)[0:14]; prefix != "24010203040506" {
+		t.Fatalf("expected randTimeIntFrom to be prefixed with the time but got %d", first)
+	}
+}
@@ -0,0 +1,67 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package acceptance
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/recording"
+)
+
+// useCassette configures the TestData to use the Cassette for the current Test, meaning that the random values
+// (and Azure Regions) used when the Test was recorded are used again when it's replayed
+func (td *TestData) useCassette(t *testing.T) {
+	cassette, started, err := recording.Start(t.Name())
+	if err != nil {
+		t.Fatalf("starting the recording for %q: %+v", t.Name(), err)
+		return
+	}
+
+	if started {
+		t.Cleanup(func() {
+			if missing := cassette.Missing(); len(missing) > 0 {
+				t.Errorf("the following requests were not found in the recording for %q:\n\n%s", t.Name(), strings.Join(missing, "\n"))
+			}
+
+			if err := cassette.Stop(); err != nil {
+				t.Errorf("stopping the recording for %q: %+v", t.Name(), err)
+			}
+		})
+	}
+
+	td.rand = cassette.Rand()
+	td.RandomInteger = randTimeIntFrom(cassette.RecordedAt, td.rand)
+	td.RandomString = td.randString(5)
+
+	locations := td.Locations
+	td.Locations = Regions{
+		Primary:   cassette.Variable("ARM_TEST_LOCATION", func() string { return locations.Primary }),
+		Secondary: cassette.Variable("ARM_TEST_LOCATION_ALT", func() string { return locations.Secondary }),
+		Ternary:   cassette.Variable("ARM_TEST_LOCATION_ALT2", func() string { return locations.Ternary }),
+	}
+
+	if recording.CurrentMode() != recording.ModeReplay {
+		return
+	}
+
+	td.Subscriptions = Subscriptions{
+		Primary:   recording.SubscriptionIdPlaceholder,
+		Secondary: recording.SecondarySubscriptionIdPlaceholder,
+	}
+
+	// the Provider is configured from the Environment, which needs to match the recording
+	variables := map[string]string{
+		"ARM_CLIENT_ID":          recording.ClientIdPlaceholder,
+		"ARM_CLIENT_SECRET":      "offline",
+		"ARM_SUBSCRIPTION_ID":    recording.SubscriptionIdPlaceholder,
+		"ARM_TENANT_ID":          recording.TenantIdPlaceholder,
+		"ARM_TEST_LOCATION":      td.Locations.Primary,
+		"ARM_TEST_LOCATION_ALT":  td.Locations.Secondary,
+		"ARM_TEST_LOCATION_ALT2": td.Locations.Ternary,
+	}
+	for key, value := range variables {
+		t.Setenv(key, value)
+	}
+}
@@ -0,0 +1,267 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package recording
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Cassette contains the sanitized HTTP Interactions for a single Acceptance Test, alongside the
+// random values used by the Test so that these can be reproduced when the Cassette is replayed
+type Cassette struct {
+	// Name is the name of the Test this Cassette was recorded for
+	Name string `json:"name"`
+
+	// RecordedAt is the time this Cassette was recorded, which is used to generate the `RandomInteger`
+	RecordedAt time.Time `json:"recordedAt"`
+
+	// Seed is used to seed the random values generated for this Test
+	Seed int64 `json:"seed"`
+
+	// Variables contains any additional values (e.g. the Azure Regions) which are required to replay this Test
+	Variables map[string]string `json:"variables,omitempty"`
+
+	// Interactions is the ordered list of the Requests sent and the Responses returned
+	Interactions []Interaction `json:"interactions"`
+
+	mode Mode
+	path string
+
+	lock    sync.Mutex
+	rand    *rand.Rand
+	cursors map[string]int
+	missing []string
+}
+
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+type Request struct {
+	Method string `json:"method"`
+	URL    string `json:"url"`
+}
+
+type Response struct {
+	StatusCode int         `json:"statusCode"`
+	Headers    http.Header `json:"headers,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+func newCassette(name string) *Cassette {
+	now := time.Now()
+	return &Cassette{
+		Name:         name,
+		RecordedAt:   now,
+		Seed:         now.UnixNano(),
+		Variables:    map[string]string{},
+		Interactions: make([]Interaction, 0),
+		mode:         ModeRecord,
+		path:         cassettePath(name),
+		cursors:      map[string]int{},
+	}
+}
+
+func loadCassette(name string) (*Cassette, error) {
+	path := cassettePath(name)
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading Cassette %q: %+v", path, err)
+	}
+
+	var cassette Cassette
+	if err := json.Unmarshal(contents, &cassette); err != nil {
+		return nil, fmt.Errorf("parsing Cassette %q: %+v", path, err)
+	}
+
+	if cassette.Variables == nil {
+		cassette.Variables = map[string]string{}
+	}
+	cassette.mode = ModeReplay
+	cassette.path = path
+	cassette.cursors = map[string]int{}
+	return &cassette, nil
+}
+
+// Rand returns a source of random values which is seeded from this Cassette, meaning that
+// the values returned are the same when this Cassette is replayed
+func (c *Cassette) Rand() *rand.Rand {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.rand == nil {
+		c.rand = rand.New(rand.NewSource(c.Seed)) // nolint:gosec
+	}
+	return c.rand
+}
+
+// Variable returns the value for the specified key, setting it to the value returned from `get` when recording
+func (c *Cassette) Variable(key string, get func() string) string {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.mode == ModeRecord {
+		c.Variables[key] = get()
+	}
+	return c.Variables[key]
+}
+
+// Missing returns the Requests which couldn't be matched against a recorded Interaction
+func (c *Cassette) Missing() []string {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return append([]string{}, c.missing...)
+}
+
+func (c *Cassette) save() error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(c.path), 0o755); err != nil {
+		return fmt.Errorf("creating directory for Cassette %q: %+v", c.path, err)
+	}
+
+	contents, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return fmt.Errorf("serializing Cassette %q: %+v", c.path, err)
+	}
+
+	if err := os.WriteFile(c.path, append(contents, '\n'), 0o600); err != nil {
+		return fmt.Errorf("writing Cassette %q: %+v", c.path, err)
+	}
+
+	return nil
+}
+
+// record appends a sanitized copy of the request and response to this Cassette
+func (c *Cassette) record(req *http.Request, resp *http.Response) error {
+	var body []byte
+	if resp.Body != nil {
+		var err error
+		body, err = io.ReadAll(resp.Body)
+		if err != nil {
+			return fmt.Errorf("reading response body: %+v", err)
+		}
+		resp.Body.Close()
+		resp.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	interaction := Interaction{
+		Request: Request{
+			Method: req.Method,
+			URL:    sanitizeURL(req.URL.String()),
+		},
+		Response: Response{
+			StatusCode: resp.StatusCode,
+			Headers:    sanitizeHeaders(resp.Header),
+			Body:       sanitizeBody(resp.Header.Get("Content-Type"), body),
+		},
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.Interactions = append(c.Interactions, interaction)
+	return nil
+}
+
+// replay returns the next recorded Interaction matching the specified method and URL - the recorded Interactions for a given
+// request are returned in order (e.g. whilst polling a long-running operation), with the last one being repeated once exhausted
+func (c *Cassette) replay(method, rawUrl string) *Interaction {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	key := interactionKey(method, rawUrl, false)
+	matches := c.matching(key, false)
+	if len(matches) == 0 {
+		// values such as GUIDs can be generated by the Provider, so fall back to ignoring these
+		key = interactionKey(method, rawUrl, true)
+		matches = c.matching(key, true)
+	}
+	if len(matches) == 0 {
+		c.missing = append(c.missing, fmt.Sprintf("%s %s", method, sanitizeURL(rawUrl)))
+		return nil
+	}
+
+	cursor := c.cursors[key]
+	if cursor >= len(matches) {
+		cursor = len(matches) - 1
+	}
+	c.cursors[key] = cursor + 1
+
+	return &c.Interactions[matches[cursor]]
+}
+
+func (c *Cassette) matching(key string, ignoreGuids bool) []int {
+	matches := make([]int, 0)
+	for i, interaction := range c.Interactions {
+		if interactionKey(interaction.Request.Method, interaction.Request.URL, ignoreGuids) == key {
+			matches = append(matches, i)
+		}
+	}
+	return matches
+}
+
+// httpResponse returns the recorded Response for the specified Request
+func (i Interaction) httpResponse(req *http.Request) *http.Response {
+	headers := i.Response.Headers.Clone()
+	if headers == nil {
+		headers = http.Header{}
+	}
+
+	// there's no need to wait between polls when the outcome is already known
+	if headers.Get("Retry-After") != "" {
+		headers.Set("Retry-After", "0")
+	}
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", i.Response.StatusCode, http.StatusText(i.Response.StatusCode)),
+		StatusCode:    i.Response.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        headers,
+		Body:          io.NopCloser(strings.NewReader(i.Response.Body)),
+		ContentLength: int64(len(i.Response.Body)),
+		Request:       req,
+	}
+}
+
+var guidRegex = regexp.MustCompile(`(?i)[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}`)
+
+// interactionKey normalizes the method and URL so that these can be compared regardless of casing or query string ordering
+func interactionKey(method, rawUrl string, ignoreGuids bool) string {
+	rawUrl = sanitizeURL(rawUrl)
+
+	u, err := url.Parse(rawUrl)
+	if err != nil {
+		return strings.ToUpper(method) + " " + strings.ToLower(rawUrl)
+	}
+
+	query := u.Query()
+	// signatures are time-based so can't be reproduced
+	query.Del("sig")
+	query.Del("se")
+	query.Del("st")
+
+	path := u.EscapedPath()
+	if ignoreGuids {
+		path = guidRegex.ReplaceAllString(path, "{guid}")
+	}
+
+	return strings.ToUpper(method) + " " + strings.ToLower(u.Host+path) + "?" + query.Encode()
+}
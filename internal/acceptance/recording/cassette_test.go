@@ -0,0 +1,162 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package recording
+
+import (
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/Azure/go-autorest/autorest"
+)
+
+func TestCassetteReplayOrdering(t *testing.T) {
+	cassette := &Cassette{
+		Interactions: []Interaction{
+			testInteraction("PUT", "https://management.azure.com/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/acctestRG?api-version=2020-06-01", 201, `{"properties":{"provisioningState":"Creating"}}`),
+			testInteraction("GET", "https://management.azure.com/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/acctestRG?api-version=2020-06-01", 200, `{"properties":{"provisioningState":"Creating"}}`),
+			testInteraction("GET", "https://management.azure.com/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/acctestRG?api-version=2020-06-01", 200, `{"properties":{"provisioningState":"Succeeded"}}`),
+		},
+		cursors: map[string]int{},
+	}
+
+	expected := []string{"Creating", "Succeeded", "Succeeded"}
+	for i, state := range expected {
+		// the casing of the path and the ordering of the query string shouldn't matter
+		interaction := cassette.replay("GET", "https://management.azure.com/subscriptions/00000000-0000-0000-0000-000000000000/resourcegroups/acctestRG?api-version=2020-06-01")
+		if interaction == nil {
+			t.Fatalf("poll %d: expected an interaction but got nil", i)
+		}
+		if !strings.Contains(interaction.Response.Body, state) {
+			t.Fatalf("poll %d: expected the provisioningState to be %q but got %q", i, state, interaction.Response.Body)
+		}
+	}
+
+	if interaction := cassette.replay("DELETE", "https://management.azure.com/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/acctestRG?api-version=2020-06-01"); interaction != nil {
+		t.Fatalf("expected no interaction for the DELETE but got %+v", *interaction)
+	}
+	if missing := cassette.Missing(); len(missing) != 1 {
+		t.Fatalf("expected 1 missing request but got %d", len(missing))
+	}
+}
+
+func TestCassetteReplayGeneratedGuids(t *testing.T) {
+	cassette := &Cassette{
+		Interactions: []Interaction{
+			testInteraction("PUT", "https://management.azure.com/subscriptions/00000000-0000-0000-0000-000000000000/providers/Microsoft.Authorization/roleAssignments/5c7d9d63-6d43-4a53-bd80-4d4bb0b04a6d?api-version=2022-04-01", 201, `{}`),
+		},
+		cursors: map[string]int{},
+	}
+
+	if interaction := cassette.replay("PUT", "https://management.azure.com/subscriptions/00000000-0000-0000-0000-000000000000/providers/Microsoft.Authorization/roleAssignments/9d0c0cbb-1d2c-44ab-9b6c-5f64f2e4f0b1?api-version=2022-04-01"); interaction == nil {
+		t.Fatalf("expected the interaction to match when the GUID differs")
+	}
+}
+
+func TestCassetteReplaySignatures(t *testing.T) {
+	cassette := &Cassette{
+		Interactions: []Interaction{
+			testInteraction("GET", "https://acctestsa.blob.core.windows.net/content?restype=container&sig=REDACTED&se=2024-01-01", 200, ""),
+		},
+		cursors: map[string]int{},
+	}
+
+	if interaction := cassette.replay("GET", "https://acctestsa.blob.core.windows.net/content?restype=container&sig=abc123&se=2025-06-01"); interaction == nil {
+		t.Fatalf("expected the interaction to match when the signature differs")
+	}
+}
+
+func TestRecordAndReplaySender(t *testing.T) {
+	t.Setenv(EnvDirectory, t.TempDir())
+	t.Setenv(EnvMode, string(ModeRecord))
+	t.Setenv("ARM_SUBSCRIPTION_ID", "11111111-2222-3333-4444-555555555555")
+
+	requestUrl := "https://management.azure.com/subscriptions/11111111-2222-3333-4444-555555555555/resourceGroups/acctestRG?api-version=2020-06-01"
+	azure := autorest.SenderFunc(func(req *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header: http.Header{
+				"Content-Type":  []string{"application/json"},
+				"Authorization": []string{"Bearer abc"},
+				"Retry-After":   []string{"10"},
+			},
+			Body:    io.NopCloser(strings.NewReader(`{"id":"/subscriptions/11111111-2222-3333-4444-555555555555/resourceGroups/acctestRG","properties":{"primaryKey":"s3cr3t"}}`)),
+			Request: req,
+		}, nil
+	})
+
+	cassette, started, err := Start(t.Name())
+	if err != nil {
+		t.Fatalf("starting the recording: %+v", err)
+	}
+	if !started {
+		t.Fatalf("expected a new recording to be started")
+	}
+
+	sender := Recorder().WrapSender(azure)
+	if _, err := sender.Do(testRequest(t, "GET", requestUrl)); err != nil {
+		t.Fatalf("sending request: %+v", err)
+	}
+	if err := cassette.Stop(); err != nil {
+		t.Fatalf("stopping the recording: %+v", err)
+	}
+
+	t.Setenv(EnvMode, string(ModeReplay))
+	replayed, _, err := Start(t.Name())
+	if err != nil {
+		t.Fatalf("starting the replay: %+v", err)
+	}
+	defer replayed.Stop() // nolint:errcheck
+
+	if replayed.Seed != cassette.Seed {
+		t.Fatalf("expected the seed to be %d but got %d", cassette.Seed, replayed.Seed)
+	}
+
+	sender = Recorder().WrapSender(autorest.SenderFunc(func(req *http.Request) (*http.Response, error) {
+		t.Fatalf("no requests should be sent when replaying")
+		return nil, nil
+	}))
+	resp, err := sender.Do(testRequest(t, "GET", "https://management.azure.com/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/acctestRG?api-version=2020-06-01"))
+	if err != nil {
+		t.Fatalf("replaying request: %+v", err)
+	}
+
+	body, _ := io.ReadAll(resp.Body)
+	if strings.Contains(string(body), "11111111-2222-3333-4444-555555555555") || strings.Contains(string(body), "s3cr3t") {
+		t.Fatalf("expected the response body to be sanitized but got %q", string(body))
+	}
+	if resp.Header.Get("Authorization") != "" {
+		t.Fatalf("expected the Authorization header to be removed")
+	}
+	if v := resp.Header.Get("Retry-After"); v != "0" {
+		t.Fatalf("expected the Retry-After header to be `0` but got %q", v)
+	}
+}
+
+func testInteraction(method, rawUrl string, statusCode int, body string) Interaction {
+	return Interaction{
+		Request: Request{
+			Method: method,
+			URL:    rawUrl,
+		},
+		Response: Response{
+			StatusCode: statusCode,
+			Body:       body,
+		},
+	}
+}
+
+func testRequest(t *testing.T, method, rawUrl string) *http.Request {
+	u, err := url.Parse(rawUrl)
+	if err != nil {
+		t.Fatalf("parsing %q: %+v", rawUrl, err)
+	}
+	return &http.Request{
+		Method: method,
+		URL:    u,
+		Header: http.Header{},
+	}
+}
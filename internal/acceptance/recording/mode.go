@@ -0,0 +1,63 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package recording
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	// EnvMode is the Environment Variable used to configure the Recording Mode
+	EnvMode = "ARM_TEST_RECORDING_MODE"
+
+	// EnvDirectory is the Environment Variable used to override the directory containing the Cassettes
+	EnvDirectory = "ARM_TEST_RECORDINGS_DIR"
+
+	// defaultDirectory is relative to the Service Package containing the Acceptance Test being run
+	defaultDirectory = "testdata/recordings"
+)
+
+type Mode string
+
+const (
+	// ModeLive sends all requests to Azure without recording them
+	ModeLive Mode = ""
+
+	// ModeRecord sends all requests to Azure and writes the sanitized interactions to a Cassette
+	ModeRecord Mode = "record"
+
+	// ModeReplay serves all requests from a previously recorded Cassette, without access to Azure
+	ModeReplay Mode = "replay"
+)
+
+// CurrentMode returns the Recording Mode configured via the `ARM_TEST_RECORDING_MODE` Environment Variable
+func CurrentMode() Mode {
+	switch Mode(strings.ToLower(os.Getenv(EnvMode))) {
+	case ModeRecord:
+		return ModeRecord
+	case ModeReplay:
+		return ModeReplay
+	}
+
+	return ModeLive
+}
+
+// Enabled returns whether Acceptance Tests are being recorded or replayed
+func Enabled() bool {
+	return CurrentMode() != ModeLive
+}
+
+// cassettePath returns the path to the Cassette for the specified Test
+func cassettePath(testName string) string {
+	directory := os.Getenv(EnvDirectory)
+	if directory == "" {
+		directory = defaultDirectory
+	}
+
+	// subtests are separated by a `/` which we don't want to nest
+	fileName := strings.NewReplacer("/", "_", "\\", "_", " ", "_").Replace(testName)
+	return filepath.Join(directory, fileName+".json")
+}
@@ -0,0 +1,229 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package recording
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/hashicorp/go-azure-sdk/sdk/client"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/common"
+)
+
+var (
+	activeLock sync.Mutex
+
+	// active is the Cassette for the Test currently being run - since the Provider (and the cached
+	// test client) are built independently of any given Test, requests are routed to this Cassette
+	active *Cassette
+
+	serverOnce sync.Once
+	server     *httptest.Server
+)
+
+// Start begins recording (or replaying) the Cassette for the specified Test. When a Cassette has already been
+// started for this Test it is returned and `started` is false, which allows multiple `TestData`'s to share a Cassette.
+func Start(testName string) (cassette *Cassette, started bool, err error) {
+	activeLock.Lock()
+	defer activeLock.Unlock()
+
+	if active != nil {
+		if active.Name == testName {
+			return active, false, nil
+		}
+		return nil, false, fmt.Errorf("the Cassette for %q is still in use - recorded tests must be run sequentially", active.Name)
+	}
+
+	switch CurrentMode() {
+	case ModeRecord:
+		registerIdentitiesFromEnvironment()
+		cassette = newCassette(testName)
+	case ModeReplay:
+		if cassette, err = loadCassette(testName); err != nil {
+			return nil, false, err
+		}
+	default:
+		return nil, false, fmt.Errorf("`%s` must be set to either %q or %q to use a Cassette", EnvMode, ModeRecord, ModeReplay)
+	}
+
+	active = cassette
+	return cassette, true, nil
+}
+
+// Stop finishes using this Cassette, writing it to disk when recording
+func (c *Cassette) Stop() error {
+	activeLock.Lock()
+	if active == c {
+		active = nil
+	}
+	activeLock.Unlock()
+
+	if c.mode == ModeRecord {
+		return c.save()
+	}
+	return nil
+}
+
+func current() *Cassette {
+	activeLock.Lock()
+	defer activeLock.Unlock()
+	return active
+}
+
+// Recorder returns the HTTP Recorder which should be used by the Provider when Acceptance Tests are
+// being recorded or replayed, or nil otherwise
+func Recorder() common.HTTPRecorder {
+	mode := CurrentMode()
+	if mode == ModeLive {
+		return nil
+	}
+
+	return &recorder{
+		mode: mode,
+	}
+}
+
+var _ common.HTTPRecorder = &recorder{}
+
+type recorder struct {
+	mode Mode
+}
+
+func (r *recorder) Offline() bool {
+	return r.mode == ModeReplay
+}
+
+func (r *recorder) OfflineIdentity() (clientId, objectId, tenantId string) {
+	return ClientIdPlaceholder, ObjectIdPlaceholder, TenantIdPlaceholder
+}
+
+func (r *recorder) SanitizeIdentity(clientId, objectId, tenantId string) {
+	registerIdentity(clientId, ClientIdPlaceholder)
+	registerIdentity(objectId, ObjectIdPlaceholder)
+	registerIdentity(tenantId, TenantIdPlaceholder)
+}
+
+// RequestMiddleware redirects requests to the local replay server when replaying
+func (r *recorder) RequestMiddleware() client.RequestMiddleware {
+	return func(req *http.Request) (*http.Request, error) {
+		if r.mode != ModeReplay {
+			return req, nil
+		}
+
+		replayServer := replayServer()
+		serverUrl, err := url.Parse(replayServer.URL)
+		if err != nil {
+			return nil, fmt.Errorf("parsing replay server URL: %+v", err)
+		}
+		if strings.EqualFold(req.URL.Host, serverUrl.Host) {
+			// polling URLs can be derived from a request that's already been redirected
+			return req, nil
+		}
+
+		// the original host is retained as the first path segment so that it survives any URLs derived from this request
+		redirected := req.Clone(req.Context())
+		redirected.URL.Scheme = serverUrl.Scheme
+		redirected.URL.Path = "/" + req.URL.Host + req.URL.Path
+		redirected.URL.RawPath = "/" + req.URL.Host + req.URL.EscapedPath()
+		redirected.URL.Host = serverUrl.Host
+		redirected.Host = serverUrl.Host
+		return redirected, nil
+	}
+}
+
+// ResponseMiddleware writes the interaction to the active Cassette when recording
+func (r *recorder) ResponseMiddleware() client.ResponseMiddleware {
+	return func(req *http.Request, resp *http.Response) (*http.Response, error) {
+		if r.mode != ModeRecord {
+			return resp, nil
+		}
+
+		if cassette := current(); cassette != nil {
+			if err := cassette.record(req, resp); err != nil {
+				return nil, fmt.Errorf("recording response for %s %s: %+v", req.Method, req.URL, err)
+			}
+		}
+		return resp, nil
+	}
+}
+
+func (r *recorder) WrapSender(sender autorest.Sender) autorest.Sender {
+	return autorest.SenderFunc(func(req *http.Request) (*http.Response, error) {
+		cassette := current()
+
+		if r.mode == ModeReplay {
+			if cassette == nil {
+				return nil, fmt.Errorf("no Cassette is in use to replay %s %s", req.Method, req.URL)
+			}
+
+			interaction := cassette.replay(req.Method, req.URL.String())
+			if interaction == nil {
+				return nil, fmt.Errorf("no recorded interaction was found for %s %s", req.Method, sanitizeURL(req.URL.String()))
+			}
+			return interaction.httpResponse(req), nil
+		}
+
+		resp, err := sender.Do(req)
+		if err == nil && cassette != nil {
+			if err := cassette.record(req, resp); err != nil {
+				return nil, fmt.Errorf("recording response for %s %s: %+v", req.Method, req.URL, err)
+			}
+		}
+		return resp, err
+	})
+}
+
+// replayServer returns the local server used to serve recorded responses to go-azure-sdk based clients, which (unlike
+// go-autorest) don't support overriding the transport used to send requests
+func replayServer() *httptest.Server {
+	serverOnce.Do(func() {
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			host, path, _ := strings.Cut(strings.TrimPrefix(req.URL.EscapedPath(), "/"), "/")
+			originalUrl := url.URL{
+				Scheme:   "https",
+				Host:     host,
+				Path:     "/" + path,
+				RawPath:  "/" + path,
+				RawQuery: req.URL.RawQuery,
+			}
+			if unescaped, err := url.PathUnescape(originalUrl.Path); err == nil {
+				originalUrl.Path = unescaped
+			}
+
+			cassette := current()
+			if cassette == nil {
+				// a 501 isn't retried by the clients
+				http.Error(w, fmt.Sprintf("no Cassette is in use to replay %s %s", req.Method, originalUrl.String()), http.StatusNotImplemented)
+				return
+			}
+
+			interaction := cassette.replay(req.Method, originalUrl.String())
+			if interaction == nil {
+				log.Printf("[DEBUG] No recorded interaction was found for %s %s", req.Method, originalUrl.String())
+				http.Error(w, fmt.Sprintf("no recorded interaction was found for %s %s", req.Method, sanitizeURL(originalUrl.String())), http.StatusNotImplemented)
+				return
+			}
+
+			resp := interaction.httpResponse(req)
+			for name, values := range resp.Header {
+				for _, value := range values {
+					w.Header().Add(name, value)
+				}
+			}
+			// the body has been decompressed when recorded
+			w.Header().Del("Content-Encoding")
+			w.Header().Del("Content-Length")
+			w.WriteHeader(resp.StatusCode)
+			_, _ = w.Write([]byte(interaction.Response.Body))
+		}))
+	})
+
+	return server
+}
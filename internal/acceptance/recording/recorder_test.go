@@ -0,0 +1,71 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package recording
+
+import (
+	"io"
+	"net/http"
+	"testing"
+)
+
+func TestReplayMiddleware(t *testing.T) {
+	t.Setenv(EnvMode, string(ModeReplay))
+
+	activeLock.Lock()
+	active = &Cassette{
+		Name: t.Name(),
+		Interactions: []Interaction{
+			{
+				Request: Request{
+					Method: "GET",
+					URL:    "https://management.azure.com/subscriptions/00000000-0000-0000-0000-000000000000/providers/Microsoft.Web/locations/westeurope/operationResults/abc%2F123?api-version=2023-01-01",
+				},
+				Response: Response{
+					StatusCode: http.StatusAccepted,
+					Headers: http.Header{
+						"Location":    []string{"https://management.azure.com/subscriptions/00000000-0000-0000-0000-000000000000/providers/Microsoft.Web/locations/westeurope/operationResults/abc%2F123?api-version=2023-01-01"},
+						"Retry-After": []string{"30"},
+					},
+				},
+			},
+		},
+		mode:    ModeReplay,
+		cursors: map[string]int{},
+	}
+	cassette := active
+	activeLock.Unlock()
+	defer cassette.Stop() // nolint:errcheck
+
+	req := testRequest(t, "GET", "https://management.azure.com/subscriptions/00000000-0000-0000-0000-000000000000/providers/Microsoft.Web/locations/westeurope/operationResults/abc%2F123?api-version=2023-01-01")
+	redirected, err := Recorder().RequestMiddleware()(req)
+	if err != nil {
+		t.Fatalf("running the request middleware: %+v", err)
+	}
+	if redirected.URL.Host == "management.azure.com" {
+		t.Fatalf("expected the request to be redirected to the replay server")
+	}
+
+	// requests which have already been redirected should be left as-is
+	again, err := Recorder().RequestMiddleware()(redirected)
+	if err != nil {
+		t.Fatalf("running the request middleware: %+v", err)
+	}
+	if again.URL.String() != redirected.URL.String() {
+		t.Fatalf("expected %q but got %q", redirected.URL.String(), again.URL.String())
+	}
+
+	resp, err := http.DefaultClient.Do(redirected)
+	if err != nil {
+		t.Fatalf("sending request: %+v", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode != http.StatusAccepted {
+		t.Fatalf("expected a %d but got %d (%+v)", http.StatusAccepted, resp.StatusCode, cassette.Missing())
+	}
+	if v := resp.Header.Get("Retry-After"); v != "0" {
+		t.Fatalf("expected the Retry-After header to be `0` but got %q", v)
+	}
+}
@@ -0,0 +1,120 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package recording
+
+import (
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/common"
+)
+
+const (
+	SubscriptionIdPlaceholder          = "00000000-0000-0000-0000-000000000000"
+	SecondarySubscriptionIdPlaceholder = "00000000-0000-0000-0000-000000000001"
+	TenantIdPlaceholder                = "00000000-0000-0000-0000-000000000002"
+	ClientIdPlaceholder                = "00000000-0000-0000-0000-000000000003"
+	ObjectIdPlaceholder                = "00000000-0000-0000-0000-000000000004"
+)
+
+var (
+	identitiesLock sync.RWMutex
+
+	// identities is a map of the real identifier to the placeholder which is recorded in its place
+	identities = map[string]string{}
+)
+
+// registerIdentity ensures that the specified value is replaced with the placeholder in any recordings
+func registerIdentity(value, placeholder string) {
+	if value == "" || value == placeholder {
+		return
+	}
+
+	identitiesLock.Lock()
+	defer identitiesLock.Unlock()
+	identities[strings.ToLower(value)] = placeholder
+}
+
+// registerIdentitiesFromEnvironment registers the identifiers configured for the Acceptance Tests
+func registerIdentitiesFromEnvironment() {
+	registerIdentity(os.Getenv("ARM_SUBSCRIPTION_ID"), SubscriptionIdPlaceholder)
+	registerIdentity(os.Getenv("ARM_TEST_SUBSCRIPTION_ID_ALT"), SecondarySubscriptionIdPlaceholder)
+	registerIdentity(os.Getenv("ARM_TENANT_ID"), TenantIdPlaceholder)
+	registerIdentity(os.Getenv("ARM_CLIENT_ID"), ClientIdPlaceholder)
+}
+
+// sanitizeIdentities replaces any registered identifiers (regardless of casing) with their placeholders
+func sanitizeIdentities(input string) string {
+	identitiesLock.RLock()
+	defer identitiesLock.RUnlock()
+
+	for value, placeholder := range identities {
+		input = replaceInsensitively(input, value, placeholder)
+	}
+	return input
+}
+
+func replaceInsensitively(input, old, new string) string {
+	if old == "" {
+		return input
+	}
+	return regexp.MustCompile("(?i)"+regexp.QuoteMeta(old)).ReplaceAllLiteralString(input, new)
+}
+
+// sanitizeURL replaces any identifiers and redacts any signatures contained within the URL
+func sanitizeURL(input string) string {
+	input = sanitizeIdentities(input)
+
+	u, err := url.Parse(input)
+	if err != nil || u.RawQuery == "" {
+		return input
+	}
+
+	return redactor.URL(u)
+}
+
+// sensitiveHeaderRegex matches the names of headers which shouldn't be recorded
+var sensitiveHeaderRegex = regexp.MustCompile(`(?i)(authorization|cookie|token|secret|signature|key$)`)
+
+// sanitizeHeaders returns a copy of the headers with any sensitive headers removed and any identifiers replaced
+func sanitizeHeaders(input http.Header) http.Header {
+	output := http.Header{}
+	for name, values := range input {
+		if sensitiveHeaderRegex.MatchString(name) {
+			continue
+		}
+
+		for _, value := range values {
+			if strings.HasPrefix(strings.ToLower(value), "http") {
+				value = sanitizeURL(value)
+			} else {
+				value = sanitizeIdentities(value)
+			}
+			output.Add(name, value)
+		}
+	}
+	return output
+}
+
+// redactor redacts the same sensitive values within the recorded requests and responses as are redacted
+// when logging HTTP requests and responses
+var redactor = common.NewRedactor()
+
+// sanitizeBody replaces any identifiers within the response body and redacts any sensitive values within a JSON body
+func sanitizeBody(contentType string, input []byte) string {
+	if len(input) == 0 {
+		return ""
+	}
+
+	body := sanitizeIdentities(string(input))
+	if !strings.Contains(strings.ToLower(contentType), "json") {
+		return body
+	}
+
+	return string(redactor.Body([]byte(body)))
+}
@@ -0,0 +1,86 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package recording
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestSanitizeBody(t *testing.T) {
+	registerIdentity("ABCDEF01-2345-6789-ABCD-EF0123456789", SubscriptionIdPlaceholder)
+
+	testData := []struct {
+		name        string
+		contentType string
+		input       string
+		expected    string
+	}{
+		{
+			name:        "empty",
+			contentType: "application/json",
+			input:       "",
+			expected:    "",
+		},
+		{
+			name:        "identifiers regardless of casing",
+			contentType: "text/plain",
+			input:       "/subscriptions/abcdef01-2345-6789-abcd-ef0123456789/resourceGroups/example",
+			expected:    "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example",
+		},
+		{
+			name:        "sensitive fields",
+			contentType: "application/json; charset=utf-8",
+			input:       `{"name":"example","properties":{"adminPassword":"p@ssw0rd","primaryKey":"abc","partitionKey":{"paths":["/id"]},"count":12345678901234567890}}`,
+			expected:    `{"name":"example","properties":{"adminPassword":"REDACTED","count":12345678901234567890,"partitionKey":{"paths":["/id"]},"primaryKey":"REDACTED"}}`,
+		},
+		{
+			name:        "list keys",
+			contentType: "application/json",
+			input:       `{"keys":[{"keyName":"key1","value":"abc","permissions":"FULL"}]}`,
+			expected:    `{"keys":[{"keyName":"key1","permissions":"FULL","value":"REDACTED"}]}`,
+		},
+		{
+			name:        "cognitive account keys",
+			contentType: "application/json",
+			input:       `{"key1":"abc","key2":"def"}`,
+			expected:    `{"key1":"REDACTED","key2":"REDACTED"}`,
+		},
+		{
+			name:        "invalid json",
+			contentType: "application/json",
+			input:       `{"password":`,
+			expected:    `{"password":`,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.name)
+
+		actual := sanitizeBody(v.contentType, []byte(v.input))
+		if actual != v.expected {
+			t.Fatalf("expected %q but got %q", v.expected, actual)
+		}
+	}
+}
+
+func TestSanitizeHeaders(t *testing.T) {
+	input := http.Header{
+		"Authorization":        []string{"Bearer abc"},
+		"Set-Cookie":           []string{"session=abc"},
+		"Azure-Asyncoperation": []string{"https://management.azure.com/operations/1?api-version=2020-01-01&sig=abc"},
+		"Content-Type":         []string{"application/json"},
+	}
+
+	actual := sanitizeHeaders(input)
+	if actual.Get("Authorization") != "" || actual.Get("Set-Cookie") != "" {
+		t.Fatalf("expected the sensitive headers to be removed but got %+v", actual)
+	}
+	if v := actual.Get("Azure-Asyncoperation"); v != "https://management.azure.com/operations/1?api-version=2020-01-01&sig=REDACTED" {
+		t.Fatalf("expected the signature to be redacted but got %q", v)
+	}
+	if v := actual.Get("Content-Type"); v != "application/json" {
+		t.Fatalf("expected the Content-Type to be retained but got %q", v)
+	}
+}
@@ -4,6 +4,7 @@
 package acceptance
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
@@ -11,9 +12,11 @@ import (
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/helpers"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/recording"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/testclient"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/types"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/provider"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/provider/framework"
 )
 
 func (td TestData) DataSourceTest(t *testing.T, steps []TestStep) {
@@ -122,32 +125,68 @@ func RunTestsInSequence(t *testing.T, tests map[string]map[string]func(t *testin
 }
 
 func (td TestData) runAcceptanceTest(t *testing.T, testCase resource.TestCase) {
-	testCase.ExternalProviders = td.externalProviders()
-	testCase.ProviderFactories = td.providers()
+	td.configureProviders(&testCase)
+
+	// recorded tests share a single Cassette at a time, and tests using a Fake Resource Manager set
+	// Environment Variables for the Provider - so neither can be run in parallel
+	if recording.Enabled() || td.fakeResourceManager != nil {
+		resource.Test(t, testCase)
+		return
+	}
 
 	resource.ParallelTest(t, testCase)
 }
 
 func (td TestData) runAcceptanceSequentialTest(t *testing.T, testCase resource.TestCase) {
-	testCase.ExternalProviders = td.externalProviders()
-	testCase.ProviderFactories = td.providers()
+	td.configureProviders(&testCase)
 
 	resource.Test(t, testCase)
 }
 
+// configureProviders configures the Providers used for this Test Case - Resources served through the Plugin Framework
+// require the Plugin SDKv2 and Plugin Framework providers to be muxed together, as in the released Provider
+func (td TestData) configureProviders(testCase *resource.TestCase) {
+	testCase.ExternalProviders = td.externalProviders()
+
+	for _, r := range provider.SupportedFrameworkResources() {
+		if r.ResourceType() == td.ResourceType {
+			testCase.ProtoV5ProviderFactories = framework.ProtoV5ProviderFactoriesInitWithHooks(context.Background(), td.providerTestHooks(), "azurerm", "azurerm-alt")
+			return
+		}
+	}
+
+	testCase.ProviderFactories = td.providers()
+}
+
 func (td TestData) providers() map[string]func() (*schema.Provider, error) {
+	hooks := td.providerTestHooks()
 	return map[string]func() (*schema.Provider, error){
 		"azurerm": func() (*schema.Provider, error) { //nolint:unparam
-			azurerm := provider.TestAzureProvider()
+			azurerm := provider.TestAzureProviderWithHooks(hooks)
 			return azurerm, nil
 		},
 		"azurerm-alt": func() (*schema.Provider, error) { //nolint:unparam
-			azurerm := provider.TestAzureProvider()
+			azurerm := provider.TestAzureProviderWithHooks(hooks)
 			return azurerm, nil
 		},
 	}
 }
 
+// providerTestHooks returns the TestHooks used to send the Provider's requests to the HTTP Recorder
+// (when Acceptance Tests are being recorded/replayed) or to the Fake Resource Manager for this Test
+func (td TestData) providerTestHooks() provider.TestHooks {
+	if td.fakeResourceManager != nil {
+		return provider.TestHooks{
+			Environment: td.fakeResourceManager.Environment(),
+			Recorder:    td.fakeResourceManager.Recorder(),
+		}
+	}
+
+	return provider.TestHooks{
+		Recorder: recording.Recorder(),
+	}
+}
+
 func (td TestData) externalProviders() map[string]resource.ExternalProvider {
 	return map[string]resource.ExternalProvider{
 		"azuread": {
@@ -11,6 +11,8 @@ import (
 
 	"github.com/hashicorp/go-azure-sdk/sdk/auth"
 	"github.com/hashicorp/go-azure-sdk/sdk/environments"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/fakearm"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/recording"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/features"
 )
@@ -24,6 +26,25 @@ func Build() (*clients.Client, error) {
 	clientLock.Lock()
 	defer clientLock.Unlock()
 
+	// each Fake Resource Manager is specific to a single test, so the client can't be cached
+	if fake := fakearm.ForMetadataHost(os.Getenv("ARM_METADATA_HOSTNAME")); fake != nil {
+		client, err := clients.Build(context.TODO(), clients.ClientBuilder{
+			AuthConfig: &auth.Credentials{
+				Environment: *fake.Environment(),
+				ClientID:    fakearm.ClientId,
+				TenantID:    fakearm.TenantId,
+			},
+			TerraformVersion: os.Getenv("TERRAFORM_CORE_VERSION"),
+			Features:         features.Default(),
+			SubscriptionID:   fakearm.SubscriptionId,
+			Recorder:         fake.Recorder(),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("building test client for the Fake Resource Manager: %+v", err)
+		}
+		return client, nil
+	}
+
 	if _client == nil {
 		var (
 			ctx = context.TODO()
@@ -70,6 +91,7 @@ func Build() (*clients.Client, error) {
 			Features:          features.Default(),
 			StorageUseAzureAD: false,
 			SubscriptionID:    os.Getenv("ARM_SUBSCRIPTION_ID"),
+			Recorder:          recording.Recorder(),
 		}
 
 		client, err := clients.Build(ctx, clientBuilder)
@@ -13,6 +13,7 @@ import (
 	"github.com/hashicorp/go-azure-sdk/sdk/auth"
 	"github.com/hashicorp/go-azure-sdk/sdk/environments"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/fakearm"
 )
 
 func PreCheck(t *testing.T) {
@@ -59,7 +60,9 @@ func GetAuthConfig(t *testing.T) *auth.Credentials {
 		metadataHost = os.Getenv("ARM_METADATA_HOSTNAME")
 	)
 
-	if metadataHost != "" {
+	if fake := fakearm.ForMetadataHost(metadataHost); fake != nil {
+		env = fake.Environment()
+	} else if metadataHost != "" {
 		if env, err = environments.FromEndpoint(ctx, fmt.Sprintf("https://%s", metadataHost)); err != nil {
 			t.Fatalf("building test client: %+v", err)
 			return nil
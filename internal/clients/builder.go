@@ -28,6 +28,7 @@ type ClientBuilder struct {
 	DisableTerraformPartnerID   bool
 	MetadataHost                string
 	PartnerID                   string
+	Recorder                    common.HTTPRecorder
 	RegisteredResourceProviders resourceproviders.ResourceProviders
 	StorageUseAzureAD           bool
 	SubscriptionID              string
@@ -51,6 +52,10 @@ func Build(ctx context.Context, builder ClientBuilder) (*Client, error) {
 		return nil, fmt.Errorf(azureStackEnvironmentError)
 	}
 
+	if builder.Recorder != nil && builder.Recorder.Offline() {
+		return buildOffline(ctx, builder)
+	}
+
 	var resourceManagerAuth, storageAuth, synapseAuth, batchManagementAuth, keyVaultAuth auth.Authorizer
 
 	resourceManagerAuth, err = auth.NewAuthorizerFromCredentials(ctx, *builder.AuthConfig, builder.AuthConfig.Environment.ResourceManager)
@@ -101,6 +106,10 @@ func Build(ctx context.Context, builder ClientBuilder) (*Client, error) {
 		return nil, fmt.Errorf("building account: %+v", err)
 	}
 
+	if builder.Recorder != nil {
+		builder.Recorder.SanitizeIdentity(account.ClientId, account.ObjectId, account.TenantId)
+	}
+
 	var managedHSMAuth auth.Authorizer
 	if builder.AuthConfig.Environment.ManagedHSM.Available() {
 		managedHSMAuth, err = auth.NewAuthorizerFromCredentials(ctx, *builder.AuthConfig, builder.AuthConfig.Environment.ManagedHSM)
@@ -153,6 +162,8 @@ func Build(ctx context.Context, builder ClientBuilder) (*Client, error) {
 		StorageUseAzureAD:           builder.StorageUseAzureAD,
 
 		ResourceManagerEndpoint: *resourceManagerEndpoint,
+
+		Recorder: builder.Recorder,
 	}
 
 	if err := client.Build(ctx, o); err != nil {
@@ -173,3 +184,71 @@ func Build(ctx context.Context, builder ClientBuilder) (*Client, error) {
 
 	return &client, nil
 }
+
+// buildOffline builds a Client which uses the recorded responses from the HTTP Recorder, meaning that
+// no credentials are required and the identity being used is taken from the recording
+func buildOffline(ctx context.Context, builder ClientBuilder) (*Client, error) {
+	resourceManagerEndpoint, ok := builder.AuthConfig.Environment.ResourceManager.Endpoint()
+	if !ok {
+		return nil, fmt.Errorf("unable to determine resource manager endpoint for the current environment")
+	}
+
+	clientId, objectId, tenantId := builder.Recorder.OfflineIdentity()
+	client := Client{
+		Account: &ResourceManagerAccount{
+			Environment:                      builder.AuthConfig.Environment,
+			ClientId:                         clientId,
+			ObjectId:                         objectId,
+			SubscriptionId:                   builder.SubscriptionID,
+			TenantId:                         tenantId,
+			AuthenticatedAsAServicePrincipal: true,
+			RegisteredResourceProviders:      builder.RegisteredResourceProviders,
+		},
+	}
+
+	authorizer := offlineAuthorizer{}
+	o := &common.ClientOptions{
+		Authorizers: &common.Authorizers{
+			BatchManagement: authorizer,
+			KeyVault:        authorizer,
+			ManagedHSM:      authorizer,
+			ResourceManager: authorizer,
+			Storage:         authorizer,
+			Synapse:         authorizer,
+			AuthorizerFunc: func(api environments.Api) (auth.Authorizer, error) {
+				return authorizer, nil
+			},
+		},
+
+		AuthConfig:  builder.AuthConfig,
+		Environment: builder.AuthConfig.Environment,
+		Features:    builder.Features,
+
+		SubscriptionId:   builder.SubscriptionID,
+		TenantId:         tenantId,
+		PartnerId:        builder.PartnerID,
+		TerraformVersion: builder.TerraformVersion,
+
+		BatchManagementAuthorizer: authWrapper.AutorestAuthorizer(authorizer),
+		KeyVaultAuthorizer:        authWrapper.AutorestAuthorizer(authorizer).BearerAuthorizerCallback(),
+		ManagedHSMAuthorizer:      authWrapper.AutorestAuthorizer(authorizer).BearerAuthorizerCallback(),
+		ResourceManagerAuthorizer: authWrapper.AutorestAuthorizer(authorizer),
+		SynapseAuthorizer:         authWrapper.AutorestAuthorizer(authorizer),
+
+		CustomCorrelationRequestID:  builder.CustomCorrelationRequestID,
+		DisableCorrelationRequestID: builder.DisableCorrelationRequestID,
+		DisableTerraformPartnerID:   builder.DisableTerraformPartnerID,
+		SkipProviderReg:             len(builder.RegisteredResourceProviders) == 0,
+		StorageUseAzureAD:           builder.StorageUseAzureAD,
+
+		ResourceManagerEndpoint: *resourceManagerEndpoint,
+
+		Recorder: builder.Recorder,
+	}
+
+	if err := client.Build(ctx, o); err != nil {
+		return nil, fmt.Errorf("building Client: %+v", err)
+	}
+
+	return &client, nil
+}
@@ -50,6 +50,9 @@ type ClientOptions struct {
 
 	ResourceManagerEndpoint string
 
+	// Recorder is used to capture/replay the HTTP traffic for the Acceptance Tests, and is nil otherwise
+	Recorder HTTPRecorder
+
 	// Legacy authorizers for go-autorest
 	BatchManagementAuthorizer autorest.Authorizer
 	KeyVaultAuthorizer        autorest.Authorizer
@@ -74,8 +77,14 @@ func (o ClientOptions) Configure(c client.BaseClient, authorizer auth.Authorizer
 		c.AppendRequestMiddleware(correlationRequestIDMiddleware(id))
 	}
 
-	c.AppendRequestMiddleware(requestLoggerMiddleware("AzureRM"))
-	c.AppendResponseMiddleware(responseLoggerMiddleware("AzureRM"))
+	requestLogger, responseLogger := loggerMiddlewares("AzureRM")
+	c.AppendRequestMiddleware(requestLogger)
+	c.AppendResponseMiddleware(responseLogger)
+
+	if o.Recorder != nil {
+		c.AppendRequestMiddleware(o.Recorder.RequestMiddleware())
+		c.AppendResponseMiddleware(o.Recorder.ResponseMiddleware())
+	}
 }
 
 // ConfigureClient sets up an autorest.Client using an autorest.Authorizer
@@ -92,6 +101,10 @@ func (o ClientOptions) ConfigureClient(c *autorest.Client, authorizer autorest.A
 		}
 		c.RequestInspector = withCorrelationRequestID(id)
 	}
+
+	if o.Recorder != nil {
+		c.Sender = o.Recorder.WrapSender(c.Sender)
+	}
 }
 
 func userAgent(userAgent, tfVersion, partnerID string, disableTerraformPartnerID bool) string {
@@ -4,13 +4,33 @@
 package common
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
 	"log"
 	"net/http"
 	"net/http/httputil"
+	"os"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/hashicorp/go-azure-sdk/sdk/client"
 )
 
+const (
+	// httpTraceEnvVar configures the format used when logging HTTP requests/responses, when set to
+	// `json` a single line of JSON is logged for each request/response, otherwise the request and
+	// response are each logged in wire format
+	httpTraceEnvVar = "ARM_HTTP_TRACE"
+
+	httpTraceFormatJSON = "json"
+
+	// maxTracedBodyLength is the maximum length of a request/response body included in a JSON trace
+	maxTracedBodyLength = 64 * 1024
+)
+
 func correlationRequestIDMiddleware(id string) client.RequestMiddleware {
 	return func(request *http.Request) (*http.Request, error) {
 		// ensure the `X-Correlation-ID` field is set
@@ -19,41 +39,319 @@ func correlationRequestIDMiddleware(id string) client.RequestMiddleware {
 	}
 }
 
-func requestLoggerMiddleware(providerName string) client.RequestMiddleware {
+// loggerMiddlewares returns the Request and Response middlewares used to log HTTP requests/responses,
+// in the format specified by the `ARM_HTTP_TRACE` environment variable
+func loggerMiddlewares(providerName string) (client.RequestMiddleware, client.ResponseMiddleware) {
+	r := NewRedactor()
+	if strings.EqualFold(os.Getenv(httpTraceEnvVar), httpTraceFormatJSON) {
+		return jsonTraceRequestMiddleware(r), jsonTraceResponseMiddleware(providerName, r, defaultPollTracker)
+	}
+
+	return requestLoggerMiddleware(providerName, r), responseLoggerMiddleware(providerName, r)
+}
+
+func requestLoggerMiddleware(providerName string, r Redactor) client.RequestMiddleware {
 	return func(request *http.Request) (*http.Request, error) {
-		// strip the authorization header prior to printing
-		authHeaderName := "Authorization"
-		auth := request.Header.Get(authHeaderName)
-		if auth != "" {
-			request.Header.Del(authHeaderName)
+		body, err := readRequestBody(request)
+		if err != nil {
+			log.Printf("[DEBUG] %s Request: %s to %s\n", providerName, request.Method, r.URL(request.URL))
+			return request, nil
+		}
+
+		// dump a redacted copy of the request to wire format
+		redacted := request.Clone(request.Context())
+		redacted.Header = r.Headers(request.Header)
+		redacted.URL, _ = request.URL.Parse(r.URL(request.URL))
+		if body != nil {
+			redactedBody := r.Body(body)
+			redacted.Body = io.NopCloser(bytes.NewReader(redactedBody))
+			redacted.ContentLength = int64(len(redactedBody))
 		}
 
-		// dump request to wire format
-		if dump, err := httputil.DumpRequestOut(request, true); err == nil {
+		if dump, err := httputil.DumpRequestOut(redacted, true); err == nil {
 			log.Printf("[DEBUG] %s Request: \n%s\n", providerName, dump)
 		} else {
 			// fallback to basic message
-			log.Printf("[DEBUG] %s Request: %s to %s\n", providerName, request.Method, request.URL)
-		}
-
-		// add the auth header back
-		if auth != "" {
-			request.Header.Add(authHeaderName, auth)
+			log.Printf("[DEBUG] %s Request: %s to %s\n", providerName, request.Method, r.URL(request.URL))
 		}
 
 		return request, nil
 	}
 }
 
-func responseLoggerMiddleware(providerName string) client.ResponseMiddleware {
+func responseLoggerMiddleware(providerName string, r Redactor) client.ResponseMiddleware {
 	return func(request *http.Request, response *http.Response) (*http.Response, error) {
-		// dump response to wire format
-		if dump, err2 := httputil.DumpResponse(response, true); err2 == nil {
-			log.Printf("[DEBUG] %s Response for %s: \n%s\n", providerName, request.URL, dump)
+		body, err := readResponseBody(response)
+		if err != nil {
+			log.Printf("[DEBUG] %s Response: %s for %s\n", providerName, response.Status, r.URL(request.URL))
+			return response, nil
+		}
+
+		// dump a redacted copy of the response to wire format
+		redacted := *response
+		redacted.Header = r.Headers(response.Header)
+		if body != nil {
+			redactedBody := r.Body(body)
+			redacted.Body = io.NopCloser(bytes.NewReader(redactedBody))
+			redacted.ContentLength = int64(len(redactedBody))
+		}
+
+		if dump, err := httputil.DumpResponse(&redacted, true); err == nil {
+			log.Printf("[DEBUG] %s Response for %s: \n%s\n", providerName, r.URL(request.URL), dump)
 		} else {
 			// fallback to basic message
-			log.Printf("[DEBUG] %s Response: %s for %s\n", providerName, response.Status, request.URL)
+			log.Printf("[DEBUG] %s Response: %s for %s\n", providerName, response.Status, r.URL(request.URL))
 		}
 		return response, nil
 	}
 }
+
+type httpTraceContextKey struct{}
+
+// httpTraceRequest is the information about a request which is recorded by the Request middleware
+// and logged alongside the response by the Response middleware
+type httpTraceRequest struct {
+	start time.Time
+	body  []byte
+}
+
+// httpTrace is the JSON representation of a single request/response
+type httpTrace struct {
+	Timestamp            string           `json:"timestamp"`
+	CorrelationRequestID string           `json:"correlation_request_id,omitempty"`
+	RequestID            string           `json:"request_id,omitempty"`
+	Method               string           `json:"method"`
+	URL                  string           `json:"url"`
+	StatusCode           int              `json:"status_code"`
+	DurationMs           int64            `json:"duration_ms"`
+	PollCount            int              `json:"lro_poll_count,omitempty"`
+	Request              httpTraceMessage `json:"request"`
+	Response             httpTraceMessage `json:"response"`
+}
+
+type httpTraceMessage struct {
+	Headers   http.Header `json:"headers,omitempty"`
+	Body      string      `json:"body,omitempty"`
+	Truncated bool        `json:"body_truncated,omitempty"`
+}
+
+func newHttpTraceMessage(r Redactor, headers http.Header, body []byte) httpTraceMessage {
+	output := httpTraceMessage{
+		Headers: r.Headers(headers),
+	}
+	if body != nil {
+		redacted := r.Body(body)
+		if len(redacted) > maxTracedBodyLength {
+			redacted = redacted[:maxTracedBodyLength]
+			output.Truncated = true
+		}
+		output.Body = string(redacted)
+	}
+	return output
+}
+
+func jsonTraceRequestMiddleware(r Redactor) client.RequestMiddleware {
+	return func(request *http.Request) (*http.Request, error) {
+		trace := httpTraceRequest{
+			start: time.Now(),
+		}
+		if body, err := readRequestBody(request); err == nil {
+			trace.body = body
+		}
+
+		return request.WithContext(context.WithValue(request.Context(), httpTraceContextKey{}, trace)), nil
+	}
+}
+
+func jsonTraceResponseMiddleware(providerName string, r Redactor, polls *pollTracker) client.ResponseMiddleware {
+	return func(request *http.Request, response *http.Response) (*http.Response, error) {
+		traceRequest, ok := request.Context().Value(httpTraceContextKey{}).(httpTraceRequest)
+		if !ok {
+			traceRequest.start = time.Now()
+		}
+
+		responseBody, err := readResponseBody(response)
+		if err != nil {
+			log.Printf("[DEBUG] %s Response: %s for %s\n", providerName, response.Status, r.URL(request.URL))
+			return response, nil
+		}
+
+		trace := httpTrace{
+			Timestamp:            traceRequest.start.UTC().Format(time.RFC3339Nano),
+			CorrelationRequestID: request.Header.Get(HeaderCorrelationRequestID),
+			RequestID:            response.Header.Get("x-ms-request-id"),
+			Method:               request.Method,
+			URL:                  r.URL(request.URL),
+			StatusCode:           response.StatusCode,
+			DurationMs:           time.Since(traceRequest.start).Milliseconds(),
+			PollCount:            polls.track(request, response, responseBody),
+			Request:              newHttpTraceMessage(r, request.Header, traceRequest.body),
+			Response:             newHttpTraceMessage(r, response.Header, responseBody),
+		}
+
+		if line, err := json.Marshal(trace); err == nil {
+			log.Printf("[DEBUG] %s HTTP Trace: %s", providerName, line)
+		} else {
+			log.Printf("[DEBUG] %s Response: %s for %s\n", providerName, response.Status, r.URL(request.URL))
+		}
+
+		return response, nil
+	}
+}
+
+// defaultPollTracker is shared between all clients, since a Long Running Operation can be polled using
+// a different client to the one which started it
+var defaultPollTracker = newPollTracker()
+
+const (
+	// pollTrackerExpiry is how long a Long Running Operation is tracked without being polled, after which
+	// it's assumed to have been abandoned (for example when the Provider was interrupted)
+	pollTrackerExpiry = 2 * time.Hour
+
+	// pollTrackerMaxSize is the maximum number of Long Running Operations which are tracked at once, after
+	// which the least recently polled is no longer tracked
+	pollTrackerMaxSize = 1000
+)
+
+// pollTracker keeps track of the polling URLs returned when starting a Long Running Operation, so that
+// the number of times each Long Running Operation has been polled can be included in the trace
+type pollTracker struct {
+	polls map[string]trackedPoll
+	lock  sync.Mutex
+
+	// now returns the current time, and can be overridden in tests
+	now func() time.Time
+}
+
+type trackedPoll struct {
+	count    int
+	lastSeen time.Time
+}
+
+func newPollTracker() *pollTracker {
+	return &pollTracker{
+		polls: make(map[string]trackedPoll),
+		now:   time.Now,
+	}
+}
+
+// track returns the number of times that the Long Running Operation polled by request has been polled
+// (including this request), or 0 when request isn't polling a Long Running Operation
+func (p *pollTracker) track(request *http.Request, response *http.Response, responseBody []byte) int {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	now := p.now()
+	p.removeExpired(now)
+
+	count := 0
+	url := request.URL.String()
+	if existing, ok := p.polls[url]; ok {
+		count = existing.count + 1
+		if pollCompleted(response, responseBody) {
+			delete(p.polls, url)
+		} else {
+			p.polls[url] = trackedPoll{count: count, lastSeen: now}
+		}
+	}
+
+	// a Long Running Operation can be started (or redirected to another polling URL) by any response
+	if response.StatusCode == http.StatusCreated || response.StatusCode == http.StatusAccepted {
+		for _, header := range []string{"Azure-AsyncOperation", "Location"} {
+			if v := response.Header.Get(header); v != "" && v != url {
+				p.removeLeastRecentlyPolled()
+				p.polls[v] = trackedPoll{count: count, lastSeen: now}
+			}
+		}
+	}
+
+	return count
+}
+
+// removeExpired stops tracking any Long Running Operations which haven't been polled within the expiry
+func (p *pollTracker) removeExpired(now time.Time) {
+	for url, poll := range p.polls {
+		if now.Sub(poll.lastSeen) > pollTrackerExpiry {
+			delete(p.polls, url)
+		}
+	}
+}
+
+// removeLeastRecentlyPolled stops tracking the least recently polled Long Running Operation when the
+// maximum number of Long Running Operations are being tracked
+func (p *pollTracker) removeLeastRecentlyPolled() {
+	if len(p.polls) < pollTrackerMaxSize {
+		return
+	}
+
+	oldestUrl := ""
+	var oldest time.Time
+	for url, poll := range p.polls {
+		if oldestUrl == "" || poll.lastSeen.Before(oldest) {
+			oldestUrl = url
+			oldest = poll.lastSeen
+		}
+	}
+	delete(p.polls, oldestUrl)
+}
+
+// pollCompleted returns whether the response for a polling request indicates that the Long Running
+// Operation has completed
+func pollCompleted(response *http.Response, responseBody []byte) bool {
+	if response.StatusCode == http.StatusAccepted {
+		return false
+	}
+	if response.StatusCode >= http.StatusMultipleChoices {
+		return true
+	}
+
+	var body struct {
+		Status     string `json:"status"`
+		Properties *struct {
+			ProvisioningState string `json:"provisioningState"`
+		} `json:"properties"`
+	}
+	if err := json.Unmarshal(responseBody, &body); err != nil {
+		return true
+	}
+	status := body.Status
+	if status == "" && body.Properties != nil {
+		status = body.Properties.ProvisioningState
+	}
+
+	switch strings.ToLower(status) {
+	case "", "succeeded", "failed", "canceled", "cancelled":
+		return true
+	}
+	return false
+}
+
+// readRequestBody reads and returns the body of the request, replacing it so that it can be read again
+func readRequestBody(request *http.Request) ([]byte, error) {
+	if request.Body == nil || request.Body == http.NoBody {
+		return nil, nil
+	}
+
+	body, err := io.ReadAll(request.Body)
+	if err != nil {
+		return nil, err
+	}
+	request.Body.Close()
+	request.Body = io.NopCloser(bytes.NewReader(body))
+	return body, nil
+}
+
+// readResponseBody reads and returns the body of the response, replacing it so that it can be read again
+func readResponseBody(response *http.Response) ([]byte, error) {
+	if response.Body == nil || response.Body == http.NoBody {
+		return nil, nil
+	}
+
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, err
+	}
+	response.Body.Close()
+	response.Body = io.NopCloser(bytes.NewReader(body))
+	return body, nil
+}
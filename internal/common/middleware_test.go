@@ -0,0 +1,195 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package common
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func captureLogs(t *testing.T) *bytes.Buffer {
+	buf := &bytes.Buffer{}
+	existing := log.Writer()
+	log.SetOutput(buf)
+	t.Cleanup(func() {
+		log.SetOutput(existing)
+	})
+	return buf
+}
+
+func testRequest(t *testing.T, method, url, body string) *http.Request {
+	request, err := http.NewRequest(method, url, strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("building request: %+v", err)
+	}
+	request.Header.Set("Authorization", "Bearer abc123")
+	request.Header.Set(HeaderCorrelationRequestID, "00000000-0000-0000-0000-000000000001")
+	return request
+}
+
+func testResponse(request *http.Request, statusCode int, headers map[string]string, body string) *http.Response {
+	response := &http.Response{
+		StatusCode: statusCode,
+		Status:     http.StatusText(statusCode),
+		Proto:      "HTTP/1.1",
+		ProtoMajor: 1,
+		ProtoMinor: 1,
+		Header:     http.Header{},
+		Body:       io.NopCloser(strings.NewReader(body)),
+		Request:    request,
+	}
+	for k, v := range headers {
+		response.Header.Set(k, v)
+	}
+	return response
+}
+
+func TestLoggerMiddlewares_WireFormatIsRedacted(t *testing.T) {
+	logs := captureLogs(t)
+	requestMiddleware, responseMiddleware := loggerMiddlewares("AzureRM")
+
+	request := testRequest(t, http.MethodPut, "https://management.azure.com/servers/example", `{"properties":{"administratorLoginPassword":"hunter2"}}`)
+	request, err := requestMiddleware(request)
+	if err != nil {
+		t.Fatalf("unexpected error: %+v", err)
+	}
+
+	response := testResponse(request, http.StatusOK, nil, `{"keys":[{"keyName":"key1","value":"secret-key"}]}`)
+	response, err = responseMiddleware(request, response)
+	if err != nil {
+		t.Fatalf("unexpected error: %+v", err)
+	}
+
+	output := logs.String()
+	for _, secret := range []string{"abc123", "hunter2", "secret-key"} {
+		if strings.Contains(output, secret) {
+			t.Fatalf("expected %q to be redacted but got:\n%s", secret, output)
+		}
+	}
+
+	// the original request and response must be unchanged
+	if v := request.Header.Get("Authorization"); v != "Bearer abc123" {
+		t.Fatalf("expected the `Authorization` header to be unchanged but got %q", v)
+	}
+	requestBody, _ := io.ReadAll(request.Body)
+	if !strings.Contains(string(requestBody), "hunter2") {
+		t.Fatalf("expected the request body to be unchanged but got %s", requestBody)
+	}
+	responseBody, _ := io.ReadAll(response.Body)
+	if !strings.Contains(string(responseBody), "secret-key") {
+		t.Fatalf("expected the response body to be unchanged but got %s", responseBody)
+	}
+}
+
+func TestLoggerMiddlewares_JSONTrace(t *testing.T) {
+	t.Setenv(httpTraceEnvVar, "json")
+	logs := captureLogs(t)
+	requestMiddleware, responseMiddleware := loggerMiddlewares("AzureRM")
+
+	request := testRequest(t, http.MethodPost, "https://management.azure.com/storageAccounts/example/listKeys", "")
+	request, err := requestMiddleware(request)
+	if err != nil {
+		t.Fatalf("unexpected error: %+v", err)
+	}
+	response := testResponse(request, http.StatusOK, map[string]string{"x-ms-request-id": "abc"}, `{"keys":[{"keyName":"key1","value":"secret-key"}]}`)
+	if _, err := responseMiddleware(request, response); err != nil {
+		t.Fatalf("unexpected error: %+v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(logs.String()), "\n")
+	if len(lines) != 1 {
+		t.Fatalf("expected a single line to be logged but got %d:\n%s", len(lines), logs.String())
+	}
+	if strings.Contains(lines[0], "secret-key") || strings.Contains(lines[0], "abc123") {
+		t.Fatalf("expected the trace to be redacted but got %s", lines[0])
+	}
+
+	_, raw, found := strings.Cut(lines[0], "AzureRM HTTP Trace: ")
+	if !found {
+		t.Fatalf("expected a HTTP Trace but got %s", lines[0])
+	}
+	var trace httpTrace
+	if err := json.Unmarshal([]byte(raw), &trace); err != nil {
+		t.Fatalf("parsing trace %s: %+v", raw, err)
+	}
+	if trace.CorrelationRequestID != "00000000-0000-0000-0000-000000000001" {
+		t.Fatalf("expected the correlation request id to be set but got %q", trace.CorrelationRequestID)
+	}
+	if trace.RequestID != "abc" {
+		t.Fatalf("expected the request id to be `abc` but got %q", trace.RequestID)
+	}
+	if trace.Method != http.MethodPost || trace.StatusCode != http.StatusOK {
+		t.Fatalf("expected a POST returning a 200 but got a %s returning a %d", trace.Method, trace.StatusCode)
+	}
+	if v := trace.Request.Headers.Get("Authorization"); v != redactedValue {
+		t.Fatalf("expected the `Authorization` header to be redacted but got %q", v)
+	}
+}
+
+func TestPollTracker(t *testing.T) {
+	tracker := newPollTracker()
+	pollUrl := "https://management.azure.com/operations/abc?api-version=2023-01-01"
+
+	start := testRequest(t, http.MethodPut, "https://management.azure.com/servers/example", "{}")
+	if count := tracker.track(start, testResponse(start, http.StatusCreated, map[string]string{"Azure-AsyncOperation": pollUrl}, "{}"), []byte("{}")); count != 0 {
+		t.Fatalf("expected the initial request to not be a poll but got %d", count)
+	}
+
+	for i, status := range []string{"InProgress", "InProgress", "Succeeded"} {
+		poll := testRequest(t, http.MethodGet, pollUrl, "")
+		body := []byte(`{"status":"` + status + `"}`)
+		if count := tracker.track(poll, testResponse(poll, http.StatusOK, nil, string(body)), body); count != i+1 {
+			t.Fatalf("expected poll %d but got %d", i+1, count)
+		}
+	}
+
+	if len(tracker.polls) != 0 {
+		t.Fatalf("expected completed polls to be removed but got %+v", tracker.polls)
+	}
+}
+
+func TestPollTracker_Expiry(t *testing.T) {
+	now := time.Now()
+	tracker := newPollTracker()
+	tracker.now = func() time.Time {
+		return now
+	}
+	pollUrl := "https://management.azure.com/operations/abc?api-version=2023-01-01"
+
+	start := testRequest(t, http.MethodPut, "https://management.azure.com/servers/example", "{}")
+	tracker.track(start, testResponse(start, http.StatusAccepted, map[string]string{"Location": pollUrl}, ""), nil)
+
+	// the Long Running Operation is abandoned, so is no longer tracked once it's expired
+	now = now.Add(pollTrackerExpiry + time.Minute)
+	poll := testRequest(t, http.MethodGet, pollUrl, "")
+	if count := tracker.track(poll, testResponse(poll, http.StatusAccepted, nil, ""), nil); count != 0 {
+		t.Fatalf("expected the expired Long Running Operation to no longer be tracked but got %d", count)
+	}
+	if len(tracker.polls) != 0 {
+		t.Fatalf("expected expired polls to be removed but got %+v", tracker.polls)
+	}
+}
+
+func TestPollTracker_MaxSize(t *testing.T) {
+	tracker := newPollTracker()
+
+	for i := 0; i < pollTrackerMaxSize+10; i++ {
+		start := testRequest(t, http.MethodPut, fmt.Sprintf("https://management.azure.com/servers/example%d", i), "{}")
+		headers := map[string]string{
+			"Azure-AsyncOperation": fmt.Sprintf("https://management.azure.com/operations/%d?api-version=2023-01-01", i),
+		}
+		tracker.track(start, testResponse(start, http.StatusCreated, headers, "{}"), []byte("{}"))
+	}
+
+	if len(tracker.polls) != pollTrackerMaxSize {
+		t.Fatalf("expected %d polls to be tracked but got %d", pollTrackerMaxSize, len(tracker.polls))
+	}
+}
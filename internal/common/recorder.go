@@ -0,0 +1,33 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package common
+
+import (
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/hashicorp/go-azure-sdk/sdk/client"
+)
+
+// HTTPRecorder captures (and replays) the HTTP traffic sent by both the go-azure-sdk and go-autorest based clients,
+// which allows the Acceptance Tests to be re-run without access to an Azure Subscription.
+type HTTPRecorder interface {
+	// RequestMiddleware returns the Request Middleware which should be appended to go-azure-sdk based clients
+	RequestMiddleware() client.RequestMiddleware
+
+	// ResponseMiddleware returns the Response Middleware which should be appended to go-azure-sdk based clients
+	ResponseMiddleware() client.ResponseMiddleware
+
+	// WrapSender wraps the Sender used by go-autorest based clients
+	WrapSender(sender autorest.Sender) autorest.Sender
+
+	// Offline returns whether previously recorded responses are being served, in which case no requests
+	// are sent to Azure and no credentials are required
+	Offline() bool
+
+	// OfflineIdentity returns the Client ID, Object ID and Tenant ID which should be used when Offline
+	OfflineIdentity() (clientId, objectId, tenantId string)
+
+	// SanitizeIdentity registers the authenticated Client ID, Object ID and Tenant ID so that these
+	// are replaced with their Offline counterparts in any recordings
+	SanitizeIdentity(clientId, objectId, tenantId string)
+}
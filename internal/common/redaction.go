@@ -0,0 +1,283 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package common
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+)
+
+const (
+	// httpTraceRedactEnvVar allows specifying additional values which should be redacted when logging HTTP
+	// requests/responses, as a comma-separated list of:
+	//
+	// * JSON keys, which are redacted at any depth (e.g. `customSecret`)
+	// * JSON paths from the root of the body, using `[]` for array items and `*` for any key (e.g. `properties.items[].token`)
+	// * Headers, prefixed with `header:` (e.g. `header:X-Custom-Key`)
+	httpTraceRedactEnvVar = "ARM_HTTP_TRACE_REDACT"
+
+	redactedValue = "REDACTED"
+)
+
+// sensitiveHeaders are the (lower-cased) headers which are always redacted
+var sensitiveHeaders = []string{
+	"authorization",
+	"cookie",
+	"ocp-apim-subscription-key",
+	"set-cookie",
+	"x-functions-key",
+	"x-ms-authorization-auxiliary",
+	"x-ms-copy-source-authorization",
+	"x-ms-encryption-key",
+}
+
+// sensitiveQueryParameters are the (lower-cased) query string parameters which are always redacted
+var sensitiveQueryParameters = []string{
+	"code",
+	"sig",
+}
+
+// sensitiveKeys are the (lower-cased) JSON keys which are redacted at any depth, including any nested values
+var sensitiveKeys = []string{
+	"accesskey",
+	"accesstoken",
+	"accountkey",
+	"authkey",
+	"customdata",
+	// e.g. the `listKeys` API for Cognitive Accounts
+	"key1",
+	"key2",
+	"primarykey",
+	"primarymasterkey",
+	"primaryreadonlymasterkey",
+	"privatekey",
+	"refreshtoken",
+	"sastoken",
+	"secondarykey",
+	"secondarymasterkey",
+	"secondaryreadonlymasterkey",
+	"sharedkey",
+	"storageaccountaccesskey",
+}
+
+// sensitiveKeySuffixes are the (lower-cased) suffixes of JSON keys which are redacted at any depth, for
+// example `administratorLoginPassword` or `primaryConnectionString`
+var sensitiveKeySuffixes = []string{
+	"connectionstring",
+	"password",
+	"secret",
+}
+
+// sensitivePaths are the JSON paths (from the root of the body) where scalar values are redacted - these
+// are used where the key is too generic to be redacted at any depth (e.g. `value`)
+var sensitivePaths = []string{
+	// Key Vault Secrets
+	"value",
+
+	// e.g. the `listKeys` API for Storage Accounts and Cognitive Accounts
+	"keys[].value",
+}
+
+// Redactor redacts sensitive values from HTTP requests and responses - this is used both when logging these
+// and when recording these for the Acceptance Tests, so that the same values are redacted in each
+type Redactor struct {
+	headers         map[string]struct{}
+	queryParameters map[string]struct{}
+	keys            map[string]struct{}
+	keySuffixes     []string
+	paths           [][]string
+}
+
+// NewRedactor returns a Redactor using the default sensitive values, in addition to those specified
+// in the `ARM_HTTP_TRACE_REDACT` environment variable
+func NewRedactor() Redactor {
+	r := Redactor{
+		headers:         make(map[string]struct{}),
+		queryParameters: make(map[string]struct{}),
+		keys:            make(map[string]struct{}),
+		keySuffixes:     sensitiveKeySuffixes,
+		paths:           make([][]string, 0),
+	}
+	for _, v := range sensitiveHeaders {
+		r.headers[v] = struct{}{}
+	}
+	for _, v := range sensitiveQueryParameters {
+		r.queryParameters[v] = struct{}{}
+	}
+	for _, v := range sensitiveKeys {
+		r.keys[v] = struct{}{}
+	}
+	for _, v := range sensitivePaths {
+		r.paths = append(r.paths, parseRedactionPath(v))
+	}
+
+	for _, v := range strings.Split(os.Getenv(httpTraceRedactEnvVar), ",") {
+		r.add(strings.TrimSpace(v))
+	}
+
+	return r
+}
+
+func (r *Redactor) add(input string) {
+	if input == "" {
+		return
+	}
+
+	input = strings.ToLower(input)
+	if header, ok := strings.CutPrefix(input, "header:"); ok {
+		r.headers[header] = struct{}{}
+		return
+	}
+
+	if strings.ContainsAny(input, ".[*") {
+		r.paths = append(r.paths, parseRedactionPath(input))
+		return
+	}
+
+	r.keys[input] = struct{}{}
+}
+
+// parseRedactionPath parses a path such as `keys[].value` into the segments `keys`, `[]` and `value`
+func parseRedactionPath(input string) []string {
+	output := make([]string, 0)
+	for _, segment := range strings.Split(strings.ToLower(input), ".") {
+		for strings.HasSuffix(segment, "[]") {
+			segment = strings.TrimSuffix(segment, "[]")
+			if segment != "" {
+				output = append(output, segment)
+			}
+			output = append(output, "[]")
+			segment = ""
+		}
+		if segment != "" {
+			output = append(output, segment)
+		}
+	}
+	return output
+}
+
+// Headers returns a copy of input with any sensitive headers redacted
+func (r Redactor) Headers(input http.Header) http.Header {
+	output := input.Clone()
+	for name := range output {
+		if _, ok := r.headers[strings.ToLower(name)]; ok {
+			output[name] = []string{redactedValue}
+		}
+	}
+	return output
+}
+
+// URL returns the string representation of input with any sensitive query string parameters redacted
+func (r Redactor) URL(input *url.URL) string {
+	if input == nil {
+		return ""
+	}
+
+	query := input.Query()
+	redacted := false
+	for name := range query {
+		if _, ok := r.queryParameters[strings.ToLower(name)]; ok {
+			query[name] = []string{redactedValue}
+			redacted = true
+		}
+	}
+	if !redacted {
+		return input.String()
+	}
+
+	output := *input
+	output.RawQuery = query.Encode()
+	return output.String()
+}
+
+// Body returns a copy of the (JSON) body with any sensitive values redacted - bodies which aren't
+// JSON are returned unchanged
+func (r Redactor) Body(input []byte) []byte {
+	if len(bytes.TrimSpace(input)) == 0 {
+		return input
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(input))
+	decoder.UseNumber()
+	var body interface{}
+	if err := decoder.Decode(&body); err != nil {
+		return input
+	}
+
+	buf := &bytes.Buffer{}
+	encoder := json.NewEncoder(buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(r.redactValue(body, []string{})); err != nil {
+		return input
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n"))
+}
+
+func (r Redactor) redactValue(input interface{}, path []string) interface{} {
+	switch v := input.(type) {
+	case map[string]interface{}:
+		for key, value := range v {
+			if value == nil {
+				continue
+			}
+
+			if r.isSensitiveKey(key) {
+				v[key] = redactedValue
+				continue
+			}
+
+			v[key] = r.redactValue(value, append(path[:len(path):len(path)], strings.ToLower(key)))
+		}
+		return v
+
+	case []interface{}:
+		for i, value := range v {
+			v[i] = r.redactValue(value, append(path[:len(path):len(path)], "[]"))
+		}
+		return v
+
+	default:
+		if r.isSensitivePath(path) {
+			return redactedValue
+		}
+		return v
+	}
+}
+
+func (r Redactor) isSensitiveKey(key string) bool {
+	key = strings.ToLower(key)
+	if _, ok := r.keys[key]; ok {
+		return true
+	}
+	for _, suffix := range r.keySuffixes {
+		if strings.HasSuffix(key, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r Redactor) isSensitivePath(path []string) bool {
+	for _, candidate := range r.paths {
+		if len(candidate) != len(path) {
+			continue
+		}
+
+		matches := true
+		for i, segment := range candidate {
+			if segment != "*" && segment != path[i] {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,135 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package common
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+)
+
+func TestRedactor_Body(t *testing.T) {
+	testData := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "not json",
+			input:    "hello world",
+			expected: "hello world",
+		},
+		{
+			name:     "empty",
+			input:    "",
+			expected: "",
+		},
+		{
+			name:     "password at any depth",
+			input:    `{"properties":{"administratorLogin":"admin","administratorLoginPassword":"P@ssw0rd"}}`,
+			expected: `{"properties":{"administratorLogin":"admin","administratorLoginPassword":"REDACTED"}}`,
+		},
+		{
+			name:     "connection strings",
+			input:    `{"primaryConnectionString":"Endpoint=sb://example","name":"example"}`,
+			expected: `{"name":"example","primaryConnectionString":"REDACTED"}`,
+		},
+		{
+			name:     "nested values beneath a sensitive key",
+			input:    `{"properties":{"secret":{"value":"abc","type":"string"}}}`,
+			expected: `{"properties":{"secret":"REDACTED"}}`,
+		},
+		{
+			name:     "null values are left as-is",
+			input:    `{"properties":{"password":null}}`,
+			expected: `{"properties":{"password":null}}`,
+		},
+		{
+			name:     "list keys",
+			input:    `{"keys":[{"keyName":"key1","value":"abc","permissions":"FULL"},{"keyName":"key2","value":"def","permissions":"FULL"}]}`,
+			expected: `{"keys":[{"keyName":"key1","permissions":"FULL","value":"REDACTED"},{"keyName":"key2","permissions":"FULL","value":"REDACTED"}]}`,
+		},
+		{
+			name:     "key vault secret",
+			input:    `{"value":"hunter2","id":"https://example.vault.azure.net/secrets/example/abc"}`,
+			expected: `{"id":"https://example.vault.azure.net/secrets/example/abc","value":"REDACTED"}`,
+		},
+		{
+			name:     "list results are left as-is",
+			input:    `{"value":[{"name":"example","properties":{"value":"not-a-secret"}}]}`,
+			expected: `{"value":[{"name":"example","properties":{"value":"not-a-secret"}}]}`,
+		},
+		{
+			name:     "numbers keep their precision",
+			input:    `{"properties":{"size":12345678901234567890}}`,
+			expected: `{"properties":{"size":12345678901234567890}}`,
+		},
+	}
+
+	r := NewRedactor()
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q..", v.name)
+
+		actual := string(r.Body([]byte(v.input)))
+		if actual != v.expected {
+			t.Fatalf("expected %s but got %s", v.expected, actual)
+		}
+	}
+}
+
+func TestRedactor_EnvironmentVariable(t *testing.T) {
+	t.Setenv(httpTraceRedactEnvVar, "customToken, properties.items[].name ,header:X-Custom-Key")
+	r := NewRedactor()
+
+	input := `{"customToken":"abc","properties":{"items":[{"name":"first","enabled":true}],"name":"example"}}`
+	expected := `{"customToken":"REDACTED","properties":{"items":[{"enabled":true,"name":"REDACTED"}],"name":"example"}}`
+	if actual := string(r.Body([]byte(input))); actual != expected {
+		t.Fatalf("expected %s but got %s", expected, actual)
+	}
+
+	headers := http.Header{}
+	headers.Set("X-Custom-Key", "abc")
+	if actual := r.Headers(headers).Get("X-Custom-Key"); actual != redactedValue {
+		t.Fatalf("expected the header `X-Custom-Key` to be redacted but got %q", actual)
+	}
+}
+
+func TestRedactor_Headers(t *testing.T) {
+	input := http.Header{}
+	input.Set("Authorization", "Bearer abc")
+	input.Set("X-Ms-Authorization-Auxiliary", "Bearer def")
+	input.Set("Content-Type", "application/json")
+
+	r := NewRedactor()
+	actual := r.Headers(input)
+
+	if v := actual.Get("Authorization"); v != redactedValue {
+		t.Fatalf("expected the `Authorization` header to be redacted but got %q", v)
+	}
+	if v := actual.Get("X-Ms-Authorization-Auxiliary"); v != redactedValue {
+		t.Fatalf("expected the `X-Ms-Authorization-Auxiliary` header to be redacted but got %q", v)
+	}
+	if v := actual.Get("Content-Type"); v != "application/json" {
+		t.Fatalf("expected the `Content-Type` header to be unchanged but got %q", v)
+	}
+	if v := input.Get("Authorization"); v != "Bearer abc" {
+		t.Fatalf("expected the original headers to be unchanged but got %q", v)
+	}
+}
+
+func TestRedactor_URL(t *testing.T) {
+	r := NewRedactor()
+
+	unchanged := "https://management.azure.com/subscriptions/123?api-version=2023-01-01"
+	input, _ := url.Parse(unchanged)
+	if actual := r.URL(input); actual != unchanged {
+		t.Fatalf("expected %q but got %q", unchanged, actual)
+	}
+
+	input, _ = url.Parse("https://example.blob.core.windows.net/container/blob?sig=abc&sv=2021-01-01")
+	expected := "https://example.blob.core.windows.net/container/blob?sig=REDACTED&sv=2021-01-01"
+	if actual := r.URL(input); actual != expected {
+		t.Fatalf("expected %q but got %q", expected, actual)
+	}
+}
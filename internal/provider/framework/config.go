@@ -6,6 +6,7 @@ package framework
 import (
 	"context"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
@@ -520,5 +521,9 @@ func (p *ProviderConfig) Load(ctx context.Context, data *ProviderModel, tfVersio
 		return
 	}
 
+	if strings.EqualFold(resourceProviderRegistrationSet, resourceproviders.ProviderRegistrationsLazy) {
+		client.ResourceProviderRegistration = resourceproviders.NewLazyRegistration(client.Resource.ResourceProvidersClient, subscriptionId)
+	}
+
 	p.Client = client
 }
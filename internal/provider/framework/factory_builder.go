@@ -14,11 +14,23 @@ import (
 )
 
 func ProtoV5ProviderFactoriesInit(ctx context.Context, providerNames ...string) map[string]func() (tfprotov5.ProviderServer, error) {
+	return protoV5ProviderFactories(ctx, provider.AzureProvider, providerNames...)
+}
+
+// ProtoV5ProviderFactoriesInitWithHooks returns the muxed Provider Factories used in the Acceptance Tests, where
+// the Plugin SDKv2 Provider is configured using the specified TestHooks
+func ProtoV5ProviderFactoriesInitWithHooks(ctx context.Context, hooks provider.TestHooks, providerNames ...string) map[string]func() (tfprotov5.ProviderServer, error) {
+	return protoV5ProviderFactories(ctx, func() *schema.Provider {
+		return provider.TestAzureProviderWithHooks(hooks)
+	}, providerNames...)
+}
+
+func protoV5ProviderFactories(ctx context.Context, v2Provider func() *schema.Provider, providerNames ...string) map[string]func() (tfprotov5.ProviderServer, error) {
 	factories := make(map[string]func() (tfprotov5.ProviderServer, error), len(providerNames))
 
 	for _, name := range providerNames {
 		factories[name] = func() (tfprotov5.ProviderServer, error) {
-			providerServerFactory, _, err := ProtoV5ProviderServerFactory(ctx)
+			providerServerFactory, err := muxedProviderServer(ctx, v2Provider())
 			if err != nil {
 				return nil, err
 			}
@@ -33,17 +45,26 @@ func ProtoV5ProviderFactoriesInit(ctx context.Context, providerNames ...string)
 func ProtoV5ProviderServerFactory(ctx context.Context) (func() tfprotov5.ProviderServer, *schema.Provider, error) {
 	v2Provider := provider.AzureProvider()
 
+	providerServerFactory, err := muxedProviderServer(ctx, v2Provider)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return providerServerFactory, v2Provider, nil
+}
+
+func muxedProviderServer(ctx context.Context, v2Provider *schema.Provider) (func() tfprotov5.ProviderServer, error) {
 	providers := []func() tfprotov5.ProviderServer{
 		v2Provider.GRPCProvider,
-		providerserver.NewProtocol5(NewFrameworkProvider(v2Provider)),
+		withLegacyTypeSystemForBridgedResources(providerserver.NewProtocol5(NewFrameworkProvider(v2Provider))),
 	}
 
 	muxServer, err := tf5muxserver.NewMuxServer(ctx, providers...)
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
 
-	return muxServer.ProviderServer, v2Provider, nil
+	return muxServer.ProviderServer, nil
 }
 
 func V5ProviderWithoutPluginSDK() func() tfprotov5.ProviderServer {
@@ -0,0 +1,57 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package framework
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/provider"
+)
+
+var _ tfprotov5.ProviderServer = legacyTypeSystemProviderServer{}
+
+// legacyTypeSystemProviderServer wraps the Plugin Framework Provider Server so that the Resources bridged from
+// Plugin SDKv2 (see provider.SupportedFrameworkResources) continue to opt into the Legacy Type System, since
+// Terraform otherwise rejects the inconsistencies between the Configuration, Plan and State which Plugin SDKv2
+// Resources are permitted to have
+type legacyTypeSystemProviderServer struct {
+	tfprotov5.ProviderServer
+
+	resourceTypes map[string]struct{}
+}
+
+func withLegacyTypeSystemForBridgedResources(server func() tfprotov5.ProviderServer) func() tfprotov5.ProviderServer {
+	resourceTypes := make(map[string]struct{})
+	for _, r := range provider.SupportedFrameworkResources() {
+		resourceTypes[r.ResourceType()] = struct{}{}
+	}
+
+	return func() tfprotov5.ProviderServer {
+		return legacyTypeSystemProviderServer{
+			ProviderServer: server(),
+			resourceTypes:  resourceTypes,
+		}
+	}
+}
+
+func (s legacyTypeSystemProviderServer) PlanResourceChange(ctx context.Context, req *tfprotov5.PlanResourceChangeRequest) (*tfprotov5.PlanResourceChangeResponse, error) {
+	resp, err := s.ProviderServer.PlanResourceChange(ctx, req)
+	if resp != nil {
+		if _, ok := s.resourceTypes[req.TypeName]; ok {
+			resp.UnsafeToUseLegacyTypeSystem = true
+		}
+	}
+	return resp, err
+}
+
+func (s legacyTypeSystemProviderServer) ApplyResourceChange(ctx context.Context, req *tfprotov5.ApplyResourceChangeRequest) (*tfprotov5.ApplyResourceChangeResponse, error) {
+	resp, err := s.ProviderServer.ApplyResourceChange(ctx, req)
+	if resp != nil {
+		if _, ok := s.resourceTypes[req.TypeName]; ok {
+			resp.UnsafeToUseLegacyTypeSystem = true
+		}
+	}
+	return resp, err
+}
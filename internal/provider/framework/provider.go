@@ -15,6 +15,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	azurerm "github.com/hashicorp/terraform-provider-azurerm/internal/provider"
 	providerfunction "github.com/hashicorp/terraform-provider-azurerm/internal/provider/function"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/resourceproviders"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk/frameworkhelpers"
@@ -206,6 +207,7 @@ func (p *azureRmFrameworkProvider) Schema(_ context.Context, _ provider.SchemaRe
 						resourceproviders.ProviderRegistrationsCore,
 						resourceproviders.ProviderRegistrationsExtended,
 						resourceproviders.ProviderRegistrationsAll,
+						resourceproviders.ProviderRegistrationsLazy,
 					),
 				},
 			},
@@ -489,6 +491,10 @@ func (p *azureRmFrameworkProvider) DataSources(_ context.Context) []func() datas
 }
 
 func (p *azureRmFrameworkProvider) Resources(_ context.Context) []func() resource.Resource {
-	// We do not currently support any Native framework Resources
-	return nil
+	if p.V2Provider == nil {
+		// Resources served through the Plugin Framework are configured using the Plugin SDKv2 Provider
+		return nil
+	}
+
+	return azurerm.FrameworkResources()
 }
@@ -0,0 +1,329 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package framework
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/provider"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+)
+
+func TestFrameworkResources_servedThroughPluginSDKUntilFourPointOh(t *testing.T) {
+	t.Setenv("ARM_FOURPOINTZERO_BETA", "false")
+
+	if resources := provider.SupportedFrameworkResources(); len(resources) > 0 {
+		t.Fatalf("expected no Resources to be served through the Plugin Framework but got %d", len(resources))
+	}
+
+	v2Provider := provider.AzureProvider()
+	for _, resourceType := range []string{"azurerm_mssql_server_dns_alias", "azurerm_resource_management_private_link_association"} {
+		if _, ok := v2Provider.ResourcesMap[resourceType]; !ok {
+			t.Fatalf("expected %q to be served through Plugin SDKv2", resourceType)
+		}
+	}
+}
+
+func TestFrameworkResources_schemaMatchesPluginSDK(t *testing.T) {
+	ctx := context.Background()
+	server := frameworkTestServer(t)
+
+	resp, err := server.GetProviderSchema(ctx, &tfprotov5.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatalf("retrieving the Provider Schema: %+v", err)
+	}
+	for _, d := range resp.Diagnostics {
+		if d.Severity == tfprotov5.DiagnosticSeverityError {
+			t.Fatalf("retrieving the Provider Schema: %s: %s", d.Summary, d.Detail)
+		}
+	}
+
+	resources := provider.SupportedFrameworkResources()
+	if len(resources) == 0 {
+		t.Fatalf("expected Resources to be served through the Plugin Framework")
+	}
+
+	for _, r := range resources {
+		t.Logf("[DEBUG] Testing %q..", r.ResourceType())
+
+		if _, ok := provider.AzureProvider().ResourcesMap[r.ResourceType()]; ok {
+			t.Fatalf("the Resource %q is also served through Plugin SDKv2", r.ResourceType())
+		}
+
+		s, ok := resp.ResourceSchemas[r.ResourceType()]
+		if !ok {
+			t.Fatalf("the Resource %q was not registered", r.ResourceType())
+		}
+
+		wrapper := sdk.NewResourceWrapper(r)
+		pluginSdkResource, err := wrapper.Resource()
+		if err != nil {
+			t.Fatalf("building the Plugin SDKv2 Resource for %q: %+v", r.ResourceType(), err)
+		}
+		pluginSdkProvider := &schema.Provider{
+			ResourcesMap: map[string]*schema.Resource{
+				r.ResourceType(): pluginSdkResource,
+			},
+		}
+		pluginSdkSchemas, err := pluginSdkProvider.GRPCProvider().GetProviderSchema(ctx, &tfprotov5.GetProviderSchemaRequest{})
+		if err != nil {
+			t.Fatalf("retrieving the Plugin SDKv2 Schema: %+v", err)
+		}
+		expected := pluginSdkSchemas.ResourceSchemas[r.ResourceType()]
+
+		if s.Version != expected.Version {
+			t.Fatalf("expected the Schema Version for %q to be %d but got %d", r.ResourceType(), expected.Version, s.Version)
+		}
+		if !s.ValueType().Equal(expected.ValueType()) {
+			t.Fatalf("expected the Schema for %q to be %s but got %s", r.ResourceType(), expected.ValueType(), s.ValueType())
+		}
+	}
+}
+
+func TestFrameworkResources_planCreate(t *testing.T) {
+	ctx := context.Background()
+	server := frameworkTestServer(t)
+	schemas, err := server.GetProviderSchema(ctx, &tfprotov5.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatalf("retrieving the Provider Schema: %+v", err)
+	}
+
+	resourceType := "azurerm_mssql_server_dns_alias"
+	objectType := schemas.ResourceSchemas[resourceType].ValueType().(tftypes.Object)
+	config := tftypes.NewValue(objectType, map[string]tftypes.Value{
+		"dns_record":      tftypes.NewValue(tftypes.String, nil),
+		"id":              tftypes.NewValue(tftypes.String, nil),
+		"mssql_server_id": tftypes.NewValue(tftypes.String, "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/example/providers/Microsoft.Sql/servers/server1"),
+		"name":            tftypes.NewValue(tftypes.String, "alias1"),
+		"timeouts":        tftypes.NewValue(objectType.AttributeTypes["timeouts"], nil),
+	})
+
+	configValue, err := tfprotov5.NewDynamicValue(objectType, config)
+	if err != nil {
+		t.Fatalf("building the Configuration: %+v", err)
+	}
+	priorState, err := tfprotov5.NewDynamicValue(objectType, tftypes.NewValue(objectType, nil))
+	if err != nil {
+		t.Fatalf("building the Prior State: %+v", err)
+	}
+
+	resp, err := server.PlanResourceChange(ctx, &tfprotov5.PlanResourceChangeRequest{
+		TypeName:         resourceType,
+		PriorState:       &priorState,
+		ProposedNewState: &configValue,
+		Config:           &configValue,
+	})
+	if err != nil {
+		t.Fatalf("planning %q: %+v", resourceType, err)
+	}
+	for _, d := range resp.Diagnostics {
+		if d.Severity == tfprotov5.DiagnosticSeverityError {
+			t.Fatalf("planning %q: %s: %s", resourceType, d.Summary, d.Detail)
+		}
+	}
+
+	if !resp.UnsafeToUseLegacyTypeSystem {
+		t.Fatalf("expected %q to use the Legacy Type System", resourceType)
+	}
+
+	planned, err := resp.PlannedState.Unmarshal(objectType)
+	if err != nil {
+		t.Fatalf("unmarshaling the Planned State: %+v", err)
+	}
+	attributes := make(map[string]tftypes.Value)
+	if err := planned.As(&attributes); err != nil {
+		t.Fatalf("converting the Planned State: %+v", err)
+	}
+	for _, name := range []string{"id", "dns_record"} {
+		if attributes[name].IsKnown() {
+			t.Fatalf("expected %q to be unknown but got %s", name, attributes[name])
+		}
+	}
+	if !attributes["name"].Equal(tftypes.NewValue(tftypes.String, "alias1")) {
+		t.Fatalf("expected `name` to be %q but got %s", "alias1", attributes["name"])
+	}
+}
+
+func TestFrameworkResources_upgradeStateFromPluginSDK(t *testing.T) {
+	testData := []struct {
+		resourceType string
+		rawState     string
+		expectedId   string
+	}{
+		{
+			resourceType: "azurerm_mssql_server_dns_alias",
+			rawState: `{
+  "dns_record": "example.database.windows.net",
+  "id": "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/example/providers/Microsoft.Sql/servers/server1/dnsAliases/alias1",
+  "mssql_server_id": "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/example/providers/Microsoft.Sql/servers/server1",
+  "name": "alias1",
+  "timeouts": null
+}`,
+			expectedId: "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/example/providers/Microsoft.Sql/servers/server1/dnsAliases/alias1",
+		},
+		{
+			resourceType: "azurerm_resource_management_private_link_association",
+			rawState: `{
+  "id": "/providers/Microsoft.Management/managementGroups/group1/providers/Microsoft.Authorization/privateLinkAssociations/00000000-0000-0000-0000-000000000000",
+  "management_group_id": "/providers/Microsoft.Management/managementGroups/group1",
+  "name": "00000000-0000-0000-0000-000000000000",
+  "public_network_access_enabled": true,
+  "resource_management_private_link_id": "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/example/providers/Microsoft.Authorization/resourceManagementPrivateLinks/link1",
+  "tenant_id": "00000000-0000-0000-0000-000000000000",
+  "timeouts": {
+    "create": "10m",
+    "delete": null,
+    "read": null
+  }
+}`,
+			expectedId: "/providers/Microsoft.Management/managementGroups/group1/providers/Microsoft.Authorization/privateLinkAssociations/00000000-0000-0000-0000-000000000000",
+		},
+	}
+
+	ctx := context.Background()
+	server := frameworkTestServer(t)
+	schemas, err := server.GetProviderSchema(ctx, &tfprotov5.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatalf("retrieving the Provider Schema: %+v", err)
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q..", v.resourceType)
+
+		resp, err := server.UpgradeResourceState(ctx, &tfprotov5.UpgradeResourceStateRequest{
+			TypeName: v.resourceType,
+			Version:  0,
+			RawState: &tfprotov5.RawState{
+				JSON: []byte(v.rawState),
+			},
+		})
+		if err != nil {
+			t.Fatalf("upgrading the state for %q: %+v", v.resourceType, err)
+		}
+		for _, d := range resp.Diagnostics {
+			if d.Severity == tfprotov5.DiagnosticSeverityError {
+				t.Fatalf("upgrading the state for %q: %s: %s", v.resourceType, d.Summary, d.Detail)
+			}
+		}
+
+		actualId := stateAttribute(t, resp.UpgradedState, schemas.ResourceSchemas[v.resourceType], "id")
+		if actualId != v.expectedId {
+			t.Fatalf("expected the `id` to be %q but got %q", v.expectedId, actualId)
+		}
+	}
+}
+
+func TestFrameworkResources_importState(t *testing.T) {
+	testData := []struct {
+		resourceType string
+		id           string
+		expectError  bool
+	}{
+		{
+			resourceType: "azurerm_mssql_server_dns_alias",
+			id:           "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/example/providers/Microsoft.Sql/servers/server1/dnsAliases/alias1",
+		},
+		{
+			// a Server ID rather than a DNS Alias ID
+			resourceType: "azurerm_mssql_server_dns_alias",
+			id:           "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/example/providers/Microsoft.Sql/servers/server1",
+			expectError:  true,
+		},
+		{
+			resourceType: "azurerm_resource_management_private_link_association",
+			id:           "/providers/Microsoft.Management/managementGroups/group1/providers/Microsoft.Authorization/privateLinkAssociations/00000000-0000-0000-0000-000000000000",
+		},
+		{
+			resourceType: "azurerm_resource_management_private_link_association",
+			id:           "not-a-resource-id",
+			expectError:  true,
+		},
+	}
+
+	ctx := context.Background()
+	server := frameworkTestServer(t)
+	schemas, err := server.GetProviderSchema(ctx, &tfprotov5.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatalf("retrieving the Provider Schema: %+v", err)
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q with %q..", v.resourceType, v.id)
+
+		resp, err := server.ImportResourceState(ctx, &tfprotov5.ImportResourceStateRequest{
+			TypeName: v.resourceType,
+			ID:       v.id,
+		})
+		if err != nil {
+			t.Fatalf("importing %q: %+v", v.resourceType, err)
+		}
+
+		hasError := false
+		for _, d := range resp.Diagnostics {
+			if d.Severity == tfprotov5.DiagnosticSeverityError {
+				hasError = true
+			}
+		}
+		if hasError != v.expectError {
+			t.Fatalf("expected an error to be %t but got %t: %s", v.expectError, hasError, diagnosticsDetail(resp.Diagnostics))
+		}
+		if v.expectError {
+			continue
+		}
+
+		if len(resp.ImportedResources) != 1 {
+			t.Fatalf("expected 1 imported resource but got %d", len(resp.ImportedResources))
+		}
+		actualId := stateAttribute(t, resp.ImportedResources[0].State, schemas.ResourceSchemas[v.resourceType], "id")
+		if actualId != v.id {
+			t.Fatalf("expected the `id` to be %q but got %q", v.id, actualId)
+		}
+	}
+}
+
+func frameworkTestServer(t *testing.T) tfprotov5.ProviderServer {
+	// Resources are only served through the Plugin Framework from 4.0
+	t.Setenv("ARM_FOURPOINTZERO_BETA", "true")
+
+	factory, _, err := ProtoV5ProviderServerFactory(context.Background())
+	if err != nil {
+		t.Fatalf("building the Provider Server: %+v", err)
+	}
+	return factory()
+}
+
+func stateAttribute(t *testing.T, state *tfprotov5.DynamicValue, s *tfprotov5.Schema, name string) string {
+	if state == nil {
+		t.Fatalf("the state was nil")
+	}
+
+	value, err := state.Unmarshal(s.ValueType())
+	if err != nil {
+		t.Fatalf("unmarshaling the state: %+v", err)
+	}
+
+	attributes := make(map[string]tftypes.Value)
+	if err := value.As(&attributes); err != nil {
+		t.Fatalf("converting the state: %+v", err)
+	}
+
+	var output string
+	if err := attributes[name].As(&output); err != nil {
+		t.Fatalf("converting %q: %+v", name, err)
+	}
+	return output
+}
+
+func diagnosticsDetail(input []*tfprotov5.Diagnostic) string {
+	output := ""
+	for _, d := range input {
+		output += fmt.Sprintf("\n%s: %s", d.Summary, d.Detail)
+	}
+	return output
+}
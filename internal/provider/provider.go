@@ -13,10 +13,12 @@ import (
 	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
 	"github.com/hashicorp/go-azure-sdk/sdk/auth"
 	"github.com/hashicorp/go-azure-sdk/sdk/environments"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/common"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/features"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/resourceproviders"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
@@ -84,8 +86,17 @@ func azureProvider(supportLegacyTestSuite bool) *schema.Provider {
 	dataSources := make(map[string]*schema.Resource)
 	resources := make(map[string]*schema.Resource)
 
+	// Resources served through the Plugin Framework are registered in the `framework` package - and since
+	// the Plugin SDKv2 and Plugin Framework providers are muxed together, these mustn't be registered here
+	frameworkResources := make(map[string]struct{})
+	for _, r := range SupportedFrameworkResources() {
+		frameworkResources[r.ResourceType()] = struct{}{}
+	}
+
 	// first handle the typed services
 	for _, service := range SupportedTypedServices() {
+		requiredResourceProviders := resourceProvidersForService(service)
+
 		logEntry("[DEBUG] Registering Data Sources for %q..", service.Name())
 		for _, ds := range service.DataSources() {
 			key := ds.ResourceType()
@@ -99,7 +110,7 @@ func azureProvider(supportLegacyTestSuite bool) *schema.Provider {
 				panic(fmt.Errorf("creating Wrapper for Data Source %q: %+v", key, err))
 			}
 
-			dataSources[key] = dataSource
+			dataSources[key] = withDataSourceResourceProviderRegistration(key, dataSource, requiredResourceProviders)
 		}
 
 		logEntry("[DEBUG] Registering Resources for %q..", service.Name())
@@ -108,25 +119,30 @@ func azureProvider(supportLegacyTestSuite bool) *schema.Provider {
 			if existing := resources[key]; existing != nil {
 				panic(fmt.Sprintf("An existing Resource exists for %q", key))
 			}
+			if _, ok := frameworkResources[key]; ok {
+				continue
+			}
 
 			wrapper := sdk.NewResourceWrapper(r)
 			resource, err := wrapper.Resource()
 			if err != nil {
 				panic(fmt.Errorf("creating Wrapper for Resource %q: %+v", key, err))
 			}
-			resources[key] = resource
+			resources[key] = withResourceProviderRegistration(key, resource, requiredResourceProviders)
 		}
 	}
 
 	// then handle the untyped services
 	for _, service := range SupportedUntypedServices() {
+		requiredResourceProviders := resourceProvidersForService(service)
+
 		logEntry("[DEBUG] Registering Data Sources for %q..", service.Name())
 		for k, v := range service.SupportedDataSources() {
 			if existing := dataSources[k]; existing != nil {
 				panic(fmt.Sprintf("An existing Data Source exists for %q", k))
 			}
 
-			dataSources[k] = v
+			dataSources[k] = withDataSourceResourceProviderRegistration(k, v, requiredResourceProviders)
 		}
 
 		logEntry("[DEBUG] Registering Resources for %q..", service.Name())
@@ -135,7 +151,7 @@ func azureProvider(supportLegacyTestSuite bool) *schema.Provider {
 				panic(fmt.Sprintf("An existing Resource exists for %q", k))
 			}
 
-			resources[k] = v
+			resources[k] = withResourceProviderRegistration(k, v, requiredResourceProviders)
 		}
 	}
 
@@ -333,6 +349,7 @@ func azureProvider(supportLegacyTestSuite bool) *schema.Provider {
 					resourceproviders.ProviderRegistrationsAll,
 					resourceproviders.ProviderRegistrationsNone,
 					resourceproviders.ProviderRegistrationsLegacy,
+					resourceproviders.ProviderRegistrationsLazy,
 				}, false),
 			},
 
@@ -375,15 +392,47 @@ func azureProvider(supportLegacyTestSuite bool) *schema.Provider {
 		delete(p.Schema, "resource_providers_to_register")
 	}
 
-	p.ConfigureContextFunc = providerConfigure(p)
+	p.ConfigureContextFunc = providerConfigure(p, nil)
 
 	return p
 }
 
+// FrameworkResources returns the Resources which are served through the Plugin Framework (see SupportedFrameworkResources),
+// which are wrapped in the same manner as the Resources served through Plugin SDKv2
+func FrameworkResources() []func() resource.Resource {
+	output := make([]func() resource.Resource, 0)
+	if !features.FourPointOhBeta() {
+		return output
+	}
+
+	for _, service := range SupportedTypedServices() {
+		v, ok := service.(sdk.TypedServiceRegistrationWithFrameworkResources)
+		if !ok {
+			continue
+		}
+
+		logEntry("[DEBUG] Registering Framework Resources for %q..", service.Name())
+		requiredResourceProviders := resourceProvidersForService(service)
+		for _, r := range v.FrameworkResources() {
+			key := r.ResourceType()
+			wrapper, err := sdk.NewFrameworkResourceWrapper(r, func(input *schema.Resource) *schema.Resource {
+				return withResourceProviderRegistration(key, input, requiredResourceProviders)
+			})
+			if err != nil {
+				panic(fmt.Errorf("creating Framework Wrapper for Resource %q: %+v", key, err))
+			}
+
+			output = append(output, wrapper)
+		}
+	}
+
+	return output
+}
+
 // providerConfigure is used to configure the cloud environment and authentication.
 // To configure behavioral aspects of the provider, use the buildClient function instead.
 // This separation allows us to robustly test different authentication scenarios.
-func providerConfigure(p *schema.Provider) schema.ConfigureContextFunc {
+func providerConfigure(p *schema.Provider, hooks *TestHooks) schema.ConfigureContextFunc {
 	return func(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
 		var auxTenants []string
 		if v, ok := d.Get("auxiliary_tenant_ids").([]interface{}); ok && len(v) > 0 {
@@ -432,7 +481,10 @@ func providerConfigure(p *schema.Provider) schema.ConfigureContextFunc {
 			metadataHost = d.Get("metadata_host").(string)
 		)
 
-		if metadataHost != "" {
+		if hooks != nil && hooks.Environment != nil {
+			logEntry("[DEBUG] Configuring cloud environment from the Test Hooks")
+			env = hooks.Environment
+		} else if metadataHost != "" {
 			logEntry("[DEBUG] Configuring cloud environment from Metadata Service at %q", metadataHost)
 			if env, err = environments.FromEndpoint(ctx, fmt.Sprintf("https://%s", metadataHost)); err != nil {
 				return nil, diag.FromErr(err)
@@ -477,13 +529,13 @@ func providerConfigure(p *schema.Provider) schema.ConfigureContextFunc {
 			EnableAuthenticationUsingGitHubOIDC:        enableOidc,
 		}
 
-		return buildClient(ctx, p, d, authConfig)
+		return buildClient(ctx, p, d, authConfig, hooks)
 	}
 }
 
 // buildClient is used to configure behavioral aspects of the provider. To configure the
 // cloud environment and authentication-related settings, use the providerConfigure function.
-func buildClient(ctx context.Context, p *schema.Provider, d *schema.ResourceData, authConfig *auth.Credentials) (*clients.Client, diag.Diagnostics) {
+func buildClient(ctx context.Context, p *schema.Provider, d *schema.ResourceData, authConfig *auth.Credentials, hooks *TestHooks) (*clients.Client, diag.Diagnostics) {
 	// TODO: This hardcoded default is for v3.x, where `resource_provider_registrations` is not defined. Remove this hardcoded default in v4.0
 	providerRegistrations := resourceproviders.ProviderRegistrationsLegacy
 	if features.FourPointOhBeta() {
@@ -511,6 +563,12 @@ func buildClient(ctx context.Context, p *schema.Provider, d *schema.ResourceData
 		requiredResourceProviders.Merge(additionalProvidersToRegister)
 	}
 
+	// only configured when the acceptance tests are being recorded/replayed, or run against the Fake Resource Manager
+	var recorder common.HTTPRecorder
+	if hooks != nil {
+		recorder = hooks.Recorder
+	}
+
 	clientBuilder := clients.ClientBuilder{
 		AuthConfig:                  authConfig,
 		DisableCorrelationRequestID: d.Get("disable_correlation_request_id").(bool),
@@ -526,6 +584,8 @@ func buildClient(ctx context.Context, p *schema.Provider, d *schema.ResourceData
 		// this field is intentionally not exposed in the provider block, since it's only used for
 		// platform level tracing
 		CustomCorrelationRequestID: os.Getenv("ARM_CORRELATION_REQUEST_ID"),
+
+		Recorder: recorder,
 	}
 
 	//lint:ignore SA1019 SDKv2 migration - staticcheck's own linter directives are currently being ignored under golangci-lint
@@ -551,5 +611,9 @@ func buildClient(ctx context.Context, p *schema.Provider, d *schema.ResourceData
 
 	}
 
+	if strings.EqualFold(providerRegistrations, resourceproviders.ProviderRegistrationsLazy) {
+		client.ResourceProviderRegistration = resourceproviders.NewLazyRegistration(client.Resource.ResourceProvidersClient, subscriptionId)
+	}
+
 	return client, nil
 }
@@ -12,12 +12,15 @@ import (
 	"testing"
 	"time"
 
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/resources/2023-07-01/resourcegroups"
 	"github.com/hashicorp/go-azure-sdk/sdk/auth"
 	"github.com/hashicorp/go-azure-sdk/sdk/environments"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/logging"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/fakearm"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/features"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/resourceproviders"
@@ -125,6 +128,46 @@ func TestProvider_counts(t *testing.T) {
 	log.Printf("Total:        %d", len(provider.ResourcesMap)+len(provider.DataSourcesMap))
 }
 
+func TestProvider_fakeResourceManager(t *testing.T) {
+	server := fakearm.New(fakearm.Options{})
+	defer server.Close()
+
+	t.Setenv("ARM_CLIENT_ID", fakearm.ClientId)
+	t.Setenv("ARM_CLIENT_SECRET", "fake")
+	t.Setenv("ARM_SUBSCRIPTION_ID", fakearm.SubscriptionId)
+	t.Setenv("ARM_TENANT_ID", fakearm.TenantId)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	logging.SetOutput(t)
+
+	provider := TestAzureProviderWithHooks(TestHooks{
+		Environment: server.Environment(),
+		Recorder:    server.Recorder(),
+	})
+	config := map[string]interface{}{
+		"skip_provider_registration": "true",
+	}
+
+	if diags := provider.Configure(ctx, terraform.NewResourceConfigRaw(config)); diags != nil && diags.HasError() {
+		t.Fatalf("provider failed to configure: %v", diags)
+	}
+
+	client := provider.Meta().(*clients.Client)
+	if client.Account.ObjectId != fakearm.ObjectId || client.Account.SubscriptionId != fakearm.SubscriptionId {
+		t.Fatalf("unexpected account %+v", client.Account)
+	}
+
+	id := commonids.NewResourceGroupID(fakearm.SubscriptionId, "example")
+	if _, err := client.Resource.ResourceGroupsClient.CreateOrUpdate(ctx, id, resourcegroups.ResourceGroup{Location: "westeurope"}); err != nil {
+		t.Fatalf("creating %s: %+v", id, err)
+	}
+	if _, ok := server.Resource(id.ID()); !ok {
+		t.Fatalf("expected %s to have been created within the Fake Resource Manager", id)
+	}
+}
+
 func TestAccProvider_resourceProviders_legacy(t *testing.T) {
 	if os.Getenv("TF_ACC") == "" {
 		t.Skip("TF_ACC not set")
@@ -354,7 +397,7 @@ func TestAccProvider_cliAuth(t *testing.T) {
 			EnableAuthenticatingUsingAzureCLI: true,
 		}
 
-		return buildClient(ctx, provider, d, authConfig)
+		return buildClient(ctx, provider, d, authConfig, nil)
 	}
 
 	d := provider.Configure(ctx, terraform.NewResourceConfigRaw(nil))
@@ -400,7 +443,7 @@ func TestAccProvider_cliAuthWithSubscriptionIdHint(t *testing.T) {
 			AzureCliSubscriptionIDHint:        d.Get("subscription_id").(string),
 		}
 
-		return buildClient(ctx, provider, d, authConfig)
+		return buildClient(ctx, provider, d, authConfig, nil)
 	}
 
 	d := provider.Configure(ctx, terraform.NewResourceConfigRaw(nil))
@@ -472,7 +515,7 @@ func TestAccProvider_clientCertificateAuth(t *testing.T) {
 			EnableAuthenticatingUsingClientCertificate: true,
 		}
 
-		return buildClient(ctx, provider, d, authConfig)
+		return buildClient(ctx, provider, d, authConfig, nil)
 	}
 
 	d := provider.Configure(ctx, terraform.NewResourceConfigRaw(nil))
@@ -545,7 +588,7 @@ func testAccProvider_clientSecretAuthFromEnvironment(t *testing.T) {
 			EnableAuthenticatingUsingClientSecret: true,
 		}
 
-		return buildClient(ctx, provider, d, authConfig)
+		return buildClient(ctx, provider, d, authConfig, nil)
 	}
 
 	d := provider.Configure(ctx, terraform.NewResourceConfigRaw(nil))
@@ -613,7 +656,7 @@ func testAccProvider_clientSecretAuthFromFiles(t *testing.T) {
 			EnableAuthenticatingUsingClientSecret: true,
 		}
 
-		return buildClient(ctx, provider, d, authConfig)
+		return buildClient(ctx, provider, d, authConfig, nil)
 	}
 
 	d := provider.Configure(ctx, terraform.NewResourceConfigRaw(nil))
@@ -673,7 +716,7 @@ func TestAccProvider_genericOidcAuth(t *testing.T) {
 			OIDCAssertionToken:            *oidcToken,
 		}
 
-		return buildClient(ctx, provider, d, authConfig)
+		return buildClient(ctx, provider, d, authConfig, nil)
 	}
 
 	d := provider.Configure(ctx, terraform.NewResourceConfigRaw(nil))
@@ -732,7 +775,7 @@ func TestAccProvider_githubOidcAuth(t *testing.T) {
 			EnableAuthenticationUsingGitHubOIDC: true,
 		}
 
-		return buildClient(ctx, provider, d, authConfig)
+		return buildClient(ctx, provider, d, authConfig, nil)
 	}
 
 	d := provider.Configure(ctx, terraform.NewResourceConfigRaw(nil))
@@ -798,7 +841,7 @@ func TestAccProvider_aksWorkloadIdentityAuth(t *testing.T) {
 			EnableAuthenticationUsingOIDC: true,
 		}
 
-		return buildClient(ctx, provider, d, authConfig)
+		return buildClient(ctx, provider, d, authConfig, nil)
 	}
 
 	// Ensure we enable AKS Workload Identity else the configuration will not be detected
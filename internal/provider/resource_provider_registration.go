@@ -0,0 +1,184 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/resourceproviders"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+)
+
+// resourceProvidersForService returns the Resource Providers required by the Resources within the specified
+// Service Registration - which is empty when the Service Registration doesn't specify these, in which case
+// nothing is registered lazily and only the Missing Subscription Registration errors are wrapped
+func resourceProvidersForService(service interface{}) resourceproviders.ResourceProviders {
+	output := make(resourceproviders.ResourceProviders)
+	if v, ok := service.(sdk.ServiceRegistrationWithResourceProviders); ok {
+		output.Add(v.ResourceProviders()...)
+	}
+	return output
+}
+
+// withResourceProviderRegistration wraps the specified Resource so that:
+//
+// 1. When Resource Providers are registered lazily, the Resource Providers required by the Service are
+// registered the first time this Resource is planned or applied.
+// 2. Errors returned from the Azure API indicating that a Resource Provider isn't registered are replaced
+// with an error naming both this Resource and the Resource Provider which requires registration.
+func withResourceProviderRegistration(resourceType string, resource *schema.Resource, requiredRPs resourceproviders.ResourceProviders) *schema.Resource {
+	ensureRegistered := func(ctx context.Context, meta interface{}) error {
+		return ensureResourceProvidersRegistered(ctx, meta, resourceType, requiredRPs)
+	}
+
+	wrapError := func(err error) error {
+		return resourceproviders.WrapMissingSubscriptionRegistrationError(resourceType, err)
+	}
+
+	wrapDiagnostics := func(diags diag.Diagnostics) diag.Diagnostics {
+		return wrapMissingSubscriptionRegistrationDiagnostics(resourceType, diags)
+	}
+
+	if f := resource.Create; f != nil {
+		resource.Create = func(d *schema.ResourceData, meta interface{}) error {
+			if err := ensureRegistered(context.Background(), meta); err != nil {
+				return err
+			}
+			return wrapError(f(d, meta))
+		}
+	}
+	if f := resource.CreateContext; f != nil {
+		resource.CreateContext = func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			if err := ensureRegistered(ctx, meta); err != nil {
+				return diag.FromErr(err)
+			}
+			return wrapDiagnostics(f(ctx, d, meta))
+		}
+	}
+
+	if f := resource.Read; f != nil {
+		resource.Read = func(d *schema.ResourceData, meta interface{}) error {
+			return wrapError(f(d, meta))
+		}
+	}
+	if f := resource.ReadContext; f != nil {
+		resource.ReadContext = func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			return wrapDiagnostics(f(ctx, d, meta))
+		}
+	}
+
+	if f := resource.Update; f != nil {
+		resource.Update = func(d *schema.ResourceData, meta interface{}) error {
+			if err := ensureRegistered(context.Background(), meta); err != nil {
+				return err
+			}
+			return wrapError(f(d, meta))
+		}
+	}
+	if f := resource.UpdateContext; f != nil {
+		resource.UpdateContext = func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			if err := ensureRegistered(ctx, meta); err != nil {
+				return diag.FromErr(err)
+			}
+			return wrapDiagnostics(f(ctx, d, meta))
+		}
+	}
+
+	if f := resource.Delete; f != nil {
+		resource.Delete = func(d *schema.ResourceData, meta interface{}) error {
+			return wrapError(f(d, meta))
+		}
+	}
+	if f := resource.DeleteContext; f != nil {
+		resource.DeleteContext = func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			return wrapDiagnostics(f(ctx, d, meta))
+		}
+	}
+
+	if len(requiredRPs) > 0 {
+		// the Resource Providers are registered during the plan, since the Azure API can be called whilst planning
+		customizeDiff := resource.CustomizeDiff
+		resource.CustomizeDiff = func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+			if err := ensureRegistered(ctx, meta); err != nil {
+				return err
+			}
+			if customizeDiff != nil {
+				return wrapError(customizeDiff(ctx, d, meta))
+			}
+			return nil
+		}
+	}
+
+	return resource
+}
+
+// withDataSourceResourceProviderRegistration wraps the specified Data Source so that:
+//
+// 1. When Resource Providers are registered lazily, the Resource Providers required by the Service are
+// registered the first time this Data Source is read.
+// 2. Errors returned from the Azure API indicating that a Resource Provider isn't registered are replaced
+// with an error naming both this Data Source and the Resource Provider which requires registration.
+func withDataSourceResourceProviderRegistration(dataSourceType string, dataSource *schema.Resource, requiredRPs resourceproviders.ResourceProviders) *schema.Resource {
+	if f := dataSource.Read; f != nil {
+		dataSource.Read = func(d *schema.ResourceData, meta interface{}) error {
+			if err := ensureResourceProvidersRegistered(context.Background(), meta, dataSourceType, requiredRPs); err != nil {
+				return err
+			}
+			return resourceproviders.WrapMissingSubscriptionRegistrationError(dataSourceType, f(d, meta))
+		}
+	}
+	if f := dataSource.ReadContext; f != nil {
+		dataSource.ReadContext = func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			if err := ensureResourceProvidersRegistered(ctx, meta, dataSourceType, requiredRPs); err != nil {
+				return diag.FromErr(err)
+			}
+			return wrapMissingSubscriptionRegistrationDiagnostics(dataSourceType, f(ctx, d, meta))
+		}
+	}
+
+	return dataSource
+}
+
+// ensureResourceProvidersRegistered registers the specified Resource Providers when these are registered lazily
+func ensureResourceProvidersRegistered(ctx context.Context, meta interface{}, resourceType string, requiredRPs resourceproviders.ResourceProviders) error {
+	if len(requiredRPs) == 0 {
+		return nil
+	}
+
+	client, ok := meta.(*clients.Client)
+	if !ok || client == nil || client.ResourceProviderRegistration == nil {
+		return nil
+	}
+
+	if _, hasDeadline := ctx.Deadline(); !hasDeadline {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, 30*time.Minute)
+		defer cancel()
+	}
+
+	if err := client.ResourceProviderRegistration.EnsureRegistered(ctx, requiredRPs); err != nil {
+		return fmt.Errorf("registering the Resource Providers required by %q: %+v", resourceType, err)
+	}
+	return nil
+}
+
+func wrapMissingSubscriptionRegistrationDiagnostics(resourceType string, diags diag.Diagnostics) diag.Diagnostics {
+	for i, d := range diags {
+		if d.Severity != diag.Error {
+			continue
+		}
+
+		var wrapped resourceproviders.MissingSubscriptionRegistrationError
+		if err := resourceproviders.WrapMissingSubscriptionRegistrationError(resourceType, errors.New(d.Summary)); errors.As(err, &wrapped) {
+			diags[i].Summary = err.Error()
+		}
+	}
+	return diags
+}
@@ -0,0 +1,116 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/resourceproviders"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+)
+
+const missingSubscriptionRegistrationError = "unexpected status 409 (409 Conflict) with error: MissingSubscriptionRegistration: The subscription is not registered to use namespace 'Microsoft.Example'."
+
+func TestWithResourceProviderRegistration_wrapsErrors(t *testing.T) {
+	resource := withResourceProviderRegistration("azurerm_example", &schema.Resource{
+		Create: func(d *schema.ResourceData, meta interface{}) error {
+			return errors.New(missingSubscriptionRegistrationError)
+		},
+		ReadContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			return diag.Errorf(missingSubscriptionRegistrationError)
+		},
+		Delete: func(d *schema.ResourceData, meta interface{}) error {
+			return errors.New("some other error")
+		},
+	}, resourceproviders.ResourceProviders{"Microsoft.Example": {}})
+
+	// Resource Providers aren't registered lazily, so this is a no-op
+	meta := &clients.Client{}
+
+	err := resource.Create(nil, meta)
+	var wrapped resourceproviders.MissingSubscriptionRegistrationError
+	if !errors.As(err, &wrapped) {
+		t.Fatalf("expected the Create error to be wrapped but got %+v", err)
+	}
+	if wrapped.ResourceType != "azurerm_example" || wrapped.Namespace != "Microsoft.Example" {
+		t.Fatalf("expected the Resource Type and Namespace to be populated but got %q and %q", wrapped.ResourceType, wrapped.Namespace)
+	}
+
+	diags := resource.ReadContext(context.TODO(), nil, meta)
+	if !diags.HasError() || !strings.Contains(diags[0].Summary, "azurerm_example") || !strings.Contains(diags[0].Summary, "Microsoft.Example") {
+		t.Fatalf("expected the Read diagnostics to be wrapped but got %+v", diags)
+	}
+
+	if err := resource.Delete(nil, meta); err == nil || err.Error() != "some other error" {
+		t.Fatalf("expected the Delete error to be returned unchanged but got %+v", err)
+	}
+
+	if resource.CustomizeDiff == nil {
+		t.Fatalf("expected a CustomizeDiff function to be configured to register the Resource Providers")
+	}
+	if err := resource.CustomizeDiff(context.TODO(), nil, meta); err != nil {
+		t.Fatalf("expected no error from CustomizeDiff but got %+v", err)
+	}
+}
+
+func TestWithResourceProviderRegistration_noResourceProviders(t *testing.T) {
+	resource := withResourceProviderRegistration("azurerm_example", &schema.Resource{}, resourceproviders.ResourceProviders{})
+
+	if resource.CustomizeDiff != nil {
+		t.Fatalf("expected no CustomizeDiff function when no Resource Providers are required")
+	}
+	if resource.Create != nil || resource.CreateContext != nil {
+		t.Fatalf("expected no Create function to be configured")
+	}
+}
+
+func TestWithDataSourceResourceProviderRegistration_wrapsErrors(t *testing.T) {
+	dataSource := withDataSourceResourceProviderRegistration("azurerm_example", &schema.Resource{
+		ReadContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			return diag.Errorf(missingSubscriptionRegistrationError)
+		},
+	}, resourceproviders.ResourceProviders{"Microsoft.Example": {}})
+
+	// Resource Providers aren't registered lazily, so this is a no-op
+	meta := &clients.Client{}
+
+	diags := dataSource.ReadContext(context.TODO(), nil, meta)
+	if !diags.HasError() || !strings.Contains(diags[0].Summary, "azurerm_example") || !strings.Contains(diags[0].Summary, "Microsoft.Example") {
+		t.Fatalf("expected the Read diagnostics to be wrapped but got %+v", diags)
+	}
+}
+
+type serviceWithoutResourceProviders struct{}
+
+func TestResourceProvidersForService(t *testing.T) {
+	for _, service := range SupportedTypedServices() {
+		v, ok := service.(sdk.ServiceRegistrationWithResourceProviders)
+		if !ok {
+			continue
+		}
+
+		requiredResourceProviders := resourceProvidersForService(service)
+		if len(requiredResourceProviders) != len(v.ResourceProviders()) {
+			t.Fatalf("expected the Service %q to require %d Resource Providers but got %d", service.Name(), len(v.ResourceProviders()), len(requiredResourceProviders))
+		}
+	}
+}
+
+func TestResourceProvidersForService_undeclared(t *testing.T) {
+	requiredResourceProviders := resourceProvidersForService(serviceWithoutResourceProviders{})
+	if len(requiredResourceProviders) != 0 {
+		t.Fatalf("expected no Resource Providers for a Service which doesn't declare these but got %+v", requiredResourceProviders)
+	}
+
+	resource := withResourceProviderRegistration("azurerm_example", &schema.Resource{}, requiredResourceProviders)
+	if resource.CustomizeDiff != nil {
+		t.Fatalf("expected no Resource Providers to be registered for a Service which doesn't declare these")
+	}
+}
@@ -4,6 +4,7 @@
 package provider
 
 import (
+	"github.com/hashicorp/terraform-provider-azurerm/internal/features"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/services/aadb2c"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/services/advisor"
@@ -334,3 +335,20 @@ func SupportedUntypedServices() []sdk.UntypedServiceRegistration {
 		return out
 	}()
 }
+
+// SupportedFrameworkResources returns the Typed Resources which are served through the Plugin Framework (rather than
+// Plugin SDKv2) - since this requires muxing the Plugin SDKv2 and Plugin Framework providers, these are only served
+// through the Plugin Framework from 4.0, until which point they're served through Plugin SDKv2
+func SupportedFrameworkResources() []sdk.Resource {
+	if !features.FourPointOhBeta() {
+		return nil
+	}
+
+	resources := make([]sdk.Resource, 0)
+	for _, service := range SupportedTypedServices() {
+		if v, ok := service.(sdk.TypedServiceRegistrationWithFrameworkResources); ok {
+			resources = append(resources, v.FrameworkResources()...)
+		}
+	}
+	return resources
+}
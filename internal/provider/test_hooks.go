@@ -0,0 +1,27 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"github.com/hashicorp/go-azure-sdk/sdk/environments"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/common"
+)
+
+// TestHooks allow the Acceptance Tests to change how the Provider sends requests, these are only
+// configurable via TestAzureProviderWithHooks and are never set for the released Provider
+type TestHooks struct {
+	// Environment overrides the Cloud Environment which would otherwise be configured from the Provider block
+	Environment *environments.Environment
+
+	// Recorder records (or replays) the HTTP requests sent by the Provider
+	Recorder common.HTTPRecorder
+}
+
+// TestAzureProviderWithHooks returns the Provider used in the Acceptance Tests, configured using the specified TestHooks
+func TestAzureProviderWithHooks(hooks TestHooks) *schema.Provider {
+	p := azureProvider(true)
+	p.ConfigureContextFunc = providerConfigure(p, &hooks)
+	return p
+}
@@ -6,6 +6,8 @@ package resourceproviders
 import (
 	"errors"
 	"fmt"
+	"regexp"
+	"strings"
 	"sync"
 )
 
@@ -79,3 +81,80 @@ func (e *registrationErrors) append(err error) {
 func (e *registrationErrors) hasErr() bool {
 	return len(e.errs) > 0
 }
+
+const missingSubscriptionRegistrationCode = "MissingSubscriptionRegistration"
+
+// missingSubscriptionRegistrationNamespace matches the namespace within the message returned alongside the
+// `MissingSubscriptionRegistration` error code, e.g. `The subscription is not registered to use namespace 'Microsoft.Foo'`
+var missingSubscriptionRegistrationNamespace = regexp.MustCompile(`(?i)registered to use namespace '([^']+)'`)
+
+const missingSubscriptionRegistrationErrorFmt = `the Resource Provider %s used by %q is not registered in this Subscription.
+
+This can be resolved by registering the Resource Provider (for example using the Azure CLI:
+"az provider register --namespace %s"), by adding it to the "resource_providers_to_register"
+property in the Provider block, or by setting "resource_provider_registrations" to "lazy" so that
+the Resource Providers required by each Resource are registered when they're first used.
+
+More information on the "resource_provider_registrations" property can be found here:
+https://registry.terraform.io/providers/hashicorp/azurerm/latest/docs#resource_provider_registrations
+
+Encountered the following error:
+
+%v`
+
+// MissingSubscriptionRegistrationError is returned when the Azure API reports that the Resource Provider
+// used by a Resource isn't registered in the Subscription, naming both the Resource and the Resource Provider.
+type MissingSubscriptionRegistrationError struct {
+	// ResourceType is the Terraform Resource Type (e.g. `azurerm_storage_account`) which was being used
+	ResourceType string
+
+	// Namespace is the Resource Provider which requires registration (e.g. `Microsoft.Storage`), which
+	// is empty when the namespace couldn't be determined from the error
+	Namespace string
+
+	err error
+}
+
+func (e MissingSubscriptionRegistrationError) Error() string {
+	namespace := fmt.Sprintf("%q", e.Namespace)
+	registerNamespace := e.Namespace
+	if e.Namespace == "" {
+		namespace = "(unknown)"
+		registerNamespace = "<namespace>"
+	}
+
+	return fmt.Sprintf(missingSubscriptionRegistrationErrorFmt, namespace, e.ResourceType, registerNamespace, e.err)
+}
+
+func (e MissingSubscriptionRegistrationError) Unwrap() error {
+	return e.err
+}
+
+// WrapMissingSubscriptionRegistrationError returns a MissingSubscriptionRegistrationError when err indicates
+// that a Resource Provider isn't registered in the Subscription - otherwise err is returned unchanged.
+func WrapMissingSubscriptionRegistrationError(resourceType string, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var existing MissingSubscriptionRegistrationError
+	if errors.As(err, &existing) {
+		return err
+	}
+
+	message := err.Error()
+	if !strings.Contains(message, missingSubscriptionRegistrationCode) {
+		return err
+	}
+
+	namespace := ""
+	if matches := missingSubscriptionRegistrationNamespace.FindStringSubmatch(message); len(matches) == 2 {
+		namespace = matches[1]
+	}
+
+	return MissingSubscriptionRegistrationError{
+		ResourceType: resourceType,
+		Namespace:    namespace,
+		err:          err,
+	}
+}
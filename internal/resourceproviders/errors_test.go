@@ -0,0 +1,83 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resourceproviders
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestWrapMissingSubscriptionRegistrationError(t *testing.T) {
+	testCases := []struct {
+		input             error
+		expectWrapped     bool
+		expectedNamespace string
+	}{
+		{
+			input: nil,
+		},
+		{
+			input: errors.New("unexpected status 404 (404 Not Found) with error: ResourceGroupNotFound: Resource group 'example' could not be found."),
+		},
+		{
+			input:             errors.New("unexpected status 409 (409 Conflict) with error: MissingSubscriptionRegistration: The subscription is not registered to use namespace 'Microsoft.Chaos'. See https://aka.ms/rps-not-found for how to register subscriptions."),
+			expectWrapped:     true,
+			expectedNamespace: "Microsoft.Chaos",
+		},
+		{
+			// wrapped errors are also detected
+			input:             fmt.Errorf("creating Storage Account: %w", errors.New(`Code="MissingSubscriptionRegistration" Message="The subscription is not registered to use namespace 'microsoft.storage'."`)),
+			expectWrapped:     true,
+			expectedNamespace: "microsoft.storage",
+		},
+		{
+			// the namespace can't always be determined
+			input:         errors.New("unexpected status 409 (409 Conflict) with error: MissingSubscriptionRegistration"),
+			expectWrapped: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Logf("[DEBUG] Testing %v", testCase.input)
+
+		actual := WrapMissingSubscriptionRegistrationError("azurerm_example", testCase.input)
+		if testCase.input == nil {
+			if actual != nil {
+				t.Fatalf("expected no error but got %+v", actual)
+			}
+			continue
+		}
+
+		var wrapped MissingSubscriptionRegistrationError
+		if isWrapped := errors.As(actual, &wrapped); isWrapped != testCase.expectWrapped {
+			t.Fatalf("expected the error to be wrapped to be %t but got %t", testCase.expectWrapped, isWrapped)
+		}
+		if !testCase.expectWrapped {
+			if actual != testCase.input {
+				t.Fatalf("expected the error to be returned unchanged")
+			}
+			continue
+		}
+
+		if wrapped.ResourceType != "azurerm_example" {
+			t.Fatalf("expected the Resource Type to be %q but got %q", "azurerm_example", wrapped.ResourceType)
+		}
+		if wrapped.Namespace != testCase.expectedNamespace {
+			t.Fatalf("expected the Namespace to be %q but got %q", testCase.expectedNamespace, wrapped.Namespace)
+		}
+		if !errors.Is(actual, testCase.input) {
+			t.Fatalf("expected the original error to be unwrappable")
+		}
+		if !strings.Contains(actual.Error(), "azurerm_example") {
+			t.Fatalf("expected the error to contain the Resource Type but got %q", actual.Error())
+		}
+
+		// wrapping an error multiple times should be a no-op
+		if again := WrapMissingSubscriptionRegistrationError("azurerm_other", actual); again != actual {
+			t.Fatalf("expected an already wrapped error to be returned unchanged")
+		}
+	}
+}
@@ -0,0 +1,67 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resourceproviders
+
+import (
+	"context"
+	"log"
+	"sync"
+
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/resources/2022-09-01/providers"
+)
+
+// LazyRegistration registers Resource Providers the first time that they're required, rather than
+// registering a fixed set of Resource Providers when the Provider is configured.
+type LazyRegistration struct {
+	client         *providers.ProvidersClient
+	subscriptionId commonids.SubscriptionId
+
+	// ensureRegistered is overridden in tests
+	ensureRegistered func(ctx context.Context, client *providers.ProvidersClient, subscriptionId commonids.SubscriptionId, requiredRPs ResourceProviders) error
+
+	ensured ResourceProviders
+	lock    sync.Mutex
+}
+
+func NewLazyRegistration(client *providers.ProvidersClient, subscriptionId commonids.SubscriptionId) *LazyRegistration {
+	return &LazyRegistration{
+		client:           client,
+		subscriptionId:   subscriptionId,
+		ensureRegistered: EnsureRegistered,
+		ensured:          make(ResourceProviders),
+	}
+}
+
+// EnsureRegistered ensures that the specified Resource Providers are registered in the Subscription, registering
+// them if necessary. Each Resource Provider is only checked the first time it's required.
+func (l *LazyRegistration) EnsureRegistered(ctx context.Context, requiredRPs ResourceProviders) error {
+	if l == nil {
+		return nil
+	}
+
+	// this intentionally holds the lock whilst registering, so that concurrent operations for Resources
+	// within the same Service wait for the Resource Providers to be registered, rather than each
+	// attempting to register these
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	pending := make(ResourceProviders)
+	for name := range requiredRPs {
+		if _, ok := l.ensured[name]; !ok {
+			pending.Add(name)
+		}
+	}
+	if len(pending) == 0 {
+		return nil
+	}
+
+	log.Printf("[DEBUG] Ensuring %d Resource Providers are registered..", len(pending))
+	if err := l.ensureRegistered(ctx, l.client, l.subscriptionId, pending); err != nil {
+		return err
+	}
+
+	l.ensured.Merge(pending)
+	return nil
+}
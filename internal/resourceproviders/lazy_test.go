@@ -0,0 +1,78 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resourceproviders
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/resources/2022-09-01/providers"
+)
+
+func TestLazyRegistration(t *testing.T) {
+	registered := make([][]string, 0)
+	shouldFail := false
+
+	l := NewLazyRegistration(nil, commonids.NewSubscriptionID("12345678-1234-9876-4563-123456789012"))
+	l.ensureRegistered = func(_ context.Context, _ *providers.ProvidersClient, _ commonids.SubscriptionId, requiredRPs ResourceProviders) error {
+		if shouldFail {
+			return errors.New("registration failed")
+		}
+
+		names := make([]string, 0)
+		for name := range requiredRPs {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		registered = append(registered, names)
+		return nil
+	}
+
+	ctx := context.TODO()
+	if err := l.EnsureRegistered(ctx, ResourceProviders{"Microsoft.Storage": {}}); err != nil {
+		t.Fatalf("unexpected error: %+v", err)
+	}
+	// already registered, so shouldn't be registered again
+	if err := l.EnsureRegistered(ctx, ResourceProviders{"Microsoft.Storage": {}}); err != nil {
+		t.Fatalf("unexpected error: %+v", err)
+	}
+	// only the new Resource Provider should be registered
+	if err := l.EnsureRegistered(ctx, ResourceProviders{"Microsoft.Storage": {}, "Microsoft.KeyVault": {}}); err != nil {
+		t.Fatalf("unexpected error: %+v", err)
+	}
+
+	if len(registered) != 2 {
+		t.Fatalf("expected 2 registrations but got %d: %+v", len(registered), registered)
+	}
+	if len(registered[0]) != 1 || registered[0][0] != "Microsoft.Storage" {
+		t.Fatalf("expected the first registration to be for `Microsoft.Storage` but got %+v", registered[0])
+	}
+	if len(registered[1]) != 1 || registered[1][0] != "Microsoft.KeyVault" {
+		t.Fatalf("expected the second registration to be for `Microsoft.KeyVault` but got %+v", registered[1])
+	}
+
+	// failed registrations should be retried
+	shouldFail = true
+	if err := l.EnsureRegistered(ctx, ResourceProviders{"Microsoft.Chaos": {}}); err == nil {
+		t.Fatalf("expected an error but didn't get one")
+	}
+	shouldFail = false
+	if err := l.EnsureRegistered(ctx, ResourceProviders{"Microsoft.Chaos": {}}); err != nil {
+		t.Fatalf("unexpected error: %+v", err)
+	}
+	if len(registered) != 3 || registered[2][0] != "Microsoft.Chaos" {
+		t.Fatalf("expected `Microsoft.Chaos` to be registered after retrying but got %+v", registered)
+	}
+}
+
+func TestLazyRegistration_Nil(t *testing.T) {
+	// a nil LazyRegistration is used when Resource Providers aren't registered lazily
+	var l *LazyRegistration
+	if err := l.EnsureRegistered(context.TODO(), ResourceProviders{"Microsoft.Storage": {}}); err != nil {
+		t.Fatalf("unexpected error: %+v", err)
+	}
+}
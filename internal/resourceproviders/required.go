@@ -27,6 +27,10 @@ const (
 	ProviderRegistrationsCore     = "core"
 	ProviderRegistrationsExtended = "extended"
 	ProviderRegistrationsAll      = "all"
+
+	// ProviderRegistrationsLazy registers the Resource Providers required by each Service the first time a
+	// Resource from that Service is used, rather than when the Provider is configured.
+	ProviderRegistrationsLazy = "lazy"
 )
 
 func (r ResourceProviders) Add(providers ...string) {
@@ -214,7 +218,8 @@ func GetResourceProvidersSet(input string) (ResourceProviders, error) {
 		return All(), nil
 	case ProviderRegistrationsExtended:
 		return Extended(), nil
-	case ProviderRegistrationsNone:
+	case ProviderRegistrationsNone, ProviderRegistrationsLazy:
+		// when registering lazily, the Resource Providers are registered when first used
 		return empty, nil
 	}
 
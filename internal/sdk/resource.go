@@ -89,6 +89,10 @@ type Resource interface {
 	IDValidationFunc() pluginsdk.SchemaValidateFunc
 }
 
+// ResourceWithStateMigration is an optional interface
+//
+// NOTE: where only the format of the Resource ID has changed between Schema versions,
+// ResourceIdStateUpgrade can be used rather than a hand-written State Upgrade.
 type ResourceWithStateMigration interface {
 	Resource
 	StateUpgraders() StateUpgradeData
@@ -99,8 +103,6 @@ type StateUpgradeData struct {
 	Upgraders     map[int]pluginsdk.StateUpgrade
 }
 
-// TODO: a generic state migration for updating ID's
-
 type ResourceWithCustomImporter interface {
 	Resource
 
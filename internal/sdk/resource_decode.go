@@ -7,11 +7,14 @@ import (
 	"fmt"
 	"reflect"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 // Decode will decode the Terraform Schema into the specified object consisting of Supported Go native Types
 // These are: int64, float64, string, bool, as well as lists and maps of these base types.
+// Pointers to structs are decoded from a single nested block (e.g. a List with `MaxItems: 1`) and types
+// implementing TFSchemaUnmarshaler, Resource IDs, `time.Time` and `time.Duration` are decoded from a string.
 // NOTE: this object must be passed by value - and must contain `tfschema`
 // struct tags for all fields
 //
@@ -23,21 +26,44 @@ import (
 //
 // var person Person
 // if err := metadata.Decode(&person); err != nil { .. }
-func (rmd ResourceMetaData) Decode(input interface{}) error {
+func (rmd ResourceMetaData) Decode(input interface{}, options ...DecodeOption) error {
 	if rmd.ResourceData == nil {
 		return fmt.Errorf("ResourceData was nil")
 	}
-	return decodeReflectedType(input, rmd.ResourceData, rmd.serializationDebugLogger)
+	return decodeReflectedType(input, rmd.ResourceData, rmd.serializationDebugLogger, options...)
 }
 
 // DecodeDiff decodes the Terraform Schema into the specified object in the
 // same manner as Decode, but using the ResourceDiff as a source. Intended
 // for use in CustomizeDiff functions.
-func (rmd ResourceMetaData) DecodeDiff(input interface{}) error {
+func (rmd ResourceMetaData) DecodeDiff(input interface{}, options ...DecodeOption) error {
 	if rmd.ResourceDiff == nil {
 		return fmt.Errorf("ResourceDiff was nil")
 	}
-	return decodeReflectedType(input, rmd.ResourceDiff, rmd.serializationDebugLogger)
+	return decodeReflectedType(input, rmd.ResourceDiff, rmd.serializationDebugLogger, options...)
+}
+
+// DecodeOption customises the behaviour of Decode and DecodeDiff
+type DecodeOption func(*decodeOptions)
+
+type decodeOptions struct {
+	// nilWhenNullInConfig specifies that pointer fields should be left nil when the attribute is null in the config
+	nilWhenNullInConfig bool
+}
+
+// NilWhenNullInConfig leaves top-level pointer fields nil when the attribute isn't set in the Terraform
+// Configuration, rather than populating these with the value from the State (or the zero value), which
+// allows distinguishing between "not set" and an explicit zero value (e.g. `false` or `0`).
+//
+// This has no effect when the Configuration isn't available (for example during a Read).
+//
+// Example Usage:
+//
+//	if err := metadata.Decode(&model, sdk.NilWhenNullInConfig()); err != nil { .. }
+func NilWhenNullInConfig() DecodeOption {
+	return func(o *decodeOptions) {
+		o.nilWhenNullInConfig = true
+	}
 }
 
 // stateRetriever is a convenience wrapper around the Plugin SDK to be able to test it more accurately
@@ -45,13 +71,24 @@ type stateRetriever interface {
 	Get(key string) interface{}
 	GetOk(key string) (interface{}, bool)
 	GetOkExists(key string) (interface{}, bool)
+	GetRawConfig() cty.Value
 }
 
-func decodeReflectedType(input interface{}, stateRetriever stateRetriever, debugLogger Logger) error {
+func decodeReflectedType(input interface{}, stateRetriever stateRetriever, debugLogger Logger, options ...DecodeOption) error {
 	if reflect.TypeOf(input).Kind() != reflect.Ptr {
 		return fmt.Errorf("need a pointer")
 	}
 
+	opts := decodeOptions{}
+	for _, option := range options {
+		option(&opts)
+	}
+
+	var config cty.Value
+	if opts.nilWhenNullInConfig {
+		config = stateRetriever.GetRawConfig()
+	}
+
 	objType := reflect.TypeOf(input).Elem()
 	for i := 0; i < objType.NumField(); i++ {
 		field := objType.Field(i)
@@ -63,6 +100,11 @@ func decodeReflectedType(input interface{}, stateRetriever stateRetriever, debug
 		}
 
 		if structTags != nil {
+			if field.Type.Kind() == reflect.Pointer && isNullInConfig(config, structTags.hclPath) {
+				debugLogger.Infof("The HCL Path %q is null in the config - leaving nil", structTags.hclPath)
+				continue
+			}
+
 			tfschemaValue, valExists := stateRetriever.GetOkExists(structTags.hclPath)
 			if !valExists {
 				continue
@@ -93,6 +135,14 @@ func setValue(input, tfschemaValue interface{}, index int, fieldName string, deb
 		}
 	}()
 
+	field := reflect.ValueOf(input).Elem().Field(index)
+	if isNestedBlock(field.Type()) {
+		return setNestedBlockValue(field, tfschemaValue, fieldName, debugLogger)
+	}
+	if isCustomType(field.Type()) || (field.Kind() == reflect.Pointer && isCustomType(field.Type().Elem())) {
+		return setCustomValue(field, tfschemaValue, fieldName, debugLogger)
+	}
+
 	if v, ok := tfschemaValue.(string); ok {
 		n := reflect.ValueOf(input).Elem().Field(index)
 		if n.Kind() == reflect.Pointer {
@@ -227,6 +277,91 @@ func setValue(input, tfschemaValue interface{}, index int, fieldName string, deb
 	return nil
 }
 
+// isNullInConfig returns whether the top-level attribute hclPath is known to be null in the raw config
+func isNullInConfig(config cty.Value, hclPath string) bool {
+	if config.IsNull() || !config.IsKnown() || !config.Type().IsObjectType() || !config.Type().HasAttribute(hclPath) {
+		return false
+	}
+
+	return config.GetAttr(hclPath).IsNull()
+}
+
+// setCustomValue decodes tfschemaValue into field, which is either a custom type or a pointer to one
+func setCustomValue(field reflect.Value, tfschemaValue interface{}, fieldName string, debugLogger Logger) error {
+	if tfschemaValue == nil {
+		return nil
+	}
+	if v, ok := tfschemaValue.(string); ok && v == "" {
+		// an empty string is the zero value in the Terraform Schema, so leave the field unset
+		debugLogger.Infof("[Custom] %q is empty - skipping", fieldName)
+		return nil
+	}
+
+	if field.Kind() == reflect.Pointer {
+		debugLogger.Infof("*[Custom] Decode %+v", tfschemaValue)
+		tmp := reflect.New(field.Type().Elem())
+		if err := unmarshalCustomType(tmp, tfschemaValue); err != nil {
+			return fmt.Errorf("decoding %q: %+v", fieldName, err)
+		}
+		field.Set(tmp)
+		return nil
+	}
+
+	debugLogger.Infof("[Custom] Decode %+v", tfschemaValue)
+	if err := unmarshalCustomType(field.Addr(), tfschemaValue); err != nil {
+		return fmt.Errorf("decoding %q: %+v", fieldName, err)
+	}
+	return nil
+}
+
+// setNestedBlockValue decodes a single nested block (a List or Set containing at most one item) into field,
+// which is a pointer to a struct - the field is left nil when the block isn't present
+func setNestedBlockValue(field reflect.Value, tfschemaValue interface{}, fieldName string, debugLogger Logger) error {
+	var items []interface{}
+	switch v := tfschemaValue.(type) {
+	case []interface{}:
+		items = v
+	case *schema.Set:
+		items = v.List()
+	case nil:
+		return nil
+	default:
+		return fmt.Errorf("expected a list or set for the nested block %q but got %T", fieldName, tfschemaValue)
+	}
+
+	if len(items) == 0 {
+		return nil
+	}
+	if len(items) > 1 {
+		return fmt.Errorf("expected at most one item for the nested block %q but got %d", fieldName, len(items))
+	}
+
+	config, ok := items[0].(map[string]interface{})
+	if !ok || config == nil {
+		return nil
+	}
+
+	elem := reflect.New(field.Type().Elem())
+	debugLogger.Infof("[Block] Decode %q into %s", fieldName, elem.Type().String())
+	for j := 0; j < elem.Type().Elem().NumField(); j++ {
+		nestedField := elem.Type().Elem().Field(j)
+
+		structTags, err := parseStructTags(nestedField.Tag)
+		if err != nil {
+			return fmt.Errorf("parsing struct tags for nested field %q: %+v", nestedField.Name, err)
+		}
+
+		if structTags != nil {
+			if err := setValue(elem.Interface(), config[structTags.hclPath], j, nestedField.Name, debugLogger); err != nil {
+				return err
+			}
+		}
+	}
+
+	field.Set(elem)
+	return nil
+}
+
 func setListValue(input interface{}, index int, fieldName string, v []interface{}, debugLogger Logger) error {
 	fieldType := reflect.ValueOf(input).Elem().Field(index).Type()
 	var slice reflect.Value
@@ -4,19 +4,70 @@
 package sdk
 
 import (
+	"fmt"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/go-cty/cty"
 )
 
 type decodeTestData struct {
 	State       map[string]interface{}
+	Config      cty.Value
+	Options     []DecodeOption
 	Input       interface{}
 	Expected    interface{}
 	ExpectError bool
 }
 
+// commaSeparatedList is a custom type stored as a comma-separated string in the Terraform Schema
+type commaSeparatedList []string
+
+func (l commaSeparatedList) MarshalTFSchema() (interface{}, error) {
+	return strings.Join(l, ","), nil
+}
+
+func (l *commaSeparatedList) UnmarshalTFSchema(input interface{}) error {
+	v, ok := input.(string)
+	if !ok {
+		return fmt.Errorf("expected a string but got %T", input)
+	}
+	*l = strings.Split(v, ",")
+	return nil
+}
+
+type SingleBlock struct {
+	Name    string        `tfschema:"name"`
+	Enabled *bool         `tfschema:"enabled"`
+	Inner   *SingleBlock  `tfschema:"inner"`
+	Timeout time.Duration `tfschema:"timeout"`
+}
+
+type WithSingleBlock struct {
+	Name  string       `tfschema:"name"`
+	Block *SingleBlock `tfschema:"block"`
+}
+
+type CustomTypes struct {
+	ResourceGroupId  commonids.ResourceGroupId  `tfschema:"resource_group_id"`
+	SubscriptionId   *commonids.SubscriptionId  `tfschema:"subscription_id"`
+	OptionalId       *commonids.ResourceGroupId `tfschema:"optional_id"`
+	StartTime        time.Time                  `tfschema:"start_time"`
+	EndTime          *time.Time                 `tfschema:"end_time"`
+	Timeout          time.Duration              `tfschema:"timeout"`
+	OptionalTimeout  *time.Duration             `tfschema:"optional_timeout"`
+	Tags             commaSeparatedList         `tfschema:"tags"`
+	ListOfCustomType []CustomTypesNested        `tfschema:"list_of_custom_type"`
+}
+
+type CustomTypesNested struct {
+	Id commonids.ResourceGroupId `tfschema:"id"`
+}
+
 type AllRequired struct {
 	String        string             `tfschema:"string"`
 	Int64         int64              `tfschema:"int64"`
@@ -1035,10 +1086,190 @@ func TestResourceDecode_NestedThreeLevelsDeepMultipleOptionalItems(t *testing.T)
 	}.test(t)
 }
 
+func TestResourceDecode_SingleBlockEmpty(t *testing.T) {
+	decodeTestData{
+		State: map[string]interface{}{
+			"name":  "bingo",
+			"block": []interface{}{},
+		},
+		Input: &WithSingleBlock{},
+		Expected: &WithSingleBlock{
+			Name: "bingo",
+		},
+	}.test(t)
+}
+
+func TestResourceDecode_SingleBlock(t *testing.T) {
+	decodeTestData{
+		State: map[string]interface{}{
+			"name": "bingo",
+			"block": []interface{}{
+				map[string]interface{}{
+					"name":    "bango",
+					"enabled": true,
+					"timeout": "1h30m0s",
+					"inner": []interface{}{
+						map[string]interface{}{
+							"name":    "bongo",
+							"enabled": false,
+							"inner":   []interface{}{},
+							"timeout": "",
+						},
+					},
+				},
+			},
+		},
+		Input: &WithSingleBlock{},
+		Expected: &WithSingleBlock{
+			Name: "bingo",
+			Block: &SingleBlock{
+				Name:    "bango",
+				Enabled: pointer.To(true),
+				Timeout: 90 * time.Minute,
+				Inner: &SingleBlock{
+					Name:    "bongo",
+					Enabled: pointer.To(false),
+				},
+			},
+		},
+	}.test(t)
+}
+
+func TestResourceDecode_SingleBlockMultipleItems(t *testing.T) {
+	decodeTestData{
+		State: map[string]interface{}{
+			"block": []interface{}{
+				map[string]interface{}{
+					"name": "bingo",
+				},
+				map[string]interface{}{
+					"name": "bango",
+				},
+			},
+		},
+		Input:       &WithSingleBlock{},
+		ExpectError: true,
+	}.test(t)
+}
+
+func TestResourceDecode_CustomTypes(t *testing.T) {
+	decodeTestData{
+		State: map[string]interface{}{
+			"resource_group_id": "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/example",
+			"subscription_id":   "/subscriptions/12345678-1234-9876-4563-123456789012",
+			"optional_id":       "",
+			"start_time":        "2023-04-01T12:30:00Z",
+			"end_time":          "2023-04-02T12:30:00+01:00",
+			"timeout":           "30m",
+			"optional_timeout":  "1h",
+			"tags":              "bingo,bango,bongo",
+			"list_of_custom_type": []interface{}{
+				map[string]interface{}{
+					"id": "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/nested",
+				},
+			},
+		},
+		Input: &CustomTypes{},
+		Expected: &CustomTypes{
+			ResourceGroupId: commonids.NewResourceGroupID("12345678-1234-9876-4563-123456789012", "example"),
+			SubscriptionId:  pointer.To(commonids.NewSubscriptionID("12345678-1234-9876-4563-123456789012")),
+			StartTime:       time.Date(2023, 4, 1, 12, 30, 0, 0, time.UTC),
+			EndTime:         pointer.To(time.Date(2023, 4, 2, 11, 30, 0, 0, time.UTC)),
+			Timeout:         30 * time.Minute,
+			OptionalTimeout: pointer.To(time.Hour),
+			Tags:            commaSeparatedList{"bingo", "bango", "bongo"},
+			ListOfCustomType: []CustomTypesNested{
+				{
+					Id: commonids.NewResourceGroupID("12345678-1234-9876-4563-123456789012", "nested"),
+				},
+			},
+		},
+	}.test(t)
+}
+
+func TestResourceDecode_CustomTypesInvalid(t *testing.T) {
+	decodeTestData{
+		State: map[string]interface{}{
+			"resource_group_id": "/subscriptions/12345678-1234-9876-4563-123456789012",
+		},
+		Input:       &CustomTypes{},
+		ExpectError: true,
+	}.test(t)
+}
+
+func TestResourceDecode_NullInConfigWithoutOption(t *testing.T) {
+	decodeTestData{
+		State: map[string]interface{}{
+			"required":      "bingo",
+			"string":        "",
+			"enabled":       false,
+			"int64":         0,
+			"empty_boolean": false,
+		},
+		Config: cty.ObjectVal(map[string]cty.Value{
+			"required":      cty.StringVal("bingo"),
+			"string":        cty.NullVal(cty.String),
+			"enabled":       cty.NullVal(cty.Bool),
+			"int64":         cty.NumberIntVal(0),
+			"empty_boolean": cty.NullVal(cty.Bool),
+		}),
+		Input: &OneRequiredRestOptional{},
+		Expected: &OneRequiredRestOptional{
+			Required: "bingo",
+			String:   pointer.To(""),
+			Enabled:  pointer.To(false),
+			Int64:    pointer.To(int64(0)),
+		},
+	}.test(t)
+}
+
+func TestResourceDecode_NullInConfigWithOption(t *testing.T) {
+	decodeTestData{
+		State: map[string]interface{}{
+			"required":      "bingo",
+			"string":        "",
+			"enabled":       false,
+			"int64":         0,
+			"empty_boolean": false,
+		},
+		Config: cty.ObjectVal(map[string]cty.Value{
+			"required":      cty.StringVal("bingo"),
+			"string":        cty.NullVal(cty.String),
+			"enabled":       cty.NullVal(cty.Bool),
+			"int64":         cty.NumberIntVal(0),
+			"empty_boolean": cty.NullVal(cty.Bool),
+		}),
+		Options: []DecodeOption{NilWhenNullInConfig()},
+		Input:   &OneRequiredRestOptional{},
+		Expected: &OneRequiredRestOptional{
+			Required: "bingo",
+			Int64:    pointer.To(int64(0)),
+		},
+	}.test(t)
+}
+
+func TestResourceDecode_NullInConfigWithOptionNoConfig(t *testing.T) {
+	// e.g. during a Read, where the config isn't available
+	decodeTestData{
+		State: map[string]interface{}{
+			"required": "bingo",
+			"string":   "",
+			"enabled":  false,
+		},
+		Options: []DecodeOption{NilWhenNullInConfig()},
+		Input:   &OneRequiredRestOptional{},
+		Expected: &OneRequiredRestOptional{
+			Required: "bingo",
+			String:   pointer.To(""),
+			Enabled:  pointer.To(false),
+		},
+	}.test(t)
+}
+
 func (testData decodeTestData) test(t *testing.T) {
 	debugLogger := ConsoleLogger{}
 	state := testData.stateWrapper()
-	if err := decodeReflectedType(testData.Input, state, debugLogger); err != nil {
+	if err := decodeReflectedType(testData.Input, state, debugLogger, testData.Options...); err != nil {
 		if testData.ExpectError {
 			// we're good
 			return
@@ -1058,11 +1289,13 @@ func (testData decodeTestData) test(t *testing.T) {
 func (testData decodeTestData) stateWrapper() testDataGetter {
 	return testDataGetter{
 		values: testData.State,
+		config: testData.Config,
 	}
 }
 
 type testDataGetter struct {
 	values map[string]interface{}
+	config cty.Value
 }
 
 func (td testDataGetter) Get(key string) interface{} {
@@ -1079,3 +1312,7 @@ func (td testDataGetter) GetOkExists(key string) (interface{}, bool) {
 	val, ok := td.values[key]
 	return val, ok
 }
+
+func (td testDataGetter) GetRawConfig() cty.Value {
+	return td.config
+}
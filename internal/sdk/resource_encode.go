@@ -11,6 +11,8 @@ import (
 )
 
 // Encode will encode the specified object into the Terraform State
+// Pointers to structs are encoded as a single nested block (e.g. a List with `MaxItems: 1`) and types
+// implementing TFSchemaMarshaler, Resource IDs, `time.Time` and `time.Duration` are encoded as a string.
 // NOTE: this requires that the object passed in is a pointer and
 // all fields contain `tfschema` struct tags
 func (rmd ResourceMetaData) Encode(input interface{}) error {
@@ -70,6 +72,48 @@ func recurse(objType reflect.Type, objVal reflect.Value, debugLogger Logger) (ou
 				continue
 			}
 
+			if isCustomType(field.Type) {
+				cv, err := marshalCustomType(fieldVal)
+				if err != nil {
+					return nil, fmt.Errorf("encoding %q: %+v", structTags.hclPath, err)
+				}
+				debugLogger.Infof("Setting %q to %+v", structTags.hclPath, cv)
+				output[structTags.hclPath] = cv
+				continue
+			}
+
+			if field.Type.Kind() == reflect.Pointer && isCustomType(field.Type.Elem()) {
+				if fieldVal.IsNil() {
+					debugLogger.Infof("Setting %q to nil", structTags.hclPath)
+					output[structTags.hclPath] = nil
+					continue
+				}
+
+				cv, err := marshalCustomType(fieldVal.Elem())
+				if err != nil {
+					return nil, fmt.Errorf("encoding %q: %+v", structTags.hclPath, err)
+				}
+				debugLogger.Infof("Setting %q to %+v", structTags.hclPath, cv)
+				output[structTags.hclPath] = cv
+				continue
+			}
+
+			if isNestedBlock(field.Type) {
+				if fieldVal.IsNil() {
+					debugLogger.Infof("[BLOCK] Setting %q to an empty list", structTags.hclPath)
+					output[structTags.hclPath] = make([]interface{}, 0)
+					continue
+				}
+
+				serialized, err := recurse(field.Type.Elem(), fieldVal.Elem(), debugLogger)
+				if err != nil {
+					return nil, fmt.Errorf("serializing nested block %q: %+v", structTags.hclPath, err)
+				}
+				debugLogger.Infof("[BLOCK] Setting %q to %+v", structTags.hclPath, serialized)
+				output[structTags.hclPath] = []interface{}{serialized}
+				continue
+			}
+
 			switch field.Type.Kind() {
 			case reflect.Int64:
 				iv := fieldVal.Int()
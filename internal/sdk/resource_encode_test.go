@@ -6,9 +6,11 @@ package sdk
 import (
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
 )
 
 type encodeTestData struct {
@@ -1048,6 +1050,110 @@ func TestResourceEncode_NestedThreeLevelsDeepMultipleOptionalItems(t *testing.T)
 	}.test(t)
 }
 
+func TestResourceEncode_SingleBlockEmpty(t *testing.T) {
+	encodeTestData{
+		Input: &WithSingleBlock{
+			Name: "bingo",
+		},
+		Expected: map[string]interface{}{
+			"name":  "bingo",
+			"block": []interface{}{},
+		},
+	}.test(t)
+}
+
+func TestResourceEncode_SingleBlock(t *testing.T) {
+	encodeTestData{
+		Input: &WithSingleBlock{
+			Name: "bingo",
+			Block: &SingleBlock{
+				Name:    "bango",
+				Enabled: pointer.To(true),
+				Timeout: 90 * time.Minute,
+				Inner: &SingleBlock{
+					Name: "bongo",
+				},
+			},
+		},
+		Expected: map[string]interface{}{
+			"name": "bingo",
+			"block": []interface{}{
+				map[string]interface{}{
+					"name":    "bango",
+					"enabled": true,
+					"timeout": "1h30m0s",
+					"inner": []interface{}{
+						map[string]interface{}{
+							"name":    "bongo",
+							"enabled": nil,
+							"timeout": "0s",
+							"inner":   []interface{}{},
+						},
+					},
+				},
+			},
+		},
+	}.test(t)
+}
+
+func TestResourceEncode_CustomTypes(t *testing.T) {
+	encodeTestData{
+		Input: &CustomTypes{
+			ResourceGroupId: commonids.NewResourceGroupID("12345678-1234-9876-4563-123456789012", "example"),
+			SubscriptionId:  pointer.To(commonids.NewSubscriptionID("12345678-1234-9876-4563-123456789012")),
+			StartTime:       time.Date(2023, 4, 1, 12, 30, 0, 0, time.UTC),
+			Timeout:         30 * time.Minute,
+			OptionalTimeout: pointer.To(time.Hour),
+			Tags:            commaSeparatedList{"bingo", "bango", "bongo"},
+			ListOfCustomType: []CustomTypesNested{
+				{
+					Id: commonids.NewResourceGroupID("12345678-1234-9876-4563-123456789012", "nested"),
+				},
+			},
+		},
+		Expected: map[string]interface{}{
+			"resource_group_id": "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/example",
+			"subscription_id":   "/subscriptions/12345678-1234-9876-4563-123456789012",
+			"optional_id":       nil,
+			"start_time":        "2023-04-01T12:30:00Z",
+			"end_time":          nil,
+			"timeout":           "30m0s",
+			"optional_timeout":  "1h0m0s",
+			"tags":              "bingo,bango,bongo",
+			"list_of_custom_type": []interface{}{
+				map[string]interface{}{
+					"id": "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/nested",
+				},
+			},
+		},
+	}.test(t)
+}
+
+func TestResourceEncode_CustomTypesZeroValues(t *testing.T) {
+	encodeTestData{
+		Input: &CustomTypes{
+			ListOfCustomType: []CustomTypesNested{
+				{},
+			},
+		},
+		Expected: map[string]interface{}{
+			"resource_group_id": "",
+			"subscription_id":   nil,
+			"optional_id":       nil,
+			"start_time":        "",
+			"end_time":          nil,
+			"timeout":           "0s",
+			"optional_timeout":  nil,
+			"tags":              "",
+			"list_of_custom_type": []interface{}{
+				map[string]interface{}{
+					"id": "",
+				},
+			},
+		},
+	}.test(t)
+}
+
 func (testData encodeTestData) test(t *testing.T) {
 	objType := reflect.TypeOf(testData.Input).Elem()
 	objVal := reflect.ValueOf(testData.Input).Elem()
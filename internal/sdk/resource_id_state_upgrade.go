@@ -0,0 +1,126 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"reflect"
+
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/resourceids"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+var _ pluginsdk.StateUpgrade = ResourceIdStateUpgrade{}
+
+// ResourceIdStateUpgrade is a generic State Upgrade for Resources where the only change between
+// Schema versions is the format of the Resource ID - for example where the casing of a segment
+// has changed (e.g. `resourcegroups` -> `resourceGroups`), or where a static segment has been renamed.
+//
+// The existing `id` (and any AdditionalIdAttributes) is parsed insensitively using OldId and then
+// rewritten in the format of NewId, for example:
+//
+//	func (r ExampleResource) StateUpgraders() sdk.StateUpgradeData {
+//		return sdk.StateUpgradeData{
+//			SchemaVersion: 1,
+//			Upgraders: map[int]pluginsdk.StateUpgrade{
+//				0: sdk.ResourceIdStateUpgrade{
+//					PointInTimeSchema: migration.ExampleV0Schema(),
+//					NewId:             &examples.ExampleId{},
+//				},
+//			},
+//		}
+//	}
+type ResourceIdStateUpgrade struct {
+	// PointInTimeSchema is the Schema of the Resource at the version being upgraded from
+	// (see pluginsdk.StateUpgrade for more information) - this can be omitted when only
+	// the UpgradeFunc is used.
+	PointInTimeSchema map[string]*pluginsdk.Schema
+
+	// OldId is the Resource ID type used to parse the existing Resource ID - when omitted NewId
+	// is used, which allows normalising the casing of the existing Resource ID.
+	OldId resourceids.ResourceId
+
+	// NewId is the Resource ID type which the Resource ID should be rewritten in the format of.
+	NewId resourceids.ResourceId
+
+	// AdditionalIdAttributes optionally specifies any other top-level attributes containing a Resource
+	// ID which should be rewritten, keyed by the name of the attribute.
+	AdditionalIdAttributes map[string]ResourceIdStateUpgradeAttribute
+}
+
+// ResourceIdStateUpgradeAttribute defines the Resource ID types used to rewrite a top-level attribute
+// containing a Resource ID, as used in ResourceIdStateUpgrade
+type ResourceIdStateUpgradeAttribute struct {
+	// OldId is the Resource ID type used to parse the existing value - when omitted NewId is used.
+	OldId resourceids.ResourceId
+
+	// NewId is the Resource ID type which the value should be rewritten in the format of.
+	NewId resourceids.ResourceId
+}
+
+func (u ResourceIdStateUpgrade) Schema() map[string]*pluginsdk.Schema {
+	return u.PointInTimeSchema
+}
+
+func (u ResourceIdStateUpgrade) UpgradeFunc() pluginsdk.StateUpgraderFunc {
+	return func(ctx context.Context, rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+		oldIdRaw, ok := rawState["id"].(string)
+		if !ok || oldIdRaw == "" {
+			return rawState, fmt.Errorf("the `id` was not found in the existing State")
+		}
+
+		newId, err := rewriteResourceId(oldIdRaw, u.OldId, u.NewId)
+		if err != nil {
+			return rawState, err
+		}
+		log.Printf("[DEBUG] Updating ID from %q to %q", oldIdRaw, newId)
+		rawState["id"] = newId
+
+		for name, attribute := range u.AdditionalIdAttributes {
+			oldValue, ok := rawState[name].(string)
+			if !ok || oldValue == "" {
+				// optional attributes can be omitted
+				continue
+			}
+
+			newValue, err := rewriteResourceId(oldValue, attribute.OldId, attribute.NewId)
+			if err != nil {
+				return rawState, fmt.Errorf("updating %q: %+v", name, err)
+			}
+			log.Printf("[DEBUG] Updating %q from %q to %q", name, oldValue, newValue)
+			rawState[name] = newValue
+		}
+
+		return rawState, nil
+	}
+}
+
+// rewriteResourceId parses input insensitively using the Resource ID type oldId (or newId when
+// oldId is nil) and returns the Resource ID in the format of newId
+func rewriteResourceId(input string, oldId resourceids.ResourceId, newId resourceids.ResourceId) (string, error) {
+	if newId == nil {
+		return "", fmt.Errorf("internal-error: the new Resource ID type must be specified")
+	}
+	if oldId == nil {
+		oldId = newId
+	}
+
+	parsed, err := resourceids.NewParserFromResourceIdType(oldId).Parse(input, true)
+	if err != nil {
+		return "", err
+	}
+
+	// the types passed in are used as templates, so a new instance is populated to avoid mutating these
+	if reflect.TypeOf(newId).Kind() != reflect.Pointer {
+		return "", fmt.Errorf("internal-error: %T must be a pointer to a type implementing `resourceids.ResourceId`", newId)
+	}
+	output := reflect.New(reflect.TypeOf(newId).Elem()).Interface().(resourceids.ResourceId)
+	if err := output.FromParseResult(*parsed); err != nil {
+		return "", fmt.Errorf("converting %q to a %T: %+v", input, newId, err)
+	}
+
+	return output.ID(), nil
+}
@@ -0,0 +1,218 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/resourceids"
+)
+
+// legacyServerFarmId is a Resource ID using a (hypothetical) legacy static segment, which has
+// since been renamed to `serverFarms` as used in `commonids.AppServicePlanId`
+type legacyServerFarmId struct {
+	SubscriptionId    string
+	ResourceGroupName string
+	ServerFarmName    string
+}
+
+func (id *legacyServerFarmId) FromParseResult(input resourceids.ParseResult) error {
+	id.SubscriptionId = input.Parsed["subscriptionId"]
+	id.ResourceGroupName = input.Parsed["resourceGroupName"]
+	id.ServerFarmName = input.Parsed["serverFarmName"]
+	return nil
+}
+
+func (id *legacyServerFarmId) ID() string {
+	fmtString := "/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Web/plans/%s"
+	return fmt.Sprintf(fmtString, id.SubscriptionId, id.ResourceGroupName, id.ServerFarmName)
+}
+
+func (id *legacyServerFarmId) String() string {
+	return fmt.Sprintf("Legacy Server Farm %q", id.ServerFarmName)
+}
+
+func (id *legacyServerFarmId) Segments() []resourceids.Segment {
+	return []resourceids.Segment{
+		resourceids.StaticSegment("staticSubscriptions", "subscriptions", "subscriptions"),
+		resourceids.SubscriptionIdSegment("subscriptionId", "12345678-1234-9876-4563-123456789012"),
+		resourceids.StaticSegment("staticResourceGroups", "resourceGroups", "resourceGroups"),
+		resourceids.ResourceGroupSegment("resourceGroupName", "example-resource-group"),
+		resourceids.StaticSegment("staticProviders", "providers", "providers"),
+		resourceids.ResourceProviderSegment("staticMicrosoftWeb", "Microsoft.Web", "Microsoft.Web"),
+		resourceids.StaticSegment("staticPlans", "plans", "plans"),
+		resourceids.UserSpecifiedSegment("serverFarmName", "serverFarmValue"),
+	}
+}
+
+func TestResourceIdStateUpgrade(t *testing.T) {
+	testData := []struct {
+		name     string
+		upgrade  ResourceIdStateUpgrade
+		input    map[string]interface{}
+		expected map[string]interface{}
+		error    bool
+	}{
+		{
+			name: "casing",
+			upgrade: ResourceIdStateUpgrade{
+				NewId: &commonids.ResourceGroupId{},
+			},
+			input: map[string]interface{}{
+				"id":   "/subscriptions/12345678-1234-9876-4563-123456789012/resourcegroups/group1",
+				"name": "group1",
+			},
+			expected: map[string]interface{}{
+				"id":   "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/group1",
+				"name": "group1",
+			},
+		},
+		{
+			name: "already in the new format",
+			upgrade: ResourceIdStateUpgrade{
+				NewId: &commonids.ResourceGroupId{},
+			},
+			input: map[string]interface{}{
+				"id": "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/group1",
+			},
+			expected: map[string]interface{}{
+				"id": "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/group1",
+			},
+		},
+		{
+			name: "renamed segment",
+			upgrade: ResourceIdStateUpgrade{
+				OldId: &legacyServerFarmId{},
+				NewId: &commonids.AppServicePlanId{},
+			},
+			input: map[string]interface{}{
+				"id": "/subscriptions/12345678-1234-9876-4563-123456789012/resourcegroups/group1/providers/microsoft.web/Plans/plan1",
+			},
+			expected: map[string]interface{}{
+				"id": "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/group1/providers/Microsoft.Web/serverFarms/plan1",
+			},
+		},
+		{
+			name: "renamed segment using the new format",
+			upgrade: ResourceIdStateUpgrade{
+				OldId: &legacyServerFarmId{},
+				NewId: &commonids.AppServicePlanId{},
+			},
+			input: map[string]interface{}{
+				"id": "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/group1/providers/Microsoft.Web/serverFarms/plan1",
+			},
+			error: true,
+		},
+		{
+			name: "additional attributes",
+			upgrade: ResourceIdStateUpgrade{
+				NewId: &commonids.AppServiceId{},
+				AdditionalIdAttributes: map[string]ResourceIdStateUpgradeAttribute{
+					"service_plan_id": {
+						OldId: &legacyServerFarmId{},
+						NewId: &commonids.AppServicePlanId{},
+					},
+					"resource_group_id": {
+						NewId: &commonids.ResourceGroupId{},
+					},
+					"virtual_network_subnet_id": {
+						NewId: &commonids.SubnetId{},
+					},
+				},
+			},
+			input: map[string]interface{}{
+				"id":                        "/subscriptions/12345678-1234-9876-4563-123456789012/resourcegroups/group1/providers/Microsoft.Web/sites/site1",
+				"resource_group_id":         "/subscriptions/12345678-1234-9876-4563-123456789012/resourcegroups/group1",
+				"service_plan_id":           "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/group1/providers/Microsoft.Web/plans/plan1",
+				"virtual_network_subnet_id": "",
+			},
+			expected: map[string]interface{}{
+				"id":                        "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/group1/providers/Microsoft.Web/sites/site1",
+				"resource_group_id":         "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/group1",
+				"service_plan_id":           "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/group1/providers/Microsoft.Web/serverFarms/plan1",
+				"virtual_network_subnet_id": "",
+			},
+		},
+		{
+			name: "additional attribute invalid",
+			upgrade: ResourceIdStateUpgrade{
+				NewId: &commonids.AppServiceId{},
+				AdditionalIdAttributes: map[string]ResourceIdStateUpgradeAttribute{
+					"resource_group_id": {
+						NewId: &commonids.ResourceGroupId{},
+					},
+				},
+			},
+			input: map[string]interface{}{
+				"id":                "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/group1/providers/Microsoft.Web/sites/site1",
+				"resource_group_id": "/subscriptions/12345678-1234-9876-4563-123456789012",
+			},
+			error: true,
+		},
+		{
+			name: "invalid id",
+			upgrade: ResourceIdStateUpgrade{
+				NewId: &commonids.ResourceGroupId{},
+			},
+			input: map[string]interface{}{
+				"id": "/subscriptions/12345678-1234-9876-4563-123456789012",
+			},
+			error: true,
+		},
+		{
+			name: "missing id",
+			upgrade: ResourceIdStateUpgrade{
+				NewId: &commonids.ResourceGroupId{},
+			},
+			input: map[string]interface{}{},
+			error: true,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q..", v.name)
+
+		actual, err := v.upgrade.UpgradeFunc()(context.TODO(), v.input, nil)
+		if err != nil {
+			if v.error {
+				continue
+			}
+
+			t.Fatalf("unexpected error: %+v", err)
+		}
+		if v.error {
+			t.Fatalf("expected an error but didn't get one")
+		}
+
+		if len(actual) != len(v.expected) {
+			t.Fatalf("expected %d attributes but got %d", len(v.expected), len(actual))
+		}
+		for key, expected := range v.expected {
+			if actual[key] != expected {
+				t.Fatalf("expected %q to be %q but got %q", key, expected, actual[key])
+			}
+		}
+	}
+}
+
+func TestResourceIdStateUpgrade_DoesNotMutateTemplate(t *testing.T) {
+	template := &commonids.ResourceGroupId{}
+	upgrade := ResourceIdStateUpgrade{
+		NewId: template,
+	}
+
+	input := map[string]interface{}{
+		"id": "/subscriptions/12345678-1234-9876-4563-123456789012/resourcegroups/group1",
+	}
+	if _, err := upgrade.UpgradeFunc()(context.TODO(), input, nil); err != nil {
+		t.Fatalf("unexpected error: %+v", err)
+	}
+
+	if template.ResourceGroupName != "" {
+		t.Fatalf("expected the template Resource ID to be unchanged but got %q", template.ResourceGroupName)
+	}
+}
@@ -0,0 +1,129 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package sdk
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/resourceids"
+)
+
+// TFSchemaMarshaler is implemented by types which know how to convert themselves into the value
+// stored in the Terraform Schema (for example a `string`), allowing these to be used in a model
+// in place of the underlying Terraform type.
+type TFSchemaMarshaler interface {
+	MarshalTFSchema() (interface{}, error)
+}
+
+// TFSchemaUnmarshaler is implemented by types which know how to populate themselves from the value
+// stored in the Terraform Schema (for example a `string`).
+//
+// This is the counterpart of TFSchemaMarshaler and should be implemented on the pointer receiver.
+type TFSchemaUnmarshaler interface {
+	UnmarshalTFSchema(input interface{}) error
+}
+
+var (
+	durationType    = reflect.TypeOf(time.Duration(0))
+	marshalerType   = reflect.TypeOf((*TFSchemaMarshaler)(nil)).Elem()
+	resourceIdType  = reflect.TypeOf((*resourceids.ResourceId)(nil)).Elem()
+	timeType        = reflect.TypeOf(time.Time{})
+	unmarshalerType = reflect.TypeOf((*TFSchemaUnmarshaler)(nil)).Elem()
+)
+
+// isCustomType returns whether the (non-pointer) type t is encoded/decoded as a single value, rather than
+// by using the Kind of the type - this covers types implementing TFSchemaMarshaler/TFSchemaUnmarshaler,
+// Resource IDs, `time.Time` (as an RFC3339 string) and `time.Duration` (as a duration string, e.g. `1h30m`).
+func isCustomType(t reflect.Type) bool {
+	ptr := reflect.PointerTo(t)
+	switch {
+	case t.Implements(marshalerType), ptr.Implements(marshalerType), ptr.Implements(unmarshalerType):
+		return true
+	case ptr.Implements(resourceIdType):
+		return true
+	case t == timeType, t == durationType:
+		return true
+	}
+	return false
+}
+
+// marshalCustomType returns the Terraform Schema representation of the custom type held in v
+func marshalCustomType(v reflect.Value) (interface{}, error) {
+	ptr := reflect.New(v.Type())
+	ptr.Elem().Set(v)
+
+	if marshaler, ok := ptr.Interface().(TFSchemaMarshaler); ok {
+		return marshaler.MarshalTFSchema()
+	}
+
+	if id, ok := ptr.Interface().(resourceids.ResourceId); ok {
+		// an unset Resource ID would otherwise be formatted with empty segments
+		if v.IsZero() {
+			return "", nil
+		}
+		return id.ID(), nil
+	}
+
+	switch v.Type() {
+	case timeType:
+		t := v.Interface().(time.Time)
+		if t.IsZero() {
+			return "", nil
+		}
+		return t.Format(time.RFC3339), nil
+
+	case durationType:
+		return v.Interface().(time.Duration).String(), nil
+	}
+
+	return nil, fmt.Errorf("the type %q doesn't implement `TFSchemaMarshaler`", v.Type())
+}
+
+// unmarshalCustomType populates target (a pointer to the custom type) from the Terraform Schema value input
+func unmarshalCustomType(target reflect.Value, input interface{}) error {
+	if unmarshaler, ok := target.Interface().(TFSchemaUnmarshaler); ok {
+		return unmarshaler.UnmarshalTFSchema(input)
+	}
+
+	raw, ok := input.(string)
+	if !ok {
+		return fmt.Errorf("expected a string to decode %q but got %T", target.Type().Elem(), input)
+	}
+
+	if id, ok := target.Interface().(resourceids.ResourceId); ok {
+		parsed, err := resourceids.NewParserFromResourceIdType(id).Parse(raw, false)
+		if err != nil {
+			return err
+		}
+		return id.FromParseResult(*parsed)
+	}
+
+	switch target.Type().Elem() {
+	case timeType:
+		t, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			return fmt.Errorf("parsing %q as an RFC3339 timestamp: %+v", raw, err)
+		}
+		target.Elem().Set(reflect.ValueOf(t))
+		return nil
+
+	case durationType:
+		d, err := time.ParseDuration(raw)
+		if err != nil {
+			return fmt.Errorf("parsing %q as a duration: %+v", raw, err)
+		}
+		target.Elem().Set(reflect.ValueOf(d))
+		return nil
+	}
+
+	return fmt.Errorf("the type %q doesn't implement `TFSchemaUnmarshaler`", target.Type().Elem())
+}
+
+// isNestedBlock returns whether the type t is a pointer to a struct which should be mapped to a
+// single nested block (e.g. a List with `MaxItems: 1`) in the Terraform Schema
+func isNestedBlock(t reflect.Type) bool {
+	return t.Kind() == reflect.Pointer && t.Elem().Kind() == reflect.Struct && !isCustomType(t.Elem())
+}
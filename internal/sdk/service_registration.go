@@ -64,3 +64,29 @@ type UntypedServiceRegistrationWithAGitHubLabel interface {
 
 	AssociatedGitHubLabel() string
 }
+
+// TypedServiceRegistrationWithFrameworkResources is a superset of TypedServiceRegistration allowing
+// Resources to be served through the Plugin Framework (rather than Plugin SDKv2) from 4.0.
+//
+// NOTE: these Resources must also be returned from Resources, since they continue to be served through
+// Plugin SDKv2 until 4.0 - at which point they're bridged into the Plugin Framework.
+type TypedServiceRegistrationWithFrameworkResources interface {
+	TypedServiceRegistration
+
+	// FrameworkResources returns a list of Resources (from Resources) which are served through the Plugin Framework
+	FrameworkResources() []Resource
+}
+
+// ServiceRegistrationWithResourceProviders is an optional interface for both Typed and Untyped Service
+// Registrations, allowing the Resource Providers required by the Resources within this Service to be
+// specified.
+//
+// When the Provider is configured to register Resource Providers lazily (`resource_provider_registrations`
+// set to `lazy`) these Resource Providers are registered the first time a Resource from this Service is
+// planned or applied (or a Data Source from this Service is read) - nothing is registered lazily for Services
+// which don't implement this interface.
+type ServiceRegistrationWithResourceProviders interface {
+	// ResourceProviders returns the Resource Provider namespaces (e.g. `Microsoft.Storage`) which must be
+	// registered to use the Resources within this Service
+	ResourceProviders() []string
+}
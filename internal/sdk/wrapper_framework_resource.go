@@ -0,0 +1,667 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package sdk
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	frameworkschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+)
+
+var (
+	_ resource.Resource                   = &FrameworkResourceWrapper{}
+	_ resource.ResourceWithConfigure      = &FrameworkResourceWrapper{}
+	_ resource.ResourceWithImportState    = &FrameworkResourceWrapper{}
+	_ resource.ResourceWithModifyPlan     = &FrameworkResourceWrapper{}
+	_ resource.ResourceWithUpgradeState   = &FrameworkResourceWrapper{}
+	_ resource.ResourceWithValidateConfig = &FrameworkResourceWrapper{}
+)
+
+// frameworkPrivateStateKey is the key within the Plugin Framework Private State used to store the
+// Private State of the Plugin SDKv2 Resource
+const frameworkPrivateStateKey = "plugin_sdk"
+
+// FrameworkResourceWrapper is a wrapper for serving a Resource implementation through the Terraform Plugin Framework.
+//
+// The Resource is built using the ResourceWrapper (as when it's served through Plugin SDKv2) and each request is
+// delegated to the Plugin SDKv2 implementation of the Resource, such that the Schema, Plan, State, Import and State
+// Upgrades are identical to those of the Resource when served through Plugin SDKv2.
+type FrameworkResourceWrapper struct {
+	resourceType string
+	server       *schema.GRPCProviderServer
+	provider     *schema.Provider
+}
+
+// NewFrameworkResourceWrapper returns a function returning a FrameworkResourceWrapper for this Resource implementation,
+// as used when registering Resources with the Plugin Framework. The wrap function (if specified) is applied to the
+// Plugin SDKv2 Resource, allowing it to be wrapped in the same manner as when it's registered with Plugin SDKv2.
+func NewFrameworkResourceWrapper(r Resource, wrap func(*schema.Resource) *schema.Resource) (func() resource.Resource, error) {
+	wrapper := NewResourceWrapper(r)
+	pluginSdkResource, err := wrapper.Resource()
+	if err != nil {
+		return nil, fmt.Errorf("creating Wrapper for Resource %q: %+v", r.ResourceType(), err)
+	}
+	if wrap != nil {
+		pluginSdkResource = wrap(pluginSdkResource)
+	}
+
+	return func() resource.Resource {
+		p := &schema.Provider{
+			ResourcesMap: map[string]*schema.Resource{
+				r.ResourceType(): pluginSdkResource,
+			},
+		}
+
+		return &FrameworkResourceWrapper{
+			resourceType: r.ResourceType(),
+			server:       schema.NewGRPCProviderServer(p),
+			provider:     p,
+		}
+	}, nil
+}
+
+func (w *FrameworkResourceWrapper) Metadata(_ context.Context, _ resource.MetadataRequest, response *resource.MetadataResponse) {
+	response.TypeName = w.resourceType
+}
+
+func (w *FrameworkResourceWrapper) Schema(ctx context.Context, _ resource.SchemaRequest, response *resource.SchemaResponse) {
+	s, err := w.pluginSdkSchema(ctx)
+	if err != nil {
+		response.Diagnostics.AddError(fmt.Sprintf("retrieving the Schema for %q", w.resourceType), err.Error())
+		return
+	}
+
+	attributes, blocks, err := frameworkSchemaForBlock(s.Block)
+	if err != nil {
+		response.Diagnostics.AddError(fmt.Sprintf("converting the Schema for %q", w.resourceType), err.Error())
+		return
+	}
+
+	response.Schema = frameworkschema.Schema{
+		Attributes: attributes,
+		Blocks:     blocks,
+		Version:    s.Version,
+	}
+}
+
+func (w *FrameworkResourceWrapper) Configure(_ context.Context, request resource.ConfigureRequest, response *resource.ConfigureResponse) {
+	if request.ProviderData == nil {
+		// the provider hasn't been configured yet
+		return
+	}
+
+	client, ok := request.ProviderData.(*clients.Client)
+	if !ok {
+		response.Diagnostics.AddError("configuring Resource", fmt.Sprintf("expected a *clients.Client but got %T", request.ProviderData))
+		return
+	}
+
+	w.provider.SetMeta(client)
+}
+
+func (w *FrameworkResourceWrapper) ValidateConfig(ctx context.Context, request resource.ValidateConfigRequest, response *resource.ValidateConfigResponse) {
+	valueType := request.Config.Schema.Type().TerraformType(ctx)
+	config, err := tfprotov5.NewDynamicValue(valueType, request.Config.Raw)
+	if err != nil {
+		response.Diagnostics.AddError("converting the Configuration", err.Error())
+		return
+	}
+
+	resp, err := w.server.ValidateResourceTypeConfig(ctx, &tfprotov5.ValidateResourceTypeConfigRequest{
+		TypeName: w.resourceType,
+		Config:   &config,
+	})
+	if err != nil {
+		response.Diagnostics.AddError(fmt.Sprintf("validating %s", w.resourceType), err.Error())
+		return
+	}
+	response.Diagnostics.Append(frameworkDiagnostics(resp.Diagnostics)...)
+}
+
+func (w *FrameworkResourceWrapper) ModifyPlan(ctx context.Context, request resource.ModifyPlanRequest, response *resource.ModifyPlanResponse) {
+	if request.Plan.Raw.IsNull() {
+		// the Resource is being destroyed, so there's nothing to plan
+		return
+	}
+
+	valueType := request.Plan.Schema.Type().TerraformType(ctx)
+	proposedNewState, err := tftypes.Transform(request.Plan.Raw, proposedNewStateTransform(request.Config.Raw))
+	if err != nil {
+		response.Diagnostics.AddError("building the Proposed New State", err.Error())
+		return
+	}
+
+	config, diags := dynamicValue(valueType, request.Config.Raw)
+	response.Diagnostics.Append(diags...)
+	priorState, diags := dynamicValue(valueType, request.State.Raw)
+	response.Diagnostics.Append(diags...)
+	proposed, diags := dynamicValue(valueType, proposedNewState)
+	response.Diagnostics.Append(diags...)
+	priorPrivate, diags := request.Private.GetKey(ctx, frameworkPrivateStateKey)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	resp, err := w.server.PlanResourceChange(ctx, &tfprotov5.PlanResourceChangeRequest{
+		TypeName:         w.resourceType,
+		PriorState:       priorState,
+		ProposedNewState: proposed,
+		Config:           config,
+		PriorPrivate:     priorPrivate,
+	})
+	if err != nil {
+		response.Diagnostics.AddError(fmt.Sprintf("planning %s", w.resourceType), err.Error())
+		return
+	}
+	response.Diagnostics.Append(frameworkDiagnostics(resp.Diagnostics)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	plannedState, err := resp.PlannedState.Unmarshal(valueType)
+	if err != nil {
+		response.Diagnostics.AddError("converting the Planned State", err.Error())
+		return
+	}
+	response.Plan.Raw = plannedState
+	response.Diagnostics.Append(response.Private.SetKey(ctx, frameworkPrivateStateKey, resp.PlannedPrivate)...)
+
+	for _, v := range resp.RequiresReplace {
+		response.RequiresReplace = append(response.RequiresReplace, frameworkPath(v))
+	}
+}
+
+func (w *FrameworkResourceWrapper) Create(ctx context.Context, request resource.CreateRequest, response *resource.CreateResponse) {
+	valueType := request.Plan.Schema.Type().TerraformType(ctx)
+
+	// the Planned Private State is passed to Create through the Response
+	plannedPrivate, diags := response.Private.GetKey(ctx, frameworkPrivateStateKey)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	newState, newPrivate, diags := w.applyResourceChange(ctx, valueType, tftypes.NewValue(valueType, nil), request.Plan.Raw, request.Config.Raw, plannedPrivate)
+	response.Diagnostics.Append(diags...)
+	if newState == nil {
+		return
+	}
+
+	response.State.Raw = *newState
+	response.Diagnostics.Append(response.Private.SetKey(ctx, frameworkPrivateStateKey, newPrivate)...)
+}
+
+func (w *FrameworkResourceWrapper) Read(ctx context.Context, request resource.ReadRequest, response *resource.ReadResponse) {
+	valueType := request.State.Schema.Type().TerraformType(ctx)
+
+	currentState, diags := dynamicValue(valueType, request.State.Raw)
+	response.Diagnostics.Append(diags...)
+	private, diags := request.Private.GetKey(ctx, frameworkPrivateStateKey)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	resp, err := w.server.ReadResource(ctx, &tfprotov5.ReadResourceRequest{
+		TypeName:     w.resourceType,
+		CurrentState: currentState,
+		Private:      private,
+	})
+	if err != nil {
+		response.Diagnostics.AddError(fmt.Sprintf("retrieving %s", w.resourceType), err.Error())
+		return
+	}
+	response.Diagnostics.Append(frameworkDiagnostics(resp.Diagnostics)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	newState, err := resp.NewState.Unmarshal(valueType)
+	if err != nil {
+		response.Diagnostics.AddError("converting the State", err.Error())
+		return
+	}
+	response.State.Raw = newState
+	response.Diagnostics.Append(response.Private.SetKey(ctx, frameworkPrivateStateKey, resp.Private)...)
+}
+
+func (w *FrameworkResourceWrapper) Update(ctx context.Context, request resource.UpdateRequest, response *resource.UpdateResponse) {
+	valueType := request.Plan.Schema.Type().TerraformType(ctx)
+
+	plannedPrivate, diags := request.Private.GetKey(ctx, frameworkPrivateStateKey)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	newState, newPrivate, diags := w.applyResourceChange(ctx, valueType, request.State.Raw, request.Plan.Raw, request.Config.Raw, plannedPrivate)
+	response.Diagnostics.Append(diags...)
+	if newState == nil {
+		return
+	}
+
+	response.State.Raw = *newState
+	response.Diagnostics.Append(response.Private.SetKey(ctx, frameworkPrivateStateKey, newPrivate)...)
+}
+
+func (w *FrameworkResourceWrapper) Delete(ctx context.Context, request resource.DeleteRequest, response *resource.DeleteResponse) {
+	valueType := request.State.Schema.Type().TerraformType(ctx)
+
+	plannedPrivate, diags := request.Private.GetKey(ctx, frameworkPrivateStateKey)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	nullValue := tftypes.NewValue(valueType, nil)
+	_, _, diags = w.applyResourceChange(ctx, valueType, request.State.Raw, nullValue, nullValue, plannedPrivate)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	response.State.RemoveResource(ctx)
+}
+
+func (w *FrameworkResourceWrapper) ImportState(ctx context.Context, request resource.ImportStateRequest, response *resource.ImportStateResponse) {
+	resp, err := w.server.ImportResourceState(ctx, &tfprotov5.ImportResourceStateRequest{
+		TypeName: w.resourceType,
+		ID:       request.ID,
+	})
+	if err != nil {
+		response.Diagnostics.AddError(fmt.Sprintf("importing %s", w.resourceType), err.Error())
+		return
+	}
+	response.Diagnostics.Append(frameworkDiagnostics(resp.Diagnostics)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	if len(resp.ImportedResources) != 1 {
+		response.Diagnostics.AddError(fmt.Sprintf("importing %s", w.resourceType), fmt.Sprintf("expected 1 imported resource but got %d", len(resp.ImportedResources)))
+		return
+	}
+	imported := resp.ImportedResources[0]
+
+	state, err := imported.State.Unmarshal(response.State.Schema.Type().TerraformType(ctx))
+	if err != nil {
+		response.Diagnostics.AddError("converting the Imported State", err.Error())
+		return
+	}
+	response.State.Raw = state
+	response.Diagnostics.Append(response.Private.SetKey(ctx, frameworkPrivateStateKey, imported.Private)...)
+}
+
+// UpgradeState returns a State Upgrader for each prior Schema Version, each of which delegates to the
+// Plugin SDKv2 Resource - which upgrades the State to the current Schema Version (including any State
+// written by Plugin SDKv2 in the Flatmap format)
+func (w *FrameworkResourceWrapper) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	upgraders := make(map[int64]resource.StateUpgrader)
+
+	s, err := w.pluginSdkSchema(ctx)
+	if err != nil {
+		return upgraders
+	}
+	valueType := s.ValueType()
+
+	for version := int64(0); version < s.Version; version++ {
+		priorVersion := version
+		upgraders[priorVersion] = resource.StateUpgrader{
+			StateUpgrader: func(ctx context.Context, request resource.UpgradeStateRequest, response *resource.UpgradeStateResponse) {
+				var rawState *tfprotov5.RawState
+				if request.RawState != nil {
+					rawState = &tfprotov5.RawState{
+						JSON:    request.RawState.JSON,
+						Flatmap: request.RawState.Flatmap,
+					}
+				}
+
+				resp, err := w.server.UpgradeResourceState(ctx, &tfprotov5.UpgradeResourceStateRequest{
+					TypeName: w.resourceType,
+					Version:  priorVersion,
+					RawState: rawState,
+				})
+				if err != nil {
+					response.Diagnostics.AddError(fmt.Sprintf("upgrading the State for %s", w.resourceType), err.Error())
+					return
+				}
+				response.Diagnostics.Append(frameworkDiagnostics(resp.Diagnostics)...)
+				if response.Diagnostics.HasError() {
+					return
+				}
+
+				upgradedState, err := resp.UpgradedState.Unmarshal(valueType)
+				if err != nil {
+					response.Diagnostics.AddError("converting the Upgraded State", err.Error())
+					return
+				}
+				dynamicValue, err := tfprotov6.NewDynamicValue(valueType, upgradedState)
+				if err != nil {
+					response.Diagnostics.AddError("converting the Upgraded State", err.Error())
+					return
+				}
+				response.DynamicValue = &dynamicValue
+			},
+		}
+	}
+
+	return upgraders
+}
+
+func (w *FrameworkResourceWrapper) applyResourceChange(ctx context.Context, valueType tftypes.Type, priorState, plannedState, config tftypes.Value, plannedPrivate []byte) (*tftypes.Value, []byte, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	prior, d := dynamicValue(valueType, priorState)
+	diags.Append(d...)
+	planned, d := dynamicValue(valueType, plannedState)
+	diags.Append(d...)
+	conf, d := dynamicValue(valueType, config)
+	diags.Append(d...)
+	if diags.HasError() {
+		return nil, nil, diags
+	}
+
+	resp, err := w.server.ApplyResourceChange(ctx, &tfprotov5.ApplyResourceChangeRequest{
+		TypeName:       w.resourceType,
+		PriorState:     prior,
+		PlannedState:   planned,
+		Config:         conf,
+		PlannedPrivate: plannedPrivate,
+	})
+	if err != nil {
+		diags.AddError(fmt.Sprintf("applying %s", w.resourceType), err.Error())
+		return nil, nil, diags
+	}
+	diags.Append(frameworkDiagnostics(resp.Diagnostics)...)
+	if diags.HasError() {
+		return nil, nil, diags
+	}
+
+	newState, err := resp.NewState.Unmarshal(valueType)
+	if err != nil {
+		diags.AddError("converting the New State", err.Error())
+		return nil, nil, diags
+	}
+
+	return &newState, resp.Private, diags
+}
+
+func (w *FrameworkResourceWrapper) pluginSdkSchema(ctx context.Context) (*tfprotov5.Schema, error) {
+	resp, err := w.server.GetProviderSchema(ctx, &tfprotov5.GetProviderSchemaRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	s, ok := resp.ResourceSchemas[w.resourceType]
+	if !ok || s == nil {
+		return nil, fmt.Errorf("the Schema for %q was not found", w.resourceType)
+	}
+
+	return s, nil
+}
+
+// proposedNewStateTransform returns a function reverting the changes made by the Plugin Framework to the Proposed
+// New State - which marks Computed attributes which are null in the Configuration as Unknown - since these are
+// instead handled by the Plugin SDKv2 Resource when planning
+func proposedNewStateTransform(config tftypes.Value) func(*tftypes.AttributePath, tftypes.Value) (tftypes.Value, error) {
+	return func(p *tftypes.AttributePath, v tftypes.Value) (tftypes.Value, error) {
+		if v.IsKnown() || len(p.Steps()) == 0 {
+			return v, nil
+		}
+
+		configValue, _, err := tftypes.WalkAttributePath(config, p)
+		if err != nil {
+			return v, nil
+		}
+		if value, ok := configValue.(tftypes.Value); ok && value.IsNull() {
+			return tftypes.NewValue(v.Type(), nil), nil
+		}
+
+		return v, nil
+	}
+}
+
+func dynamicValue(valueType tftypes.Type, value tftypes.Value) (*tfprotov5.DynamicValue, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if value.Type() == nil {
+		value = tftypes.NewValue(valueType, nil)
+	}
+
+	output, err := tfprotov5.NewDynamicValue(valueType, value)
+	if err != nil {
+		diags.AddError("converting value", err.Error())
+		return nil, diags
+	}
+
+	return &output, diags
+}
+
+// frameworkPath converts the specified Attribute Path into a Plugin Framework Path - since elements within a Set
+// are identified by their value, a path to an element within a Set is instead converted into a path to the Set
+func frameworkPath(input *tftypes.AttributePath) path.Path {
+	output := path.Empty()
+	for _, step := range input.Steps() {
+		switch v := step.(type) {
+		case tftypes.AttributeName:
+			output = output.AtName(string(v))
+		case tftypes.ElementKeyInt:
+			output = output.AtListIndex(int(v))
+		case tftypes.ElementKeyString:
+			output = output.AtMapKey(string(v))
+		default:
+			return output
+		}
+	}
+
+	return output
+}
+
+func frameworkDiagnostics(input []*tfprotov5.Diagnostic) diag.Diagnostics {
+	var output diag.Diagnostics
+	for _, v := range input {
+		if v == nil {
+			continue
+		}
+
+		switch v.Severity {
+		case tfprotov5.DiagnosticSeverityError:
+			output.AddError(v.Summary, v.Detail)
+		case tfprotov5.DiagnosticSeverityWarning:
+			output.AddWarning(v.Summary, v.Detail)
+		}
+	}
+
+	return output
+}
+
+// frameworkSchemaForBlock converts the Plugin SDKv2 Schema for a Block into the equivalent Plugin Framework
+// Attributes and Blocks, such that the Schema (and so the State) is identical when served by either
+func frameworkSchemaForBlock(input *tfprotov5.SchemaBlock) (map[string]frameworkschema.Attribute, map[string]frameworkschema.Block, error) {
+	attributes := make(map[string]frameworkschema.Attribute)
+	blocks := make(map[string]frameworkschema.Block)
+	if input == nil {
+		return attributes, blocks, nil
+	}
+
+	for _, v := range input.Attributes {
+		attribute, err := frameworkAttribute(v)
+		if err != nil {
+			return nil, nil, fmt.Errorf("converting the attribute %q: %+v", v.Name, err)
+		}
+		attributes[v.Name] = attribute
+	}
+
+	for _, v := range input.BlockTypes {
+		nestedAttributes, nestedBlocks, err := frameworkSchemaForBlock(v.Block)
+		if err != nil {
+			return nil, nil, fmt.Errorf("converting the block %q: %+v", v.TypeName, err)
+		}
+
+		var description string
+		if v.Block != nil {
+			description = v.Block.Description
+		}
+
+		switch v.Nesting {
+		case tfprotov5.SchemaNestedBlockNestingModeList:
+			blocks[v.TypeName] = frameworkschema.ListNestedBlock{
+				NestedObject: frameworkschema.NestedBlockObject{
+					Attributes: nestedAttributes,
+					Blocks:     nestedBlocks,
+				},
+				Description: description,
+			}
+		case tfprotov5.SchemaNestedBlockNestingModeSet:
+			blocks[v.TypeName] = frameworkschema.SetNestedBlock{
+				NestedObject: frameworkschema.NestedBlockObject{
+					Attributes: nestedAttributes,
+					Blocks:     nestedBlocks,
+				},
+				Description: description,
+			}
+		case tfprotov5.SchemaNestedBlockNestingModeSingle, tfprotov5.SchemaNestedBlockNestingModeGroup:
+			blocks[v.TypeName] = frameworkschema.SingleNestedBlock{
+				Attributes:  nestedAttributes,
+				Blocks:      nestedBlocks,
+				Description: description,
+			}
+		default:
+			return nil, nil, fmt.Errorf("the block %q uses an unsupported nesting mode %q", v.TypeName, v.Nesting)
+		}
+	}
+
+	return attributes, blocks, nil
+}
+
+func frameworkAttribute(input *tfprotov5.SchemaAttribute) (frameworkschema.Attribute, error) {
+	switch {
+	case input.Type.Is(tftypes.String):
+		return frameworkschema.StringAttribute{
+			Required:    input.Required,
+			Optional:    input.Optional,
+			Computed:    input.Computed,
+			Sensitive:   input.Sensitive,
+			Description: input.Description,
+		}, nil
+	case input.Type.Is(tftypes.Bool):
+		return frameworkschema.BoolAttribute{
+			Required:    input.Required,
+			Optional:    input.Optional,
+			Computed:    input.Computed,
+			Sensitive:   input.Sensitive,
+			Description: input.Description,
+		}, nil
+	case input.Type.Is(tftypes.Number):
+		return frameworkschema.NumberAttribute{
+			Required:    input.Required,
+			Optional:    input.Optional,
+			Computed:    input.Computed,
+			Sensitive:   input.Sensitive,
+			Description: input.Description,
+		}, nil
+	}
+
+	attrType, err := frameworkType(input.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	switch v := attrType.(type) {
+	case types.ListType:
+		return frameworkschema.ListAttribute{
+			ElementType: v.ElemType,
+			Required:    input.Required,
+			Optional:    input.Optional,
+			Computed:    input.Computed,
+			Sensitive:   input.Sensitive,
+			Description: input.Description,
+		}, nil
+	case types.SetType:
+		return frameworkschema.SetAttribute{
+			ElementType: v.ElemType,
+			Required:    input.Required,
+			Optional:    input.Optional,
+			Computed:    input.Computed,
+			Sensitive:   input.Sensitive,
+			Description: input.Description,
+		}, nil
+	case types.MapType:
+		return frameworkschema.MapAttribute{
+			ElementType: v.ElemType,
+			Required:    input.Required,
+			Optional:    input.Optional,
+			Computed:    input.Computed,
+			Sensitive:   input.Sensitive,
+			Description: input.Description,
+		}, nil
+	case types.ObjectType:
+		return frameworkschema.ObjectAttribute{
+			AttributeTypes: v.AttrTypes,
+			Required:       input.Required,
+			Optional:       input.Optional,
+			Computed:       input.Computed,
+			Sensitive:      input.Sensitive,
+			Description:    input.Description,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("unsupported type %s", input.Type)
+}
+
+func frameworkType(input tftypes.Type) (attr.Type, error) {
+	switch v := input.(type) {
+	case tftypes.List:
+		elemType, err := frameworkType(v.ElementType)
+		if err != nil {
+			return nil, err
+		}
+		return types.ListType{ElemType: elemType}, nil
+	case tftypes.Set:
+		elemType, err := frameworkType(v.ElementType)
+		if err != nil {
+			return nil, err
+		}
+		return types.SetType{ElemType: elemType}, nil
+	case tftypes.Map:
+		elemType, err := frameworkType(v.ElementType)
+		if err != nil {
+			return nil, err
+		}
+		return types.MapType{ElemType: elemType}, nil
+	case tftypes.Object:
+		attrTypes := make(map[string]attr.Type, len(v.AttributeTypes))
+		for name, attributeType := range v.AttributeTypes {
+			t, err := frameworkType(attributeType)
+			if err != nil {
+				return nil, err
+			}
+			attrTypes[name] = t
+		}
+		return types.ObjectType{AttrTypes: attrTypes}, nil
+	}
+
+	switch {
+	case input.Is(tftypes.String):
+		return types.StringType, nil
+	case input.Is(tftypes.Bool):
+		return types.BoolType, nil
+	case input.Is(tftypes.Number):
+		return types.NumberType, nil
+	}
+
+	return nil, fmt.Errorf("unsupported type %s", input)
+}
@@ -49,7 +49,7 @@ func validateModelObjectRecursively(prefix string, objType reflect.Type, objVal
 		field := objType.Field(i)
 		fieldVal := objVal.Field(i)
 
-		if field.Type.Kind() == reflect.Slice {
+		if field.Type.Kind() == reflect.Slice && !isCustomType(field.Type) && !isCustomType(field.Type.Elem()) {
 			sv := fieldVal.Slice(0, fieldVal.Len())
 			innerType := sv.Type().Elem()
 			innerVal := reflect.Indirect(reflect.New(innerType))
@@ -59,6 +59,15 @@ func validateModelObjectRecursively(prefix string, objType reflect.Type, objVal
 			}
 		}
 
+		if isNestedBlock(field.Type) {
+			innerType := field.Type.Elem()
+			innerVal := reflect.Indirect(reflect.New(innerType))
+			fieldName := strings.TrimPrefix(fmt.Sprintf("%s.%s", prefix, field.Name), ".")
+			if err := validateModelObjectRecursively(fieldName, innerType, innerVal); err != nil {
+				return err
+			}
+		}
+
 		fieldName := strings.TrimPrefix(fmt.Sprintf("%s.%s", prefix, field.Name), ".")
 		structTags, err := parseStructTags(field.Tag)
 		if err != nil {
@@ -3,7 +3,10 @@
 
 package sdk
 
-import "testing"
+import (
+	"testing"
+	"time"
+)
 
 func TestValidateTopLevelObjectValid(t *testing.T) {
 	type Person struct {
@@ -69,3 +72,28 @@ func TestValidateNestedObjectInvalid(t *testing.T) {
 		t.Fatalf("expected an error but didn't get one")
 	}
 }
+
+func TestValidateNestedBlockInvalid(t *testing.T) {
+	type Address struct {
+		Street string
+	}
+	type Person struct {
+		Name    string   `tfschema:"name"`
+		Address *Address `tfschema:"address"`
+	}
+	if err := ValidateModelObject(&Person{}); err == nil {
+		t.Fatalf("expected an error but didn't get one")
+	}
+}
+
+func TestValidateCustomTypesValid(t *testing.T) {
+	type Person struct {
+		Name     string             `tfschema:"name"`
+		Birthday *time.Time         `tfschema:"birthday"`
+		Timeout  time.Duration      `tfschema:"timeout"`
+		Tags     commaSeparatedList `tfschema:"tags"`
+	}
+	if err := ValidateModelObject(&Person{}); err != nil {
+		t.Fatalf("error: %+v", err)
+	}
+}
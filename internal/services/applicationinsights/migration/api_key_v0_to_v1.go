@@ -4,10 +4,8 @@
 package migration
 
 import (
-	"context"
-	"log"
-
 	apikeys "github.com/hashicorp/go-azure-sdk/resource-manager/applicationinsights/2015-05-01/componentapikeysapis"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
 )
 
@@ -20,23 +18,13 @@ func (ApiKeyUpgradeV0ToV1) Schema() map[string]*pluginsdk.Schema {
 }
 
 func (ApiKeyUpgradeV0ToV1) UpgradeFunc() pluginsdk.StateUpgraderFunc {
-	return func(ctx context.Context, rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
-		// old:
-		// 	/subscriptions/{subscriptionId}/resourceGroups/{resourceGroupName}/providers/microsoft.insights/components/component1/apikeys/key1
-		// new:
-		// 	/subscriptions/{subscriptionId}/resourceGroups/{resourceGroupName}/providers/Microsoft.Insights/components/component1/apiKeys/key1
-		oldIdRaw := rawState["id"].(string)
-		id, err := apikeys.ParseApiKeyIDInsensitively(oldIdRaw)
-		if err != nil {
-			return rawState, err
-		}
-
-		newId := id.ID()
-		log.Printf("[DEBUG] Updating ID from %q to %q", oldIdRaw, newId)
-		rawState["id"] = newId
-
-		return rawState, nil
-	}
+	// old:
+	// 	/subscriptions/{subscriptionId}/resourceGroups/{resourceGroupName}/providers/microsoft.insights/components/component1/apikeys/key1
+	// new:
+	// 	/subscriptions/{subscriptionId}/resourceGroups/{resourceGroupName}/providers/Microsoft.Insights/components/component1/apiKeys/key1
+	return sdk.ResourceIdStateUpgrade{
+		NewId: &apikeys.ApiKeyId{},
+	}.UpgradeFunc()
 }
 
 func apiKeySchemaForV0AndV1() map[string]*pluginsdk.Schema {
@@ -4,11 +4,9 @@
 package migration
 
 import (
-	"context"
-	"log"
-
 	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonschema"
 	components "github.com/hashicorp/go-azure-sdk/resource-manager/applicationinsights/2020-02-02/componentsapis"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tags"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
 )
@@ -22,24 +20,13 @@ func (ComponentUpgradeV0ToV1) Schema() map[string]*pluginsdk.Schema {
 }
 
 func (ComponentUpgradeV0ToV1) UpgradeFunc() pluginsdk.StateUpgraderFunc {
-	return func(ctx context.Context, rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
-		// old:
-		// 	/subscriptions/{subscriptionId}/resourceGroups/{resourceGroupName}/providers/microsoft.insights/components/component1
-		// new:
-		// 	/subscriptions/{subscriptionId}/resourceGroups/{resourceGroupName}/providers/Microsoft.Insights/components/component1
-		oldIdRaw := rawState["id"].(string)
-		id, err := components.ParseComponentIDInsensitively(oldIdRaw)
-		if err != nil {
-			return rawState, err
-		}
-
-		newId := id.ID()
-
-		log.Printf("[DEBUG] Updating ID from %q to %q", oldIdRaw, newId)
-		rawState["id"] = newId
-
-		return rawState, nil
-	}
+	// old:
+	// 	/subscriptions/{subscriptionId}/resourceGroups/{resourceGroupName}/providers/microsoft.insights/components/component1
+	// new:
+	// 	/subscriptions/{subscriptionId}/resourceGroups/{resourceGroupName}/providers/Microsoft.Insights/components/component1
+	return sdk.ResourceIdStateUpgrade{
+		NewId: &components.ComponentId{},
+	}.UpgradeFunc()
 }
 
 func componentSchemaForV0AndV1() map[string]*pluginsdk.Schema {
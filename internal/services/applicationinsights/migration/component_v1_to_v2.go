@@ -4,11 +4,9 @@
 package migration
 
 import (
-	"context"
-	"log"
-
 	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonschema"
 	components "github.com/hashicorp/go-azure-sdk/resource-manager/applicationinsights/2020-02-02/componentsapis"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tags"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
 )
@@ -22,22 +20,11 @@ func (ComponentUpgradeV1ToV2) Schema() map[string]*pluginsdk.Schema {
 }
 
 func (ComponentUpgradeV1ToV2) UpgradeFunc() pluginsdk.StateUpgraderFunc {
-	return func(ctx context.Context, rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
-		// This state migration is identical to v0 -> v1, however we need to apply it again because application insights
-		// resources with the incorrect casing could still be imported and exist within some user's state
-		oldIdRaw := rawState["id"].(string)
-		id, err := components.ParseComponentIDInsensitively(oldIdRaw)
-		if err != nil {
-			return rawState, err
-		}
-
-		newId := id.ID()
-
-		log.Printf("[DEBUG] Updating ID from %q to %q", oldIdRaw, newId)
-		rawState["id"] = newId
-
-		return rawState, nil
-	}
+	// This state migration is identical to v0 -> v1, however we need to apply it again because application insights
+	// resources with the incorrect casing could still be imported and exist within some user's state
+	return sdk.ResourceIdStateUpgrade{
+		NewId: &components.ComponentId{},
+	}.UpgradeFunc()
 }
 
 func componentSchemaForV1AndV2() map[string]*pluginsdk.Schema {
@@ -4,11 +4,9 @@
 package migration
 
 import (
-	"context"
-	"log"
-
 	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonschema"
 	"github.com/hashicorp/go-azure-sdk/resource-manager/applicationinsights/2022-06-15/webtestsapis"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tags"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
 )
@@ -22,23 +20,13 @@ func (WebTestUpgradeV0ToV1) Schema() map[string]*pluginsdk.Schema {
 }
 
 func (WebTestUpgradeV0ToV1) UpgradeFunc() pluginsdk.StateUpgraderFunc {
-	return func(ctx context.Context, rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
-		// old:
-		// 	/subscriptions/{subscriptionId}/resourceGroups/{resourceGroupName}/providers/microsoft.insights/webtests/test1
-		// new:
-		// 	/subscriptions/{subscriptionId}/resourceGroups/{resourceGroupName}/providers/Microsoft.Insights/webTests/test1
-		oldIdRaw := rawState["id"].(string)
-		id, err := webtestsapis.ParseWebTestIDInsensitively(oldIdRaw)
-		if err != nil {
-			return rawState, err
-		}
-
-		newId := id.ID()
-		log.Printf("[DEBUG] Updating ID from %q to %q", oldIdRaw, newId)
-		rawState["id"] = newId
-
-		return rawState, nil
-	}
+	// old:
+	// 	/subscriptions/{subscriptionId}/resourceGroups/{resourceGroupName}/providers/microsoft.insights/webtests/test1
+	// new:
+	// 	/subscriptions/{subscriptionId}/resourceGroups/{resourceGroupName}/providers/Microsoft.Insights/webTests/test1
+	return sdk.ResourceIdStateUpgrade{
+		NewId: &webtestsapis.WebTestId{},
+	}.UpgradeFunc()
 }
 
 func webTestSchemaForV0AndV1() map[string]*pluginsdk.Schema {
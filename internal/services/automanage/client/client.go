@@ -8,14 +8,16 @@ import (
 
 	"github.com/hashicorp/go-azure-sdk/resource-manager/automanage/2022-05-04/configurationprofileassignments"
 	"github.com/hashicorp/go-azure-sdk/resource-manager/automanage/2022-05-04/configurationprofilehciassignments"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/automanage/2022-05-04/configurationprofilehcrpassignments"
 	"github.com/hashicorp/go-azure-sdk/resource-manager/automanage/2022-05-04/configurationprofiles"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/common"
 )
 
 type Client struct {
-	ConfigurationProfilesClient              *configurationprofiles.ConfigurationProfilesClient
-	ConfigurationProfileHCIAssignmentsClient *configurationprofilehciassignments.ConfigurationProfileHCIAssignmentsClient
-	ConfigurationProfileVMAssignmentsClient  *configurationprofileassignments.ConfigurationProfileAssignmentsClient
+	ConfigurationProfilesClient               *configurationprofiles.ConfigurationProfilesClient
+	ConfigurationProfileHCIAssignmentsClient  *configurationprofilehciassignments.ConfigurationProfileHCIAssignmentsClient
+	ConfigurationProfileHCRPAssignmentsClient *configurationprofilehcrpassignments.ConfigurationProfileHCRPAssignmentsClient
+	ConfigurationProfileVMAssignmentsClient   *configurationprofileassignments.ConfigurationProfileAssignmentsClient
 }
 
 func NewClient(o *common.ClientOptions) (*Client, error) {
@@ -31,6 +33,12 @@ func NewClient(o *common.ClientOptions) (*Client, error) {
 	}
 	o.Configure(configurationProfileHCIAssignmentsClient.Client, o.Authorizers.ResourceManager)
 
+	configurationProfileHCRPAssignmentsClient, err := configurationprofilehcrpassignments.NewConfigurationProfileHCRPAssignmentsClientWithBaseURI(o.Environment.ResourceManager)
+	if err != nil {
+		return nil, fmt.Errorf("building ConfigurationProfileHCRPAssignments client: %+v", err)
+	}
+	o.Configure(configurationProfileHCRPAssignmentsClient.Client, o.Authorizers.ResourceManager)
+
 	configurationProfileVMAssignmentsClient, err := configurationprofileassignments.NewConfigurationProfileAssignmentsClientWithBaseURI(o.Environment.ResourceManager)
 	if err != nil {
 		return nil, fmt.Errorf("building ConfigurationProfilesHCIAssignments client: %+v", err)
@@ -38,8 +46,9 @@ func NewClient(o *common.ClientOptions) (*Client, error) {
 	o.Configure(configurationProfileVMAssignmentsClient.Client, o.Authorizers.ResourceManager)
 
 	return &Client{
-		ConfigurationProfilesClient:              configurationProfilesClient,
-		ConfigurationProfileHCIAssignmentsClient: configurationProfileHCIAssignmentsClient,
-		ConfigurationProfileVMAssignmentsClient:  configurationProfileVMAssignmentsClient,
+		ConfigurationProfilesClient:               configurationProfilesClient,
+		ConfigurationProfileHCIAssignmentsClient:  configurationProfileHCIAssignmentsClient,
+		ConfigurationProfileHCRPAssignmentsClient: configurationProfileHCRPAssignmentsClient,
+		ConfigurationProfileVMAssignmentsClient:   configurationProfileVMAssignmentsClient,
 	}, nil
 }
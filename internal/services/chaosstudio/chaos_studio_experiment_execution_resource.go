@@ -0,0 +1,439 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package chaosstudio
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/chaosstudio/2023-11-01/experiments"
+	"github.com/hashicorp/go-azure-sdk/sdk/client/pollers"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/chaosstudio/custompollers"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+var _ sdk.ResourceWithUpdate = ChaosStudioExperimentExecutionResource{}
+
+type ChaosStudioExperimentExecutionResource struct{}
+
+type ChaosStudioExperimentExecutionResourceSchema struct {
+	ExperimentId      string                                `tfschema:"experiment_id"`
+	Triggers          map[string]string                     `tfschema:"triggers"`
+	WaitForCompletion bool                                  `tfschema:"wait_for_completion"`
+	CancelOnDestroy   bool                                  `tfschema:"cancel_on_destroy"`
+	Status            string                                `tfschema:"status"`
+	FailureReason     string                                `tfschema:"failure_reason"`
+	StartedAt         string                                `tfschema:"started_at"`
+	StoppedAt         string                                `tfschema:"stopped_at"`
+	Steps             []ExperimentExecutionStepStatusSchema `tfschema:"step"`
+}
+
+type ExperimentExecutionStepStatusSchema struct {
+	Id       string                                  `tfschema:"id"`
+	Name     string                                  `tfschema:"name"`
+	Status   string                                  `tfschema:"status"`
+	Branches []ExperimentExecutionBranchStatusSchema `tfschema:"branch"`
+}
+
+type ExperimentExecutionBranchStatusSchema struct {
+	Id      string                                  `tfschema:"id"`
+	Name    string                                  `tfschema:"name"`
+	Status  string                                  `tfschema:"status"`
+	Actions []ExperimentExecutionActionStatusSchema `tfschema:"action"`
+}
+
+type ExperimentExecutionActionStatusSchema struct {
+	Id        string `tfschema:"id"`
+	Name      string `tfschema:"name"`
+	Status    string `tfschema:"status"`
+	StartTime string `tfschema:"start_time"`
+	EndTime   string `tfschema:"end_time"`
+}
+
+func (r ChaosStudioExperimentExecutionResource) ModelObject() interface{} {
+	return &ChaosStudioExperimentExecutionResourceSchema{}
+}
+
+func (r ChaosStudioExperimentExecutionResource) IDValidationFunc() pluginsdk.SchemaValidateFunc {
+	return experiments.ValidateExecutionID
+}
+
+func (r ChaosStudioExperimentExecutionResource) ResourceType() string {
+	return "azurerm_chaos_studio_experiment_execution"
+}
+
+func (r ChaosStudioExperimentExecutionResource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"experiment_id": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: experiments.ValidateExperimentID,
+		},
+
+		"triggers": {
+			Type:     pluginsdk.TypeMap,
+			Optional: true,
+			ForceNew: true,
+			Elem: &pluginsdk.Schema{
+				Type: pluginsdk.TypeString,
+			},
+		},
+
+		"wait_for_completion": {
+			Type:     pluginsdk.TypeBool,
+			Optional: true,
+			Default:  true,
+		},
+
+		"cancel_on_destroy": {
+			Type:     pluginsdk.TypeBool,
+			Optional: true,
+			Default:  false,
+		},
+	}
+}
+
+func (r ChaosStudioExperimentExecutionResource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"status": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+
+		"failure_reason": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+
+		"started_at": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+
+		"stopped_at": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+
+		"step": {
+			Type:     pluginsdk.TypeList,
+			Computed: true,
+			Elem: &pluginsdk.Resource{
+				Schema: map[string]*pluginsdk.Schema{
+					"id": {
+						Type:     pluginsdk.TypeString,
+						Computed: true,
+					},
+
+					"name": {
+						Type:     pluginsdk.TypeString,
+						Computed: true,
+					},
+
+					"status": {
+						Type:     pluginsdk.TypeString,
+						Computed: true,
+					},
+
+					"branch": {
+						Type:     pluginsdk.TypeList,
+						Computed: true,
+						Elem: &pluginsdk.Resource{
+							Schema: map[string]*pluginsdk.Schema{
+								"id": {
+									Type:     pluginsdk.TypeString,
+									Computed: true,
+								},
+
+								"name": {
+									Type:     pluginsdk.TypeString,
+									Computed: true,
+								},
+
+								"status": {
+									Type:     pluginsdk.TypeString,
+									Computed: true,
+								},
+
+								"action": {
+									Type:     pluginsdk.TypeList,
+									Computed: true,
+									Elem: &pluginsdk.Resource{
+										Schema: map[string]*pluginsdk.Schema{
+											"id": {
+												Type:     pluginsdk.TypeString,
+												Computed: true,
+											},
+
+											"name": {
+												Type:     pluginsdk.TypeString,
+												Computed: true,
+											},
+
+											"status": {
+												Type:     pluginsdk.TypeString,
+												Computed: true,
+											},
+
+											"start_time": {
+												Type:     pluginsdk.TypeString,
+												Computed: true,
+											},
+
+											"end_time": {
+												Type:     pluginsdk.TypeString,
+												Computed: true,
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r ChaosStudioExperimentExecutionResource) Create() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		// an Experiment can run for up to 12 hours
+		Timeout: 12 * time.Hour,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.ChaosStudio.V20231101.Experiments
+
+			var config ChaosStudioExperimentExecutionResourceSchema
+			if err := metadata.Decode(&config); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			experimentId, err := experiments.ParseExperimentID(config.ExperimentId)
+			if err != nil {
+				return err
+			}
+
+			// the Start operation doesn't return the ID of the Execution, so we need to determine which Execution is new
+			previous, err := client.ListAllExecutionsComplete(ctx, *experimentId)
+			if err != nil {
+				return fmt.Errorf("listing the Executions of %s: %+v", *experimentId, err)
+			}
+			previousExecutions := make(map[string]struct{})
+			for _, item := range previous.Items {
+				if item.Name != nil {
+					previousExecutions[strings.ToLower(*item.Name)] = struct{}{}
+				}
+			}
+
+			if _, err := client.Start(ctx, *experimentId); err != nil {
+				return fmt.Errorf("starting %s: %+v", *experimentId, err)
+			}
+
+			deadline, ok := ctx.Deadline()
+			if !ok {
+				return fmt.Errorf("internal-error: context had no deadline")
+			}
+			stateConf := &pluginsdk.StateChangeConf{
+				Pending: []string{"Starting"},
+				Target:  []string{"Started"},
+				Refresh: func() (interface{}, string, error) {
+					resp, err := client.ListAllExecutionsComplete(ctx, *experimentId)
+					if err != nil {
+						return nil, "", fmt.Errorf("listing the Executions of %s: %+v", *experimentId, err)
+					}
+					for _, item := range resp.Items {
+						if item.Name == nil {
+							continue
+						}
+						if _, exists := previousExecutions[strings.ToLower(*item.Name)]; !exists {
+							return *item.Name, "Started", nil
+						}
+					}
+					return nil, "Starting", nil
+				},
+				MinTimeout: 10 * time.Second,
+				Timeout:    time.Until(deadline),
+			}
+			executionName, err := stateConf.WaitForStateContext(ctx)
+			if err != nil {
+				return fmt.Errorf("waiting for %s to start: %+v", *experimentId, err)
+			}
+
+			id := experiments.NewExecutionID(experimentId.SubscriptionId, experimentId.ResourceGroupName, experimentId.ExperimentName, executionName.(string))
+			metadata.SetID(id)
+
+			if config.WaitForCompletion {
+				pollerType := custompollers.NewChaosStudioExperimentExecutionPoller(client, id)
+				poller := pollers.NewPoller(pollerType, 10*time.Second, pollers.DefaultNumberOfDroppedConnectionsToAllow)
+				if err := poller.PollUntilDone(ctx); err != nil {
+					return fmt.Errorf("waiting for %s to finish: %+v", id, err)
+				}
+			}
+
+			return nil
+		},
+	}
+}
+
+func (r ChaosStudioExperimentExecutionResource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.ChaosStudio.V20231101.Experiments
+
+			id, err := experiments.ParseExecutionID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			var state ChaosStudioExperimentExecutionResourceSchema
+			if err := metadata.Decode(&state); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			existing, err := client.GetExecution(ctx, *id)
+			if err != nil {
+				if response.WasNotFound(existing.HttpResponse) {
+					return metadata.MarkAsGone(*id)
+				}
+				return fmt.Errorf("retrieving %s: %+v", *id, err)
+			}
+
+			details, err := client.ExecutionDetails(ctx, *id)
+			if err != nil {
+				return fmt.Errorf("retrieving the details of %s: %+v", *id, err)
+			}
+
+			state.ExperimentId = experiments.NewExperimentID(id.SubscriptionId, id.ResourceGroupName, id.ExperimentName).ID()
+
+			if model := details.Model; model != nil {
+				if props := model.Properties; props != nil {
+					state.Status = pointer.From(props.Status)
+					state.FailureReason = pointer.From(props.FailureReason)
+					state.StartedAt = pointer.From(props.StartedAt)
+					state.StoppedAt = pointer.From(props.StoppedAt)
+
+					state.Steps = make([]ExperimentExecutionStepStatusSchema, 0)
+					if props.RunInformation != nil {
+						state.Steps = flattenExperimentExecutionSteps(props.RunInformation.Steps)
+					}
+				}
+			}
+
+			return metadata.Encode(&state)
+		},
+	}
+}
+
+func (r ChaosStudioExperimentExecutionResource) Update() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			// `wait_for_completion` and `cancel_on_destroy` only affect the behaviour of Terraform, so there's
+			// nothing to update in Azure - the new values are persisted into the state by the subsequent Read
+			return nil
+		},
+	}
+}
+
+func (r ChaosStudioExperimentExecutionResource) Delete() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.ChaosStudio.V20231101.Experiments
+
+			id, err := experiments.ParseExecutionID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			var state ChaosStudioExperimentExecutionResourceSchema
+			if err := metadata.Decode(&state); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			// an Execution can't be deleted, it's retained in the history of the Experiment
+			if !state.CancelOnDestroy {
+				log.Printf("[DEBUG] removing %s from the state without cancelling it", *id)
+				return nil
+			}
+
+			existing, err := client.GetExecution(ctx, *id)
+			if err != nil {
+				if response.WasNotFound(existing.HttpResponse) {
+					return nil
+				}
+				return fmt.Errorf("retrieving %s: %+v", *id, err)
+			}
+			if model := existing.Model; model != nil && model.Properties != nil && custompollers.IsExperimentExecutionTerminalStatus(pointer.From(model.Properties.Status)) {
+				log.Printf("[DEBUG] %s has already finished running - nothing to cancel", *id)
+				return nil
+			}
+
+			// Cancel operates on the Execution which is currently running for this Experiment
+			experimentId := experiments.NewExperimentID(id.SubscriptionId, id.ResourceGroupName, id.ExperimentName)
+			if _, err := client.Cancel(ctx, experimentId); err != nil {
+				return fmt.Errorf("cancelling %s: %+v", *id, err)
+			}
+
+			pollerType := custompollers.NewChaosStudioExperimentExecutionPoller(client, *id)
+			poller := pollers.NewPoller(pollerType, 10*time.Second, pollers.DefaultNumberOfDroppedConnectionsToAllow)
+			if err := poller.PollUntilDone(ctx); err != nil {
+				return fmt.Errorf("waiting for %s to be cancelled: %+v", *id, err)
+			}
+
+			return nil
+		},
+	}
+}
+
+func flattenExperimentExecutionSteps(input *[]experiments.StepStatus) []ExperimentExecutionStepStatusSchema {
+	output := make([]ExperimentExecutionStepStatusSchema, 0)
+	if input == nil {
+		return output
+	}
+
+	for _, step := range *input {
+		branches := make([]ExperimentExecutionBranchStatusSchema, 0)
+		if step.Branches != nil {
+			for _, branch := range *step.Branches {
+				actions := make([]ExperimentExecutionActionStatusSchema, 0)
+				if branch.Actions != nil {
+					for _, action := range *branch.Actions {
+						actions = append(actions, ExperimentExecutionActionStatusSchema{
+							Id:        pointer.From(action.ActionId),
+							Name:      pointer.From(action.ActionName),
+							Status:    pointer.From(action.Status),
+							StartTime: pointer.From(action.StartTime),
+							EndTime:   pointer.From(action.EndTime),
+						})
+					}
+				}
+
+				branches = append(branches, ExperimentExecutionBranchStatusSchema{
+					Id:      pointer.From(branch.BranchId),
+					Name:    pointer.From(branch.BranchName),
+					Status:  pointer.From(branch.Status),
+					Actions: actions,
+				})
+			}
+		}
+
+		output = append(output, ExperimentExecutionStepStatusSchema{
+			Id:       pointer.From(step.StepId),
+			Name:     pointer.From(step.StepName),
+			Status:   pointer.From(step.Status),
+			Branches: branches,
+		})
+	}
+
+	return output
+}
@@ -0,0 +1,149 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package chaosstudio_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/go-azure-sdk/resource-manager/chaosstudio/2023-11-01/experiments"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/check"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+)
+
+type ChaosStudioExperimentExecutionTestResource struct{}
+
+func TestAccChaosStudioExperimentExecution_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_chaos_studio_experiment_execution", "test")
+	r := ChaosStudioExperimentExecutionTestResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("status").IsNotEmpty(),
+				check.That(data.ResourceName).Key("started_at").IsNotEmpty(),
+				check.That(data.ResourceName).Key("step.#").HasValue("1"),
+				check.That(data.ResourceName).Key("step.0.branch.#").HasValue("1"),
+			),
+		},
+		data.ImportStep("triggers", "wait_for_completion", "cancel_on_destroy"),
+	})
+}
+
+func TestAccChaosStudioExperimentExecution_cancelOnDestroy(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_chaos_studio_experiment_execution", "test")
+	r := ChaosStudioExperimentExecutionTestResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.cancelOnDestroy(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("status").IsNotEmpty(),
+			),
+		},
+		data.ImportStep("triggers", "wait_for_completion", "cancel_on_destroy"),
+	})
+}
+
+func (r ChaosStudioExperimentExecutionTestResource) Exists(ctx context.Context, clients *clients.Client, state *pluginsdk.InstanceState) (*bool, error) {
+	id, err := experiments.ParseExecutionID(state.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := clients.ChaosStudio.V20231101.Experiments.GetExecution(ctx, *id)
+	if err != nil {
+		return nil, fmt.Errorf("retrieving %s: %+v", *id, err)
+	}
+
+	return utils.Bool(resp.Model != nil), nil
+}
+
+func (r ChaosStudioExperimentExecutionTestResource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_chaos_studio_experiment_execution" "test" {
+  experiment_id = azurerm_chaos_studio_experiment.test.id
+
+  triggers = {
+    run = "1"
+  }
+
+  depends_on = [
+    azurerm_role_assignment.test,
+  ]
+}
+`, r.template(data))
+}
+
+func (r ChaosStudioExperimentExecutionTestResource) cancelOnDestroy(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_chaos_studio_experiment_execution" "test" {
+  experiment_id       = azurerm_chaos_studio_experiment.test.id
+  wait_for_completion = false
+  cancel_on_destroy   = true
+
+  depends_on = [
+    azurerm_role_assignment.test,
+  ]
+}
+`, r.template(data))
+}
+
+func (r ChaosStudioExperimentExecutionTestResource) template(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_chaos_studio_experiment" "test" {
+  location            = azurerm_resource_group.test.location
+  name                = "acctestcse-${var.random_string}"
+  resource_group_name = azurerm_resource_group.test.name
+
+  identity {
+    type = "SystemAssigned"
+  }
+
+  selectors {
+    name                    = "Selector1"
+    chaos_studio_target_ids = [azurerm_chaos_studio_target.test.id]
+  }
+
+  steps {
+    name = "acctestcse-${var.random_string}"
+    branch {
+      name = "acctestcse-${var.random_string}"
+      actions {
+        urn           = azurerm_chaos_studio_capability.test.urn
+        selector_name = "Selector1"
+        parameters = {
+          abruptShutdown = "false"
+        }
+        action_type = "continuous"
+        duration    = "PT5M"
+      }
+    }
+  }
+}
+
+resource "azurerm_role_assignment" "test" {
+  scope                = azurerm_linux_virtual_machine.test.id
+  role_definition_name = "Virtual Machine Contributor"
+  principal_id         = azurerm_chaos_studio_experiment.test.identity[0].principal_id
+}
+`, ChaosStudioExperimentTestResource{}.templateVM(data))
+}
@@ -0,0 +1,62 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package custompollers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/go-azure-sdk/resource-manager/chaosstudio/2023-11-01/experiments"
+	"github.com/hashicorp/go-azure-sdk/sdk/client/pollers"
+)
+
+var _ pollers.PollerType = &chaosStudioExperimentExecutionPoller{}
+
+// ExperimentExecutionTerminalStatuses are the statuses of an Experiment Execution which has finished running
+var ExperimentExecutionTerminalStatuses = []string{
+	"Cancelled",
+	"Failed",
+	"Success",
+}
+
+type chaosStudioExperimentExecutionPoller struct {
+	client *experiments.ExperimentsClient
+	id     experiments.ExecutionId
+}
+
+// NewChaosStudioExperimentExecutionPoller polls an Experiment Execution until it has finished running - regardless
+// of whether the Experiment was successful, since the outcome is surfaced by the calling resource
+func NewChaosStudioExperimentExecutionPoller(client *experiments.ExperimentsClient, id experiments.ExecutionId) *chaosStudioExperimentExecutionPoller {
+	return &chaosStudioExperimentExecutionPoller{
+		client: client,
+		id:     id,
+	}
+}
+
+func (p chaosStudioExperimentExecutionPoller) Poll(ctx context.Context) (*pollers.PollResult, error) {
+	resp, err := p.client.GetExecution(ctx, p.id)
+	if err != nil {
+		return nil, fmt.Errorf("retrieving %s: %+v", p.id, err)
+	}
+
+	status := ""
+	if model := resp.Model; model != nil && model.Properties != nil && model.Properties.Status != nil {
+		status = *model.Properties.Status
+	}
+
+	if IsExperimentExecutionTerminalStatus(status) {
+		return &pollingSuccess, nil
+	}
+	return &pollingInProgress, nil
+}
+
+func IsExperimentExecutionTerminalStatus(status string) bool {
+	for _, v := range ExperimentExecutionTerminalStatuses {
+		if strings.EqualFold(v, status) {
+			return true
+		}
+	}
+	return false
+}
@@ -11,11 +11,20 @@ type Registration struct {
 	autoRegistration
 }
 
+var _ sdk.ServiceRegistrationWithResourceProviders = Registration{}
+
 // Name is the name of this Service
 func (r Registration) Name() string {
 	return r.autoRegistration.Name()
 }
 
+// ResourceProviders returns the Resource Providers required by the Resources within this Service
+func (r Registration) ResourceProviders() []string {
+	return []string{
+		"Microsoft.Chaos",
+	}
+}
+
 // WebsiteCategories returns a list of categories which can be used for the sidebar
 func (r Registration) WebsiteCategories() []string {
 	return r.autoRegistration.WebsiteCategories()
@@ -33,6 +42,7 @@ func (r Registration) Resources() []sdk.Resource {
 	resources := []sdk.Resource{
 		ChaosStudioCapabilityResource{},
 		ChaosStudioExperimentResource{},
+		ChaosStudioExperimentExecutionResource{},
 	}
 	resources = append(resources, r.autoRegistration.Resources()...)
 	return resources
@@ -0,0 +1,41 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package azuresdkhacks
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/go-azure-sdk/sdk/client"
+	"github.com/hashicorp/go-azure-sdk/sdk/client/dataplane"
+)
+
+// NOTE: this workaround is needed since go-azure-sdk doesn't include a client for the Grafana HTTP API, the
+// Dashboard operations used by `azurerm_dashboard_grafana_dashboard` are implemented here atop the `dataplane` base layer
+
+// the Grafana HTTP API isn't versioned, however the base client requires an API Version, which is never sent
+const defaultApiVersion = "unversioned"
+
+type DashboardsClient struct {
+	Client *dataplane.Client
+}
+
+func NewDashboardsClientWithBaseURI(endpoint string) *DashboardsClient {
+	return &DashboardsClient{
+		Client: dataplane.NewDataPlaneClient(endpoint, "grafana", defaultApiVersion),
+	}
+}
+
+func (c DashboardsClient) newRequest(ctx context.Context, input client.RequestOptions) (*client.Request, error) {
+	if err := input.Validate(); err != nil {
+		return nil, fmt.Errorf("pre-validating request payload: %+v", err)
+	}
+
+	req, err := c.Client.NewRequest(ctx, input)
+	if err != nil {
+		return nil, fmt.Errorf("building %s request: %+v", input.HttpMethod, err)
+	}
+
+	return req, nil
+}
@@ -0,0 +1,48 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package azuresdkhacks
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/hashicorp/go-azure-sdk/sdk/client"
+	"github.com/hashicorp/go-azure-sdk/sdk/odata"
+)
+
+type DeleteDashboardOperationResponse struct {
+	HttpResponse *http.Response
+	OData        *odata.OData
+}
+
+// DeleteDashboard deletes a Dashboard by its UID
+func (c DashboardsClient) DeleteDashboard(ctx context.Context, uid string) (result DeleteDashboardOperationResponse, err error) {
+	opts := client.RequestOptions{
+		ContentType: "application/json; charset=utf-8",
+		ExpectedStatusCodes: []int{
+			http.StatusOK,
+		},
+		HttpMethod: http.MethodDelete,
+		Path:       fmt.Sprintf("/api/dashboards/uid/%s", url.PathEscape(uid)),
+	}
+
+	req, err := c.newRequest(ctx, opts)
+	if err != nil {
+		return
+	}
+
+	var resp *client.Response
+	resp, err = req.Execute(ctx)
+	if resp != nil {
+		result.OData = resp.OData
+		result.HttpResponse = resp.Response
+	}
+	if err != nil {
+		return
+	}
+
+	return
+}
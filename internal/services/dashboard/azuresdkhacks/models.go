@@ -0,0 +1,35 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package azuresdkhacks
+
+import "encoding/json"
+
+type Dashboard struct {
+	Dashboard json.RawMessage `json:"dashboard"`
+	Meta      *DashboardMeta  `json:"meta,omitempty"`
+}
+
+type DashboardMeta struct {
+	FolderTitle *string `json:"folderTitle,omitempty"`
+	FolderUid   *string `json:"folderUid,omitempty"`
+	Slug        *string `json:"slug,omitempty"`
+	Url         *string `json:"url,omitempty"`
+	Version     *int64  `json:"version,omitempty"`
+}
+
+type ImportDashboardRequest struct {
+	Dashboard json.RawMessage `json:"dashboard"`
+	FolderUid *string         `json:"folderUid,omitempty"`
+	Message   *string         `json:"message,omitempty"`
+	Overwrite bool            `json:"overwrite"`
+}
+
+type ImportDashboardResult struct {
+	Id      *int64  `json:"id,omitempty"`
+	Slug    *string `json:"slug,omitempty"`
+	Status  *string `json:"status,omitempty"`
+	Uid     *string `json:"uid,omitempty"`
+	Url     *string `json:"url,omitempty"`
+	Version *int64  `json:"version,omitempty"`
+}
@@ -7,11 +7,15 @@ import (
 	"fmt"
 
 	"github.com/hashicorp/go-azure-sdk/resource-manager/dashboard/2023-09-01/grafanaresource"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/dashboard/2023-09-01/managedprivateendpoints"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/common"
 )
 
 type Client struct {
-	GrafanaResourceClient *grafanaresource.GrafanaResourceClient
+	GrafanaResourceClient         *grafanaresource.GrafanaResourceClient
+	ManagedPrivateEndpointsClient *managedprivateendpoints.ManagedPrivateEndpointsClient
+
+	o *common.ClientOptions
 }
 
 func NewClient(o *common.ClientOptions) (*Client, error) {
@@ -21,7 +25,15 @@ func NewClient(o *common.ClientOptions) (*Client, error) {
 	}
 	o.Configure(grafanaResourceClient.Client, o.Authorizers.ResourceManager)
 
+	managedPrivateEndpointsClient, err := managedprivateendpoints.NewManagedPrivateEndpointsClientWithBaseURI(o.Environment.ResourceManager)
+	if err != nil {
+		return nil, fmt.Errorf("building ManagedPrivateEndpoints client: %+v", err)
+	}
+	o.Configure(managedPrivateEndpointsClient.Client, o.Authorizers.ResourceManager)
+
 	return &Client{
-		GrafanaResourceClient: grafanaResourceClient,
+		GrafanaResourceClient:         grafanaResourceClient,
+		ManagedPrivateEndpointsClient: managedPrivateEndpointsClient,
+		o:                             o,
 	}, nil
 }
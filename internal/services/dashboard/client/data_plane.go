@@ -0,0 +1,44 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/go-azure-sdk/resource-manager/dashboard/2023-09-01/grafanaresource"
+	"github.com/hashicorp/go-azure-sdk/sdk/environments"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/dashboard/azuresdkhacks"
+)
+
+// grafanaResourceIdentifier is the Application ID of Azure Managed Grafana, which is used as the audience for tokens
+// issued for the Grafana HTTP API - this is the same across all Azure environments
+const grafanaResourceIdentifier = "ce34e7e5-485f-4d76-964f-b3d2b16d1e4f"
+
+// DashboardsClientForGrafana returns a client for the Grafana HTTP API of the specified Grafana instance
+func (c *Client) DashboardsClientForGrafana(ctx context.Context, id grafanaresource.GrafanaId) (*azuresdkhacks.DashboardsClient, error) {
+	existing, err := c.GrafanaResourceClient.GrafanaGet(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("retrieving %s: %+v", id, err)
+	}
+	endpoint := ""
+	if model := existing.Model; model != nil && model.Properties != nil && model.Properties.Endpoint != nil {
+		endpoint = strings.TrimSuffix(*model.Properties.Endpoint, "/")
+	}
+	if endpoint == "" {
+		return nil, fmt.Errorf("retrieving %s: unable to determine the Grafana endpoint since `model.Properties.Endpoint` was nil", id)
+	}
+
+	api := environments.NewApiEndpoint("Grafana", endpoint, nil).WithResourceIdentifier(grafanaResourceIdentifier)
+	authorizer, err := c.o.Authorizers.AuthorizerFunc(api)
+	if err != nil {
+		return nil, fmt.Errorf("obtaining auth token for %q: %+v", endpoint, err)
+	}
+
+	client := azuresdkhacks.NewDashboardsClientWithBaseURI(endpoint)
+	c.o.Configure(client.Client, authorizer)
+
+	return client, nil
+}
@@ -0,0 +1,390 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package dashboard
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/dashboard/2023-09-01/grafanaresource"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/dashboard/azuresdkhacks"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/dashboard/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/dashboard/validate"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+)
+
+type DashboardGrafanaDashboardModel struct {
+	GrafanaId  string `tfschema:"grafana_id"`
+	ConfigJson string `tfschema:"config_json"`
+	FolderUid  string `tfschema:"folder_uid"`
+	Message    string `tfschema:"message"`
+	Uid        string `tfschema:"uid"`
+	Version    int64  `tfschema:"version"`
+	Url        string `tfschema:"url"`
+}
+
+type DashboardGrafanaDashboardResource struct{}
+
+var (
+	_ sdk.ResourceWithUpdate        = DashboardGrafanaDashboardResource{}
+	_ sdk.ResourceWithCustomizeDiff = DashboardGrafanaDashboardResource{}
+)
+
+func (r DashboardGrafanaDashboardResource) ResourceType() string {
+	return "azurerm_dashboard_grafana_dashboard"
+}
+
+func (r DashboardGrafanaDashboardResource) ModelObject() interface{} {
+	return &DashboardGrafanaDashboardModel{}
+}
+
+func (r DashboardGrafanaDashboardResource) IDValidationFunc() pluginsdk.SchemaValidateFunc {
+	return validate.GrafanaDashboardID
+}
+
+func (r DashboardGrafanaDashboardResource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"grafana_id": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: grafanaresource.ValidateGrafanaID,
+		},
+
+		"config_json": {
+			Type:             pluginsdk.TypeString,
+			Required:         true,
+			ValidateFunc:     validation.StringIsJSON,
+			DiffSuppressFunc: suppressGrafanaDashboardConfigJsonDiff,
+		},
+
+		"folder_uid": {
+			Type:         pluginsdk.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+		},
+
+		"message": {
+			Type:         pluginsdk.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+		},
+	}
+}
+
+func (r DashboardGrafanaDashboardResource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"uid": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+
+		"version": {
+			Type:     pluginsdk.TypeInt,
+			Computed: true,
+		},
+
+		"url": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+	}
+}
+
+func (r DashboardGrafanaDashboardResource) CustomizeDiff() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			diff := metadata.ResourceDiff
+
+			// the UID identifies the Dashboard, so changing it within `config_json` requires a new Dashboard
+			existingUid := diff.Get("uid").(string)
+			if existingUid == "" || !diff.HasChange("config_json") {
+				return nil
+			}
+
+			config, err := expandGrafanaDashboardConfigJson(diff.Get("config_json").(string))
+			if err != nil {
+				// the value isn't known yet
+				return nil
+			}
+
+			if uid, ok := config["uid"].(string); ok && uid != "" && uid != existingUid {
+				return diff.ForceNew("config_json")
+			}
+
+			return nil
+		},
+	}
+}
+
+func (r DashboardGrafanaDashboardResource) Create() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			var model DashboardGrafanaDashboardModel
+			if err := metadata.Decode(&model); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			grafanaId, err := grafanaresource.ParseGrafanaID(model.GrafanaId)
+			if err != nil {
+				return err
+			}
+
+			client, err := metadata.Client.Dashboard.DashboardsClientForGrafana(ctx, *grafanaId)
+			if err != nil {
+				return fmt.Errorf("building Grafana client for %s: %+v", *grafanaId, err)
+			}
+
+			config, err := expandGrafanaDashboardConfigJson(model.ConfigJson)
+			if err != nil {
+				return fmt.Errorf("expanding `config_json`: %+v", err)
+			}
+
+			// a new Dashboard mustn't specify the numeric ID, which is specific to the Grafana instance it was exported from
+			delete(config, "id")
+
+			if uid, ok := config["uid"].(string); ok && uid != "" {
+				id := parse.NewGrafanaDashboardID(*grafanaId, uid)
+				existing, err := client.GetDashboard(ctx, uid)
+				if err != nil && !response.WasNotFound(existing.HttpResponse) {
+					return fmt.Errorf("checking for existing %s: %+v", id, err)
+				}
+
+				if !response.WasNotFound(existing.HttpResponse) {
+					return metadata.ResourceRequiresImport(r.ResourceType(), id)
+				}
+			}
+
+			payload, err := expandGrafanaDashboardImportRequest(config, model, false)
+			if err != nil {
+				return err
+			}
+
+			resp, err := client.ImportDashboard(ctx, *payload)
+			if err != nil {
+				return fmt.Errorf("creating Dashboard within %s: %+v", *grafanaId, err)
+			}
+
+			if resp.Model == nil || resp.Model.Uid == nil {
+				return fmt.Errorf("creating Dashboard within %s: the UID of the Dashboard was not returned", *grafanaId)
+			}
+
+			id := parse.NewGrafanaDashboardID(*grafanaId, *resp.Model.Uid)
+			metadata.SetID(id)
+			return nil
+		},
+	}
+}
+
+func (r DashboardGrafanaDashboardResource) Update() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			id, err := parse.GrafanaDashboardID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			var model DashboardGrafanaDashboardModel
+			if err := metadata.Decode(&model); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			client, err := metadata.Client.Dashboard.DashboardsClientForGrafana(ctx, id.GrafanaId)
+			if err != nil {
+				return fmt.Errorf("building Grafana client for %s: %+v", id.GrafanaId, err)
+			}
+
+			config, err := expandGrafanaDashboardConfigJson(model.ConfigJson)
+			if err != nil {
+				return fmt.Errorf("expanding `config_json`: %+v", err)
+			}
+
+			// the existing Dashboard is replaced, which is matched on the UID rather than the numeric ID
+			delete(config, "id")
+			config["uid"] = id.DashboardUid
+
+			payload, err := expandGrafanaDashboardImportRequest(config, model, true)
+			if err != nil {
+				return err
+			}
+
+			if _, err := client.ImportDashboard(ctx, *payload); err != nil {
+				return fmt.Errorf("updating %s: %+v", *id, err)
+			}
+
+			return nil
+		},
+	}
+}
+
+func (r DashboardGrafanaDashboardResource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			id, err := parse.GrafanaDashboardID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			client, err := metadata.Client.Dashboard.DashboardsClientForGrafana(ctx, id.GrafanaId)
+			if err != nil {
+				return fmt.Errorf("building Grafana client for %s: %+v", id.GrafanaId, err)
+			}
+
+			resp, err := client.GetDashboard(ctx, id.DashboardUid)
+			if err != nil {
+				if response.WasNotFound(resp.HttpResponse) {
+					return metadata.MarkAsGone(id)
+				}
+
+				return fmt.Errorf("retrieving %s: %+v", *id, err)
+			}
+
+			state := DashboardGrafanaDashboardModel{
+				GrafanaId: id.GrafanaId.ID(),
+				Uid:       id.DashboardUid,
+				Message:   metadata.ResourceData.Get("message").(string),
+			}
+
+			if model := resp.Model; model != nil {
+				config, err := expandGrafanaDashboardConfigJson(string(model.Dashboard))
+				if err != nil {
+					return fmt.Errorf("parsing the Dashboard JSON for %s: %+v", *id, err)
+				}
+
+				// the UID is generated by Grafana when it's omitted from `config_json`, in which case it's exposed
+				// only through the `uid` attribute to avoid a diff
+				if existing := metadata.ResourceData.Get("config_json").(string); existing != "" {
+					if existingConfig, err := expandGrafanaDashboardConfigJson(existing); err == nil {
+						if _, ok := existingConfig["uid"]; !ok {
+							delete(config, "uid")
+						}
+					}
+				}
+
+				configJson, err := flattenGrafanaDashboardConfigJson(config)
+				if err != nil {
+					return fmt.Errorf("flattening the Dashboard JSON for %s: %+v", *id, err)
+				}
+				state.ConfigJson = configJson
+
+				if meta := model.Meta; meta != nil {
+					state.FolderUid = pointer.From(meta.FolderUid)
+					state.Version = pointer.From(meta.Version)
+					if meta.Url != nil {
+						state.Url = fmt.Sprintf("%s%s", client.Client.BaseUri, *meta.Url)
+					}
+				}
+			}
+
+			return metadata.Encode(&state)
+		},
+	}
+}
+
+func (r DashboardGrafanaDashboardResource) Delete() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			id, err := parse.GrafanaDashboardID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			client, err := metadata.Client.Dashboard.DashboardsClientForGrafana(ctx, id.GrafanaId)
+			if err != nil {
+				return fmt.Errorf("building Grafana client for %s: %+v", id.GrafanaId, err)
+			}
+
+			if resp, err := client.DeleteDashboard(ctx, id.DashboardUid); err != nil {
+				if response.WasNotFound(resp.HttpResponse) {
+					return nil
+				}
+				return fmt.Errorf("deleting %s: %+v", *id, err)
+			}
+
+			return nil
+		},
+	}
+}
+
+func expandGrafanaDashboardImportRequest(config map[string]interface{}, model DashboardGrafanaDashboardModel, overwrite bool) (*azuresdkhacks.ImportDashboardRequest, error) {
+	dashboard, err := json.Marshal(config)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling `config_json`: %+v", err)
+	}
+
+	payload := azuresdkhacks.ImportDashboardRequest{
+		Dashboard: dashboard,
+		Overwrite: overwrite,
+	}
+
+	if model.FolderUid != "" {
+		payload.FolderUid = pointer.To(model.FolderUid)
+	}
+
+	if model.Message != "" {
+		payload.Message = pointer.To(model.Message)
+	}
+
+	return &payload, nil
+}
+
+func expandGrafanaDashboardConfigJson(input string) (map[string]interface{}, error) {
+	config := make(map[string]interface{})
+	if err := json.Unmarshal([]byte(input), &config); err != nil {
+		return nil, err
+	}
+
+	return config, nil
+}
+
+// flattenGrafanaDashboardConfigJson removes the fields which are managed by Grafana (the numeric ID and version)
+// and returns the normalized JSON
+func flattenGrafanaDashboardConfigJson(config map[string]interface{}) (string, error) {
+	delete(config, "id")
+	delete(config, "version")
+
+	output, err := json.Marshal(config)
+	if err != nil {
+		return "", err
+	}
+
+	return string(output), nil
+}
+
+func suppressGrafanaDashboardConfigJsonDiff(_, old, new string, _ *pluginsdk.ResourceData) bool {
+	oldConfig, err := expandGrafanaDashboardConfigJson(old)
+	if err != nil {
+		return false
+	}
+	newConfig, err := expandGrafanaDashboardConfigJson(new)
+	if err != nil {
+		return false
+	}
+
+	if _, ok := newConfig["uid"]; !ok {
+		delete(oldConfig, "uid")
+	}
+
+	oldJson, err := flattenGrafanaDashboardConfigJson(oldConfig)
+	if err != nil {
+		return false
+	}
+	newJson, err := flattenGrafanaDashboardConfigJson(newConfig)
+	if err != nil {
+		return false
+	}
+
+	return oldJson == newJson
+}
@@ -0,0 +1,217 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package dashboard_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/check"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/dashboard/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+type DashboardGrafanaDashboardResource struct{}
+
+func TestAccDashboardGrafanaDashboard_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_dashboard_grafana_dashboard", "test")
+	r := DashboardGrafanaDashboardResource{}
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("uid").Exists(),
+				check.That(data.ResourceName).Key("url").Exists(),
+			),
+		},
+		data.ImportStep("message"),
+	})
+}
+
+func TestAccDashboardGrafanaDashboard_requiresImport(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_dashboard_grafana_dashboard", "test")
+	r := DashboardGrafanaDashboardResource{}
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.RequiresImportErrorStep(r.requiresImport),
+	})
+}
+
+func TestAccDashboardGrafanaDashboard_update(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_dashboard_grafana_dashboard", "test")
+	r := DashboardGrafanaDashboardResource{}
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep("message"),
+		{
+			Config: r.updated(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("version").HasValue("2"),
+			),
+		},
+		data.ImportStep("message"),
+	})
+}
+
+func TestAccDashboardGrafanaDashboard_generatedUid(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_dashboard_grafana_dashboard", "test")
+	r := DashboardGrafanaDashboardResource{}
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.generatedUid(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("uid").Exists(),
+			),
+		},
+		data.ImportStep("config_json"),
+	})
+}
+
+func (r DashboardGrafanaDashboardResource) Exists(ctx context.Context, clients *clients.Client, state *pluginsdk.InstanceState) (*bool, error) {
+	id, err := parse.GrafanaDashboardID(state.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := clients.Dashboard.DashboardsClientForGrafana(ctx, id.GrafanaId)
+	if err != nil {
+		return nil, fmt.Errorf("building Grafana client for %s: %+v", id.GrafanaId, err)
+	}
+
+	resp, err := client.GetDashboard(ctx, id.DashboardUid)
+	if err != nil {
+		if response.WasNotFound(resp.HttpResponse) {
+			return pointer.To(false), nil
+		}
+		return nil, fmt.Errorf("retrieving %s: %+v", id, err)
+	}
+	return pointer.To(resp.Model != nil), nil
+}
+
+func (r DashboardGrafanaDashboardResource) template(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+data "azurerm_client_config" "current" {}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctest-rg-%[1]d"
+  location = "%[2]s"
+}
+
+resource "azurerm_dashboard_grafana" "test" {
+  name                  = "a-dg-%[1]d"
+  resource_group_name   = azurerm_resource_group.test.name
+  location              = azurerm_resource_group.test.location
+  grafana_major_version = "10"
+}
+
+resource "azurerm_role_assignment" "test" {
+  scope                = azurerm_dashboard_grafana.test.id
+  role_definition_name = "Grafana Admin"
+  principal_id         = data.azurerm_client_config.current.object_id
+}
+`, data.RandomInteger, data.Locations.Primary)
+}
+
+func (r DashboardGrafanaDashboardResource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_dashboard_grafana_dashboard" "test" {
+  grafana_id = azurerm_dashboard_grafana.test.id
+  config_json = jsonencode({
+    uid           = "acctest-%d"
+    title         = "acctest-dashboard"
+    schemaVersion = 39
+    panels        = []
+  })
+
+  depends_on = [azurerm_role_assignment.test]
+}
+`, r.template(data), data.RandomInteger)
+}
+
+func (r DashboardGrafanaDashboardResource) requiresImport(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_dashboard_grafana_dashboard" "import" {
+  grafana_id  = azurerm_dashboard_grafana_dashboard.test.grafana_id
+  config_json = azurerm_dashboard_grafana_dashboard.test.config_json
+}
+`, r.basic(data))
+}
+
+func (r DashboardGrafanaDashboardResource) updated(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_dashboard_grafana_dashboard" "test" {
+  grafana_id = azurerm_dashboard_grafana.test.id
+  message    = "adding a panel"
+  config_json = jsonencode({
+    uid           = "acctest-%d"
+    title         = "acctest-dashboard-updated"
+    schemaVersion = 39
+    panels = [
+      {
+        id    = 1
+        type  = "text"
+        title = "Notes"
+        gridPos = {
+          h = 8
+          w = 12
+          x = 0
+          y = 0
+        }
+        options = {
+          mode    = "markdown"
+          content = "Managed by Terraform"
+        }
+      }
+    ]
+  })
+
+  depends_on = [azurerm_role_assignment.test]
+}
+`, r.template(data), data.RandomInteger)
+}
+
+func (r DashboardGrafanaDashboardResource) generatedUid(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_dashboard_grafana_dashboard" "test" {
+  grafana_id = azurerm_dashboard_grafana.test.id
+  config_json = jsonencode({
+    title         = "acctest-dashboard-%d"
+    schemaVersion = 39
+    panels        = []
+  })
+
+  depends_on = [azurerm_role_assignment.test]
+}
+`, r.template(data), data.RandomInteger)
+}
@@ -0,0 +1,288 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package dashboard
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonschema"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/location"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/dashboard/2023-09-01/managedprivateendpoints"
+	"github.com/hashicorp/terraform-provider-azurerm/helpers/azure"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+)
+
+type DashboardGrafanaManagedPrivateEndpointModel struct {
+	Name                        string            `tfschema:"name"`
+	GrafanaId                   string            `tfschema:"grafana_id"`
+	Location                    string            `tfschema:"location"`
+	PrivateLinkResourceId       string            `tfschema:"private_link_resource_id"`
+	GroupIds                    []string          `tfschema:"group_ids"`
+	PrivateLinkResourceRegion   string            `tfschema:"private_link_resource_region"`
+	PrivateLinkServiceUrl       string            `tfschema:"private_link_service_url"`
+	RequestMessage              string            `tfschema:"request_message"`
+	Tags                        map[string]string `tfschema:"tags"`
+	ConnectionStatus            string            `tfschema:"connection_status"`
+	PrivateLinkServicePrivateIP string            `tfschema:"private_link_service_private_ip"`
+}
+
+type DashboardGrafanaManagedPrivateEndpointResource struct{}
+
+var _ sdk.ResourceWithUpdate = DashboardGrafanaManagedPrivateEndpointResource{}
+
+func (r DashboardGrafanaManagedPrivateEndpointResource) ResourceType() string {
+	return "azurerm_dashboard_grafana_managed_private_endpoint"
+}
+
+func (r DashboardGrafanaManagedPrivateEndpointResource) ModelObject() interface{} {
+	return &DashboardGrafanaManagedPrivateEndpointModel{}
+}
+
+func (r DashboardGrafanaManagedPrivateEndpointResource) IDValidationFunc() pluginsdk.SchemaValidateFunc {
+	return managedprivateendpoints.ValidateManagedPrivateEndpointID
+}
+
+func (r DashboardGrafanaManagedPrivateEndpointResource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"name": {
+			Type:     pluginsdk.TypeString,
+			Required: true,
+			ForceNew: true,
+			ValidateFunc: validation.StringMatch(
+				regexp.MustCompile(`^[a-zA-Z][-a-zA-Z\d]{0,18}[a-zA-Z\d]$`),
+				`The name length must be from 2 to 20 characters. The name can only contain letters, numbers and dashes, and it must begin with a letter and end with a letter or digit.`,
+			),
+		},
+
+		"grafana_id": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: managedprivateendpoints.ValidateGrafanaID,
+		},
+
+		"location": commonschema.Location(),
+
+		"private_link_resource_id": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: azure.ValidateResourceID,
+		},
+
+		"group_ids": {
+			Type:     pluginsdk.TypeList,
+			Optional: true,
+			ForceNew: true,
+			Elem: &pluginsdk.Schema{
+				Type:         pluginsdk.TypeString,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+		},
+
+		// when omitted the region of the Private Link Resource is used
+		"private_link_resource_region": {
+			Type:             pluginsdk.TypeString,
+			Optional:         true,
+			Computed:         true,
+			ForceNew:         true,
+			ValidateFunc:     location.EnhancedValidate,
+			StateFunc:        location.StateFunc,
+			DiffSuppressFunc: location.DiffSuppressFunc,
+		},
+
+		"private_link_service_url": {
+			Type:         pluginsdk.TypeString,
+			Optional:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+		},
+
+		"request_message": {
+			Type:         pluginsdk.TypeString,
+			Optional:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+		},
+
+		"tags": commonschema.Tags(),
+	}
+}
+
+func (r DashboardGrafanaManagedPrivateEndpointResource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"connection_status": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+
+		"private_link_service_private_ip": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+	}
+}
+
+func (r DashboardGrafanaManagedPrivateEndpointResource) Create() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			var model DashboardGrafanaManagedPrivateEndpointModel
+			if err := metadata.Decode(&model); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			client := metadata.Client.Dashboard.ManagedPrivateEndpointsClient
+
+			grafanaId, err := managedprivateendpoints.ParseGrafanaID(model.GrafanaId)
+			if err != nil {
+				return err
+			}
+
+			id := managedprivateendpoints.NewManagedPrivateEndpointID(grafanaId.SubscriptionId, grafanaId.ResourceGroupName, grafanaId.GrafanaName, model.Name)
+			existing, err := client.Get(ctx, id)
+			if err != nil && !response.WasNotFound(existing.HttpResponse) {
+				return fmt.Errorf("checking for existing %s: %+v", id, err)
+			}
+
+			if !response.WasNotFound(existing.HttpResponse) {
+				return metadata.ResourceRequiresImport(r.ResourceType(), id)
+			}
+
+			properties := managedprivateendpoints.ManagedPrivateEndpointModel{
+				Location: location.Normalize(model.Location),
+				Properties: &managedprivateendpoints.ManagedPrivateEndpointModelProperties{
+					GroupIds:              pointer.To(model.GroupIds),
+					PrivateLinkResourceId: pointer.To(model.PrivateLinkResourceId),
+				},
+				Tags: pointer.To(model.Tags),
+			}
+
+			if model.PrivateLinkResourceRegion != "" {
+				properties.Properties.PrivateLinkResourceRegion = pointer.To(location.Normalize(model.PrivateLinkResourceRegion))
+			}
+
+			if model.PrivateLinkServiceUrl != "" {
+				properties.Properties.PrivateLinkServiceUrl = pointer.To(model.PrivateLinkServiceUrl)
+			}
+
+			if model.RequestMessage != "" {
+				properties.Properties.RequestMessage = pointer.To(model.RequestMessage)
+			}
+
+			if err := client.CreateThenPoll(ctx, id, properties); err != nil {
+				return fmt.Errorf("creating %s: %+v", id, err)
+			}
+
+			metadata.SetID(id)
+			return nil
+		},
+	}
+}
+
+func (r DashboardGrafanaManagedPrivateEndpointResource) Update() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Dashboard.ManagedPrivateEndpointsClient
+
+			id, err := managedprivateendpoints.ParseManagedPrivateEndpointID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			var model DashboardGrafanaManagedPrivateEndpointModel
+			if err := metadata.Decode(&model); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			if metadata.ResourceData.HasChange("tags") {
+				payload := managedprivateendpoints.ManagedPrivateEndpointUpdateParameters{
+					Tags: pointer.To(model.Tags),
+				}
+
+				if err := client.UpdateThenPoll(ctx, *id, payload); err != nil {
+					return fmt.Errorf("updating %s: %+v", id, err)
+				}
+			}
+
+			return nil
+		},
+	}
+}
+
+func (r DashboardGrafanaManagedPrivateEndpointResource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Dashboard.ManagedPrivateEndpointsClient
+
+			id, err := managedprivateendpoints.ParseManagedPrivateEndpointID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			resp, err := client.Get(ctx, *id)
+			if err != nil {
+				if response.WasNotFound(resp.HttpResponse) {
+					return metadata.MarkAsGone(id)
+				}
+
+				return fmt.Errorf("retrieving %s: %+v", *id, err)
+			}
+
+			state := DashboardGrafanaManagedPrivateEndpointModel{
+				Name:      id.ManagedPrivateEndpointName,
+				GrafanaId: managedprivateendpoints.NewGrafanaID(id.SubscriptionId, id.ResourceGroupName, id.GrafanaName).ID(),
+			}
+
+			if model := resp.Model; model != nil {
+				state.Location = location.Normalize(model.Location)
+				state.Tags = pointer.From(model.Tags)
+
+				if props := model.Properties; props != nil {
+					state.GroupIds = pointer.From(props.GroupIds)
+					state.PrivateLinkResourceId = pointer.From(props.PrivateLinkResourceId)
+					state.PrivateLinkResourceRegion = location.NormalizeNilable(props.PrivateLinkResourceRegion)
+					state.PrivateLinkServiceUrl = pointer.From(props.PrivateLinkServiceUrl)
+					state.PrivateLinkServicePrivateIP = pointer.From(props.PrivateLinkServicePrivateIP)
+					state.RequestMessage = pointer.From(props.RequestMessage)
+
+					if connectionState := props.ConnectionState; connectionState != nil && connectionState.Status != nil {
+						state.ConnectionStatus = string(*connectionState.Status)
+					}
+				}
+			}
+
+			return metadata.Encode(&state)
+		},
+	}
+}
+
+func (r DashboardGrafanaManagedPrivateEndpointResource) Delete() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Dashboard.ManagedPrivateEndpointsClient
+
+			id, err := managedprivateendpoints.ParseManagedPrivateEndpointID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			if err := client.DeleteThenPoll(ctx, *id); err != nil {
+				return fmt.Errorf("deleting %s: %+v", id, err)
+			}
+
+			return nil
+		},
+	}
+}
@@ -0,0 +1,181 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package dashboard_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/dashboard/2023-09-01/managedprivateendpoints"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/check"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+type DashboardGrafanaManagedPrivateEndpointResource struct{}
+
+func TestAccDashboardGrafanaManagedPrivateEndpoint_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_dashboard_grafana_managed_private_endpoint", "test")
+	r := DashboardGrafanaManagedPrivateEndpointResource{}
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccDashboardGrafanaManagedPrivateEndpoint_requiresImport(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_dashboard_grafana_managed_private_endpoint", "test")
+	r := DashboardGrafanaManagedPrivateEndpointResource{}
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.RequiresImportErrorStep(r.requiresImport),
+	})
+}
+
+func TestAccDashboardGrafanaManagedPrivateEndpoint_complete(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_dashboard_grafana_managed_private_endpoint", "test")
+	r := DashboardGrafanaManagedPrivateEndpointResource{}
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.complete(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccDashboardGrafanaManagedPrivateEndpoint_update(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_dashboard_grafana_managed_private_endpoint", "test")
+	r := DashboardGrafanaManagedPrivateEndpointResource{}
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.complete(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func (r DashboardGrafanaManagedPrivateEndpointResource) Exists(ctx context.Context, clients *clients.Client, state *pluginsdk.InstanceState) (*bool, error) {
+	id, err := managedprivateendpoints.ParseManagedPrivateEndpointID(state.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := clients.Dashboard.ManagedPrivateEndpointsClient.Get(ctx, *id)
+	if err != nil {
+		if response.WasNotFound(resp.HttpResponse) {
+			return pointer.To(false), nil
+		}
+		return nil, fmt.Errorf("retrieving %s: %+v", id, err)
+	}
+	return pointer.To(resp.Model != nil), nil
+}
+
+func (r DashboardGrafanaManagedPrivateEndpointResource) template(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctest-rg-%[1]d"
+  location = "%[2]s"
+}
+
+resource "azurerm_dashboard_grafana" "test" {
+  name                  = "a-dg-%[1]d"
+  resource_group_name   = azurerm_resource_group.test.name
+  location              = azurerm_resource_group.test.location
+  grafana_major_version = "10"
+}
+
+resource "azurerm_monitor_workspace" "test" {
+  name                          = "acctest-mw-%[1]d"
+  resource_group_name           = azurerm_resource_group.test.name
+  location                      = azurerm_resource_group.test.location
+  public_network_access_enabled = false
+}
+`, data.RandomInteger, data.Locations.Primary)
+}
+
+func (r DashboardGrafanaManagedPrivateEndpointResource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_dashboard_grafana_managed_private_endpoint" "test" {
+  name                     = "a-mpe-%d"
+  grafana_id               = azurerm_dashboard_grafana.test.id
+  location                 = azurerm_dashboard_grafana.test.location
+  private_link_resource_id = azurerm_monitor_workspace.test.id
+  group_ids                = ["prometheusMetrics"]
+}
+`, r.template(data), data.RandomIntOfLength(8))
+}
+
+func (r DashboardGrafanaManagedPrivateEndpointResource) requiresImport(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_dashboard_grafana_managed_private_endpoint" "import" {
+  name                     = azurerm_dashboard_grafana_managed_private_endpoint.test.name
+  grafana_id               = azurerm_dashboard_grafana_managed_private_endpoint.test.grafana_id
+  location                 = azurerm_dashboard_grafana_managed_private_endpoint.test.location
+  private_link_resource_id = azurerm_dashboard_grafana_managed_private_endpoint.test.private_link_resource_id
+  group_ids                = azurerm_dashboard_grafana_managed_private_endpoint.test.group_ids
+}
+`, r.basic(data))
+}
+
+func (r DashboardGrafanaManagedPrivateEndpointResource) complete(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_dashboard_grafana_managed_private_endpoint" "test" {
+  name                         = "a-mpe-%d"
+  grafana_id                   = azurerm_dashboard_grafana.test.id
+  location                     = azurerm_dashboard_grafana.test.location
+  private_link_resource_id     = azurerm_monitor_workspace.test.id
+  private_link_resource_region = azurerm_monitor_workspace.test.location
+  group_ids                    = ["prometheusMetrics"]
+  request_message              = "please approve"
+
+  tags = {
+    environment = "test"
+  }
+}
+`, r.template(data), data.RandomIntOfLength(8))
+}
@@ -0,0 +1,59 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package parse
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/resourceids"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/dashboard/2023-09-01/grafanaresource"
+)
+
+var _ resourceids.Id = GrafanaDashboardId{}
+
+type GrafanaDashboardId struct {
+	GrafanaId    grafanaresource.GrafanaId
+	DashboardUid string
+}
+
+func (id GrafanaDashboardId) ID() string {
+	return fmt.Sprintf("%s|%s", id.GrafanaId.ID(), id.DashboardUid)
+}
+
+func (id GrafanaDashboardId) String() string {
+	components := []string{
+		fmt.Sprintf("GrafanaId %s", id.GrafanaId.ID()),
+		fmt.Sprintf("DashboardUid %s", id.DashboardUid),
+	}
+	return fmt.Sprintf("Grafana Dashboard: %s", strings.Join(components, " / "))
+}
+
+func NewGrafanaDashboardID(grafanaId grafanaresource.GrafanaId, dashboardUid string) GrafanaDashboardId {
+	return GrafanaDashboardId{
+		GrafanaId:    grafanaId,
+		DashboardUid: dashboardUid,
+	}
+}
+
+func GrafanaDashboardID(input string) (*GrafanaDashboardId, error) {
+	splitId := strings.Split(input, "|")
+	if len(splitId) != 2 {
+		return nil, fmt.Errorf("expected ID to be in the format {GrafanaId}|{DashboardUid} but got %q", input)
+	}
+
+	grafanaId, err := grafanaresource.ParseGrafanaID(splitId[0])
+	if err != nil {
+		return nil, err
+	}
+
+	if splitId[1] == "" {
+		return nil, fmt.Errorf("expected the Dashboard UID within %q to not be empty", input)
+	}
+
+	return &GrafanaDashboardId{
+		GrafanaId:    *grafanaId,
+		DashboardUid: splitId[1],
+	}, nil
+}
@@ -0,0 +1,70 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package parse
+
+import (
+	"testing"
+
+	"github.com/hashicorp/go-azure-sdk/resource-manager/dashboard/2023-09-01/grafanaresource"
+)
+
+func TestGrafanaDashboardID(t *testing.T) {
+	testData := []struct {
+		Input    string
+		Error    bool
+		Expected *GrafanaDashboardId
+	}{
+		{
+			// empty
+			Input: "",
+			Error: true,
+		},
+		{
+			// missing dashboard uid
+			Input: "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/resGroup1/providers/Microsoft.Dashboard/grafana/grafana1",
+			Error: true,
+		},
+		{
+			// empty dashboard uid
+			Input: "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/resGroup1/providers/Microsoft.Dashboard/grafana/grafana1|",
+			Error: true,
+		},
+		{
+			// invalid grafana
+			Input: "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/resGroup1|dashboard1",
+			Error: true,
+		},
+		{
+			// valid
+			Input: "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/resGroup1/providers/Microsoft.Dashboard/grafana/grafana1|dashboard1",
+			Expected: &GrafanaDashboardId{
+				GrafanaId:    grafanaresource.NewGrafanaID("12345678-1234-9876-4563-123456789012", "resGroup1", "grafana1"),
+				DashboardUid: "dashboard1",
+			},
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Input)
+
+		actual, err := GrafanaDashboardID(v.Input)
+		if err != nil {
+			if v.Error {
+				continue
+			}
+
+			t.Fatalf("Expect a value but got an error: %s", err)
+		}
+		if v.Error {
+			t.Fatal("Expect an error but didn't get one")
+		}
+
+		if actual.GrafanaId != v.Expected.GrafanaId {
+			t.Fatalf("Expected %+v but got %+v for GrafanaId", v.Expected.GrafanaId, actual.GrafanaId)
+		}
+		if actual.DashboardUid != v.Expected.DashboardUid {
+			t.Fatalf("Expected %q but got %q for DashboardUid", v.Expected.DashboardUid, actual.DashboardUid)
+		}
+	}
+}
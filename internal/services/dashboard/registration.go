@@ -13,6 +13,7 @@ type Registration struct{}
 var (
 	_ sdk.TypedServiceRegistrationWithAGitHubLabel   = Registration{}
 	_ sdk.UntypedServiceRegistrationWithAGitHubLabel = Registration{}
+	_ sdk.ServiceRegistrationWithResourceProviders   = Registration{}
 )
 
 func (r Registration) AssociatedGitHubLabel() string {
@@ -24,6 +25,13 @@ func (r Registration) Name() string {
 	return "Dashboard"
 }
 
+// ResourceProviders returns the Resource Providers required by the Resources within this Service
+func (r Registration) ResourceProviders() []string {
+	return []string{
+		"Microsoft.Dashboard",
+	}
+}
+
 // WebsiteCategories returns a list of categories which can be used for the sidebar
 func (r Registration) WebsiteCategories() []string {
 	return []string{
@@ -52,5 +60,7 @@ func (r Registration) DataSources() []sdk.DataSource {
 func (r Registration) Resources() []sdk.Resource {
 	return []sdk.Resource{
 		DashboardGrafanaResource{},
+		DashboardGrafanaDashboardResource{},
+		DashboardGrafanaManagedPrivateEndpointResource{},
 	}
 }
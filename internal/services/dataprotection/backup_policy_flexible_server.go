@@ -0,0 +1,565 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package dataprotection
+
+import (
+	"strings"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonschema"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/dataprotection/2024-04-01/backuppolicies"
+	azValidate "github.com/hashicorp/terraform-provider-azurerm/helpers/validate"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+)
+
+// The Backup Policies for the PostgreSQL and MySQL Flexible Servers share the same schema and policy rules, only the
+// name validation and the Data Source Type differ between them.
+
+type BackupPolicyFlexibleServerDefaultRetentionRule struct {
+	LifeCycle []BackupPolicyFlexibleServerLifeCycle `tfschema:"life_cycle"`
+}
+
+type BackupPolicyFlexibleServerLifeCycle struct {
+	DataStoreType string `tfschema:"data_store_type"`
+	Duration      string `tfschema:"duration"`
+}
+
+type BackupPolicyFlexibleServerRetentionRule struct {
+	Name      string                                `tfschema:"name"`
+	Criteria  []BackupPolicyFlexibleServerCriteria  `tfschema:"criteria"`
+	LifeCycle []BackupPolicyFlexibleServerLifeCycle `tfschema:"life_cycle"`
+	Priority  int64                                 `tfschema:"priority"`
+}
+
+type BackupPolicyFlexibleServerCriteria struct {
+	AbsoluteCriteria     string   `tfschema:"absolute_criteria"`
+	DaysOfWeek           []string `tfschema:"days_of_week"`
+	MonthsOfYear         []string `tfschema:"months_of_year"`
+	ScheduledBackupTimes []string `tfschema:"scheduled_backup_times"`
+	WeeksOfMonth         []string `tfschema:"weeks_of_month"`
+}
+
+// backupPolicyFlexibleServerArguments returns the arguments shared by the Flexible Server Backup Policy Resources
+func backupPolicyFlexibleServerArguments(nameValidateFunc pluginsdk.SchemaValidateFunc) map[string]*pluginsdk.Schema {
+	arguments := map[string]*pluginsdk.Schema{
+		"name": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: nameValidateFunc,
+		},
+
+		"vault_id": commonschema.ResourceIDReferenceRequiredForceNew(pointer.To(backuppolicies.BackupVaultId{})),
+
+		"backup_repeating_time_intervals": {
+			Type:     pluginsdk.TypeList,
+			Required: true,
+			ForceNew: true,
+			MinItems: 1,
+			Elem: &pluginsdk.Schema{
+				Type:         pluginsdk.TypeString,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+		},
+
+		"default_retention_rule": {
+			Type:     pluginsdk.TypeList,
+			Required: true,
+			ForceNew: true,
+			MaxItems: 1,
+			Elem: &pluginsdk.Resource{
+				Schema: map[string]*pluginsdk.Schema{
+					"life_cycle": {
+						Type:     pluginsdk.TypeList,
+						Required: true,
+						ForceNew: true,
+						Elem: &pluginsdk.Resource{
+							Schema: map[string]*pluginsdk.Schema{
+								"data_store_type": {
+									Type:     pluginsdk.TypeString,
+									Required: true,
+									ForceNew: true,
+									ValidateFunc: validation.StringInSlice([]string{
+										// Confirmed with the service team that current possible value only support `VaultStore`.
+										// However, considering that `ArchiveStore` will be supported in the future, it would be exposed for user specification.
+										string(backuppolicies.DataStoreTypesVaultStore),
+									}, false),
+								},
+
+								"duration": {
+									Type:         pluginsdk.TypeString,
+									Required:     true,
+									ForceNew:     true,
+									ValidateFunc: azValidate.ISO8601Duration,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+
+		"retention_rule": {
+			Type:     pluginsdk.TypeList,
+			Optional: true,
+			ForceNew: true,
+			Elem: &pluginsdk.Resource{
+				Schema: map[string]*pluginsdk.Schema{
+					"name": {
+						Type:         pluginsdk.TypeString,
+						Required:     true,
+						ForceNew:     true,
+						ValidateFunc: validation.StringIsNotEmpty,
+					},
+
+					"criteria": {
+						Type:     pluginsdk.TypeList,
+						Required: true,
+						ForceNew: true,
+						MaxItems: 1,
+						Elem: &pluginsdk.Resource{
+							Schema: map[string]*pluginsdk.Schema{
+								"absolute_criteria": {
+									Type:         pluginsdk.TypeString,
+									Optional:     true,
+									ForceNew:     true,
+									ValidateFunc: validation.StringInSlice(backuppolicies.PossibleValuesForAbsoluteMarker(), false),
+								},
+
+								"days_of_week": {
+									Type:     pluginsdk.TypeSet,
+									Optional: true,
+									ForceNew: true,
+									MinItems: 1,
+									Elem: &pluginsdk.Schema{
+										Type:         pluginsdk.TypeString,
+										ValidateFunc: validation.StringInSlice(backuppolicies.PossibleValuesForDayOfWeek(), false),
+									},
+								},
+
+								"months_of_year": {
+									Type:     pluginsdk.TypeSet,
+									Optional: true,
+									ForceNew: true,
+									MinItems: 1,
+									Elem: &pluginsdk.Schema{
+										Type:         pluginsdk.TypeString,
+										ValidateFunc: validation.StringInSlice(backuppolicies.PossibleValuesForMonth(), false),
+									},
+								},
+
+								"scheduled_backup_times": {
+									Type:     pluginsdk.TypeSet,
+									Optional: true,
+									ForceNew: true,
+									MinItems: 1,
+									Elem: &pluginsdk.Schema{
+										Type:         pluginsdk.TypeString,
+										ValidateFunc: validation.IsRFC3339Time,
+									},
+								},
+
+								"weeks_of_month": {
+									Type:     pluginsdk.TypeSet,
+									Optional: true,
+									ForceNew: true,
+									MinItems: 1,
+									Elem: &pluginsdk.Schema{
+										Type:         pluginsdk.TypeString,
+										ValidateFunc: validation.StringInSlice(backuppolicies.PossibleValuesForWeekNumber(), false),
+									},
+								},
+							},
+						},
+					},
+
+					"life_cycle": {
+						Type:     pluginsdk.TypeList,
+						Required: true,
+						ForceNew: true,
+						Elem: &pluginsdk.Resource{
+							Schema: map[string]*pluginsdk.Schema{
+								"data_store_type": {
+									Type:     pluginsdk.TypeString,
+									Required: true,
+									ForceNew: true,
+									ValidateFunc: validation.StringInSlice([]string{
+										// Confirmed with the service team that currently only `VaultStore` is supported.
+										// However, considering that `ArchiveStore` will be supported in the future, it would be exposed for user specification.
+										string(backuppolicies.DataStoreTypesVaultStore),
+									}, false),
+								},
+
+								"duration": {
+									Type:         pluginsdk.TypeString,
+									Required:     true,
+									ForceNew:     true,
+									ValidateFunc: azValidate.ISO8601Duration,
+								},
+							},
+						},
+					},
+
+					"priority": {
+						Type:     pluginsdk.TypeInt,
+						Required: true,
+						ForceNew: true,
+					},
+				},
+			},
+		},
+
+		"time_zone": {
+			Type:         pluginsdk.TypeString,
+			Optional:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+		},
+	}
+	return arguments
+}
+
+func expandBackupPolicyFlexibleServerAzureBackupRules(input []string, timeZone string, taggingCriteria []backuppolicies.TaggingCriteria) []backuppolicies.BasePolicyRule {
+	results := make([]backuppolicies.BasePolicyRule, 0)
+
+	results = append(results, backuppolicies.AzureBackupRule{
+		Name: "BackupIntervals",
+		DataStore: backuppolicies.DataStoreInfoBase{
+			DataStoreType: backuppolicies.DataStoreTypesVaultStore,
+			ObjectType:    "DataStoreInfoBase",
+		},
+		BackupParameters: backuppolicies.AzureBackupParams{
+			BackupType: "Full",
+		},
+		Trigger: backuppolicies.ScheduleBasedTriggerContext{
+			Schedule: backuppolicies.BackupSchedule{
+				RepeatingTimeIntervals: input,
+				TimeZone:               pointer.To(timeZone),
+			},
+			TaggingCriteria: taggingCriteria,
+		},
+	})
+
+	return results
+}
+
+func expandBackupPolicyFlexibleServerAzureRetentionRules(input []BackupPolicyFlexibleServerRetentionRule) []backuppolicies.BasePolicyRule {
+	results := make([]backuppolicies.BasePolicyRule, 0)
+
+	for _, item := range input {
+		results = append(results, backuppolicies.AzureRetentionRule{
+			Name:       item.Name,
+			IsDefault:  utils.Bool(false),
+			Lifecycles: expandBackupPolicyFlexibleServerLifeCycle(item.LifeCycle),
+		})
+	}
+
+	return results
+}
+
+func expandBackupPolicyFlexibleServerDefaultAzureRetentionRule(input []BackupPolicyFlexibleServerDefaultRetentionRule) backuppolicies.BasePolicyRule {
+	result := backuppolicies.AzureRetentionRule{
+		Name:      "Default",
+		IsDefault: utils.Bool(true),
+	}
+
+	if len(input) > 0 {
+		result.Lifecycles = expandBackupPolicyFlexibleServerLifeCycle(input[0].LifeCycle)
+	}
+
+	return result
+}
+
+func expandBackupPolicyFlexibleServerLifeCycle(input []BackupPolicyFlexibleServerLifeCycle) []backuppolicies.SourceLifeCycle {
+	results := make([]backuppolicies.SourceLifeCycle, 0)
+
+	for _, item := range input {
+		sourceLifeCycle := backuppolicies.SourceLifeCycle{
+			DeleteAfter: backuppolicies.AbsoluteDeleteOption{
+				Duration: item.Duration,
+			},
+			SourceDataStore: backuppolicies.DataStoreInfoBase{
+				DataStoreType: backuppolicies.DataStoreTypes(item.DataStoreType),
+				ObjectType:    "DataStoreInfoBase",
+			},
+			TargetDataStoreCopySettings: &[]backuppolicies.TargetCopySetting{},
+		}
+
+		results = append(results, sourceLifeCycle)
+	}
+
+	return results
+}
+
+func expandBackupPolicyFlexibleServerTaggingCriteria(input []BackupPolicyFlexibleServerRetentionRule) []backuppolicies.TaggingCriteria {
+	results := []backuppolicies.TaggingCriteria{
+		{
+			Criteria:        nil,
+			IsDefault:       true,
+			TaggingPriority: 99,
+			TagInfo: backuppolicies.RetentionTag{
+				Id:      utils.String("Default_"),
+				TagName: "Default",
+			},
+		},
+	}
+
+	for _, item := range input {
+		result := backuppolicies.TaggingCriteria{
+			IsDefault:       false,
+			Criteria:        expandBackupPolicyFlexibleServerCriteria(item.Criteria),
+			TaggingPriority: item.Priority,
+			TagInfo: backuppolicies.RetentionTag{
+				Id:      utils.String(item.Name + "_"),
+				TagName: item.Name,
+			},
+		}
+
+		results = append(results, result)
+	}
+
+	return results
+}
+
+func expandBackupPolicyFlexibleServerCriteria(input []BackupPolicyFlexibleServerCriteria) *[]backuppolicies.BackupCriteria {
+	if len(input) == 0 {
+		return nil
+	}
+
+	results := make([]backuppolicies.BackupCriteria, 0)
+
+	for _, item := range input {
+		var absoluteCriteria []backuppolicies.AbsoluteMarker
+		if absoluteCriteriaRaw := item.AbsoluteCriteria; len(absoluteCriteriaRaw) > 0 {
+			absoluteCriteria = []backuppolicies.AbsoluteMarker{backuppolicies.AbsoluteMarker(absoluteCriteriaRaw)}
+		}
+
+		var daysOfWeek []backuppolicies.DayOfWeek
+		if len(item.DaysOfWeek) > 0 {
+			daysOfWeek = make([]backuppolicies.DayOfWeek, 0)
+			for _, value := range item.DaysOfWeek {
+				daysOfWeek = append(daysOfWeek, backuppolicies.DayOfWeek(value))
+			}
+		}
+
+		var monthsOfYear []backuppolicies.Month
+		if len(item.MonthsOfYear) > 0 {
+			monthsOfYear = make([]backuppolicies.Month, 0)
+			for _, value := range item.MonthsOfYear {
+				monthsOfYear = append(monthsOfYear, backuppolicies.Month(value))
+			}
+		}
+
+		var weeksOfMonth []backuppolicies.WeekNumber
+		if len(item.WeeksOfMonth) > 0 {
+			weeksOfMonth = make([]backuppolicies.WeekNumber, 0)
+			for _, value := range item.WeeksOfMonth {
+				weeksOfMonth = append(weeksOfMonth, backuppolicies.WeekNumber(value))
+			}
+		}
+
+		var scheduleTimes []string
+		if len(item.ScheduledBackupTimes) > 0 {
+			scheduleTimes = item.ScheduledBackupTimes
+		}
+
+		results = append(results, backuppolicies.ScheduleBasedBackupCriteria{
+			AbsoluteCriteria: pointer.To(absoluteCriteria),
+			DaysOfMonth:      nil,
+			DaysOfTheWeek:    pointer.To(daysOfWeek),
+			MonthsOfYear:     pointer.To(monthsOfYear),
+			ScheduleTimes:    pointer.To(scheduleTimes),
+			WeeksOfTheMonth:  pointer.To(weeksOfMonth),
+		})
+	}
+
+	return &results
+}
+
+func flattenBackupPolicyFlexibleServerBackupRules(input []backuppolicies.BasePolicyRule) []string {
+	backupRules := make([]string, 0)
+
+	for _, item := range input {
+		if v, ok := item.(backuppolicies.AzureBackupRule); ok {
+			if v.Trigger != nil {
+				if scheduleBasedTrigger, ok := v.Trigger.(backuppolicies.ScheduleBasedTriggerContext); ok {
+					backupRules = scheduleBasedTrigger.Schedule.RepeatingTimeIntervals
+					return backupRules
+				}
+			}
+		}
+	}
+
+	return backupRules
+}
+
+func flattenBackupPolicyFlexibleServerBackupTimeZone(input []backuppolicies.BasePolicyRule) string {
+	var timeZone string
+
+	for _, item := range input {
+		if backupRule, ok := item.(backuppolicies.AzureBackupRule); ok {
+			if backupRule.Trigger != nil {
+				if scheduleBasedTrigger, ok := backupRule.Trigger.(backuppolicies.ScheduleBasedTriggerContext); ok {
+					timeZone = pointer.From(scheduleBasedTrigger.Schedule.TimeZone)
+					return timeZone
+				}
+			}
+		}
+	}
+
+	return timeZone
+}
+
+func flattenBackupPolicyFlexibleServerDefaultRetentionRule(input []backuppolicies.BasePolicyRule) []BackupPolicyFlexibleServerDefaultRetentionRule {
+	results := make([]BackupPolicyFlexibleServerDefaultRetentionRule, 0)
+
+	for _, item := range input {
+		if retentionRule, ok := item.(backuppolicies.AzureRetentionRule); ok {
+			if pointer.From(retentionRule.IsDefault) {
+				var lifeCycle []BackupPolicyFlexibleServerLifeCycle
+				if v := retentionRule.Lifecycles; len(v) > 0 {
+					lifeCycle = flattenBackupPolicyFlexibleServerLifeCycles(v)
+				}
+
+				results = append(results, BackupPolicyFlexibleServerDefaultRetentionRule{
+					LifeCycle: lifeCycle,
+				})
+			}
+		}
+	}
+
+	return results
+}
+
+func flattenBackupPolicyFlexibleServerRetentionRules(input []backuppolicies.BasePolicyRule) []BackupPolicyFlexibleServerRetentionRule {
+	results := make([]BackupPolicyFlexibleServerRetentionRule, 0)
+	var taggingCriterias []backuppolicies.TaggingCriteria
+
+	for _, item := range input {
+		if backupRule, ok := item.(backuppolicies.AzureBackupRule); ok {
+			if trigger, ok := backupRule.Trigger.(backuppolicies.ScheduleBasedTriggerContext); ok {
+				if trigger.TaggingCriteria != nil {
+					taggingCriterias = trigger.TaggingCriteria
+				}
+			}
+		}
+	}
+
+	for _, item := range input {
+		if retentionRule, ok := item.(backuppolicies.AzureRetentionRule); ok {
+			var name string
+			var taggingPriority int64
+			var taggingCriteria []BackupPolicyFlexibleServerCriteria
+
+			if !pointer.From(retentionRule.IsDefault) {
+				name = retentionRule.Name
+
+				for _, criteria := range taggingCriterias {
+					if strings.EqualFold(criteria.TagInfo.TagName, name) {
+						taggingPriority = criteria.TaggingPriority
+						taggingCriteria = flattenBackupPolicyFlexibleServerBackupCriteria(criteria.Criteria)
+						break
+					}
+				}
+
+				var lifeCycle []BackupPolicyFlexibleServerLifeCycle
+				if v := retentionRule.Lifecycles; len(v) > 0 {
+					lifeCycle = flattenBackupPolicyFlexibleServerLifeCycles(v)
+				}
+
+				results = append(results, BackupPolicyFlexibleServerRetentionRule{
+					Name:      name,
+					Priority:  taggingPriority,
+					Criteria:  taggingCriteria,
+					LifeCycle: lifeCycle,
+				})
+			}
+		}
+	}
+
+	return results
+}
+
+func flattenBackupPolicyFlexibleServerLifeCycles(input []backuppolicies.SourceLifeCycle) []BackupPolicyFlexibleServerLifeCycle {
+	results := make([]BackupPolicyFlexibleServerLifeCycle, 0)
+
+	for _, item := range input {
+		var duration string
+		var dataStoreType string
+
+		if deleteOption, ok := item.DeleteAfter.(backuppolicies.AbsoluteDeleteOption); ok {
+			duration = deleteOption.Duration
+		}
+
+		dataStoreType = string(item.SourceDataStore.DataStoreType)
+
+		results = append(results, BackupPolicyFlexibleServerLifeCycle{
+			Duration:      duration,
+			DataStoreType: dataStoreType,
+		})
+	}
+
+	return results
+}
+
+func flattenBackupPolicyFlexibleServerBackupCriteria(input *[]backuppolicies.BackupCriteria) []BackupPolicyFlexibleServerCriteria {
+	results := make([]BackupPolicyFlexibleServerCriteria, 0)
+	if input == nil {
+		return results
+	}
+
+	for _, item := range pointer.From(input) {
+		if criteria, ok := item.(backuppolicies.ScheduleBasedBackupCriteria); ok {
+			var absoluteCriteria string
+			if criteria.AbsoluteCriteria != nil && len(pointer.From(criteria.AbsoluteCriteria)) > 0 {
+				absoluteCriteria = string((pointer.From(criteria.AbsoluteCriteria))[0])
+			}
+
+			var daysOfWeek []string
+			if criteria.DaysOfTheWeek != nil {
+				daysOfWeek = make([]string, 0)
+
+				for _, item := range pointer.From(criteria.DaysOfTheWeek) {
+					daysOfWeek = append(daysOfWeek, (string)(item))
+				}
+			}
+
+			var monthsOfYear []string
+			if criteria.MonthsOfYear != nil {
+				monthsOfYear = make([]string, 0)
+
+				for _, item := range pointer.From(criteria.MonthsOfYear) {
+					monthsOfYear = append(monthsOfYear, (string)(item))
+				}
+			}
+
+			var weeksOfMonth []string
+			if criteria.WeeksOfTheMonth != nil {
+				weeksOfMonth = make([]string, 0)
+
+				for _, item := range pointer.From(criteria.WeeksOfTheMonth) {
+					weeksOfMonth = append(weeksOfMonth, (string)(item))
+				}
+			}
+
+			var scheduleTimes []string
+			if criteria.ScheduleTimes != nil {
+				scheduleTimes = make([]string, 0)
+				scheduleTimes = append(scheduleTimes, pointer.From(criteria.ScheduleTimes)...)
+			}
+
+			results = append(results, BackupPolicyFlexibleServerCriteria{
+				AbsoluteCriteria:     absoluteCriteria,
+				DaysOfWeek:           daysOfWeek,
+				MonthsOfYear:         monthsOfYear,
+				WeeksOfMonth:         weeksOfMonth,
+				ScheduledBackupTimes: scheduleTimes,
+			})
+		}
+	}
+
+	return results
+}
@@ -0,0 +1,208 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package dataprotection_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/go-azure-sdk/resource-manager/dataprotection/2024-04-01/backupinstances"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/check"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+)
+
+type DataProtectionBackupInstanceMySQLFlexibleServerResource struct{}
+
+func TestAccDataProtectionBackupInstanceMySQLFlexibleServer_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_data_protection_backup_instance_mysql_flexible_server", "test")
+	r := DataProtectionBackupInstanceMySQLFlexibleServerResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccDataProtectionBackupInstanceMySQLFlexibleServer_requiresImport(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_data_protection_backup_instance_mysql_flexible_server", "test")
+	r := DataProtectionBackupInstanceMySQLFlexibleServerResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.RequiresImportErrorStep(r.requiresImport),
+	})
+}
+
+func TestAccDataProtectionBackupInstanceMySQLFlexibleServer_update(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_data_protection_backup_instance_mysql_flexible_server", "test")
+	r := DataProtectionBackupInstanceMySQLFlexibleServerResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.update(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func (r DataProtectionBackupInstanceMySQLFlexibleServerResource) Exists(ctx context.Context, client *clients.Client, state *pluginsdk.InstanceState) (*bool, error) {
+	id, err := backupinstances.ParseBackupInstanceID(state.ID)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.DataProtection.BackupInstanceClient.Get(ctx, *id)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %+v", *id, err)
+	}
+	return utils.Bool(resp.Model != nil), nil
+}
+
+func (r DataProtectionBackupInstanceMySQLFlexibleServerResource) template(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+resource "azurerm_resource_group" "test" {
+  name     = "acctest-dataprotection-%d"
+  location = "%s"
+}
+
+resource "azurerm_mysql_flexible_server" "test" {
+  name                   = "acctest-mysqlfs-%d"
+  resource_group_name    = azurerm_resource_group.test.name
+  location               = azurerm_resource_group.test.location
+  administrator_login    = "adminTerraform"
+  administrator_password = "QAZwsx123"
+  sku_name               = "GP_Standard_D2ds_v4"
+  version                = "8.0.21"
+  zone                   = "1"
+}
+
+resource "azurerm_data_protection_backup_vault" "test" {
+  name                = "acctest-dataprotection-vault-%d"
+  resource_group_name = azurerm_resource_group.test.name
+  location            = azurerm_resource_group.test.location
+  datastore_type      = "VaultStore"
+  redundancy          = "LocallyRedundant"
+  soft_delete         = "Off"
+
+  identity {
+    type = "SystemAssigned"
+  }
+}
+
+resource "azurerm_role_assignment" "test" {
+  scope                = azurerm_resource_group.test.id
+  role_definition_name = "Reader"
+  principal_id         = azurerm_data_protection_backup_vault.test.identity.0.principal_id
+}
+
+resource "azurerm_role_assignment" "test2" {
+  scope                = azurerm_mysql_flexible_server.test.id
+  role_definition_name = "MySQL Backup And Export Operator"
+  principal_id         = azurerm_data_protection_backup_vault.test.identity.0.principal_id
+}
+
+resource "azurerm_data_protection_backup_policy_mysql_flexible_server" "test" {
+  name                            = "acctest-dp-%d"
+  vault_id                        = azurerm_data_protection_backup_vault.test.id
+  backup_repeating_time_intervals = ["R/2021-05-23T02:30:00+00:00/P1W"]
+
+  default_retention_rule {
+    life_cycle {
+      duration        = "P4M"
+      data_store_type = "VaultStore"
+    }
+  }
+
+  depends_on = [azurerm_role_assignment.test, azurerm_role_assignment.test2]
+}
+`, data.RandomInteger, data.Locations.Primary, data.RandomInteger, data.RandomInteger, data.RandomInteger)
+}
+
+func (r DataProtectionBackupInstanceMySQLFlexibleServerResource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+%s
+
+resource "azurerm_data_protection_backup_instance_mysql_flexible_server" "test" {
+  name             = "acctest-dbi-%d"
+  location         = azurerm_resource_group.test.location
+  vault_id         = azurerm_data_protection_backup_vault.test.id
+  server_id        = azurerm_mysql_flexible_server.test.id
+  backup_policy_id = azurerm_data_protection_backup_policy_mysql_flexible_server.test.id
+}
+`, r.template(data), data.RandomInteger)
+}
+
+func (r DataProtectionBackupInstanceMySQLFlexibleServerResource) requiresImport(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_data_protection_backup_instance_mysql_flexible_server" "import" {
+  name             = azurerm_data_protection_backup_instance_mysql_flexible_server.test.name
+  location         = azurerm_data_protection_backup_instance_mysql_flexible_server.test.location
+  vault_id         = azurerm_data_protection_backup_instance_mysql_flexible_server.test.vault_id
+  server_id        = azurerm_data_protection_backup_instance_mysql_flexible_server.test.server_id
+  backup_policy_id = azurerm_data_protection_backup_instance_mysql_flexible_server.test.backup_policy_id
+}
+`, r.basic(data))
+}
+
+func (r DataProtectionBackupInstanceMySQLFlexibleServerResource) update(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+%s
+
+resource "azurerm_data_protection_backup_policy_mysql_flexible_server" "test2" {
+  name                            = "acctest-dp2-%d"
+  vault_id                        = azurerm_data_protection_backup_vault.test.id
+  backup_repeating_time_intervals = ["R/2021-05-23T02:30:00+00:00/P1W"]
+
+  default_retention_rule {
+    life_cycle {
+      duration        = "P4M"
+      data_store_type = "VaultStore"
+    }
+  }
+
+  depends_on = [azurerm_role_assignment.test, azurerm_role_assignment.test2]
+}
+
+resource "azurerm_data_protection_backup_instance_mysql_flexible_server" "test" {
+  name             = "acctest-dbi-%d"
+  location         = azurerm_resource_group.test.location
+  vault_id         = azurerm_data_protection_backup_vault.test.id
+  server_id        = azurerm_mysql_flexible_server.test.id
+  backup_policy_id = azurerm_data_protection_backup_policy_mysql_flexible_server.test2.id
+}
+`, r.template(data), data.RandomInteger, data.RandomInteger)
+}
@@ -147,7 +147,7 @@ func (r DataProtectionBackupInstancePostgreSQLFlexibleServerResource) Create() s
 				Delay:        5 * time.Second,
 				Pending:      []string{string(backupinstances.CurrentProtectionStateConfiguringProtection)},
 				Target:       []string{string(backupinstances.CurrentProtectionStateProtectionConfigured)},
-				Refresh:      dataProtectionBackupInstancePostgreSQLFlexibleServerStateRefreshFunc(ctx, client, id),
+				Refresh:      backupInstanceFlexibleServerStateRefreshFunc(ctx, client, id),
 				PollInterval: 1 * time.Minute,
 				Timeout:      time.Until(deadline),
 			}
@@ -261,7 +261,7 @@ func (r DataProtectionBackupInstancePostgreSQLFlexibleServerResource) Update() s
 				Delay:        5 * time.Second,
 				Pending:      []string{string(backupinstances.CurrentProtectionStateUpdatingProtection)},
 				Target:       []string{string(backupinstances.CurrentProtectionStateProtectionConfigured)},
-				Refresh:      dataProtectionBackupInstancePostgreSQLFlexibleServerStateRefreshFunc(ctx, client, *id),
+				Refresh:      backupInstanceFlexibleServerStateRefreshFunc(ctx, client, *id),
 				PollInterval: 1 * time.Minute,
 				Timeout:      time.Until(deadline),
 			}
@@ -295,22 +295,3 @@ func (r DataProtectionBackupInstancePostgreSQLFlexibleServerResource) Delete() s
 		},
 	}
 }
-
-func dataProtectionBackupInstancePostgreSQLFlexibleServerStateRefreshFunc(ctx context.Context, client *backupinstances.BackupInstancesClient, id backupinstances.BackupInstanceId) pluginsdk.StateRefreshFunc {
-	return func() (interface{}, string, error) {
-		resp, err := client.Get(ctx, id)
-		if err != nil {
-			return nil, "", fmt.Errorf("polling for %s: %+v", id, err)
-		}
-
-		if resp.Model == nil {
-			return nil, "", fmt.Errorf("polling for %s: `model` was nil", id)
-		}
-
-		if resp.Model.Properties == nil {
-			return nil, "", fmt.Errorf("polling for %s: `properties` was nil", id)
-		}
-
-		return resp, string(pointer.From(resp.Model.Properties.CurrentProtectionState)), nil
-	}
-}
@@ -0,0 +1,158 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package dataprotection
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/dataprotection/2024-04-01/backuppolicies"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/dataprotection/validate"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+type BackupPolicyMySQLFlexibleServerModel struct {
+	Name                         string                                           `tfschema:"name"`
+	VaultId                      string                                           `tfschema:"vault_id"`
+	BackupRepeatingTimeIntervals []string                                         `tfschema:"backup_repeating_time_intervals"`
+	DefaultRetentionRule         []BackupPolicyFlexibleServerDefaultRetentionRule `tfschema:"default_retention_rule"`
+	RetentionRules               []BackupPolicyFlexibleServerRetentionRule        `tfschema:"retention_rule"`
+	TimeZone                     string                                           `tfschema:"time_zone"`
+}
+
+type DataProtectionBackupPolicyMySQLFlexibleServerResource struct{}
+
+var _ sdk.Resource = DataProtectionBackupPolicyMySQLFlexibleServerResource{}
+
+func (r DataProtectionBackupPolicyMySQLFlexibleServerResource) ResourceType() string {
+	return "azurerm_data_protection_backup_policy_mysql_flexible_server"
+}
+
+func (r DataProtectionBackupPolicyMySQLFlexibleServerResource) ModelObject() interface{} {
+	return &BackupPolicyMySQLFlexibleServerModel{}
+}
+
+func (r DataProtectionBackupPolicyMySQLFlexibleServerResource) IDValidationFunc() pluginsdk.SchemaValidateFunc {
+	return backuppolicies.ValidateBackupPolicyID
+}
+
+func (r DataProtectionBackupPolicyMySQLFlexibleServerResource) Arguments() map[string]*pluginsdk.Schema {
+	return backupPolicyFlexibleServerArguments(validate.BackupPolicyMySQLFlexibleServerName)
+}
+
+func (r DataProtectionBackupPolicyMySQLFlexibleServerResource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{}
+}
+
+func (r DataProtectionBackupPolicyMySQLFlexibleServerResource) Create() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			var model BackupPolicyMySQLFlexibleServerModel
+			if err := metadata.Decode(&model); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			client := metadata.Client.DataProtection.BackupPolicyClient
+			subscriptionId := metadata.Client.Account.SubscriptionId
+
+			vaultId, _ := backuppolicies.ParseBackupVaultID(model.VaultId)
+			id := backuppolicies.NewBackupPolicyID(subscriptionId, vaultId.ResourceGroupName, vaultId.BackupVaultName, model.Name)
+
+			existing, err := client.Get(ctx, id)
+			if err != nil {
+				if !response.WasNotFound(existing.HttpResponse) {
+					return fmt.Errorf("checking for existing %s: %+v", id, err)
+				}
+			}
+
+			if !response.WasNotFound(existing.HttpResponse) {
+				return metadata.ResourceRequiresImport(r.ResourceType(), id)
+			}
+
+			policyRules := make([]backuppolicies.BasePolicyRule, 0)
+			policyRules = append(policyRules, expandBackupPolicyFlexibleServerAzureBackupRules(model.BackupRepeatingTimeIntervals, model.TimeZone, expandBackupPolicyFlexibleServerTaggingCriteria(model.RetentionRules))...)
+			policyRules = append(policyRules, expandBackupPolicyFlexibleServerDefaultAzureRetentionRule(model.DefaultRetentionRule))
+			policyRules = append(policyRules, expandBackupPolicyFlexibleServerAzureRetentionRules(model.RetentionRules)...)
+
+			parameters := backuppolicies.BaseBackupPolicyResource{
+				Properties: backuppolicies.BackupPolicy{
+					PolicyRules:     policyRules,
+					DatasourceTypes: []string{"Microsoft.DBforMySQL/flexibleServers"},
+				},
+			}
+
+			if _, err := client.CreateOrUpdate(ctx, id, parameters); err != nil {
+				return fmt.Errorf("creating %s: %+v", id, err)
+			}
+
+			metadata.SetID(id)
+
+			return nil
+		},
+	}
+}
+
+func (r DataProtectionBackupPolicyMySQLFlexibleServerResource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.DataProtection.BackupPolicyClient
+
+			id, err := backuppolicies.ParseBackupPolicyID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			resp, err := client.Get(ctx, *id)
+			if err != nil {
+				if response.WasNotFound(resp.HttpResponse) {
+					return metadata.MarkAsGone(*id)
+				}
+
+				return fmt.Errorf("retrieving %s: %+v", *id, err)
+			}
+
+			vaultId := backuppolicies.NewBackupVaultID(id.SubscriptionId, id.ResourceGroupName, id.BackupVaultName)
+			state := BackupPolicyMySQLFlexibleServerModel{
+				Name:    id.BackupPolicyName,
+				VaultId: vaultId.ID(),
+			}
+
+			if model := resp.Model; model != nil {
+				if properties, ok := model.Properties.(backuppolicies.BackupPolicy); ok {
+					state.DefaultRetentionRule = flattenBackupPolicyFlexibleServerDefaultRetentionRule(properties.PolicyRules)
+					state.RetentionRules = flattenBackupPolicyFlexibleServerRetentionRules(properties.PolicyRules)
+					state.BackupRepeatingTimeIntervals = flattenBackupPolicyFlexibleServerBackupRules(properties.PolicyRules)
+					state.TimeZone = flattenBackupPolicyFlexibleServerBackupTimeZone(properties.PolicyRules)
+				}
+			}
+
+			return metadata.Encode(&state)
+		},
+	}
+}
+
+func (r DataProtectionBackupPolicyMySQLFlexibleServerResource) Delete() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.DataProtection.BackupPolicyClient
+
+			id, err := backuppolicies.ParseBackupPolicyID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			if _, err := client.Delete(ctx, *id); err != nil {
+				return fmt.Errorf("deleting %s: %+v", *id, err)
+			}
+
+			return nil
+		},
+	}
+}
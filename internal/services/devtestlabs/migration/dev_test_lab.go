@@ -4,12 +4,10 @@
 package migration
 
 import (
-	"context"
-	"log"
-
 	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonschema"
 	"github.com/hashicorp/go-azure-sdk/resource-manager/devtestlab/2018-09-15/labs"
 	"github.com/hashicorp/terraform-provider-azurerm/helpers/azure"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tags"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
 )
@@ -23,23 +21,13 @@ func (DevTestLabUpgradeV0ToV1) Schema() map[string]*pluginsdk.Schema {
 }
 
 func (DevTestLabUpgradeV0ToV1) UpgradeFunc() pluginsdk.StateUpgraderFunc {
-	return func(ctx context.Context, rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
-		// old:
-		// 	/subscriptions/{subscriptionId}/resourceGroups/{resourceGroupName}/providers/microsoft.devtestlab/labs/{labName}
-		// new:
-		// 	/subscriptions/{subscriptionId}/resourceGroups/{resourceGroupName}/providers/Microsoft.DevTestLab/labs/{labName}
-		oldId := rawState["id"].(string)
-		id, err := labs.ParseLabIDInsensitively(oldId)
-		if err != nil {
-			return rawState, err
-		}
-
-		newId := id.ID()
-		log.Printf("[DEBUG] Updating ID from %q to %q", oldId, newId)
-		rawState["id"] = newId
-
-		return rawState, nil
-	}
+	// old:
+	// 	/subscriptions/{subscriptionId}/resourceGroups/{resourceGroupName}/providers/microsoft.devtestlab/labs/{labName}
+	// new:
+	// 	/subscriptions/{subscriptionId}/resourceGroups/{resourceGroupName}/providers/Microsoft.DevTestLab/labs/{labName}
+	return sdk.ResourceIdStateUpgrade{
+		NewId: &labs.LabId{},
+	}.UpgradeFunc()
 }
 
 func devTestLabSchemaForV0AndV1() map[string]*pluginsdk.Schema {
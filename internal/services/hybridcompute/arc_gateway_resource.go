@@ -0,0 +1,241 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package hybridcompute
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonschema"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/location"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/hybridcompute/2024-05-20-preview/gateways"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+)
+
+type ArcGatewayModel struct {
+	Name              string            `tfschema:"name"`
+	ResourceGroupName string            `tfschema:"resource_group_name"`
+	Location          string            `tfschema:"location"`
+	AllowedFeatures   []string          `tfschema:"allowed_features"`
+	GatewayType       string            `tfschema:"gateway_type"`
+	Tags              map[string]string `tfschema:"tags"`
+	GatewayEndpoint   string            `tfschema:"gateway_endpoint"`
+	GatewayId         string            `tfschema:"gateway_id"`
+}
+
+type ArcGatewayResource struct{}
+
+var _ sdk.ResourceWithUpdate = ArcGatewayResource{}
+
+func (r ArcGatewayResource) ResourceType() string {
+	return "azurerm_arc_gateway"
+}
+
+func (r ArcGatewayResource) ModelObject() interface{} {
+	return &ArcGatewayModel{}
+}
+
+func (r ArcGatewayResource) IDValidationFunc() pluginsdk.SchemaValidateFunc {
+	return gateways.ValidateGatewayID
+}
+
+func (r ArcGatewayResource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"name": {
+			Type:     pluginsdk.TypeString,
+			Required: true,
+			ForceNew: true,
+			ValidateFunc: validation.All(
+				validation.StringIsNotEmpty,
+				validation.StringDoesNotContainAny("/"),
+			),
+		},
+
+		"resource_group_name": commonschema.ResourceGroupName(),
+
+		"location": commonschema.Location(),
+
+		"allowed_features": {
+			Type:     pluginsdk.TypeList,
+			Optional: true,
+			Computed: true,
+			Elem: &pluginsdk.Schema{
+				Type:         pluginsdk.TypeString,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+		},
+
+		"gateway_type": {
+			Type:         pluginsdk.TypeString,
+			Optional:     true,
+			ForceNew:     true,
+			Default:      string(gateways.GatewayTypePublic),
+			ValidateFunc: validation.StringInSlice(gateways.PossibleValuesForGatewayType(), false),
+		},
+
+		"tags": commonschema.Tags(),
+	}
+}
+
+func (r ArcGatewayResource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"gateway_endpoint": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+
+		"gateway_id": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+	}
+}
+
+func (r ArcGatewayResource) Create() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.HybridCompute.GatewaysClient
+			subscriptionId := metadata.Client.Account.SubscriptionId
+
+			var model ArcGatewayModel
+			if err := metadata.Decode(&model); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			id := gateways.NewGatewayID(subscriptionId, model.ResourceGroupName, model.Name)
+			existing, err := client.Get(ctx, id)
+			if err != nil && !response.WasNotFound(existing.HttpResponse) {
+				return fmt.Errorf("checking for existing %s: %+v", id, err)
+			}
+
+			if !response.WasNotFound(existing.HttpResponse) {
+				return metadata.ResourceRequiresImport(r.ResourceType(), id)
+			}
+
+			parameters := gateways.Gateway{
+				Location: location.Normalize(model.Location),
+				Properties: &gateways.GatewayProperties{
+					GatewayType: pointer.To(gateways.GatewayType(model.GatewayType)),
+				},
+				Tags: pointer.To(model.Tags),
+			}
+
+			if len(model.AllowedFeatures) > 0 {
+				parameters.Properties.AllowedFeatures = pointer.To(model.AllowedFeatures)
+			}
+
+			if err := client.CreateOrUpdateThenPoll(ctx, id, parameters); err != nil {
+				return fmt.Errorf("creating %s: %+v", id, err)
+			}
+
+			metadata.SetID(id)
+			return nil
+		},
+	}
+}
+
+func (r ArcGatewayResource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.HybridCompute.GatewaysClient
+
+			id, err := gateways.ParseGatewayID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			resp, err := client.Get(ctx, *id)
+			if err != nil {
+				if response.WasNotFound(resp.HttpResponse) {
+					return metadata.MarkAsGone(id)
+				}
+
+				return fmt.Errorf("retrieving %s: %+v", *id, err)
+			}
+
+			state := ArcGatewayModel{
+				Name:              id.GatewayName,
+				ResourceGroupName: id.ResourceGroupName,
+			}
+
+			if model := resp.Model; model != nil {
+				state.Location = location.Normalize(model.Location)
+				state.Tags = pointer.From(model.Tags)
+
+				if props := model.Properties; props != nil {
+					state.AllowedFeatures = pointer.From(props.AllowedFeatures)
+					state.GatewayType = string(pointer.From(props.GatewayType))
+					state.GatewayEndpoint = pointer.From(props.GatewayEndpoint)
+					state.GatewayId = pointer.From(props.GatewayId)
+				}
+			}
+
+			return metadata.Encode(&state)
+		},
+	}
+}
+
+func (r ArcGatewayResource) Update() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.HybridCompute.GatewaysClient
+
+			id, err := gateways.ParseGatewayID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			var model ArcGatewayModel
+			if err := metadata.Decode(&model); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			parameters := gateways.GatewayUpdate{}
+
+			if metadata.ResourceData.HasChange("allowed_features") {
+				parameters.Properties = &gateways.GatewayUpdateProperties{
+					AllowedFeatures: pointer.To(model.AllowedFeatures),
+				}
+			}
+
+			if metadata.ResourceData.HasChange("tags") {
+				parameters.Tags = pointer.To(model.Tags)
+			}
+
+			if _, err := client.Update(ctx, *id, parameters); err != nil {
+				return fmt.Errorf("updating %s: %+v", *id, err)
+			}
+
+			return nil
+		},
+	}
+}
+
+func (r ArcGatewayResource) Delete() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.HybridCompute.GatewaysClient
+
+			id, err := gateways.ParseGatewayID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			if err := client.DeleteThenPoll(ctx, *id); err != nil {
+				return fmt.Errorf("deleting %s: %+v", *id, err)
+			}
+
+			return nil
+		},
+	}
+}
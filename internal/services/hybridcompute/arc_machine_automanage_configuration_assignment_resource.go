@@ -0,0 +1,170 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package hybridcompute
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/automanage/2022-05-04/configurationprofilehcrpassignments"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/automanage/2022-05-04/configurationprofiles"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/hybridcompute/2022-11-10/machines"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+type ArcMachineAutomanageConfigurationAssignmentModel struct {
+	ArcMachineId    string `tfschema:"arc_machine_id"`
+	ConfigurationId string `tfschema:"configuration_id"`
+}
+
+type ArcMachineAutomanageConfigurationAssignmentResource struct{}
+
+var _ sdk.Resource = ArcMachineAutomanageConfigurationAssignmentResource{}
+
+func (r ArcMachineAutomanageConfigurationAssignmentResource) ResourceType() string {
+	return "azurerm_arc_machine_automanage_configuration_assignment"
+}
+
+func (r ArcMachineAutomanageConfigurationAssignmentResource) ModelObject() interface{} {
+	return &ArcMachineAutomanageConfigurationAssignmentModel{}
+}
+
+func (r ArcMachineAutomanageConfigurationAssignmentResource) IDValidationFunc() pluginsdk.SchemaValidateFunc {
+	return configurationprofilehcrpassignments.ValidateProviders2ConfigurationProfileAssignmentID
+}
+
+func (r ArcMachineAutomanageConfigurationAssignmentResource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"arc_machine_id": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: machines.ValidateMachineID,
+		},
+
+		"configuration_id": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: configurationprofiles.ValidateConfigurationProfileID,
+		},
+	}
+}
+
+func (r ArcMachineAutomanageConfigurationAssignmentResource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{}
+}
+
+func (r ArcMachineAutomanageConfigurationAssignmentResource) Create() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Automanage.ConfigurationProfileHCRPAssignmentsClient
+
+			var model ArcMachineAutomanageConfigurationAssignmentModel
+			if err := metadata.Decode(&model); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			machineId, err := machines.ParseMachineID(model.ArcMachineId)
+			if err != nil {
+				return err
+			}
+
+			configurationId, err := configurationprofiles.ParseConfigurationProfileID(model.ConfigurationId)
+			if err != nil {
+				return err
+			}
+
+			// Currently, the configuration profile assignment name has to be hardcoded to "default" by API requirement.
+			id := configurationprofilehcrpassignments.NewProviders2ConfigurationProfileAssignmentID(machineId.SubscriptionId, machineId.ResourceGroupName, machineId.MachineName, "default")
+			existing, err := client.Get(ctx, id)
+			if err != nil && !response.WasNotFound(existing.HttpResponse) {
+				return fmt.Errorf("checking for existing %s: %+v", id, err)
+			}
+
+			if !response.WasNotFound(existing.HttpResponse) {
+				return metadata.ResourceRequiresImport(r.ResourceType(), id)
+			}
+
+			properties := configurationprofilehcrpassignments.ConfigurationProfileAssignment{
+				Name: pointer.To(id.ConfigurationProfileAssignmentName),
+				Properties: &configurationprofilehcrpassignments.ConfigurationProfileAssignmentProperties{
+					ConfigurationProfile: pointer.To(configurationId.ID()),
+					TargetId:             pointer.To(machineId.ID()),
+				},
+			}
+
+			if _, err := client.CreateOrUpdate(ctx, id, properties); err != nil {
+				return fmt.Errorf("creating %s: %+v", id, err)
+			}
+
+			metadata.SetID(id)
+			return nil
+		},
+	}
+}
+
+func (r ArcMachineAutomanageConfigurationAssignmentResource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Automanage.ConfigurationProfileHCRPAssignmentsClient
+
+			id, err := configurationprofilehcrpassignments.ParseProviders2ConfigurationProfileAssignmentID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			resp, err := client.Get(ctx, *id)
+			if err != nil {
+				if response.WasNotFound(resp.HttpResponse) {
+					return metadata.MarkAsGone(id)
+				}
+
+				return fmt.Errorf("retrieving %s: %+v", *id, err)
+			}
+
+			state := ArcMachineAutomanageConfigurationAssignmentModel{
+				ArcMachineId: machines.NewMachineID(id.SubscriptionId, id.ResourceGroupName, id.MachineName).ID(),
+			}
+
+			if model := resp.Model; model != nil {
+				if props := model.Properties; props != nil && props.ConfigurationProfile != nil {
+					configurationId, err := configurationprofiles.ParseConfigurationProfileIDInsensitively(*props.ConfigurationProfile)
+					if err != nil {
+						return err
+					}
+					state.ConfigurationId = configurationId.ID()
+				}
+			}
+
+			return metadata.Encode(&state)
+		},
+	}
+}
+
+func (r ArcMachineAutomanageConfigurationAssignmentResource) Delete() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Automanage.ConfigurationProfileHCRPAssignmentsClient
+
+			id, err := configurationprofilehcrpassignments.ParseProviders2ConfigurationProfileAssignmentID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			if _, err := client.Delete(ctx, *id); err != nil {
+				return fmt.Errorf("deleting %s: %+v", *id, err)
+			}
+
+			return nil
+		},
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package hybridcompute_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/automanage/2022-05-04/configurationprofilehcrpassignments"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/check"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+type ArcMachineAutomanageConfigurationAssignmentResource struct{}
+
+func TestAccArcMachineAutomanageConfigurationAssignment_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_arc_machine_automanage_configuration_assignment", "test")
+	r := ArcMachineAutomanageConfigurationAssignmentResource{}
+	template := ArcMachineExtensionResource{}.template(data)
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data, template),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccArcMachineAutomanageConfigurationAssignment_requiresImport(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_arc_machine_automanage_configuration_assignment", "test")
+	r := ArcMachineAutomanageConfigurationAssignmentResource{}
+	template := ArcMachineExtensionResource{}.template(data)
+	basicConfig := r.basic(data, template)
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: basicConfig,
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		{
+			Config:      r.requiresImport(basicConfig),
+			ExpectError: acceptance.RequiresImportError("azurerm_arc_machine_automanage_configuration_assignment"),
+		},
+	})
+}
+
+func (r ArcMachineAutomanageConfigurationAssignmentResource) Exists(ctx context.Context, clients *clients.Client, state *pluginsdk.InstanceState) (*bool, error) {
+	id, err := configurationprofilehcrpassignments.ParseProviders2ConfigurationProfileAssignmentID(state.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := clients.Automanage.ConfigurationProfileHCRPAssignmentsClient.Get(ctx, *id)
+	if err != nil {
+		if response.WasNotFound(resp.HttpResponse) {
+			return pointer.To(false), nil
+		}
+		return nil, fmt.Errorf("retrieving %s: %+v", *id, err)
+	}
+	return pointer.To(resp.Model != nil), nil
+}
+
+func (r ArcMachineAutomanageConfigurationAssignmentResource) basic(data acceptance.TestData, template string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_automanage_configuration" "test" {
+  name                = "acctest-amcp-%d"
+  resource_group_name = azurerm_resource_group.test.name
+  location            = azurerm_resource_group.test.location
+}
+
+resource "azurerm_arc_machine_automanage_configuration_assignment" "test" {
+  arc_machine_id   = data.azurerm_arc_machine.test.id
+  configuration_id = azurerm_automanage_configuration.test.id
+}
+`, template, data.RandomInteger)
+}
+
+func (r ArcMachineAutomanageConfigurationAssignmentResource) requiresImport(basicConfig string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_arc_machine_automanage_configuration_assignment" "import" {
+  arc_machine_id   = azurerm_arc_machine_automanage_configuration_assignment.test.arc_machine_id
+  configuration_id = azurerm_arc_machine_automanage_configuration_assignment.test.configuration_id
+}
+`, basicConfig)
+}
@@ -0,0 +1,553 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package hybridcompute
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonschema"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/location"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/hybridcompute/2022-11-10/machines"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/hybridcompute/2024-05-20-preview/machineruncommands"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+)
+
+type ArcMachineRunCommandModel struct {
+	Name                      string                                  `tfschema:"name"`
+	ArcMachineId              string                                  `tfschema:"arc_machine_id"`
+	Location                  string                                  `tfschema:"location"`
+	Source                    []ArcMachineRunCommandSource            `tfschema:"source"`
+	Parameters                []ArcMachineRunCommandParameter         `tfschema:"parameter"`
+	ProtectedParameters       []ArcMachineRunCommandParameter         `tfschema:"protected_parameter"`
+	RunAsUser                 string                                  `tfschema:"run_as_user"`
+	RunAsPassword             string                                  `tfschema:"run_as_password"`
+	OutputBlobUri             string                                  `tfschema:"output_blob_uri"`
+	OutputBlobManagedIdentity []ArcMachineRunCommandManagedIdentity   `tfschema:"output_blob_managed_identity"`
+	ErrorBlobUri              string                                  `tfschema:"error_blob_uri"`
+	ErrorBlobManagedIdentity  []ArcMachineRunCommandManagedIdentity   `tfschema:"error_blob_managed_identity"`
+	Tags                      map[string]string                       `tfschema:"tags"`
+	InstanceView              []ArcMachineRunCommandInstanceViewModel `tfschema:"instance_view"`
+}
+
+type ArcMachineRunCommandSource struct {
+	CommandId                string                                `tfschema:"command_id"`
+	Script                   string                                `tfschema:"script"`
+	ScriptUri                string                                `tfschema:"script_uri"`
+	ScriptUriManagedIdentity []ArcMachineRunCommandManagedIdentity `tfschema:"script_uri_managed_identity"`
+}
+
+type ArcMachineRunCommandParameter struct {
+	Name  string `tfschema:"name"`
+	Value string `tfschema:"value"`
+}
+
+type ArcMachineRunCommandManagedIdentity struct {
+	ClientId string `tfschema:"client_id"`
+	ObjectId string `tfschema:"object_id"`
+}
+
+type ArcMachineRunCommandInstanceViewModel struct {
+	ExecutionState   string `tfschema:"execution_state"`
+	ExecutionMessage string `tfschema:"execution_message"`
+	ExitCode         int64  `tfschema:"exit_code"`
+	Output           string `tfschema:"output"`
+	Error            string `tfschema:"error"`
+	StartTime        string `tfschema:"start_time"`
+	EndTime          string `tfschema:"end_time"`
+}
+
+type ArcMachineRunCommandResource struct{}
+
+var _ sdk.ResourceWithUpdate = ArcMachineRunCommandResource{}
+
+func (r ArcMachineRunCommandResource) ResourceType() string {
+	return "azurerm_arc_machine_run_command"
+}
+
+func (r ArcMachineRunCommandResource) ModelObject() interface{} {
+	return &ArcMachineRunCommandModel{}
+}
+
+func (r ArcMachineRunCommandResource) IDValidationFunc() pluginsdk.SchemaValidateFunc {
+	return machineruncommands.ValidateRunCommandID
+}
+
+func (r ArcMachineRunCommandResource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"name": {
+			Type:     pluginsdk.TypeString,
+			Required: true,
+			ForceNew: true,
+			ValidateFunc: validation.All(
+				validation.StringIsNotEmpty,
+				validation.StringDoesNotContainAny("/"),
+			),
+		},
+
+		"arc_machine_id": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: machines.ValidateMachineID,
+		},
+
+		"location": commonschema.Location(),
+
+		"source": {
+			Type:     pluginsdk.TypeList,
+			Required: true,
+			MaxItems: 1,
+			Elem: &pluginsdk.Resource{
+				Schema: map[string]*pluginsdk.Schema{
+					"command_id": {
+						Type:         pluginsdk.TypeString,
+						Optional:     true,
+						ValidateFunc: validation.StringIsNotEmpty,
+						ExactlyOneOf: []string{"source.0.command_id", "source.0.script", "source.0.script_uri"},
+					},
+
+					"script": {
+						Type:         pluginsdk.TypeString,
+						Optional:     true,
+						ValidateFunc: validation.StringIsNotEmpty,
+						ExactlyOneOf: []string{"source.0.command_id", "source.0.script", "source.0.script_uri"},
+					},
+
+					"script_uri": {
+						Type:         pluginsdk.TypeString,
+						Optional:     true,
+						ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+						ExactlyOneOf: []string{"source.0.command_id", "source.0.script", "source.0.script_uri"},
+					},
+
+					"script_uri_managed_identity": arcMachineRunCommandManagedIdentitySchema(),
+				},
+			},
+		},
+
+		"parameter": arcMachineRunCommandParameterSchema(false),
+
+		"protected_parameter": arcMachineRunCommandParameterSchema(true),
+
+		"run_as_user": {
+			Type:         pluginsdk.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+		},
+
+		"run_as_password": {
+			Type:         pluginsdk.TypeString,
+			Optional:     true,
+			Sensitive:    true,
+			ValidateFunc: validation.StringIsNotEmpty,
+			RequiredWith: []string{"run_as_user"},
+		},
+
+		"output_blob_uri": {
+			Type:         pluginsdk.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+		},
+
+		"output_blob_managed_identity": arcMachineRunCommandManagedIdentitySchema(),
+
+		"error_blob_uri": {
+			Type:         pluginsdk.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+		},
+
+		"error_blob_managed_identity": arcMachineRunCommandManagedIdentitySchema(),
+
+		"tags": commonschema.Tags(),
+	}
+}
+
+func (r ArcMachineRunCommandResource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"instance_view": {
+			Type:     pluginsdk.TypeList,
+			Computed: true,
+			Elem: &pluginsdk.Resource{
+				Schema: map[string]*pluginsdk.Schema{
+					"execution_state": {
+						Type:     pluginsdk.TypeString,
+						Computed: true,
+					},
+
+					"execution_message": {
+						Type:     pluginsdk.TypeString,
+						Computed: true,
+					},
+
+					"exit_code": {
+						Type:     pluginsdk.TypeInt,
+						Computed: true,
+					},
+
+					"output": {
+						Type:     pluginsdk.TypeString,
+						Computed: true,
+					},
+
+					"error": {
+						Type:     pluginsdk.TypeString,
+						Computed: true,
+					},
+
+					"start_time": {
+						Type:     pluginsdk.TypeString,
+						Computed: true,
+					},
+
+					"end_time": {
+						Type:     pluginsdk.TypeString,
+						Computed: true,
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r ArcMachineRunCommandResource) Create() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.HybridCompute.MachineRunCommandsClient
+
+			var model ArcMachineRunCommandModel
+			if err := metadata.Decode(&model); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			machineId, err := machines.ParseMachineID(model.ArcMachineId)
+			if err != nil {
+				return err
+			}
+
+			id := machineruncommands.NewRunCommandID(machineId.SubscriptionId, machineId.ResourceGroupName, machineId.MachineName, model.Name)
+			existing, err := client.Get(ctx, id)
+			if err != nil && !response.WasNotFound(existing.HttpResponse) {
+				return fmt.Errorf("checking for existing %s: %+v", id, err)
+			}
+
+			if !response.WasNotFound(existing.HttpResponse) {
+				return metadata.ResourceRequiresImport(r.ResourceType(), id)
+			}
+
+			if err := client.CreateOrUpdateThenPoll(ctx, id, expandArcMachineRunCommand(model)); err != nil {
+				return fmt.Errorf("creating %s: %+v", id, err)
+			}
+
+			metadata.SetID(id)
+			return nil
+		},
+	}
+}
+
+func (r ArcMachineRunCommandResource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.HybridCompute.MachineRunCommandsClient
+
+			id, err := machineruncommands.ParseRunCommandID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			resp, err := client.Get(ctx, *id)
+			if err != nil {
+				if response.WasNotFound(resp.HttpResponse) {
+					return metadata.MarkAsGone(id)
+				}
+
+				return fmt.Errorf("retrieving %s: %+v", *id, err)
+			}
+
+			var config ArcMachineRunCommandModel
+			if err := metadata.Decode(&config); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			state := ArcMachineRunCommandModel{
+				Name:         id.RunCommandName,
+				ArcMachineId: machines.NewMachineID(id.SubscriptionId, id.ResourceGroupName, id.MachineName).ID(),
+				// the API doesn't return these sensitive values, so they're kept from the config
+				ProtectedParameters: config.ProtectedParameters,
+				RunAsPassword:       config.RunAsPassword,
+			}
+
+			if model := resp.Model; model != nil {
+				state.Location = location.Normalize(model.Location)
+				state.Tags = pointer.From(model.Tags)
+
+				if props := model.Properties; props != nil {
+					state.Source = flattenArcMachineRunCommandSource(props.Source)
+					state.Parameters = flattenArcMachineRunCommandParameters(props.Parameters)
+					state.RunAsUser = pointer.From(props.RunAsUser)
+					state.OutputBlobManagedIdentity = flattenArcMachineRunCommandManagedIdentity(props.OutputBlobManagedIdentity)
+					state.ErrorBlobManagedIdentity = flattenArcMachineRunCommandManagedIdentity(props.ErrorBlobManagedIdentity)
+					state.InstanceView = flattenArcMachineRunCommandInstanceView(props.InstanceView)
+
+					// the blob URIs are returned with a SAS token appended, so the configured values are retained
+					state.OutputBlobUri = config.OutputBlobUri
+					state.ErrorBlobUri = config.ErrorBlobUri
+				}
+			}
+
+			return metadata.Encode(&state)
+		},
+	}
+}
+
+func (r ArcMachineRunCommandResource) Update() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.HybridCompute.MachineRunCommandsClient
+
+			id, err := machineruncommands.ParseRunCommandID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			var model ArcMachineRunCommandModel
+			if err := metadata.Decode(&model); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			// the script is re-run whenever the Run Command is updated, so the full payload is sent
+			if err := client.CreateOrUpdateThenPoll(ctx, *id, expandArcMachineRunCommand(model)); err != nil {
+				return fmt.Errorf("updating %s: %+v", *id, err)
+			}
+
+			return nil
+		},
+	}
+}
+
+func (r ArcMachineRunCommandResource) Delete() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.HybridCompute.MachineRunCommandsClient
+
+			id, err := machineruncommands.ParseRunCommandID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			if err := client.DeleteThenPoll(ctx, *id); err != nil {
+				return fmt.Errorf("deleting %s: %+v", *id, err)
+			}
+
+			return nil
+		},
+	}
+}
+
+func arcMachineRunCommandManagedIdentitySchema() *pluginsdk.Schema {
+	return &pluginsdk.Schema{
+		Type:     pluginsdk.TypeList,
+		Optional: true,
+		MaxItems: 1,
+		Elem: &pluginsdk.Resource{
+			Schema: map[string]*pluginsdk.Schema{
+				"client_id": {
+					Type:         pluginsdk.TypeString,
+					Optional:     true,
+					ValidateFunc: validation.IsUUID,
+				},
+
+				"object_id": {
+					Type:         pluginsdk.TypeString,
+					Optional:     true,
+					ValidateFunc: validation.IsUUID,
+				},
+			},
+		},
+	}
+}
+
+func arcMachineRunCommandParameterSchema(sensitive bool) *pluginsdk.Schema {
+	return &pluginsdk.Schema{
+		Type:      pluginsdk.TypeList,
+		Optional:  true,
+		Sensitive: sensitive,
+		Elem: &pluginsdk.Resource{
+			Schema: map[string]*pluginsdk.Schema{
+				"name": {
+					Type:         pluginsdk.TypeString,
+					Required:     true,
+					ValidateFunc: validation.StringIsNotEmpty,
+				},
+
+				"value": {
+					Type:         pluginsdk.TypeString,
+					Required:     true,
+					Sensitive:    sensitive,
+					ValidateFunc: validation.StringIsNotEmpty,
+				},
+			},
+		},
+	}
+}
+
+func expandArcMachineRunCommand(input ArcMachineRunCommandModel) machineruncommands.MachineRunCommand {
+	props := machineruncommands.MachineRunCommandProperties{
+		AsyncExecution:            pointer.To(false),
+		Source:                    expandArcMachineRunCommandSource(input.Source),
+		Parameters:                expandArcMachineRunCommandParameters(input.Parameters),
+		ProtectedParameters:       expandArcMachineRunCommandParameters(input.ProtectedParameters),
+		OutputBlobManagedIdentity: expandArcMachineRunCommandManagedIdentity(input.OutputBlobManagedIdentity),
+		ErrorBlobManagedIdentity:  expandArcMachineRunCommandManagedIdentity(input.ErrorBlobManagedIdentity),
+	}
+
+	if input.RunAsUser != "" {
+		props.RunAsUser = pointer.To(input.RunAsUser)
+	}
+
+	if input.RunAsPassword != "" {
+		props.RunAsPassword = pointer.To(input.RunAsPassword)
+	}
+
+	if input.OutputBlobUri != "" {
+		props.OutputBlobUri = pointer.To(input.OutputBlobUri)
+	}
+
+	if input.ErrorBlobUri != "" {
+		props.ErrorBlobUri = pointer.To(input.ErrorBlobUri)
+	}
+
+	return machineruncommands.MachineRunCommand{
+		Location:   location.Normalize(input.Location),
+		Properties: &props,
+		Tags:       pointer.To(input.Tags),
+	}
+}
+
+func expandArcMachineRunCommandSource(input []ArcMachineRunCommandSource) *machineruncommands.MachineRunCommandScriptSource {
+	if len(input) == 0 {
+		return nil
+	}
+
+	source := input[0]
+	output := machineruncommands.MachineRunCommandScriptSource{
+		ScriptUriManagedIdentity: expandArcMachineRunCommandManagedIdentity(source.ScriptUriManagedIdentity),
+	}
+
+	if source.CommandId != "" {
+		output.CommandId = pointer.To(source.CommandId)
+	}
+
+	if source.Script != "" {
+		output.Script = pointer.To(source.Script)
+	}
+
+	if source.ScriptUri != "" {
+		output.ScriptUri = pointer.To(source.ScriptUri)
+	}
+
+	return &output
+}
+
+func expandArcMachineRunCommandParameters(input []ArcMachineRunCommandParameter) *[]machineruncommands.RunCommandInputParameter {
+	output := make([]machineruncommands.RunCommandInputParameter, 0)
+	for _, v := range input {
+		output = append(output, machineruncommands.RunCommandInputParameter{
+			Name:  v.Name,
+			Value: v.Value,
+		})
+	}
+
+	return &output
+}
+
+func expandArcMachineRunCommandManagedIdentity(input []ArcMachineRunCommandManagedIdentity) *machineruncommands.RunCommandManagedIdentity {
+	if len(input) == 0 {
+		return nil
+	}
+
+	output := machineruncommands.RunCommandManagedIdentity{}
+
+	if v := input[0].ClientId; v != "" {
+		output.ClientId = pointer.To(v)
+	}
+
+	if v := input[0].ObjectId; v != "" {
+		output.ObjectId = pointer.To(v)
+	}
+
+	return &output
+}
+
+func flattenArcMachineRunCommandSource(input *machineruncommands.MachineRunCommandScriptSource) []ArcMachineRunCommandSource {
+	if input == nil {
+		return []ArcMachineRunCommandSource{}
+	}
+
+	return []ArcMachineRunCommandSource{
+		{
+			CommandId:                pointer.From(input.CommandId),
+			Script:                   pointer.From(input.Script),
+			ScriptUri:                pointer.From(input.ScriptUri),
+			ScriptUriManagedIdentity: flattenArcMachineRunCommandManagedIdentity(input.ScriptUriManagedIdentity),
+		},
+	}
+}
+
+func flattenArcMachineRunCommandParameters(input *[]machineruncommands.RunCommandInputParameter) []ArcMachineRunCommandParameter {
+	output := make([]ArcMachineRunCommandParameter, 0)
+	if input == nil {
+		return output
+	}
+
+	for _, v := range *input {
+		output = append(output, ArcMachineRunCommandParameter{
+			Name:  v.Name,
+			Value: v.Value,
+		})
+	}
+
+	return output
+}
+
+func flattenArcMachineRunCommandManagedIdentity(input *machineruncommands.RunCommandManagedIdentity) []ArcMachineRunCommandManagedIdentity {
+	if input == nil || (input.ClientId == nil && input.ObjectId == nil) {
+		return []ArcMachineRunCommandManagedIdentity{}
+	}
+
+	return []ArcMachineRunCommandManagedIdentity{
+		{
+			ClientId: pointer.From(input.ClientId),
+			ObjectId: pointer.From(input.ObjectId),
+		},
+	}
+}
+
+func flattenArcMachineRunCommandInstanceView(input *machineruncommands.MachineRunCommandInstanceView) []ArcMachineRunCommandInstanceViewModel {
+	if input == nil {
+		return []ArcMachineRunCommandInstanceViewModel{}
+	}
+
+	return []ArcMachineRunCommandInstanceViewModel{
+		{
+			ExecutionState:   string(pointer.From(input.ExecutionState)),
+			ExecutionMessage: pointer.From(input.ExecutionMessage),
+			ExitCode:         pointer.From(input.ExitCode),
+			Output:           pointer.From(input.Output),
+			Error:            pointer.From(input.Error),
+			StartTime:        pointer.From(input.StartTime),
+			EndTime:          pointer.From(input.EndTime),
+		},
+	}
+}
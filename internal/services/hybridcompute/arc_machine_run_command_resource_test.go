@@ -0,0 +1,162 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package hybridcompute_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/hybridcompute/2024-05-20-preview/machineruncommands"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/check"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+type ArcMachineRunCommandResource struct{}
+
+func TestAccArcMachineRunCommand_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_arc_machine_run_command", "test")
+	r := ArcMachineRunCommandResource{}
+	template := ArcMachineExtensionResource{}.template(data)
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data, template),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("instance_view.0.execution_state").HasValue("Succeeded"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccArcMachineRunCommand_requiresImport(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_arc_machine_run_command", "test")
+	r := ArcMachineRunCommandResource{}
+	template := ArcMachineExtensionResource{}.template(data)
+	basicConfig := r.basic(data, template)
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: basicConfig,
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		{
+			Config:      r.requiresImport(basicConfig),
+			ExpectError: acceptance.RequiresImportError("azurerm_arc_machine_run_command"),
+		},
+	})
+}
+
+func TestAccArcMachineRunCommand_update(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_arc_machine_run_command", "test")
+	r := ArcMachineRunCommandResource{}
+	template := ArcMachineExtensionResource{}.template(data)
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data, template),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.complete(data, template),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep("protected_parameter"),
+		{
+			Config: r.basic(data, template),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func (r ArcMachineRunCommandResource) Exists(ctx context.Context, clients *clients.Client, state *pluginsdk.InstanceState) (*bool, error) {
+	id, err := machineruncommands.ParseRunCommandID(state.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := clients.HybridCompute.MachineRunCommandsClient.Get(ctx, *id)
+	if err != nil {
+		if response.WasNotFound(resp.HttpResponse) {
+			return pointer.To(false), nil
+		}
+		return nil, fmt.Errorf("retrieving %s: %+v", *id, err)
+	}
+	return pointer.To(resp.Model != nil), nil
+}
+
+func (r ArcMachineRunCommandResource) basic(data acceptance.TestData, template string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_arc_machine_run_command" "test" {
+  name           = "acctest-amrc-%d"
+  arc_machine_id = data.azurerm_arc_machine.test.id
+  location       = azurerm_resource_group.test.location
+
+  source {
+    script = "echo 'hello world'"
+  }
+}
+`, template, data.RandomInteger)
+}
+
+func (r ArcMachineRunCommandResource) requiresImport(basicConfig string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_arc_machine_run_command" "import" {
+  name           = azurerm_arc_machine_run_command.test.name
+  arc_machine_id = azurerm_arc_machine_run_command.test.arc_machine_id
+  location       = azurerm_arc_machine_run_command.test.location
+
+  source {
+    script = "echo 'hello world'"
+  }
+}
+`, basicConfig)
+}
+
+func (r ArcMachineRunCommandResource) complete(data acceptance.TestData, template string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_arc_machine_run_command" "test" {
+  name           = "acctest-amrc-%d"
+  arc_machine_id = data.azurerm_arc_machine.test.id
+  location       = azurerm_resource_group.test.location
+
+  source {
+    script = "echo $GREETING $SECRET > /dev/null"
+  }
+
+  parameter {
+    name  = "GREETING"
+    value = "hello"
+  }
+
+  protected_parameter {
+    name  = "SECRET"
+    value = "world"
+  }
+
+  tags = {
+    Environment = "Staging"
+  }
+}
+`, template, data.RandomInteger)
+}
@@ -10,23 +10,55 @@ import (
 	"github.com/hashicorp/go-azure-sdk/resource-manager/hybridcompute/2022-11-10/machines"
 	"github.com/hashicorp/go-azure-sdk/resource-manager/hybridcompute/2022-11-10/privateendpointconnections"
 	"github.com/hashicorp/go-azure-sdk/resource-manager/hybridcompute/2022-11-10/privatelinkscopes"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/hybridcompute/2024-05-20-preview/gateways"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/hybridcompute/2024-05-20-preview/licenseprofiles"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/hybridcompute/2024-05-20-preview/licenses"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/hybridcompute/2024-05-20-preview/machineruncommands"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/common"
 )
 
 type Client struct {
+	GatewaysClient                   *gateways.GatewaysClient
+	LicenseProfilesClient            *licenseprofiles.LicenseProfilesClient
+	LicensesClient                   *licenses.LicensesClient
 	MachineExtensionsClient          *machineextensions.MachineExtensionsClient
+	MachineRunCommandsClient         *machineruncommands.MachineRunCommandsClient
 	MachinesClient                   *machines.MachinesClient
 	PrivateEndpointConnectionsClient *privateendpointconnections.PrivateEndpointConnectionsClient
 	PrivateLinkScopesClient          *privatelinkscopes.PrivateLinkScopesClient
 }
 
 func NewClient(o *common.ClientOptions) (*Client, error) {
+	gatewaysClient, err := gateways.NewGatewaysClientWithBaseURI(o.Environment.ResourceManager)
+	if err != nil {
+		return nil, fmt.Errorf("building Gateways client: %+v", err)
+	}
+	o.Configure(gatewaysClient.Client, o.Authorizers.ResourceManager)
+
+	licenseProfilesClient, err := licenseprofiles.NewLicenseProfilesClientWithBaseURI(o.Environment.ResourceManager)
+	if err != nil {
+		return nil, fmt.Errorf("building LicenseProfiles client: %+v", err)
+	}
+	o.Configure(licenseProfilesClient.Client, o.Authorizers.ResourceManager)
+
+	licensesClient, err := licenses.NewLicensesClientWithBaseURI(o.Environment.ResourceManager)
+	if err != nil {
+		return nil, fmt.Errorf("building Licenses client: %+v", err)
+	}
+	o.Configure(licensesClient.Client, o.Authorizers.ResourceManager)
+
 	machineExtensionsClient, err := machineextensions.NewMachineExtensionsClientWithBaseURI(o.Environment.ResourceManager)
 	if err != nil {
 		return nil, fmt.Errorf("building MachineExtensions client: %+v", err)
 	}
 	o.Configure(machineExtensionsClient.Client, o.Authorizers.ResourceManager)
 
+	machineRunCommandsClient, err := machineruncommands.NewMachineRunCommandsClientWithBaseURI(o.Environment.ResourceManager)
+	if err != nil {
+		return nil, fmt.Errorf("building MachineRunCommands client: %+v", err)
+	}
+	o.Configure(machineRunCommandsClient.Client, o.Authorizers.ResourceManager)
+
 	machinesClient, err := machines.NewMachinesClientWithBaseURI(o.Environment.ResourceManager)
 	if err != nil {
 		return nil, fmt.Errorf("building Machines client: %+v", err)
@@ -46,7 +78,11 @@ func NewClient(o *common.ClientOptions) (*Client, error) {
 	o.Configure(privateLinkScopesClient.Client, o.Authorizers.ResourceManager)
 
 	return &Client{
+		GatewaysClient:                   gatewaysClient,
+		LicenseProfilesClient:            licenseProfilesClient,
+		LicensesClient:                   licensesClient,
 		MachineExtensionsClient:          machineExtensionsClient,
+		MachineRunCommandsClient:         machineRunCommandsClient,
 		MachinesClient:                   machinesClient,
 		PrivateEndpointConnectionsClient: privateEndpointConnectionsClient,
 		PrivateLinkScopesClient:          privateLinkScopesClient,
@@ -0,0 +1,282 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package hybridcompute
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/hybridcompute/2022-11-10/machines"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/hybridcompute/2024-05-20-preview/licenseprofiles"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/hybridcompute/2024-05-20-preview/licenses"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/hybridcompute/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/hybridcompute/validate"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+type HybridComputeLicenseProfileModel struct {
+	ArcMachineId                     string `tfschema:"arc_machine_id"`
+	EsuLicenseId                     string `tfschema:"esu_license_id"`
+	SoftwareAssuranceCustomerEnabled bool   `tfschema:"software_assurance_customer_enabled"`
+	EsuEligibility                   string `tfschema:"esu_eligibility"`
+	EsuKeyState                      string `tfschema:"esu_key_state"`
+	ServerType                       string `tfschema:"server_type"`
+}
+
+type HybridComputeLicenseProfileResource struct{}
+
+var _ sdk.ResourceWithUpdate = HybridComputeLicenseProfileResource{}
+
+func (r HybridComputeLicenseProfileResource) ResourceType() string {
+	return "azurerm_hybrid_compute_license_profile"
+}
+
+func (r HybridComputeLicenseProfileResource) ModelObject() interface{} {
+	return &HybridComputeLicenseProfileModel{}
+}
+
+func (r HybridComputeLicenseProfileResource) IDValidationFunc() pluginsdk.SchemaValidateFunc {
+	return validate.LicenseProfileID
+}
+
+func (r HybridComputeLicenseProfileResource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"arc_machine_id": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: machines.ValidateMachineID,
+		},
+
+		"esu_license_id": {
+			Type:         pluginsdk.TypeString,
+			Optional:     true,
+			ValidateFunc: licenses.ValidateLicenseID,
+		},
+
+		"software_assurance_customer_enabled": {
+			Type:     pluginsdk.TypeBool,
+			Optional: true,
+			Default:  false,
+		},
+	}
+}
+
+func (r HybridComputeLicenseProfileResource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"esu_eligibility": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+
+		"esu_key_state": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+
+		"server_type": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+	}
+}
+
+func (r HybridComputeLicenseProfileResource) Create() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.HybridCompute.LicenseProfilesClient
+			machinesClient := metadata.Client.HybridCompute.MachinesClient
+
+			var model HybridComputeLicenseProfileModel
+			if err := metadata.Decode(&model); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			machineId, err := machines.ParseMachineID(model.ArcMachineId)
+			if err != nil {
+				return err
+			}
+
+			// the license profile name has to be `default` by API requirement
+			id := parse.NewLicenseProfileID(machineId.SubscriptionId, machineId.ResourceGroupName, machineId.MachineName, "default")
+			profileMachineId := licenseprofiles.NewMachineID(id.SubscriptionId, id.ResourceGroupName, id.MachineName)
+			existing, err := client.Get(ctx, profileMachineId)
+			if err != nil && !response.WasNotFound(existing.HttpResponse) {
+				return fmt.Errorf("checking for existing %s: %+v", id, err)
+			}
+
+			if !response.WasNotFound(existing.HttpResponse) {
+				return metadata.ResourceRequiresImport(r.ResourceType(), id)
+			}
+
+			// the license profile has to be created in the same location as the Arc Machine
+			machine, err := machinesClient.Get(ctx, *machineId, machines.DefaultGetOperationOptions())
+			if err != nil {
+				return fmt.Errorf("retrieving %s: %+v", *machineId, err)
+			}
+			if machine.Model == nil {
+				return fmt.Errorf("retrieving %s: `model` was nil", *machineId)
+			}
+
+			parameters := licenseprofiles.LicenseProfile{
+				Location: machine.Model.Location,
+				Properties: &licenseprofiles.LicenseProfileProperties{
+					SoftwareAssurance: &licenseprofiles.LicenseProfilePropertiesSoftwareAssurance{
+						SoftwareAssuranceCustomer: pointer.To(model.SoftwareAssuranceCustomerEnabled),
+					},
+				},
+			}
+
+			if model.EsuLicenseId != "" {
+				parameters.Properties.EsuProfile = &licenseprofiles.LicenseProfileArmEsuProperties{
+					AssignedLicense: pointer.To(model.EsuLicenseId),
+				}
+			}
+
+			if err := client.CreateOrUpdateThenPoll(ctx, profileMachineId, parameters); err != nil {
+				return fmt.Errorf("creating %s: %+v", id, err)
+			}
+
+			metadata.SetID(id)
+			return nil
+		},
+	}
+}
+
+func (r HybridComputeLicenseProfileResource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.HybridCompute.LicenseProfilesClient
+
+			id, err := parse.LicenseProfileID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+			profileMachineId := licenseprofiles.NewMachineID(id.SubscriptionId, id.ResourceGroupName, id.MachineName)
+
+			resp, err := client.Get(ctx, profileMachineId)
+			if err != nil {
+				if response.WasNotFound(resp.HttpResponse) {
+					return metadata.MarkAsGone(id)
+				}
+
+				return fmt.Errorf("retrieving %s: %+v", *id, err)
+			}
+
+			state := HybridComputeLicenseProfileModel{
+				ArcMachineId: machines.NewMachineID(id.SubscriptionId, id.ResourceGroupName, id.MachineName).ID(),
+			}
+
+			if model := resp.Model; model != nil {
+				if props := model.Properties; props != nil {
+					if esu := props.EsuProfile; esu != nil {
+						if v := pointer.From(esu.AssignedLicense); v != "" {
+							licenseId, err := licenses.ParseLicenseIDInsensitively(v)
+							if err != nil {
+								return err
+							}
+							state.EsuLicenseId = licenseId.ID()
+						}
+
+						state.EsuEligibility = string(pointer.From(esu.EsuEligibility))
+						state.EsuKeyState = string(pointer.From(esu.EsuKeyState))
+						state.ServerType = string(pointer.From(esu.ServerType))
+					}
+
+					if sa := props.SoftwareAssurance; sa != nil {
+						state.SoftwareAssuranceCustomerEnabled = pointer.From(sa.SoftwareAssuranceCustomer)
+					}
+				}
+			}
+
+			return metadata.Encode(&state)
+		},
+	}
+}
+
+func (r HybridComputeLicenseProfileResource) Update() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.HybridCompute.LicenseProfilesClient
+
+			id, err := parse.LicenseProfileID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+			profileMachineId := licenseprofiles.NewMachineID(id.SubscriptionId, id.ResourceGroupName, id.MachineName)
+
+			var model HybridComputeLicenseProfileModel
+			if err := metadata.Decode(&model); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			resp, err := client.Get(ctx, profileMachineId)
+			if err != nil {
+				return fmt.Errorf("retrieving %s: %+v", *id, err)
+			}
+
+			existing := resp.Model
+			if existing == nil {
+				return fmt.Errorf("retrieving %s: `model` was nil", *id)
+			}
+			if existing.Properties == nil {
+				existing.Properties = &licenseprofiles.LicenseProfileProperties{}
+			}
+
+			if metadata.ResourceData.HasChange("esu_license_id") {
+				// an empty value unassigns the license from the machine
+				existing.Properties.EsuProfile = &licenseprofiles.LicenseProfileArmEsuProperties{
+					AssignedLicense: pointer.To(model.EsuLicenseId),
+				}
+			} else if existing.Properties.EsuProfile != nil {
+				// only the assigned license can be sent, the remaining properties are read-only
+				existing.Properties.EsuProfile = &licenseprofiles.LicenseProfileArmEsuProperties{
+					AssignedLicense: existing.Properties.EsuProfile.AssignedLicense,
+				}
+			}
+
+			if metadata.ResourceData.HasChange("software_assurance_customer_enabled") {
+				existing.Properties.SoftwareAssurance = &licenseprofiles.LicenseProfilePropertiesSoftwareAssurance{
+					SoftwareAssuranceCustomer: pointer.To(model.SoftwareAssuranceCustomerEnabled),
+				}
+			}
+
+			existing.SystemData = nil
+
+			if err := client.CreateOrUpdateThenPoll(ctx, profileMachineId, *existing); err != nil {
+				return fmt.Errorf("updating %s: %+v", *id, err)
+			}
+
+			return nil
+		},
+	}
+}
+
+func (r HybridComputeLicenseProfileResource) Delete() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.HybridCompute.LicenseProfilesClient
+
+			id, err := parse.LicenseProfileID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+			profileMachineId := licenseprofiles.NewMachineID(id.SubscriptionId, id.ResourceGroupName, id.MachineName)
+
+			if err := client.DeleteThenPoll(ctx, profileMachineId); err != nil {
+				return fmt.Errorf("deleting %s: %+v", *id, err)
+			}
+
+			return nil
+		},
+	}
+}
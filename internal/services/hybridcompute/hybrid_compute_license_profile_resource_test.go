@@ -0,0 +1,140 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package hybridcompute_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/hybridcompute/2024-05-20-preview/licenseprofiles"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/check"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/hybridcompute/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+type HybridComputeLicenseProfileResource struct{}
+
+func TestAccHybridComputeLicenseProfile_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_hybrid_compute_license_profile", "test")
+	r := HybridComputeLicenseProfileResource{}
+	template := ArcMachineExtensionResource{}.template(data)
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data, template),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccHybridComputeLicenseProfile_requiresImport(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_hybrid_compute_license_profile", "test")
+	r := HybridComputeLicenseProfileResource{}
+	template := ArcMachineExtensionResource{}.template(data)
+	basicConfig := r.basic(data, template)
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: basicConfig,
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		{
+			Config:      r.requiresImport(basicConfig),
+			ExpectError: acceptance.RequiresImportError("azurerm_hybrid_compute_license_profile"),
+		},
+	})
+}
+
+func TestAccHybridComputeLicenseProfile_update(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_hybrid_compute_license_profile", "test")
+	r := HybridComputeLicenseProfileResource{}
+	template := ArcMachineExtensionResource{}.template(data)
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data, template),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.complete(data, template),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("software_assurance_customer_enabled").HasValue("true"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func (r HybridComputeLicenseProfileResource) Exists(ctx context.Context, clients *clients.Client, state *pluginsdk.InstanceState) (*bool, error) {
+	id, err := parse.LicenseProfileID(state.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := clients.HybridCompute.LicenseProfilesClient.Get(ctx, licenseprofiles.NewMachineID(id.SubscriptionId, id.ResourceGroupName, id.MachineName))
+	if err != nil {
+		if response.WasNotFound(resp.HttpResponse) {
+			return pointer.To(false), nil
+		}
+		return nil, fmt.Errorf("retrieving %s: %+v", *id, err)
+	}
+	return pointer.To(resp.Model != nil), nil
+}
+
+func (r HybridComputeLicenseProfileResource) basic(data acceptance.TestData, template string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_hybrid_compute_license_profile" "test" {
+  arc_machine_id = data.azurerm_arc_machine.test.id
+}
+`, template)
+}
+
+func (r HybridComputeLicenseProfileResource) requiresImport(basicConfig string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_hybrid_compute_license_profile" "import" {
+  arc_machine_id = azurerm_hybrid_compute_license_profile.test.arc_machine_id
+}
+`, basicConfig)
+}
+
+func (r HybridComputeLicenseProfileResource) complete(data acceptance.TestData, template string) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "azurerm_hybrid_compute_license" "test" {
+  name                = "acctest-hcl-%[2]d"
+  resource_group_name = azurerm_resource_group.test.name
+  location            = azurerm_resource_group.test.location
+
+  license_details {
+    state      = "Activated"
+    target     = "Windows Server 2012"
+    edition    = "Standard"
+    type       = "pCore"
+    processors = 8
+  }
+}
+
+resource "azurerm_hybrid_compute_license_profile" "test" {
+  arc_machine_id                      = data.azurerm_arc_machine.test.id
+  esu_license_id                      = azurerm_hybrid_compute_license.test.id
+  software_assurance_customer_enabled = true
+}
+`, template, data.RandomInteger)
+}
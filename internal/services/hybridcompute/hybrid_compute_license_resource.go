@@ -0,0 +1,327 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package hybridcompute
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonschema"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/location"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/hybridcompute/2024-05-20-preview/licenses"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+)
+
+type HybridComputeLicenseModel struct {
+	Name              string                        `tfschema:"name"`
+	ResourceGroupName string                        `tfschema:"resource_group_name"`
+	Location          string                        `tfschema:"location"`
+	LicenseDetails    []HybridComputeLicenseDetails `tfschema:"license_details"`
+	LicenseType       string                        `tfschema:"license_type"`
+	Tags              map[string]string             `tfschema:"tags"`
+	TenantId          string                        `tfschema:"tenant_id"`
+}
+
+type HybridComputeLicenseDetails struct {
+	Edition          string `tfschema:"edition"`
+	Processors       int64  `tfschema:"processors"`
+	State            string `tfschema:"state"`
+	Target           string `tfschema:"target"`
+	Type             string `tfschema:"type"`
+	AssignedLicenses int64  `tfschema:"assigned_licenses"`
+	ImmutableId      string `tfschema:"immutable_id"`
+}
+
+type HybridComputeLicenseResource struct{}
+
+var _ sdk.ResourceWithUpdate = HybridComputeLicenseResource{}
+
+func (r HybridComputeLicenseResource) ResourceType() string {
+	return "azurerm_hybrid_compute_license"
+}
+
+func (r HybridComputeLicenseResource) ModelObject() interface{} {
+	return &HybridComputeLicenseModel{}
+}
+
+func (r HybridComputeLicenseResource) IDValidationFunc() pluginsdk.SchemaValidateFunc {
+	return licenses.ValidateLicenseID
+}
+
+func (r HybridComputeLicenseResource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"name": {
+			Type:     pluginsdk.TypeString,
+			Required: true,
+			ForceNew: true,
+			ValidateFunc: validation.All(
+				validation.StringIsNotEmpty,
+				validation.StringDoesNotContainAny("/"),
+			),
+		},
+
+		"resource_group_name": commonschema.ResourceGroupName(),
+
+		"location": commonschema.Location(),
+
+		"license_details": {
+			Type:     pluginsdk.TypeList,
+			Required: true,
+			MaxItems: 1,
+			Elem: &pluginsdk.Resource{
+				Schema: map[string]*pluginsdk.Schema{
+					"edition": {
+						Type:         pluginsdk.TypeString,
+						Required:     true,
+						ValidateFunc: validation.StringInSlice(licenses.PossibleValuesForLicenseEdition(), false),
+					},
+
+					"processors": {
+						Type:         pluginsdk.TypeInt,
+						Required:     true,
+						ValidateFunc: validation.IntAtLeast(1),
+					},
+
+					"state": {
+						Type:         pluginsdk.TypeString,
+						Required:     true,
+						ValidateFunc: validation.StringInSlice(licenses.PossibleValuesForLicenseState(), false),
+					},
+
+					// the target and core type of an ESU license can't be changed once it's been created
+					"target": {
+						Type:         pluginsdk.TypeString,
+						Required:     true,
+						ForceNew:     true,
+						ValidateFunc: validation.StringInSlice(licenses.PossibleValuesForLicenseTarget(), false),
+					},
+
+					"type": {
+						Type:         pluginsdk.TypeString,
+						Required:     true,
+						ForceNew:     true,
+						ValidateFunc: validation.StringInSlice(licenses.PossibleValuesForLicenseCoreType(), false),
+					},
+
+					"assigned_licenses": {
+						Type:     pluginsdk.TypeInt,
+						Computed: true,
+					},
+
+					"immutable_id": {
+						Type:     pluginsdk.TypeString,
+						Computed: true,
+					},
+				},
+			},
+		},
+
+		"license_type": {
+			Type:         pluginsdk.TypeString,
+			Optional:     true,
+			ForceNew:     true,
+			Default:      string(licenses.LicenseTypeESU),
+			ValidateFunc: validation.StringInSlice(licenses.PossibleValuesForLicenseType(), false),
+		},
+
+		"tags": commonschema.Tags(),
+	}
+}
+
+func (r HybridComputeLicenseResource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"tenant_id": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+	}
+}
+
+func (r HybridComputeLicenseResource) Create() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.HybridCompute.LicensesClient
+			subscriptionId := metadata.Client.Account.SubscriptionId
+
+			var model HybridComputeLicenseModel
+			if err := metadata.Decode(&model); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			id := licenses.NewLicenseID(subscriptionId, model.ResourceGroupName, model.Name)
+			existing, err := client.Get(ctx, id)
+			if err != nil && !response.WasNotFound(existing.HttpResponse) {
+				return fmt.Errorf("checking for existing %s: %+v", id, err)
+			}
+
+			if !response.WasNotFound(existing.HttpResponse) {
+				return metadata.ResourceRequiresImport(r.ResourceType(), id)
+			}
+
+			parameters := licenses.License{
+				Location: location.Normalize(model.Location),
+				Properties: &licenses.LicenseProperties{
+					LicenseDetails: expandHybridComputeLicenseDetails(model.LicenseDetails),
+					LicenseType:    pointer.To(licenses.LicenseType(model.LicenseType)),
+				},
+				Tags: pointer.To(model.Tags),
+			}
+
+			if err := client.CreateOrUpdateThenPoll(ctx, id, parameters); err != nil {
+				return fmt.Errorf("creating %s: %+v", id, err)
+			}
+
+			metadata.SetID(id)
+			return nil
+		},
+	}
+}
+
+func (r HybridComputeLicenseResource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.HybridCompute.LicensesClient
+
+			id, err := licenses.ParseLicenseID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			resp, err := client.Get(ctx, *id)
+			if err != nil {
+				if response.WasNotFound(resp.HttpResponse) {
+					return metadata.MarkAsGone(id)
+				}
+
+				return fmt.Errorf("retrieving %s: %+v", *id, err)
+			}
+
+			state := HybridComputeLicenseModel{
+				Name:              id.LicenseName,
+				ResourceGroupName: id.ResourceGroupName,
+			}
+
+			if model := resp.Model; model != nil {
+				state.Location = location.Normalize(model.Location)
+				state.Tags = pointer.From(model.Tags)
+
+				if props := model.Properties; props != nil {
+					state.LicenseDetails = flattenHybridComputeLicenseDetails(props.LicenseDetails)
+					state.LicenseType = string(pointer.From(props.LicenseType))
+					state.TenantId = pointer.From(props.TenantId)
+				}
+			}
+
+			return metadata.Encode(&state)
+		},
+	}
+}
+
+func (r HybridComputeLicenseResource) Update() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.HybridCompute.LicensesClient
+
+			id, err := licenses.ParseLicenseID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			var model HybridComputeLicenseModel
+			if err := metadata.Decode(&model); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			resp, err := client.Get(ctx, *id)
+			if err != nil {
+				return fmt.Errorf("retrieving %s: %+v", *id, err)
+			}
+
+			existing := resp.Model
+			if existing == nil {
+				return fmt.Errorf("retrieving %s: `model` was nil", *id)
+			}
+			if existing.Properties == nil {
+				return fmt.Errorf("retrieving %s: `properties` was nil", *id)
+			}
+
+			if metadata.ResourceData.HasChange("license_details") {
+				existing.Properties.LicenseDetails = expandHybridComputeLicenseDetails(model.LicenseDetails)
+			}
+
+			if metadata.ResourceData.HasChange("tags") {
+				existing.Tags = pointer.To(model.Tags)
+			}
+
+			existing.SystemData = nil
+
+			if err := client.CreateOrUpdateThenPoll(ctx, *id, *existing); err != nil {
+				return fmt.Errorf("updating %s: %+v", *id, err)
+			}
+
+			return nil
+		},
+	}
+}
+
+func (r HybridComputeLicenseResource) Delete() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.HybridCompute.LicensesClient
+
+			id, err := licenses.ParseLicenseID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			if err := client.DeleteThenPoll(ctx, *id); err != nil {
+				return fmt.Errorf("deleting %s: %+v", *id, err)
+			}
+
+			return nil
+		},
+	}
+}
+
+func expandHybridComputeLicenseDetails(input []HybridComputeLicenseDetails) *licenses.LicenseDetails {
+	if len(input) == 0 {
+		return nil
+	}
+
+	details := input[0]
+	return &licenses.LicenseDetails{
+		Edition:    pointer.To(licenses.LicenseEdition(details.Edition)),
+		Processors: pointer.To(details.Processors),
+		State:      pointer.To(licenses.LicenseState(details.State)),
+		Target:     pointer.To(licenses.LicenseTarget(details.Target)),
+		Type:       pointer.To(licenses.LicenseCoreType(details.Type)),
+	}
+}
+
+func flattenHybridComputeLicenseDetails(input *licenses.LicenseDetails) []HybridComputeLicenseDetails {
+	if input == nil {
+		return []HybridComputeLicenseDetails{}
+	}
+
+	return []HybridComputeLicenseDetails{
+		{
+			Edition:          string(pointer.From(input.Edition)),
+			Processors:       pointer.From(input.Processors),
+			State:            string(pointer.From(input.State)),
+			Target:           string(pointer.From(input.Target)),
+			Type:             string(pointer.From(input.Type)),
+			AssignedLicenses: pointer.From(input.AssignedLicenses),
+			ImmutableId:      pointer.From(input.ImmutableId),
+		},
+	}
+}
@@ -0,0 +1,142 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package hybridcompute_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/hybridcompute/2024-05-20-preview/licenses"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/check"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+type HybridComputeLicenseResource struct{}
+
+func TestAccHybridComputeLicense_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_hybrid_compute_license", "test")
+	r := HybridComputeLicenseResource{}
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data, "Deactivated", 8),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("license_details.0.immutable_id").IsNotEmpty(),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccHybridComputeLicense_requiresImport(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_hybrid_compute_license", "test")
+	r := HybridComputeLicenseResource{}
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data, "Deactivated", 8),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.RequiresImportErrorStep(r.requiresImport),
+	})
+}
+
+func TestAccHybridComputeLicense_update(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_hybrid_compute_license", "test")
+	r := HybridComputeLicenseResource{}
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data, "Deactivated", 8),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.basic(data, "Activated", 16),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("license_details.0.state").HasValue("Activated"),
+			),
+		},
+		data.ImportStep(),
+		{
+			// an activated license can only be deactivated, the processor count can't be decreased whilst it's active
+			Config: r.basic(data, "Deactivated", 16),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func (r HybridComputeLicenseResource) Exists(ctx context.Context, clients *clients.Client, state *pluginsdk.InstanceState) (*bool, error) {
+	id, err := licenses.ParseLicenseID(state.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := clients.HybridCompute.LicensesClient.Get(ctx, *id)
+	if err != nil {
+		if response.WasNotFound(resp.HttpResponse) {
+			return pointer.To(false), nil
+		}
+		return nil, fmt.Errorf("retrieving %s: %+v", *id, err)
+	}
+	return pointer.To(resp.Model != nil), nil
+}
+
+func (r HybridComputeLicenseResource) basic(data acceptance.TestData, state string, processors int) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-hcl-%[1]d"
+  location = "%[2]s"
+}
+
+resource "azurerm_hybrid_compute_license" "test" {
+  name                = "acctest-hcl-%[1]d"
+  resource_group_name = azurerm_resource_group.test.name
+  location            = azurerm_resource_group.test.location
+
+  license_details {
+    state      = "%[3]s"
+    target     = "Windows Server 2012"
+    edition    = "Standard"
+    type       = "pCore"
+    processors = %[4]d
+  }
+}
+`, data.RandomInteger, data.Locations.Primary, state, processors)
+}
+
+func (r HybridComputeLicenseResource) requiresImport(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_hybrid_compute_license" "import" {
+  name                = azurerm_hybrid_compute_license.test.name
+  resource_group_name = azurerm_hybrid_compute_license.test.resource_group_name
+  location            = azurerm_hybrid_compute_license.test.location
+
+  license_details {
+    state      = "Deactivated"
+    target     = "Windows Server 2012"
+    edition    = "Standard"
+    type       = "pCore"
+    processors = 8
+  }
+}
+`, r.basic(data, "Deactivated", 8))
+}
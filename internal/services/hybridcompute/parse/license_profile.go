@@ -0,0 +1,119 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package parse
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/resourceids"
+)
+
+var _ resourceids.ResourceId = &LicenseProfileId{}
+
+// LicenseProfileId is a struct representing the Resource ID for a Hybrid Compute License Profile
+type LicenseProfileId struct {
+	SubscriptionId     string
+	ResourceGroupName  string
+	MachineName        string
+	LicenseProfileName string
+}
+
+// NewLicenseProfileID returns a new LicenseProfileId struct
+func NewLicenseProfileID(subscriptionId string, resourceGroupName string, machineName string, licenseProfileName string) LicenseProfileId {
+	return LicenseProfileId{
+		SubscriptionId:     subscriptionId,
+		ResourceGroupName:  resourceGroupName,
+		MachineName:        machineName,
+		LicenseProfileName: licenseProfileName,
+	}
+}
+
+// LicenseProfileID parses 'input' into a LicenseProfileId
+func LicenseProfileID(input string) (*LicenseProfileId, error) {
+	parser := resourceids.NewParserFromResourceIdType(&LicenseProfileId{})
+	parsed, err := parser.Parse(input, false)
+	if err != nil {
+		return nil, fmt.Errorf("parsing %q: %+v", input, err)
+	}
+
+	id := LicenseProfileId{}
+	if err := id.FromParseResult(*parsed); err != nil {
+		return nil, err
+	}
+
+	return &id, nil
+}
+
+// LicenseProfileIDInsensitively parses 'input' case-insensitively into a LicenseProfileId
+// note: this method should only be used for API response data and not user input
+func LicenseProfileIDInsensitively(input string) (*LicenseProfileId, error) {
+	parser := resourceids.NewParserFromResourceIdType(&LicenseProfileId{})
+	parsed, err := parser.Parse(input, true)
+	if err != nil {
+		return nil, fmt.Errorf("parsing %q: %+v", input, err)
+	}
+
+	id := LicenseProfileId{}
+	if err := id.FromParseResult(*parsed); err != nil {
+		return nil, err
+	}
+
+	return &id, nil
+}
+
+func (id *LicenseProfileId) FromParseResult(input resourceids.ParseResult) error {
+	var ok bool
+
+	if id.SubscriptionId, ok = input.Parsed["subscriptionId"]; !ok {
+		return resourceids.NewSegmentNotSpecifiedError(id, "subscriptionId", input)
+	}
+
+	if id.ResourceGroupName, ok = input.Parsed["resourceGroupName"]; !ok {
+		return resourceids.NewSegmentNotSpecifiedError(id, "resourceGroupName", input)
+	}
+
+	if id.MachineName, ok = input.Parsed["machineName"]; !ok {
+		return resourceids.NewSegmentNotSpecifiedError(id, "machineName", input)
+	}
+
+	if id.LicenseProfileName, ok = input.Parsed["licenseProfileName"]; !ok {
+		return resourceids.NewSegmentNotSpecifiedError(id, "licenseProfileName", input)
+	}
+
+	return nil
+}
+
+// ID returns the formatted License Profile ID
+func (id LicenseProfileId) ID() string {
+	fmtString := "/subscriptions/%s/resourceGroups/%s/providers/Microsoft.HybridCompute/machines/%s/licenseProfiles/%s"
+	return fmt.Sprintf(fmtString, id.SubscriptionId, id.ResourceGroupName, id.MachineName, id.LicenseProfileName)
+}
+
+// Segments returns a slice of Resource ID Segments which comprise this License Profile ID
+func (id LicenseProfileId) Segments() []resourceids.Segment {
+	return []resourceids.Segment{
+		resourceids.StaticSegment("staticSubscriptions", "subscriptions", "subscriptions"),
+		resourceids.SubscriptionIdSegment("subscriptionId", "12345678-1234-9876-4563-123456789012"),
+		resourceids.StaticSegment("staticResourceGroups", "resourceGroups", "resourceGroups"),
+		resourceids.ResourceGroupSegment("resourceGroupName", "example-resource-group"),
+		resourceids.StaticSegment("staticProviders", "providers", "providers"),
+		resourceids.ResourceProviderSegment("staticMicrosoftHybridCompute", "Microsoft.HybridCompute", "Microsoft.HybridCompute"),
+		resourceids.StaticSegment("staticMachines", "machines", "machines"),
+		resourceids.UserSpecifiedSegment("machineName", "machineValue"),
+		resourceids.StaticSegment("staticLicenseProfiles", "licenseProfiles", "licenseProfiles"),
+		resourceids.UserSpecifiedSegment("licenseProfileName", "licenseProfileValue"),
+	}
+}
+
+// String returns a human-readable description of this License Profile ID
+func (id LicenseProfileId) String() string {
+	components := []string{
+		fmt.Sprintf("Subscription: %q", id.SubscriptionId),
+		fmt.Sprintf("Resource Group Name: %q", id.ResourceGroupName),
+		fmt.Sprintf("Machine Name: %q", id.MachineName),
+		fmt.Sprintf("License Profile Name: %q", id.LicenseProfileName),
+	}
+	return fmt.Sprintf("License Profile (%s)", strings.Join(components, "\n"))
+}
@@ -58,7 +58,12 @@ func (r Registration) DataSources() []sdk.DataSource {
 // Resources returns a list of Resources supported by this Service
 func (r Registration) Resources() []sdk.Resource {
 	return []sdk.Resource{
+		ArcGatewayResource{},
+		ArcMachineAutomanageConfigurationAssignmentResource{},
 		ArcMachineExtensionResource{},
+		ArcMachineRunCommandResource{},
 		ArcPrivateLinkScopeResource{},
+		HybridComputeLicenseResource{},
+		HybridComputeLicenseProfileResource{},
 	}
 }
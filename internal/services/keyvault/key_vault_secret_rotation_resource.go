@@ -0,0 +1,343 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package keyvault
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/eventgrid/2022-06-15/eventsubscriptions"
+	"github.com/hashicorp/terraform-provider-azurerm/helpers/azure"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/keyvault/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/keyvault/validate"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+)
+
+// keyVaultSecretNearExpiryEventType is raised by Key Vault 30 days before the expiration date of a Secret
+const keyVaultSecretNearExpiryEventType = "Microsoft.KeyVault.SecretNearExpiry"
+
+type KeyVaultSecretRotationResource struct{}
+
+var _ sdk.ResourceWithUpdate = KeyVaultSecretRotationResource{}
+
+type KeyVaultSecretRotationModel struct {
+	Name                          string `tfschema:"name"`
+	KeyVaultSecretId              string `tfschema:"key_vault_secret_id"`
+	FunctionId                    string `tfschema:"function_id"`
+	MaxEventsPerBatch             int64  `tfschema:"max_events_per_batch"`
+	PreferredBatchSizeInKilobytes int64  `tfschema:"preferred_batch_size_in_kilobytes"`
+	MaxDeliveryAttempts           int64  `tfschema:"max_delivery_attempts"`
+	EventTimeToLiveInMinutes      int64  `tfschema:"event_time_to_live_in_minutes"`
+	KeyVaultId                    string `tfschema:"key_vault_id"`
+}
+
+func (r KeyVaultSecretRotationResource) ResourceType() string {
+	return "azurerm_key_vault_secret_rotation"
+}
+
+func (r KeyVaultSecretRotationResource) IDValidationFunc() pluginsdk.SchemaValidateFunc {
+	return eventsubscriptions.ValidateScopedEventSubscriptionID
+}
+
+func (r KeyVaultSecretRotationResource) ModelObject() interface{} {
+	return &KeyVaultSecretRotationModel{}
+}
+
+func (r KeyVaultSecretRotationResource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"name": {
+			Type:     pluginsdk.TypeString,
+			Required: true,
+			ForceNew: true,
+			ValidateFunc: validation.StringMatch(
+				regexp.MustCompile("^[-a-zA-Z0-9]{3,64}$"),
+				"`name` must be between 3 and 64 characters long and can only contain letters, numbers and hyphens",
+			),
+		},
+
+		"key_vault_secret_id": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validate.VersionlessNestedItemId,
+		},
+
+		"function_id": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ValidateFunc: azure.ValidateResourceID,
+		},
+
+		"max_events_per_batch": {
+			Type:         pluginsdk.TypeInt,
+			Optional:     true,
+			Default:      1,
+			ValidateFunc: validation.IntBetween(1, 5000),
+		},
+
+		"preferred_batch_size_in_kilobytes": {
+			Type:         pluginsdk.TypeInt,
+			Optional:     true,
+			Default:      64,
+			ValidateFunc: validation.IntBetween(1, 1024),
+		},
+
+		"max_delivery_attempts": {
+			Type:         pluginsdk.TypeInt,
+			Optional:     true,
+			Default:      30,
+			ValidateFunc: validation.IntBetween(1, 30),
+		},
+
+		"event_time_to_live_in_minutes": {
+			Type:         pluginsdk.TypeInt,
+			Optional:     true,
+			Default:      1440,
+			ValidateFunc: validation.IntBetween(1, 1440),
+		},
+	}
+}
+
+func (r KeyVaultSecretRotationResource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"key_vault_id": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+	}
+}
+
+func (r KeyVaultSecretRotationResource) Create() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.EventGrid.EventSubscriptions
+			keyVaultsClient := metadata.Client.KeyVault
+
+			var config KeyVaultSecretRotationModel
+			if err := metadata.Decode(&config); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			secretId, err := parse.ParseOptionallyVersionedNestedItemID(config.KeyVaultSecretId)
+			if err != nil {
+				return err
+			}
+			if secretId.NestedItemType != parse.NestedItemTypeSecret {
+				return fmt.Errorf("expected `key_vault_secret_id` to be the ID of a Secret but got a %q", string(secretId.NestedItemType))
+			}
+
+			subscriptionId := commonids.NewSubscriptionID(metadata.Client.Account.SubscriptionId)
+			keyVaultIdRaw, err := keyVaultsClient.KeyVaultIDFromBaseUrl(ctx, subscriptionId, secretId.KeyVaultBaseUrl)
+			if err != nil {
+				return fmt.Errorf("retrieving the Resource ID the Key Vault at URL %q: %+v", secretId.KeyVaultBaseUrl, err)
+			}
+			if keyVaultIdRaw == nil {
+				return fmt.Errorf("unable to determine the Resource ID for the Key Vault at URL %q", secretId.KeyVaultBaseUrl)
+			}
+			keyVaultId, err := commonids.ParseKeyVaultID(*keyVaultIdRaw)
+			if err != nil {
+				return err
+			}
+
+			// the Event Subscription is scoped to the Key Vault, which implicitly creates the System Topic for it
+			id := eventsubscriptions.NewScopedEventSubscriptionID(keyVaultId.ID(), config.Name)
+
+			existing, err := client.Get(ctx, id)
+			if err != nil {
+				if !response.WasNotFound(existing.HttpResponse) {
+					return fmt.Errorf("checking for the presence of an existing %s: %+v", id, err)
+				}
+			}
+			if !response.WasNotFound(existing.HttpResponse) {
+				return metadata.ResourceRequiresImport(r.ResourceType(), id)
+			}
+
+			payload := eventsubscriptions.EventSubscription{
+				Properties: r.expandProperties(config, secretId.Name),
+			}
+			if err := client.CreateOrUpdateThenPoll(ctx, id, payload); err != nil {
+				return fmt.Errorf("creating %s: %+v", id, err)
+			}
+
+			metadata.SetID(id)
+			return nil
+		},
+	}
+}
+
+func (r KeyVaultSecretRotationResource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.EventGrid.EventSubscriptions
+			keyVaultsClient := metadata.Client.KeyVault
+
+			id, err := eventsubscriptions.ParseScopedEventSubscriptionID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			keyVaultId, err := commonids.ParseKeyVaultIDInsensitively(id.Scope)
+			if err != nil {
+				return fmt.Errorf("parsing the scope of %s as a Key Vault ID: %+v", id, err)
+			}
+
+			resp, err := client.Get(ctx, *id)
+			if err != nil {
+				if response.WasNotFound(resp.HttpResponse) {
+					return metadata.MarkAsGone(id)
+				}
+				return fmt.Errorf("retrieving %s: %+v", id, err)
+			}
+
+			state := KeyVaultSecretRotationModel{
+				Name:       id.EventSubscriptionName,
+				KeyVaultId: keyVaultId.ID(),
+			}
+
+			if model := resp.Model; model != nil && model.Properties != nil {
+				props := model.Properties
+
+				secretName := r.flattenSecretName(props.Filter)
+				if secretName == "" {
+					return fmt.Errorf("%s doesn't filter on the Subject of a Secret - it may not have been created by this resource", id)
+				}
+
+				keyVaultBaseUrl, err := keyVaultsClient.BaseUriForKeyVault(ctx, *keyVaultId)
+				if err != nil {
+					return fmt.Errorf("looking up the Base URI for %s: %+v", keyVaultId, err)
+				}
+				secretId, err := parse.NewNestedItemID(*keyVaultBaseUrl, parse.NestedItemTypeSecret, secretName, "")
+				if err != nil {
+					return err
+				}
+				state.KeyVaultSecretId = secretId.VersionlessID()
+
+				if destination, ok := props.Destination.(eventsubscriptions.AzureFunctionEventSubscriptionDestination); ok && destination.Properties != nil {
+					state.FunctionId = pointer.From(destination.Properties.ResourceId)
+					state.MaxEventsPerBatch = pointer.From(destination.Properties.MaxEventsPerBatch)
+					state.PreferredBatchSizeInKilobytes = pointer.From(destination.Properties.PreferredBatchSizeInKilobytes)
+				}
+
+				if policy := props.RetryPolicy; policy != nil {
+					state.MaxDeliveryAttempts = pointer.From(policy.MaxDeliveryAttempts)
+					state.EventTimeToLiveInMinutes = pointer.From(policy.EventTimeToLiveInMinutes)
+				}
+			}
+
+			return metadata.Encode(&state)
+		},
+	}
+}
+
+func (r KeyVaultSecretRotationResource) Update() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.EventGrid.EventSubscriptions
+
+			id, err := eventsubscriptions.ParseScopedEventSubscriptionID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			var config KeyVaultSecretRotationModel
+			if err := metadata.Decode(&config); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			secretId, err := parse.ParseOptionallyVersionedNestedItemID(config.KeyVaultSecretId)
+			if err != nil {
+				return err
+			}
+
+			// the Destination, Filter and Retry Policy are sent in full, so the Event Subscription is replaced as a whole
+			payload := eventsubscriptions.EventSubscription{
+				Properties: r.expandProperties(config, secretId.Name),
+			}
+			if err := client.CreateOrUpdateThenPoll(ctx, *id, payload); err != nil {
+				return fmt.Errorf("updating %s: %+v", id, err)
+			}
+
+			return nil
+		},
+	}
+}
+
+func (r KeyVaultSecretRotationResource) Delete() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.EventGrid.EventSubscriptions
+
+			id, err := eventsubscriptions.ParseScopedEventSubscriptionID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			if err := client.DeleteThenPoll(ctx, *id); err != nil {
+				return fmt.Errorf("deleting %s: %+v", id, err)
+			}
+
+			return nil
+		},
+	}
+}
+
+func (r KeyVaultSecretRotationResource) expandProperties(input KeyVaultSecretRotationModel, secretName string) *eventsubscriptions.EventSubscriptionProperties {
+	return &eventsubscriptions.EventSubscriptionProperties{
+		Destination: eventsubscriptions.AzureFunctionEventSubscriptionDestination{
+			Properties: &eventsubscriptions.AzureFunctionEventSubscriptionDestinationProperties{
+				ResourceId:                    pointer.To(input.FunctionId),
+				MaxEventsPerBatch:             pointer.To(input.MaxEventsPerBatch),
+				PreferredBatchSizeInKilobytes: pointer.To(input.PreferredBatchSizeInKilobytes),
+			},
+		},
+		EventDeliverySchema: pointer.To(eventsubscriptions.EventDeliverySchemaEventGridSchema),
+		Filter: &eventsubscriptions.EventSubscriptionFilter{
+			IncludedEventTypes: &[]string{
+				keyVaultSecretNearExpiryEventType,
+			},
+			// the Subject of a Key Vault event is the name of the Secret - which must be matched exactly, since
+			// filtering on a prefix would also match other Secrets sharing it
+			AdvancedFilters: &[]eventsubscriptions.AdvancedFilter{
+				eventsubscriptions.StringInAdvancedFilter{
+					Key:    pointer.To("subject"),
+					Values: &[]string{secretName},
+				},
+			},
+		},
+		RetryPolicy: &eventsubscriptions.RetryPolicy{
+			EventTimeToLiveInMinutes: pointer.To(input.EventTimeToLiveInMinutes),
+			MaxDeliveryAttempts:      pointer.To(input.MaxDeliveryAttempts),
+		},
+	}
+}
+
+func (r KeyVaultSecretRotationResource) flattenSecretName(input *eventsubscriptions.EventSubscriptionFilter) string {
+	if input == nil || input.AdvancedFilters == nil {
+		return ""
+	}
+
+	for _, v := range *input.AdvancedFilters {
+		filter, ok := v.(eventsubscriptions.StringInAdvancedFilter)
+		if !ok || !strings.EqualFold(pointer.From(filter.Key), "subject") {
+			continue
+		}
+		if values := pointer.From(filter.Values); len(values) == 1 {
+			return values[0]
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,244 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package keyvault_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/eventgrid/2022-06-15/eventsubscriptions"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/check"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+type KeyVaultSecretRotationResource struct{}
+
+func TestAccKeyVaultSecretRotation_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_key_vault_secret_rotation", "test")
+	r := KeyVaultSecretRotationResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("key_vault_id").IsNotEmpty(),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccKeyVaultSecretRotation_requiresImport(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_key_vault_secret_rotation", "test")
+	r := KeyVaultSecretRotationResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.RequiresImportErrorStep(r.requiresImport),
+	})
+}
+
+func TestAccKeyVaultSecretRotation_complete(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_key_vault_secret_rotation", "test")
+	r := KeyVaultSecretRotationResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.complete(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccKeyVaultSecretRotation_update(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_key_vault_secret_rotation", "test")
+	r := KeyVaultSecretRotationResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.complete(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func (KeyVaultSecretRotationResource) Exists(ctx context.Context, clients *clients.Client, state *pluginsdk.InstanceState) (*bool, error) {
+	id, err := eventsubscriptions.ParseScopedEventSubscriptionID(state.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := clients.EventGrid.EventSubscriptions.Get(ctx, *id)
+	if err != nil {
+		if response.WasNotFound(resp.HttpResponse) {
+			return pointer.To(false), nil
+		}
+		return nil, fmt.Errorf("retrieving %s: %+v", *id, err)
+	}
+
+	return pointer.To(resp.Model != nil), nil
+}
+
+func (r KeyVaultSecretRotationResource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_key_vault_secret_rotation" "test" {
+  name                = "acctest-ksr-%d"
+  key_vault_secret_id = azurerm_key_vault_secret.test.versionless_id
+  function_id         = "${azurerm_linux_function_app.test.id}/functions/${azurerm_function_app_function.test.name}"
+}
+`, r.template(data), data.RandomInteger)
+}
+
+func (r KeyVaultSecretRotationResource) requiresImport(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_key_vault_secret_rotation" "import" {
+  name                = azurerm_key_vault_secret_rotation.test.name
+  key_vault_secret_id = azurerm_key_vault_secret_rotation.test.key_vault_secret_id
+  function_id         = azurerm_key_vault_secret_rotation.test.function_id
+}
+`, r.basic(data))
+}
+
+func (r KeyVaultSecretRotationResource) complete(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_key_vault_secret_rotation" "test" {
+  name                              = "acctest-ksr-%d"
+  key_vault_secret_id               = azurerm_key_vault_secret.test.versionless_id
+  function_id                       = "${azurerm_linux_function_app.test.id}/functions/${azurerm_function_app_function.test.name}"
+  max_events_per_batch              = 10
+  preferred_batch_size_in_kilobytes = 128
+  max_delivery_attempts             = 10
+  event_time_to_live_in_minutes     = 60
+}
+`, r.template(data), data.RandomInteger)
+}
+
+func (KeyVaultSecretRotationResource) template(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+data "azurerm_client_config" "current" {}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-kvsr-%[1]d"
+  location = "%[2]s"
+}
+
+resource "azurerm_key_vault" "test" {
+  name                       = "acctestkv-%[3]s"
+  location                   = azurerm_resource_group.test.location
+  resource_group_name        = azurerm_resource_group.test.name
+  tenant_id                  = data.azurerm_client_config.current.tenant_id
+  sku_name                   = "standard"
+  soft_delete_retention_days = 7
+
+  access_policy {
+    tenant_id = data.azurerm_client_config.current.tenant_id
+    object_id = data.azurerm_client_config.current.object_id
+
+    secret_permissions = [
+      "Get",
+      "Delete",
+      "List",
+      "Purge",
+      "Recover",
+      "Set",
+    ]
+  }
+}
+
+resource "azurerm_key_vault_secret" "test" {
+  name            = "secret-%[3]s"
+  value           = "rick-and-morty"
+  key_vault_id    = azurerm_key_vault.test.id
+  expiration_date = "2030-01-01T00:00:00Z"
+}
+
+resource "azurerm_storage_account" "test" {
+  name                     = "acctestsa%[3]s"
+  resource_group_name      = azurerm_resource_group.test.name
+  location                 = azurerm_resource_group.test.location
+  account_tier             = "Standard"
+  account_replication_type = "LRS"
+}
+
+resource "azurerm_service_plan" "test" {
+  name                = "acctestASP-%[1]d"
+  location            = azurerm_resource_group.test.location
+  resource_group_name = azurerm_resource_group.test.name
+  os_type             = "Linux"
+  sku_name            = "S1"
+}
+
+resource "azurerm_linux_function_app" "test" {
+  name                = "acctest-LFA-%[1]d"
+  location            = azurerm_resource_group.test.location
+  resource_group_name = azurerm_resource_group.test.name
+  service_plan_id     = azurerm_service_plan.test.id
+
+  storage_account_name       = azurerm_storage_account.test.name
+  storage_account_access_key = azurerm_storage_account.test.primary_access_key
+
+  site_config {
+    application_stack {
+      python_version = "3.9"
+    }
+  }
+}
+
+resource "azurerm_function_app_function" "test" {
+  name            = "rotate"
+  function_app_id = azurerm_linux_function_app.test.id
+  language        = "Python"
+  config_json = jsonencode({
+    "bindings" = [
+      {
+        "name"      = "event"
+        "direction" = "in"
+        "type"      = "eventGridTrigger"
+      },
+    ]
+  })
+}
+`, data.RandomInteger, data.Locations.Primary, data.RandomString)
+}
@@ -0,0 +1,50 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package azuresdkhacks
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/hashicorp/go-azure-sdk/sdk/client"
+	"github.com/hashicorp/go-azure-sdk/sdk/client/dataplane"
+)
+
+// NOTE: this workaround is needed since go-azure-sdk doesn't include a Data Plane SDK for Load Testing, the
+// operations used by the Load Test resources are implemented here atop the `dataplane` base layer
+// TODO: switch to go-azure-sdk once the Load Testing Data Plane API is available there
+
+const defaultApiVersion = "2024-12-01"
+
+type LoadTestingClient struct {
+	Client *dataplane.Client
+}
+
+func NewLoadTestingClientWithBaseURI(endpoint string) *LoadTestingClient {
+	return &LoadTestingClient{
+		Client: dataplane.NewDataPlaneClient(endpoint, "loadtesting", defaultApiVersion),
+	}
+}
+
+// newRequest builds a request against the Load Testing data plane - unlike Resource Manager the base client
+// doesn't append the API Version, so this (and any other query string parameters) are set here
+func (c LoadTestingClient) newRequest(ctx context.Context, input client.RequestOptions, query url.Values) (*client.Request, error) {
+	if err := input.Validate(); err != nil {
+		return nil, fmt.Errorf("pre-validating request payload: %+v", err)
+	}
+
+	req, err := c.Client.NewRequest(ctx, input)
+	if err != nil {
+		return nil, fmt.Errorf("building %s request: %+v", input.HttpMethod, err)
+	}
+
+	if query == nil {
+		query = url.Values{}
+	}
+	query.Set("api-version", c.Client.ApiVersion)
+	req.URL.RawQuery = query.Encode()
+
+	return req, nil
+}
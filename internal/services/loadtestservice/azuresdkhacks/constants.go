@@ -0,0 +1,168 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package azuresdkhacks
+
+type FileType string
+
+const (
+	FileTypeADDITIONALARTIFACTS FileType = "ADDITIONAL_ARTIFACTS"
+	FileTypeJMXFILE             FileType = "JMX_FILE"
+	FileTypeTESTSCRIPT          FileType = "TEST_SCRIPT"
+	FileTypeURLTESTCONFIG       FileType = "URL_TEST_CONFIG"
+	FileTypeUSERPROPERTIES      FileType = "USER_PROPERTIES"
+	FileTypeZIPPEDARTIFACTS     FileType = "ZIPPED_ARTIFACTS"
+)
+
+func PossibleValuesForFileType() []string {
+	return []string{
+		string(FileTypeADDITIONALARTIFACTS),
+		string(FileTypeJMXFILE),
+		string(FileTypeTESTSCRIPT),
+		string(FileTypeURLTESTCONFIG),
+		string(FileTypeUSERPROPERTIES),
+		string(FileTypeZIPPEDARTIFACTS),
+	}
+}
+
+type FileValidationStatus string
+
+const (
+	FileValidationStatusNOTVALIDATED          FileValidationStatus = "NOT_VALIDATED"
+	FileValidationStatusVALIDATIONFAILURE     FileValidationStatus = "VALIDATION_FAILURE"
+	FileValidationStatusVALIDATIONINITIATED   FileValidationStatus = "VALIDATION_INITIATED"
+	FileValidationStatusVALIDATIONNOTREQUIRED FileValidationStatus = "VALIDATION_NOT_REQUIRED"
+	FileValidationStatusVALIDATIONSUCCESS     FileValidationStatus = "VALIDATION_SUCCESS"
+)
+
+func PossibleValuesForFileValidationStatus() []string {
+	return []string{
+		string(FileValidationStatusNOTVALIDATED),
+		string(FileValidationStatusVALIDATIONFAILURE),
+		string(FileValidationStatusVALIDATIONINITIATED),
+		string(FileValidationStatusVALIDATIONNOTREQUIRED),
+		string(FileValidationStatusVALIDATIONSUCCESS),
+	}
+}
+
+type PFAction string
+
+const (
+	PFActionContinue PFAction = "continue"
+	PFActionStop     PFAction = "stop"
+)
+
+func PossibleValuesForPFAction() []string {
+	return []string{
+		string(PFActionContinue),
+		string(PFActionStop),
+	}
+}
+
+type PFAgFunc string
+
+const (
+	PFAgFuncAvg        PFAgFunc = "avg"
+	PFAgFuncCount      PFAgFunc = "count"
+	PFAgFuncMax        PFAgFunc = "max"
+	PFAgFuncMin        PFAgFunc = "min"
+	PFAgFuncPFiveZero  PFAgFunc = "p50"
+	PFAgFuncPNineFive  PFAgFunc = "p95"
+	PFAgFuncPNineNine  PFAgFunc = "p99"
+	PFAgFuncPNineZero  PFAgFunc = "p90"
+	PFAgFuncPercentage PFAgFunc = "percentage"
+)
+
+func PossibleValuesForPFAgFunc() []string {
+	return []string{
+		string(PFAgFuncAvg),
+		string(PFAgFuncCount),
+		string(PFAgFuncMax),
+		string(PFAgFuncMin),
+		string(PFAgFuncPFiveZero),
+		string(PFAgFuncPNineFive),
+		string(PFAgFuncPNineNine),
+		string(PFAgFuncPNineZero),
+		string(PFAgFuncPercentage),
+	}
+}
+
+type PFMetrics string
+
+const (
+	PFMetricsError          PFMetrics = "error"
+	PFMetricsLatency        PFMetrics = "latency"
+	PFMetricsRequests       PFMetrics = "requests"
+	PFMetricsRequestsPerSec PFMetrics = "requests_per_sec"
+	PFMetricsResponseTimeMs PFMetrics = "response_time_ms"
+)
+
+func PossibleValuesForPFMetrics() []string {
+	return []string{
+		string(PFMetricsError),
+		string(PFMetricsLatency),
+		string(PFMetricsRequests),
+		string(PFMetricsRequestsPerSec),
+		string(PFMetricsResponseTimeMs),
+	}
+}
+
+type PFResult string
+
+const (
+	PFResultFailed       PFResult = "failed"
+	PFResultPassed       PFResult = "passed"
+	PFResultUndetermined PFResult = "undetermined"
+)
+
+type PFTestResult string
+
+const (
+	PFTestResultFAILED        PFTestResult = "FAILED"
+	PFTestResultNOTApplicable PFTestResult = "NOT_APPLICABLE"
+	PFTestResultPASSED        PFTestResult = "PASSED"
+)
+
+type SecretType string
+
+const (
+	SecretTypeAKVSECRETURI SecretType = "AKV_SECRET_URI"
+	SecretTypeSECRETVALUE  SecretType = "SECRET_VALUE"
+)
+
+type TestKind string
+
+const (
+	TestKindJMX    TestKind = "JMX"
+	TestKindLocust TestKind = "Locust"
+	TestKindURL    TestKind = "URL"
+)
+
+func PossibleValuesForTestKind() []string {
+	return []string{
+		string(TestKindJMX),
+		string(TestKindLocust),
+		string(TestKindURL),
+	}
+}
+
+type TestRunStatus string
+
+const (
+	TestRunStatusACCEPTED          TestRunStatus = "ACCEPTED"
+	TestRunStatusCANCELLED         TestRunStatus = "CANCELLED"
+	TestRunStatusCANCELLING        TestRunStatus = "CANCELLING"
+	TestRunStatusCONFIGURED        TestRunStatus = "CONFIGURED"
+	TestRunStatusCONFIGURING       TestRunStatus = "CONFIGURING"
+	TestRunStatusDEPROVISIONED     TestRunStatus = "DEPROVISIONED"
+	TestRunStatusDEPROVISIONING    TestRunStatus = "DEPROVISIONING"
+	TestRunStatusDONE              TestRunStatus = "DONE"
+	TestRunStatusEXECUTED          TestRunStatus = "EXECUTED"
+	TestRunStatusEXECUTING         TestRunStatus = "EXECUTING"
+	TestRunStatusFAILED            TestRunStatus = "FAILED"
+	TestRunStatusNOTSTARTED        TestRunStatus = "NOTSTARTED"
+	TestRunStatusPROVISIONED       TestRunStatus = "PROVISIONED"
+	TestRunStatusPROVISIONING      TestRunStatus = "PROVISIONING"
+	TestRunStatusVALIDATIONFAILURE TestRunStatus = "VALIDATION_FAILURE"
+	TestRunStatusVALIDATIONSUCCESS TestRunStatus = "VALIDATION_SUCCESS"
+)
@@ -0,0 +1,68 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package azuresdkhacks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/hashicorp/go-azure-sdk/sdk/client"
+	"github.com/hashicorp/go-azure-sdk/sdk/odata"
+)
+
+type CreateOrUpdateAppComponentsOperationResponse struct {
+	HttpResponse *http.Response
+	OData        *odata.OData
+	Model        *TestAppComponents
+}
+
+// CreateOrUpdateAppComponents adds, updates or removes (by specifying a `nil` value) App Components of a Test
+func (c LoadTestingClient) CreateOrUpdateAppComponents(ctx context.Context, testId string, input TestAppComponents) (result CreateOrUpdateAppComponentsOperationResponse, err error) {
+	opts := client.RequestOptions{
+		ContentType: "application/merge-patch+json",
+		ExpectedStatusCodes: []int{
+			http.StatusOK,
+			http.StatusCreated,
+		},
+		HttpMethod: http.MethodPatch,
+		Path:       fmt.Sprintf("/tests/%s/app-components", url.PathEscape(testId)),
+	}
+
+	req, err := c.newRequest(ctx, opts, nil)
+	if err != nil {
+		return
+	}
+
+	// the merge-patch content type isn't handled by the base client, so the payload is marshalled here
+	payload, err := json.Marshal(input)
+	if err != nil {
+		err = fmt.Errorf("marshaling payload: %+v", err)
+		return
+	}
+	if err = req.Marshal(payload); err != nil {
+		return
+	}
+
+	var resp *client.Response
+	resp, err = req.Execute(ctx)
+	if resp != nil {
+		result.OData = resp.OData
+		result.HttpResponse = resp.Response
+	}
+	if err != nil {
+		return
+	}
+
+	var model TestAppComponents
+	result.Model = &model
+
+	if err = resp.Unmarshal(result.Model); err != nil {
+		return
+	}
+
+	return
+}
@@ -0,0 +1,68 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package azuresdkhacks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/hashicorp/go-azure-sdk/sdk/client"
+	"github.com/hashicorp/go-azure-sdk/sdk/odata"
+)
+
+type CreateOrUpdateTestOperationResponse struct {
+	HttpResponse *http.Response
+	OData        *odata.OData
+	Model        *Test
+}
+
+// CreateOrUpdateTest creates a Test, or updates the specified properties of an existing Test
+func (c LoadTestingClient) CreateOrUpdateTest(ctx context.Context, testId string, input Test) (result CreateOrUpdateTestOperationResponse, err error) {
+	opts := client.RequestOptions{
+		ContentType: "application/merge-patch+json",
+		ExpectedStatusCodes: []int{
+			http.StatusOK,
+			http.StatusCreated,
+		},
+		HttpMethod: http.MethodPatch,
+		Path:       fmt.Sprintf("/tests/%s", url.PathEscape(testId)),
+	}
+
+	req, err := c.newRequest(ctx, opts, nil)
+	if err != nil {
+		return
+	}
+
+	// the merge-patch content type isn't handled by the base client, so the payload is marshalled here
+	payload, err := json.Marshal(input)
+	if err != nil {
+		err = fmt.Errorf("marshaling payload: %+v", err)
+		return
+	}
+	if err = req.Marshal(payload); err != nil {
+		return
+	}
+
+	var resp *client.Response
+	resp, err = req.Execute(ctx)
+	if resp != nil {
+		result.OData = resp.OData
+		result.HttpResponse = resp.Response
+	}
+	if err != nil {
+		return
+	}
+
+	var model Test
+	result.Model = &model
+
+	if err = resp.Unmarshal(result.Model); err != nil {
+		return
+	}
+
+	return
+}
@@ -0,0 +1,48 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package azuresdkhacks
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/hashicorp/go-azure-sdk/sdk/client"
+	"github.com/hashicorp/go-azure-sdk/sdk/odata"
+)
+
+type DeleteTestOperationResponse struct {
+	HttpResponse *http.Response
+	OData        *odata.OData
+}
+
+// DeleteTest deletes a Test including its files and App Components
+func (c LoadTestingClient) DeleteTest(ctx context.Context, testId string) (result DeleteTestOperationResponse, err error) {
+	opts := client.RequestOptions{
+		ContentType: "application/json; charset=utf-8",
+		ExpectedStatusCodes: []int{
+			http.StatusNoContent,
+		},
+		HttpMethod: http.MethodDelete,
+		Path:       fmt.Sprintf("/tests/%s", url.PathEscape(testId)),
+	}
+
+	req, err := c.newRequest(ctx, opts, nil)
+	if err != nil {
+		return
+	}
+
+	var resp *client.Response
+	resp, err = req.Execute(ctx)
+	if resp != nil {
+		result.OData = resp.OData
+		result.HttpResponse = resp.Response
+	}
+	if err != nil {
+		return
+	}
+
+	return
+}
@@ -0,0 +1,48 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package azuresdkhacks
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/hashicorp/go-azure-sdk/sdk/client"
+	"github.com/hashicorp/go-azure-sdk/sdk/odata"
+)
+
+type DeleteTestFileOperationResponse struct {
+	HttpResponse *http.Response
+	OData        *odata.OData
+}
+
+// DeleteTestFile deletes a file from a Test
+func (c LoadTestingClient) DeleteTestFile(ctx context.Context, testId string, fileName string) (result DeleteTestFileOperationResponse, err error) {
+	opts := client.RequestOptions{
+		ContentType: "application/json; charset=utf-8",
+		ExpectedStatusCodes: []int{
+			http.StatusNoContent,
+		},
+		HttpMethod: http.MethodDelete,
+		Path:       fmt.Sprintf("/tests/%s/files/%s", url.PathEscape(testId), url.PathEscape(fileName)),
+	}
+
+	req, err := c.newRequest(ctx, opts, nil)
+	if err != nil {
+		return
+	}
+
+	var resp *client.Response
+	resp, err = req.Execute(ctx)
+	if resp != nil {
+		result.OData = resp.OData
+		result.HttpResponse = resp.Response
+	}
+	if err != nil {
+		return
+	}
+
+	return
+}
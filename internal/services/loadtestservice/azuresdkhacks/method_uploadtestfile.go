@@ -0,0 +1,63 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package azuresdkhacks
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/hashicorp/go-azure-sdk/sdk/client"
+	"github.com/hashicorp/go-azure-sdk/sdk/odata"
+)
+
+type UploadTestFileOperationResponse struct {
+	HttpResponse *http.Response
+	OData        *odata.OData
+	Model        *TestFileInfo
+}
+
+// UploadTestFile uploads a file to a Test, replacing any existing file with the same name
+func (c LoadTestingClient) UploadTestFile(ctx context.Context, testId string, fileName string, fileType FileType, content []byte) (result UploadTestFileOperationResponse, err error) {
+	opts := client.RequestOptions{
+		ContentType: "application/octet-stream",
+		ExpectedStatusCodes: []int{
+			http.StatusCreated,
+		},
+		HttpMethod: http.MethodPut,
+		Path:       fmt.Sprintf("/tests/%s/files/%s", url.PathEscape(testId), url.PathEscape(fileName)),
+	}
+
+	query := url.Values{}
+	query.Set("fileType", string(fileType))
+
+	req, err := c.newRequest(ctx, opts, query)
+	if err != nil {
+		return
+	}
+
+	if err = req.Marshal(content); err != nil {
+		return
+	}
+
+	var resp *client.Response
+	resp, err = req.Execute(ctx)
+	if resp != nil {
+		result.OData = resp.OData
+		result.HttpResponse = resp.Response
+	}
+	if err != nil {
+		return
+	}
+
+	var model TestFileInfo
+	result.Model = &model
+
+	if err = resp.Unmarshal(result.Model); err != nil {
+		return
+	}
+
+	return
+}
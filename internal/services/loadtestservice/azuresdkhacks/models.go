@@ -0,0 +1,102 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package azuresdkhacks
+
+type AppComponent struct {
+	DisplayName    *string `json:"displayName,omitempty"`
+	Kind           *string `json:"kind,omitempty"`
+	ResourceGroup  *string `json:"resourceGroup,omitempty"`
+	ResourceId     string  `json:"resourceId"`
+	ResourceName   string  `json:"resourceName"`
+	ResourceType   string  `json:"resourceType"`
+	SubscriptionId *string `json:"subscriptionId,omitempty"`
+}
+
+type ErrorDetails struct {
+	Message *string `json:"message,omitempty"`
+}
+
+type LoadTestConfiguration struct {
+	EngineInstances *int64 `json:"engineInstances,omitempty"`
+	QuickStartTest  *bool  `json:"quickStartTest,omitempty"`
+	SplitAllCSVs    *bool  `json:"splitAllCSVs,omitempty"`
+}
+
+type PassFailCriteria struct {
+	// PassFailMetrics is keyed by an arbitrary (unique) identifier, a `nil` value removes the criterion
+	PassFailMetrics *map[string]*PassFailMetric `json:"passFailMetrics,omitempty"`
+}
+
+type PassFailMetric struct {
+	Action       *PFAction  `json:"action,omitempty"`
+	ActualValue  *float64   `json:"actualValue,omitempty"`
+	Aggregate    *PFAgFunc  `json:"aggregate,omitempty"`
+	ClientMetric *PFMetrics `json:"clientMetric,omitempty"`
+	Condition    *string    `json:"condition,omitempty"`
+	RequestName  *string    `json:"requestName,omitempty"`
+	Result       *PFResult  `json:"result,omitempty"`
+	Value        *float64   `json:"value,omitempty"`
+}
+
+type Secret struct {
+	Type  *SecretType `json:"type,omitempty"`
+	Value *string     `json:"value,omitempty"`
+}
+
+type Test struct {
+	CreatedDateTime               *string                `json:"createdDateTime,omitempty"`
+	Description                   *string                `json:"description,omitempty"`
+	DisplayName                   *string                `json:"displayName,omitempty"`
+	EnvironmentVariables          *map[string]*string    `json:"environmentVariables,omitempty"`
+	InputArtifacts                *TestInputArtifacts    `json:"inputArtifacts,omitempty"`
+	KeyvaultReferenceIdentityId   *string                `json:"keyvaultReferenceIdentityId,omitempty"`
+	KeyvaultReferenceIdentityType *string                `json:"keyvaultReferenceIdentityType,omitempty"`
+	Kind                          *TestKind              `json:"kind,omitempty"`
+	LastModifiedDateTime          *string                `json:"lastModifiedDateTime,omitempty"`
+	LoadTestConfiguration         *LoadTestConfiguration `json:"loadTestConfiguration,omitempty"`
+	PassFailCriteria              *PassFailCriteria      `json:"passFailCriteria,omitempty"`
+	Secrets                       *map[string]*Secret    `json:"secrets,omitempty"`
+	TestId                        *string                `json:"testId,omitempty"`
+}
+
+type TestAppComponents struct {
+	// Components is keyed by the Resource ID of the App Component, a `nil` value removes the App Component
+	Components *map[string]*AppComponent `json:"components,omitempty"`
+	TestId     *string                   `json:"testId,omitempty"`
+}
+
+type TestFileInfo struct {
+	ExpireDateTime           *string               `json:"expireDateTime,omitempty"`
+	FileName                 *string               `json:"fileName,omitempty"`
+	FileType                 *FileType             `json:"fileType,omitempty"`
+	Url                      *string               `json:"url,omitempty"`
+	ValidationFailureDetails *string               `json:"validationFailureDetails,omitempty"`
+	ValidationStatus         *FileValidationStatus `json:"validationStatus,omitempty"`
+}
+
+type TestInputArtifacts struct {
+	AdditionalFileInfo        *[]TestFileInfo `json:"additionalFileInfo,omitempty"`
+	ConfigFileInfo            *TestFileInfo   `json:"configFileInfo,omitempty"`
+	InputArtifactsZipFileInfo *TestFileInfo   `json:"inputArtifactsZipFileInfo,omitempty"`
+	TestScriptFileInfo        *TestFileInfo   `json:"testScriptFileInfo,omitempty"`
+	UrlTestConfigFileInfo     *TestFileInfo   `json:"urlTestConfigFileInfo,omitempty"`
+	UserPropFileInfo          *TestFileInfo   `json:"userPropFileInfo,omitempty"`
+}
+
+type TestRun struct {
+	Description          *string             `json:"description,omitempty"`
+	DisplayName          *string             `json:"displayName,omitempty"`
+	Duration             *int64              `json:"duration,omitempty"`
+	EndDateTime          *string             `json:"endDateTime,omitempty"`
+	EnvironmentVariables *map[string]*string `json:"environmentVariables,omitempty"`
+	ErrorDetails         *[]ErrorDetails     `json:"errorDetails,omitempty"`
+	PassFailCriteria     *PassFailCriteria   `json:"passFailCriteria,omitempty"`
+	PortalUrl            *string             `json:"portalUrl,omitempty"`
+	StartDateTime        *string             `json:"startDateTime,omitempty"`
+	Status               *TestRunStatus      `json:"status,omitempty"`
+	TestId               *string             `json:"testId,omitempty"`
+	TestResult           *PFTestResult       `json:"testResult,omitempty"`
+	TestRunId            *string             `json:"testRunId,omitempty"`
+	VirtualUsers         *int64              `json:"virtualUsers,omitempty"`
+}
@@ -13,6 +13,8 @@ import (
 
 type AutoClient struct {
 	V20221201 loadtestserviceV20221201.Client
+
+	o *common.ClientOptions
 }
 
 func NewClient(o *common.ClientOptions) (*AutoClient, error) {
@@ -26,5 +28,6 @@ func NewClient(o *common.ClientOptions) (*AutoClient, error) {
 
 	return &AutoClient{
 		V20221201: *v20221201Client,
+		o:         o,
 	}, nil
 }
@@ -0,0 +1,51 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/go-azure-sdk/resource-manager/loadtestservice/2022-12-01/loadtests"
+	"github.com/hashicorp/go-azure-sdk/sdk/environments"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/loadtestservice/azuresdkhacks"
+)
+
+// DataPlaneClientForLoadTest returns a Data Plane client for the specified Load Test
+func (c *AutoClient) DataPlaneClientForLoadTest(ctx context.Context, id loadtests.LoadTestId) (*azuresdkhacks.LoadTestingClient, error) {
+	existing, err := c.V20221201.LoadTests.Get(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("retrieving %s: %+v", id, err)
+	}
+	dataPlaneUri := ""
+	if model := existing.Model; model != nil && model.Properties != nil && model.Properties.DataPlaneURI != nil {
+		dataPlaneUri = *model.Properties.DataPlaneURI
+	}
+	if dataPlaneUri == "" {
+		return nil, fmt.Errorf("retrieving %s: unable to determine the Data Plane URI since `model.Properties.DataPlaneURI` was nil", id)
+	}
+
+	// the Data Plane URI is returned without a scheme in the format `{uniqueId}.{region}.cnt-prod.loadtesting.azure.com`
+	// however the authorization token is needed for `https://cnt-prod.loadtesting.azure.com`, so we'll want to compute
+	// that (since it varies per environment)
+	host := strings.TrimPrefix(dataPlaneUri, "https://")
+	segments := strings.Split(host, ".")
+	if len(segments) < 3 {
+		return nil, fmt.Errorf("parsing the Data Plane URI %q for %s: expected at least 3 segments but got %d", dataPlaneUri, id, len(segments))
+	}
+	endpoint := fmt.Sprintf("https://%s", host)
+	resourceIdentifier := fmt.Sprintf("https://%s", strings.Join(segments[2:], "."))
+
+	api := environments.NewApiEndpoint("LoadTestService", endpoint, nil).WithResourceIdentifier(resourceIdentifier)
+	authorizer, err := c.o.Authorizers.AuthorizerFunc(api)
+	if err != nil {
+		return nil, fmt.Errorf("obtaining auth token for %q: %+v", endpoint, err)
+	}
+
+	client := azuresdkhacks.NewLoadTestingClientWithBaseURI(endpoint)
+	c.o.Configure(client.Client, authorizer)
+
+	return client, nil
+}
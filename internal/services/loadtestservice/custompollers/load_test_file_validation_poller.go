@@ -0,0 +1,65 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package custompollers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-sdk/sdk/client/pollers"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/loadtestservice/azuresdkhacks"
+)
+
+var _ pollers.PollerType = &loadTestFileValidationPoller{}
+
+var (
+	pollingSuccess = pollers.PollResult{
+		Status:       pollers.PollingStatusSucceeded,
+		PollInterval: 10 * time.Second,
+	}
+	pollingInProgress = pollers.PollResult{
+		Status:       pollers.PollingStatusInProgress,
+		PollInterval: 10 * time.Second,
+	}
+)
+
+type loadTestFileValidationPoller struct {
+	client   *azuresdkhacks.LoadTestingClient
+	testId   string
+	fileName string
+}
+
+// NewLoadTestFileValidationPoller polls a file uploaded to a Load Test until the service has finished validating it
+func NewLoadTestFileValidationPoller(client *azuresdkhacks.LoadTestingClient, testId, fileName string) *loadTestFileValidationPoller {
+	return &loadTestFileValidationPoller{
+		client:   client,
+		testId:   testId,
+		fileName: fileName,
+	}
+}
+
+func (p loadTestFileValidationPoller) Poll(ctx context.Context) (*pollers.PollResult, error) {
+	resp, err := p.client.GetTestFile(ctx, p.testId, p.fileName)
+	if err != nil {
+		return nil, fmt.Errorf("retrieving file %q for Test %q: %+v", p.fileName, p.testId, err)
+	}
+	if resp.Model == nil {
+		return nil, fmt.Errorf("retrieving file %q for Test %q: `model` was nil", p.fileName, p.testId)
+	}
+
+	switch pointer.From(resp.Model.ValidationStatus) {
+	case azuresdkhacks.FileValidationStatusVALIDATIONSUCCESS, azuresdkhacks.FileValidationStatusVALIDATIONNOTREQUIRED:
+		return &pollingSuccess, nil
+
+	case azuresdkhacks.FileValidationStatusVALIDATIONFAILURE:
+		return &pollers.PollResult{
+			Status:       pollers.PollingStatusFailed,
+			PollInterval: 10 * time.Second,
+		}, fmt.Errorf("validating file %q for Test %q: %s", p.fileName, p.testId, pointer.From(resp.Model.ValidationFailureDetails))
+	}
+
+	return &pollingInProgress, nil
+}
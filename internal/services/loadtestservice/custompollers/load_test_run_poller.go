@@ -0,0 +1,63 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package custompollers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-sdk/sdk/client/pollers"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/loadtestservice/azuresdkhacks"
+)
+
+var _ pollers.PollerType = &loadTestRunPoller{}
+
+// LoadTestRunTerminalStatuses are the statuses of a Test Run which has finished running
+var LoadTestRunTerminalStatuses = []azuresdkhacks.TestRunStatus{
+	azuresdkhacks.TestRunStatusCANCELLED,
+	azuresdkhacks.TestRunStatusDONE,
+	azuresdkhacks.TestRunStatusFAILED,
+	azuresdkhacks.TestRunStatusVALIDATIONFAILURE,
+}
+
+type loadTestRunPoller struct {
+	client    *azuresdkhacks.LoadTestingClient
+	testRunId string
+}
+
+// NewLoadTestRunPoller polls a Test Run until it has finished running - regardless of whether the
+// Test Run was successful, since the outcome is surfaced by the calling resource
+func NewLoadTestRunPoller(client *azuresdkhacks.LoadTestingClient, testRunId string) *loadTestRunPoller {
+	return &loadTestRunPoller{
+		client:    client,
+		testRunId: testRunId,
+	}
+}
+
+func (p loadTestRunPoller) Poll(ctx context.Context) (*pollers.PollResult, error) {
+	resp, err := p.client.GetTestRun(ctx, p.testRunId)
+	if err != nil {
+		return nil, fmt.Errorf("retrieving Test Run %q: %+v", p.testRunId, err)
+	}
+
+	status := azuresdkhacks.TestRunStatus("")
+	if model := resp.Model; model != nil {
+		status = pointer.From(model.Status)
+	}
+
+	if IsLoadTestRunTerminalStatus(status) {
+		return &pollingSuccess, nil
+	}
+	return &pollingInProgress, nil
+}
+
+func IsLoadTestRunTerminalStatus(status azuresdkhacks.TestRunStatus) bool {
+	for _, v := range LoadTestRunTerminalStatuses {
+		if v == status {
+			return true
+		}
+	}
+	return false
+}
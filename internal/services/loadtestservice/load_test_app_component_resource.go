@@ -0,0 +1,269 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package loadtestservice
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/loadtestservice/2022-12-01/loadtests"
+	"github.com/hashicorp/terraform-provider-azurerm/helpers/azure"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/loadtestservice/azuresdkhacks"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/loadtestservice/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/loadtestservice/validate"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+)
+
+var _ sdk.Resource = LoadTestAppComponentResource{}
+
+type LoadTestAppComponentResource struct{}
+
+type LoadTestAppComponentResourceModel struct {
+	LoadTestTestId   string `tfschema:"load_test_test_id"`
+	TargetResourceId string `tfschema:"target_resource_id"`
+	Kind             string `tfschema:"kind"`
+	ResourceName     string `tfschema:"resource_name"`
+	ResourceType     string `tfschema:"resource_type"`
+}
+
+func (r LoadTestAppComponentResource) ModelObject() interface{} {
+	return &LoadTestAppComponentResourceModel{}
+}
+
+func (r LoadTestAppComponentResource) IDValidationFunc() pluginsdk.SchemaValidateFunc {
+	return validate.LoadTestAppComponentID
+}
+
+func (r LoadTestAppComponentResource) ResourceType() string {
+	return "azurerm_load_test_app_component"
+}
+
+func (r LoadTestAppComponentResource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"load_test_test_id": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validate.LoadTestTestID,
+		},
+
+		"target_resource_id": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: azure.ValidateResourceID,
+		},
+
+		"kind": {
+			Type:         pluginsdk.TypeString,
+			Optional:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+		},
+	}
+}
+
+func (r LoadTestAppComponentResource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"resource_name": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+
+		"resource_type": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+	}
+}
+
+func (r LoadTestAppComponentResource) Create() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			var config LoadTestAppComponentResourceModel
+			if err := metadata.Decode(&config); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			testId, err := parse.LoadTestTestID(config.LoadTestTestId)
+			if err != nil {
+				return err
+			}
+
+			id := parse.NewLoadTestAppComponentID(*testId, config.TargetResourceId)
+
+			loadTestId := loadtests.NewLoadTestID(testId.SubscriptionId, testId.ResourceGroup, testId.LoadTestName)
+			client, err := metadata.Client.LoadTestService.DataPlaneClientForLoadTest(ctx, loadTestId)
+			if err != nil {
+				return fmt.Errorf("building Data Plane client for %s: %+v", id, err)
+			}
+
+			existing, err := client.GetAppComponents(ctx, testId.TestName)
+			if err != nil {
+				return fmt.Errorf("retrieving the App Components for %s: %+v", *testId, err)
+			}
+			if findLoadTestAppComponent(existing.Model, id.TargetResourceId) != nil {
+				return metadata.ResourceRequiresImport(r.ResourceType(), id)
+			}
+
+			resourceType, resourceName, err := loadTestAppComponentTypeAndName(id.TargetResourceId)
+			if err != nil {
+				return err
+			}
+
+			component := &azuresdkhacks.AppComponent{
+				ResourceId:   id.TargetResourceId,
+				ResourceName: resourceName,
+				ResourceType: resourceType,
+			}
+			if config.Kind != "" {
+				component.Kind = pointer.To(config.Kind)
+			}
+
+			payload := azuresdkhacks.TestAppComponents{
+				Components: &map[string]*azuresdkhacks.AppComponent{
+					id.TargetResourceId: component,
+				},
+			}
+			if _, err := client.CreateOrUpdateAppComponents(ctx, testId.TestName, payload); err != nil {
+				return fmt.Errorf("creating %s: %+v", id, err)
+			}
+
+			metadata.SetID(id)
+			return nil
+		},
+	}
+}
+
+func (r LoadTestAppComponentResource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			id, err := parse.LoadTestAppComponentID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			testId := id.LoadTestTestId
+			loadTestId := loadtests.NewLoadTestID(testId.SubscriptionId, testId.ResourceGroup, testId.LoadTestName)
+			client, err := metadata.Client.LoadTestService.DataPlaneClientForLoadTest(ctx, loadTestId)
+			if err != nil {
+				return fmt.Errorf("building Data Plane client for %s: %+v", *id, err)
+			}
+
+			resp, err := client.GetAppComponents(ctx, testId.TestName)
+			if err != nil {
+				if response.WasNotFound(resp.HttpResponse) {
+					return metadata.MarkAsGone(id)
+				}
+				return fmt.Errorf("retrieving %s: %+v", *id, err)
+			}
+
+			component := findLoadTestAppComponent(resp.Model, id.TargetResourceId)
+			if component == nil {
+				return metadata.MarkAsGone(id)
+			}
+
+			state := LoadTestAppComponentResourceModel{
+				LoadTestTestId:   testId.ID(),
+				TargetResourceId: id.TargetResourceId,
+				Kind:             pointer.From(component.Kind),
+				ResourceName:     component.ResourceName,
+				ResourceType:     component.ResourceType,
+			}
+
+			return metadata.Encode(&state)
+		},
+	}
+}
+
+func (r LoadTestAppComponentResource) Delete() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			id, err := parse.LoadTestAppComponentID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			testId := id.LoadTestTestId
+			loadTestId := loadtests.NewLoadTestID(testId.SubscriptionId, testId.ResourceGroup, testId.LoadTestName)
+			client, err := metadata.Client.LoadTestService.DataPlaneClientForLoadTest(ctx, loadTestId)
+			if err != nil {
+				return fmt.Errorf("building Data Plane client for %s: %+v", *id, err)
+			}
+
+			existing, err := client.GetAppComponents(ctx, testId.TestName)
+			if err != nil {
+				if response.WasNotFound(existing.HttpResponse) {
+					return nil
+				}
+				return fmt.Errorf("retrieving %s: %+v", *id, err)
+			}
+
+			// the key of the App Component may have been normalised by the service, so the existing key is used
+			key := id.TargetResourceId
+			if existing.Model != nil && existing.Model.Components != nil {
+				for k := range *existing.Model.Components {
+					if strings.EqualFold(k, id.TargetResourceId) {
+						key = k
+					}
+				}
+			}
+
+			// an App Component is removed by sending a `null` value within the JSON Merge Patch
+			payload := azuresdkhacks.TestAppComponents{
+				Components: &map[string]*azuresdkhacks.AppComponent{
+					key: nil,
+				},
+			}
+			if _, err := client.CreateOrUpdateAppComponents(ctx, testId.TestName, payload); err != nil {
+				return fmt.Errorf("deleting %s: %+v", *id, err)
+			}
+
+			return nil
+		},
+	}
+}
+
+func findLoadTestAppComponent(input *azuresdkhacks.TestAppComponents, targetResourceId string) *azuresdkhacks.AppComponent {
+	if input == nil || input.Components == nil {
+		return nil
+	}
+
+	for k, v := range *input.Components {
+		if v != nil && strings.EqualFold(k, targetResourceId) {
+			return v
+		}
+	}
+
+	return nil
+}
+
+// loadTestAppComponentTypeAndName returns the Resource Type (e.g. `Microsoft.Web/sites`) and name of the specified Resource ID
+func loadTestAppComponentTypeAndName(input string) (string, string, error) {
+	index := strings.LastIndex(strings.ToLower(input), "/providers/")
+	if index == -1 {
+		return "", "", fmt.Errorf("determining the Resource Type of %q: expected the ID to contain a `providers` segment", input)
+	}
+
+	segments := strings.Split(strings.Trim(input[index+len("/providers/"):], "/"), "/")
+	if len(segments) < 3 || len(segments)%2 != 1 {
+		return "", "", fmt.Errorf("determining the Resource Type of %q: expected the ID to be in the format `/providers/{namespace}/{type}/{name}`", input)
+	}
+
+	types := []string{segments[0]}
+	for i := 1; i < len(segments); i += 2 {
+		types = append(types, segments[i])
+	}
+
+	return strings.Join(types, "/"), segments[len(segments)-1], nil
+}
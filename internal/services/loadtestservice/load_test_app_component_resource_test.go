@@ -0,0 +1,121 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package loadtestservice_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-azure-sdk/resource-manager/loadtestservice/2022-12-01/loadtests"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/check"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/loadtestservice/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+)
+
+type LoadTestAppComponentTestResource struct{}
+
+func TestAccLoadTestAppComponent_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_load_test_app_component", "test")
+	r := LoadTestAppComponentTestResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("resource_type").HasValue("Microsoft.Web/sites"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccLoadTestAppComponent_requiresImport(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_load_test_app_component", "test")
+	r := LoadTestAppComponentTestResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.RequiresImportErrorStep(r.requiresImport),
+	})
+}
+
+func (r LoadTestAppComponentTestResource) Exists(ctx context.Context, clients *clients.Client, state *pluginsdk.InstanceState) (*bool, error) {
+	id, err := parse.LoadTestAppComponentID(state.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	testId := id.LoadTestTestId
+	client, err := clients.LoadTestService.DataPlaneClientForLoadTest(ctx, loadtests.NewLoadTestID(testId.SubscriptionId, testId.ResourceGroup, testId.LoadTestName))
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.GetAppComponents(ctx, testId.TestName)
+	if err != nil {
+		return nil, fmt.Errorf("retrieving %s: %+v", *id, err)
+	}
+
+	if resp.Model != nil && resp.Model.Components != nil {
+		for k, v := range *resp.Model.Components {
+			if v != nil && strings.EqualFold(k, id.TargetResourceId) {
+				return utils.Bool(true), nil
+			}
+		}
+	}
+
+	return utils.Bool(false), nil
+}
+
+func (r LoadTestAppComponentTestResource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_service_plan" "test" {
+  name                = "acctestsp-%[2]d"
+  location            = azurerm_resource_group.test.location
+  resource_group_name = azurerm_resource_group.test.name
+  os_type             = "Linux"
+  sku_name            = "B1"
+}
+
+resource "azurerm_linux_web_app" "test" {
+  name                = "acctestwa-%[2]d"
+  location            = azurerm_resource_group.test.location
+  resource_group_name = azurerm_resource_group.test.name
+  service_plan_id     = azurerm_service_plan.test.id
+
+  site_config {}
+}
+
+resource "azurerm_load_test_app_component" "test" {
+  load_test_test_id  = azurerm_load_test_test.test.id
+  target_resource_id = azurerm_linux_web_app.test.id
+  kind               = "app"
+}
+`, LoadTestTestTestResource{}.basic(data), data.RandomInteger)
+}
+
+func (r LoadTestAppComponentTestResource) requiresImport(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_load_test_app_component" "import" {
+  load_test_test_id  = azurerm_load_test_app_component.test.load_test_test_id
+  target_resource_id = azurerm_load_test_app_component.test.target_resource_id
+  kind               = azurerm_load_test_app_component.test.kind
+}
+`, r.basic(data))
+}
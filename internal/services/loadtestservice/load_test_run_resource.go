@@ -0,0 +1,353 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package loadtestservice
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/loadtestservice/2022-12-01/loadtests"
+	"github.com/hashicorp/go-azure-sdk/sdk/client/pollers"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/loadtestservice/azuresdkhacks"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/loadtestservice/custompollers"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/loadtestservice/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/loadtestservice/validate"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+)
+
+var _ sdk.Resource = LoadTestRunResource{}
+
+type LoadTestRunResource struct{}
+
+type LoadTestRunResourceModel struct {
+	Name                 string            `tfschema:"name"`
+	LoadTestTestId       string            `tfschema:"load_test_test_id"`
+	DisplayName          string            `tfschema:"display_name"`
+	Description          string            `tfschema:"description"`
+	EnvironmentVariables map[string]string `tfschema:"environment_variables"`
+	Triggers             map[string]string `tfschema:"triggers"`
+	Status               string            `tfschema:"status"`
+	TestResult           string            `tfschema:"test_result"`
+	StartTime            string            `tfschema:"start_time"`
+	EndTime              string            `tfschema:"end_time"`
+	VirtualUsers         int64             `tfschema:"virtual_users"`
+	PortalUrl            string            `tfschema:"portal_url"`
+}
+
+func (r LoadTestRunResource) ModelObject() interface{} {
+	return &LoadTestRunResourceModel{}
+}
+
+func (r LoadTestRunResource) IDValidationFunc() pluginsdk.SchemaValidateFunc {
+	return validate.LoadTestRunID
+}
+
+func (r LoadTestRunResource) ResourceType() string {
+	return "azurerm_load_test_run"
+}
+
+func (r LoadTestRunResource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"name": {
+			Type:     pluginsdk.TypeString,
+			Required: true,
+			ForceNew: true,
+			ValidateFunc: validation.StringMatch(
+				regexp.MustCompile(`^[a-z0-9_-]{2,50}$`),
+				"`name` must be between 2 and 50 characters and can only contain lowercase letters, numbers, underscores and hyphens",
+			),
+		},
+
+		"load_test_test_id": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validate.LoadTestTestID,
+		},
+
+		"display_name": {
+			Type:         pluginsdk.TypeString,
+			Optional:     true,
+			Computed:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.StringLenBetween(2, 50),
+		},
+
+		"description": {
+			Type:         pluginsdk.TypeString,
+			Optional:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.StringLenBetween(1, 100),
+		},
+
+		"environment_variables": {
+			Type:     pluginsdk.TypeMap,
+			Optional: true,
+			ForceNew: true,
+			Elem: &pluginsdk.Schema{
+				Type: pluginsdk.TypeString,
+			},
+		},
+
+		"triggers": {
+			Type:     pluginsdk.TypeMap,
+			Optional: true,
+			ForceNew: true,
+			Elem: &pluginsdk.Schema{
+				Type: pluginsdk.TypeString,
+			},
+		},
+	}
+}
+
+func (r LoadTestRunResource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"status": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+
+		"test_result": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+
+		"start_time": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+
+		"end_time": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+
+		"virtual_users": {
+			Type:     pluginsdk.TypeInt,
+			Computed: true,
+		},
+
+		"portal_url": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+	}
+}
+
+func (r LoadTestRunResource) Create() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		// a Test Run can run for up to 24 hours
+		Timeout: 24 * time.Hour,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			var config LoadTestRunResourceModel
+			if err := metadata.Decode(&config); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			testId, err := parse.LoadTestTestID(config.LoadTestTestId)
+			if err != nil {
+				return err
+			}
+
+			id := parse.NewLoadTestRunID(testId.SubscriptionId, testId.ResourceGroup, testId.LoadTestName, config.Name)
+
+			loadTestId := loadtests.NewLoadTestID(testId.SubscriptionId, testId.ResourceGroup, testId.LoadTestName)
+			client, err := metadata.Client.LoadTestService.DataPlaneClientForLoadTest(ctx, loadTestId)
+			if err != nil {
+				return fmt.Errorf("building Data Plane client for %s: %+v", id, err)
+			}
+
+			existing, err := client.GetTestRun(ctx, id.TestRunName)
+			if err != nil && !response.WasNotFound(existing.HttpResponse) {
+				return fmt.Errorf("checking for the presence of an existing %s: %+v", id, err)
+			}
+			if !response.WasNotFound(existing.HttpResponse) {
+				return metadata.ResourceRequiresImport(r.ResourceType(), id)
+			}
+
+			payload := azuresdkhacks.TestRun{
+				TestId: pointer.To(testId.TestName),
+			}
+			if config.DisplayName != "" {
+				payload.DisplayName = pointer.To(config.DisplayName)
+			}
+			if config.Description != "" {
+				payload.Description = pointer.To(config.Description)
+			}
+			if len(config.EnvironmentVariables) > 0 {
+				payload.EnvironmentVariables = expandLoadTestEnvironmentVariables(config.EnvironmentVariables, nil)
+			}
+
+			if _, err := client.CreateOrUpdateTestRun(ctx, id.TestRunName, payload); err != nil {
+				return fmt.Errorf("creating %s: %+v", id, err)
+			}
+
+			metadata.SetID(id)
+
+			pollerType := custompollers.NewLoadTestRunPoller(client, id.TestRunName)
+			poller := pollers.NewPoller(pollerType, 10*time.Second, pollers.DefaultNumberOfDroppedConnectionsToAllow)
+			if err := poller.PollUntilDone(ctx); err != nil {
+				return fmt.Errorf("waiting for %s to finish: %+v", id, err)
+			}
+
+			resp, err := client.GetTestRun(ctx, id.TestRunName)
+			if err != nil {
+				return fmt.Errorf("retrieving %s: %+v", id, err)
+			}
+			if resp.Model == nil {
+				return fmt.Errorf("retrieving %s: `model` was nil", id)
+			}
+
+			// the Test Run is intentionally kept in the state, so that it's re-run (by being replaced) during the next apply
+			switch status := pointer.From(resp.Model.Status); status {
+			case azuresdkhacks.TestRunStatusFAILED, azuresdkhacks.TestRunStatusVALIDATIONFAILURE, azuresdkhacks.TestRunStatusCANCELLED:
+				return fmt.Errorf("%s finished with the status %q: %s", id, string(status), flattenLoadTestRunErrorDetails(resp.Model.ErrorDetails))
+			}
+
+			if pointer.From(resp.Model.TestResult) == azuresdkhacks.PFTestResultFAILED {
+				return fmt.Errorf("%s violated the pass/fail criteria of the Test:\n%s", id, flattenLoadTestRunFailedCriteria(resp.Model.PassFailCriteria))
+			}
+
+			return nil
+		},
+	}
+}
+
+func (r LoadTestRunResource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			id, err := parse.LoadTestRunID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			loadTestId := loadtests.NewLoadTestID(id.SubscriptionId, id.ResourceGroup, id.LoadTestName)
+			client, err := metadata.Client.LoadTestService.DataPlaneClientForLoadTest(ctx, loadTestId)
+			if err != nil {
+				return fmt.Errorf("building Data Plane client for %s: %+v", *id, err)
+			}
+
+			var state LoadTestRunResourceModel
+			if err := metadata.Decode(&state); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			resp, err := client.GetTestRun(ctx, id.TestRunName)
+			if err != nil {
+				if response.WasNotFound(resp.HttpResponse) {
+					return metadata.MarkAsGone(id)
+				}
+				return fmt.Errorf("retrieving %s: %+v", *id, err)
+			}
+
+			state.Name = id.TestRunName
+
+			if model := resp.Model; model != nil {
+				state.LoadTestTestId = parse.NewLoadTestTestID(id.SubscriptionId, id.ResourceGroup, id.LoadTestName, pointer.From(model.TestId)).ID()
+				state.DisplayName = pointer.From(model.DisplayName)
+				state.Description = pointer.From(model.Description)
+				state.EnvironmentVariables = flattenLoadTestEnvironmentVariables(model.EnvironmentVariables)
+				state.Status = string(pointer.From(model.Status))
+				state.TestResult = string(pointer.From(model.TestResult))
+				state.StartTime = pointer.From(model.StartDateTime)
+				state.EndTime = pointer.From(model.EndDateTime)
+				state.VirtualUsers = pointer.From(model.VirtualUsers)
+				state.PortalUrl = pointer.From(model.PortalUrl)
+			}
+
+			return metadata.Encode(&state)
+		},
+	}
+}
+
+func (r LoadTestRunResource) Delete() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			id, err := parse.LoadTestRunID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			loadTestId := loadtests.NewLoadTestID(id.SubscriptionId, id.ResourceGroup, id.LoadTestName)
+			client, err := metadata.Client.LoadTestService.DataPlaneClientForLoadTest(ctx, loadTestId)
+			if err != nil {
+				return fmt.Errorf("building Data Plane client for %s: %+v", *id, err)
+			}
+
+			existing, err := client.GetTestRun(ctx, id.TestRunName)
+			if err != nil {
+				if response.WasNotFound(existing.HttpResponse) {
+					return nil
+				}
+				return fmt.Errorf("retrieving %s: %+v", *id, err)
+			}
+
+			// a Test Run which is still in progress has to be stopped before it can be deleted
+			if model := existing.Model; model != nil && !custompollers.IsLoadTestRunTerminalStatus(pointer.From(model.Status)) {
+				if _, err := client.StopTestRun(ctx, id.TestRunName); err != nil {
+					return fmt.Errorf("stopping %s: %+v", *id, err)
+				}
+
+				pollerType := custompollers.NewLoadTestRunPoller(client, id.TestRunName)
+				poller := pollers.NewPoller(pollerType, 10*time.Second, pollers.DefaultNumberOfDroppedConnectionsToAllow)
+				if err := poller.PollUntilDone(ctx); err != nil {
+					return fmt.Errorf("waiting for %s to stop: %+v", *id, err)
+				}
+			}
+
+			if resp, err := client.DeleteTestRun(ctx, id.TestRunName); err != nil && !response.WasNotFound(resp.HttpResponse) {
+				return fmt.Errorf("deleting %s: %+v", *id, err)
+			}
+
+			return nil
+		},
+	}
+}
+
+func flattenLoadTestRunErrorDetails(input *[]azuresdkhacks.ErrorDetails) string {
+	if input == nil {
+		return ""
+	}
+
+	messages := make([]string, 0)
+	for _, v := range *input {
+		if v.Message != nil {
+			messages = append(messages, *v.Message)
+		}
+	}
+
+	return strings.Join(messages, "; ")
+}
+
+func flattenLoadTestRunFailedCriteria(input *azuresdkhacks.PassFailCriteria) string {
+	if input == nil || input.PassFailMetrics == nil {
+		return ""
+	}
+
+	failures := make([]string, 0)
+	for _, v := range *input.PassFailMetrics {
+		if v == nil || pointer.From(v.Result) != azuresdkhacks.PFResultFailed {
+			continue
+		}
+
+		criterion := fmt.Sprintf("%s(%s) %s %v", string(pointer.From(v.Aggregate)), string(pointer.From(v.ClientMetric)), pointer.From(v.Condition), pointer.From(v.Value))
+		if v.RequestName != nil {
+			criterion = fmt.Sprintf("%s for request %q", criterion, *v.RequestName)
+		}
+		failures = append(failures, fmt.Sprintf("* %s (actual value: %v)", criterion, pointer.From(v.ActualValue)))
+	}
+	sort.Strings(failures)
+
+	return strings.Join(failures, "\n")
+}
@@ -0,0 +1,117 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package loadtestservice_test
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/go-azure-sdk/resource-manager/loadtestservice/2022-12-01/loadtests"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/check"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/loadtestservice/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+)
+
+type LoadTestRunTestResource struct{}
+
+func TestAccLoadTestRun_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_load_test_run", "test")
+	r := LoadTestRunTestResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("status").HasValue("DONE"),
+				check.That(data.ResourceName).Key("test_result").HasValue("PASSED"),
+				check.That(data.ResourceName).Key("start_time").IsNotEmpty(),
+			),
+		},
+		data.ImportStep("triggers"),
+	})
+}
+
+func TestAccLoadTestRun_criteriaViolated(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_load_test_run", "test")
+	r := LoadTestRunTestResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config:      r.criteriaViolated(data),
+			ExpectError: regexp.MustCompile("violated the pass/fail criteria"),
+		},
+	})
+}
+
+func (r LoadTestRunTestResource) Exists(ctx context.Context, clients *clients.Client, state *pluginsdk.InstanceState) (*bool, error) {
+	id, err := parse.LoadTestRunID(state.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := clients.LoadTestService.DataPlaneClientForLoadTest(ctx, loadtests.NewLoadTestID(id.SubscriptionId, id.ResourceGroup, id.LoadTestName))
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.GetTestRun(ctx, id.TestRunName)
+	if err != nil {
+		return nil, fmt.Errorf("retrieving %s: %+v", *id, err)
+	}
+
+	return utils.Bool(resp.Model != nil), nil
+}
+
+func (r LoadTestRunTestResource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_load_test_run" "test" {
+  name              = "acctest-run-%[2]d"
+  load_test_test_id = azurerm_load_test_test.test.id
+  display_name      = "Acceptance Test Run"
+
+  triggers = {
+    run = "1"
+  }
+}
+`, LoadTestTestTestResource{}.basic(data), data.RandomInteger)
+}
+
+func (r LoadTestRunTestResource) criteriaViolated(data acceptance.TestData) string {
+	template := LoadTestTestTestResource{}.template(data)
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_load_test_test" "test" {
+  name         = "acctest-%[2]d"
+  load_test_id = azurerm_load_test.test.id
+
+  test_script {
+    file_name = "test.jmx"
+    content   = local.jmx_script
+  }
+
+  pass_fail_criterion {
+    client_metric = "response_time_ms"
+    aggregate     = "avg"
+    condition     = ">"
+    value         = 0
+  }
+
+  depends_on = [azurerm_role_assignment.test]
+}
+
+resource "azurerm_load_test_run" "test" {
+  name              = "acctest-run-%[2]d"
+  load_test_test_id = azurerm_load_test_test.test.id
+}
+`, template, data.RandomInteger)
+}
@@ -0,0 +1,707 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package loadtestservice
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/loadtestservice/2022-12-01/loadtests"
+	"github.com/hashicorp/go-azure-sdk/sdk/client/pollers"
+	"github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	keyVaultValidate "github.com/hashicorp/terraform-provider-azurerm/internal/services/keyvault/validate"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/loadtestservice/azuresdkhacks"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/loadtestservice/custompollers"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/loadtestservice/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/loadtestservice/validate"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+)
+
+var _ sdk.ResourceWithUpdate = LoadTestTestResource{}
+
+type LoadTestTestResource struct{}
+
+type LoadTestTestResourceModel struct {
+	Name                        string                           `tfschema:"name"`
+	LoadTestId                  string                           `tfschema:"load_test_id"`
+	DisplayName                 string                           `tfschema:"display_name"`
+	Description                 string                           `tfschema:"description"`
+	Kind                        string                           `tfschema:"kind"`
+	EngineInstances             int64                            `tfschema:"engine_instances"`
+	SplitCSVEnabled             bool                             `tfschema:"split_csv_enabled"`
+	TestScript                  []LoadTestTestFileModel          `tfschema:"test_script"`
+	AdditionalFiles             []LoadTestTestFileModel          `tfschema:"additional_file"`
+	EnvironmentVariables        map[string]string                `tfschema:"environment_variables"`
+	Secrets                     []LoadTestTestSecretModel        `tfschema:"secret"`
+	KeyVaultReferenceIdentityId string                           `tfschema:"key_vault_reference_identity_id"`
+	PassFailCriteria            []LoadTestPassFailCriterionModel `tfschema:"pass_fail_criterion"`
+}
+
+type LoadTestTestFileModel struct {
+	FileName string `tfschema:"file_name"`
+	Content  string `tfschema:"content"`
+}
+
+type LoadTestTestSecretModel struct {
+	Name             string `tfschema:"name"`
+	KeyVaultSecretId string `tfschema:"key_vault_secret_id"`
+}
+
+type LoadTestPassFailCriterionModel struct {
+	ClientMetric string  `tfschema:"client_metric"`
+	Aggregate    string  `tfschema:"aggregate"`
+	Condition    string  `tfschema:"condition"`
+	Value        float64 `tfschema:"value"`
+	RequestName  string  `tfschema:"request_name"`
+	Action       string  `tfschema:"action"`
+}
+
+func (r LoadTestTestResource) ModelObject() interface{} {
+	return &LoadTestTestResourceModel{}
+}
+
+func (r LoadTestTestResource) IDValidationFunc() pluginsdk.SchemaValidateFunc {
+	return validate.LoadTestTestID
+}
+
+func (r LoadTestTestResource) ResourceType() string {
+	return "azurerm_load_test_test"
+}
+
+func (r LoadTestTestResource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"name": {
+			Type:     pluginsdk.TypeString,
+			Required: true,
+			ForceNew: true,
+			ValidateFunc: validation.StringMatch(
+				regexp.MustCompile(`^[a-z0-9_-]{2,50}$`),
+				"`name` must be between 2 and 50 characters and can only contain lowercase letters, numbers, underscores and hyphens",
+			),
+		},
+
+		"load_test_id": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: loadtests.ValidateLoadTestID,
+		},
+
+		"test_script": {
+			Type:     pluginsdk.TypeList,
+			Required: true,
+			MaxItems: 1,
+			Elem:     loadTestTestFileSchema(),
+		},
+
+		"display_name": {
+			Type:         pluginsdk.TypeString,
+			Optional:     true,
+			Computed:     true,
+			ValidateFunc: validation.StringLenBetween(2, 50),
+		},
+
+		"description": {
+			Type:         pluginsdk.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.StringLenBetween(1, 100),
+		},
+
+		"kind": {
+			Type:     pluginsdk.TypeString,
+			Optional: true,
+			ForceNew: true,
+			Default:  string(azuresdkhacks.TestKindJMX),
+			ValidateFunc: validation.StringInSlice([]string{
+				string(azuresdkhacks.TestKindJMX),
+				string(azuresdkhacks.TestKindLocust),
+			}, false),
+		},
+
+		"engine_instances": {
+			Type:         pluginsdk.TypeInt,
+			Optional:     true,
+			Default:      1,
+			ValidateFunc: validation.IntBetween(1, 400),
+		},
+
+		"split_csv_enabled": {
+			Type:     pluginsdk.TypeBool,
+			Optional: true,
+			Default:  false,
+		},
+
+		"additional_file": {
+			Type:     pluginsdk.TypeList,
+			Optional: true,
+			Elem:     loadTestTestFileSchema(),
+		},
+
+		"environment_variables": {
+			Type:     pluginsdk.TypeMap,
+			Optional: true,
+			Elem: &pluginsdk.Schema{
+				Type: pluginsdk.TypeString,
+			},
+		},
+
+		"secret": {
+			Type:     pluginsdk.TypeSet,
+			Optional: true,
+			Elem: &pluginsdk.Resource{
+				Schema: map[string]*pluginsdk.Schema{
+					"name": {
+						Type:         pluginsdk.TypeString,
+						Required:     true,
+						ValidateFunc: validation.StringIsNotEmpty,
+					},
+
+					"key_vault_secret_id": {
+						Type:         pluginsdk.TypeString,
+						Required:     true,
+						ValidateFunc: keyVaultValidate.NestedItemIdWithOptionalVersion,
+					},
+				},
+			},
+		},
+
+		"key_vault_reference_identity_id": {
+			Type:         pluginsdk.TypeString,
+			Optional:     true,
+			ValidateFunc: commonids.ValidateUserAssignedIdentityID,
+		},
+
+		"pass_fail_criterion": {
+			Type:     pluginsdk.TypeSet,
+			Optional: true,
+			Elem: &pluginsdk.Resource{
+				Schema: map[string]*pluginsdk.Schema{
+					"client_metric": {
+						Type:         pluginsdk.TypeString,
+						Required:     true,
+						ValidateFunc: validation.StringInSlice(azuresdkhacks.PossibleValuesForPFMetrics(), false),
+					},
+
+					"aggregate": {
+						Type:         pluginsdk.TypeString,
+						Required:     true,
+						ValidateFunc: validation.StringInSlice(azuresdkhacks.PossibleValuesForPFAgFunc(), false),
+					},
+
+					"condition": {
+						Type:     pluginsdk.TypeString,
+						Required: true,
+						ValidateFunc: validation.StringInSlice([]string{
+							"<",
+							">",
+						}, false),
+					},
+
+					"value": {
+						Type:     pluginsdk.TypeFloat,
+						Required: true,
+					},
+
+					"request_name": {
+						Type:         pluginsdk.TypeString,
+						Optional:     true,
+						ValidateFunc: validation.StringIsNotEmpty,
+					},
+
+					"action": {
+						Type:         pluginsdk.TypeString,
+						Optional:     true,
+						Default:      string(azuresdkhacks.PFActionContinue),
+						ValidateFunc: validation.StringInSlice(azuresdkhacks.PossibleValuesForPFAction(), false),
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r LoadTestTestResource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{}
+}
+
+func (r LoadTestTestResource) Create() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			var config LoadTestTestResourceModel
+			if err := metadata.Decode(&config); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			loadTestId, err := loadtests.ParseLoadTestID(config.LoadTestId)
+			if err != nil {
+				return err
+			}
+
+			id := parse.NewLoadTestTestID(loadTestId.SubscriptionId, loadTestId.ResourceGroupName, loadTestId.LoadTestName, config.Name)
+
+			client, err := metadata.Client.LoadTestService.DataPlaneClientForLoadTest(ctx, *loadTestId)
+			if err != nil {
+				return fmt.Errorf("building Data Plane client for %s: %+v", id, err)
+			}
+
+			existing, err := client.GetTest(ctx, id.TestName)
+			if err != nil && !response.WasNotFound(existing.HttpResponse) {
+				return fmt.Errorf("checking for the presence of an existing %s: %+v", id, err)
+			}
+			if !response.WasNotFound(existing.HttpResponse) {
+				return metadata.ResourceRequiresImport(r.ResourceType(), id)
+			}
+
+			passFailCriteria, err := expandLoadTestPassFailCriteria(config.PassFailCriteria, nil)
+			if err != nil {
+				return err
+			}
+
+			payload := azuresdkhacks.Test{
+				Description:          pointer.To(config.Description),
+				EnvironmentVariables: expandLoadTestEnvironmentVariables(config.EnvironmentVariables, nil),
+				Kind:                 pointer.To(azuresdkhacks.TestKind(config.Kind)),
+				LoadTestConfiguration: &azuresdkhacks.LoadTestConfiguration{
+					EngineInstances: pointer.To(config.EngineInstances),
+					SplitAllCSVs:    pointer.To(config.SplitCSVEnabled),
+				},
+				PassFailCriteria: passFailCriteria,
+				Secrets:          expandLoadTestSecrets(config.Secrets, nil),
+			}
+			if config.DisplayName != "" {
+				payload.DisplayName = pointer.To(config.DisplayName)
+			}
+			expandLoadTestKeyVaultReferenceIdentity(&payload, config.KeyVaultReferenceIdentityId)
+
+			if _, err := client.CreateOrUpdateTest(ctx, id.TestName, payload); err != nil {
+				return fmt.Errorf("creating %s: %+v", id, err)
+			}
+
+			metadata.SetID(id)
+
+			if err := uploadLoadTestTestScript(ctx, client, id, config.Kind, config.TestScript); err != nil {
+				return err
+			}
+
+			for _, file := range config.AdditionalFiles {
+				if _, err := client.UploadTestFile(ctx, id.TestName, file.FileName, azuresdkhacks.FileTypeADDITIONALARTIFACTS, []byte(file.Content)); err != nil {
+					return fmt.Errorf("uploading additional file %q for %s: %+v", file.FileName, id, err)
+				}
+			}
+
+			return nil
+		},
+	}
+}
+
+func (r LoadTestTestResource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			id, err := parse.LoadTestTestID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			loadTestId := loadtests.NewLoadTestID(id.SubscriptionId, id.ResourceGroup, id.LoadTestName)
+			client, err := metadata.Client.LoadTestService.DataPlaneClientForLoadTest(ctx, loadTestId)
+			if err != nil {
+				return fmt.Errorf("building Data Plane client for %s: %+v", *id, err)
+			}
+
+			var state LoadTestTestResourceModel
+			if err := metadata.Decode(&state); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			resp, err := client.GetTest(ctx, id.TestName)
+			if err != nil {
+				if response.WasNotFound(resp.HttpResponse) {
+					return metadata.MarkAsGone(id)
+				}
+				return fmt.Errorf("retrieving %s: %+v", *id, err)
+			}
+
+			state.Name = id.TestName
+			state.LoadTestId = loadTestId.ID()
+
+			if model := resp.Model; model != nil {
+				state.DisplayName = pointer.From(model.DisplayName)
+				state.Description = pointer.From(model.Description)
+				state.Kind = string(azuresdkhacks.TestKindJMX)
+				if model.Kind != nil {
+					state.Kind = string(*model.Kind)
+				}
+
+				state.EngineInstances = 1
+				state.SplitCSVEnabled = false
+				if config := model.LoadTestConfiguration; config != nil {
+					state.EngineInstances = pointer.From(config.EngineInstances)
+					state.SplitCSVEnabled = pointer.From(config.SplitAllCSVs)
+				}
+
+				state.EnvironmentVariables = flattenLoadTestEnvironmentVariables(model.EnvironmentVariables)
+				state.Secrets = flattenLoadTestSecrets(model.Secrets)
+				state.KeyVaultReferenceIdentityId = pointer.From(model.KeyvaultReferenceIdentityId)
+				state.PassFailCriteria = flattenLoadTestPassFailCriteria(model.PassFailCriteria)
+
+				// the contents of the files can't be retrieved, so the values from the state are retained where the file still exists
+				testScript := make([]LoadTestTestFileModel, 0)
+				additionalFiles := make([]LoadTestTestFileModel, 0)
+				if artifacts := model.InputArtifacts; artifacts != nil {
+					if info := artifacts.TestScriptFileInfo; info != nil && info.FileName != nil {
+						file := LoadTestTestFileModel{
+							FileName: *info.FileName,
+						}
+						if len(state.TestScript) > 0 && state.TestScript[0].FileName == file.FileName {
+							file.Content = state.TestScript[0].Content
+						}
+						testScript = append(testScript, file)
+					}
+
+					if artifacts.AdditionalFileInfo != nil {
+						existingFiles := make(map[string]struct{})
+						for _, info := range *artifacts.AdditionalFileInfo {
+							existingFiles[pointer.From(info.FileName)] = struct{}{}
+						}
+						for _, file := range state.AdditionalFiles {
+							if _, ok := existingFiles[file.FileName]; ok {
+								additionalFiles = append(additionalFiles, file)
+							}
+						}
+					}
+				}
+				state.TestScript = testScript
+				state.AdditionalFiles = additionalFiles
+			}
+
+			return metadata.Encode(&state)
+		},
+	}
+}
+
+func (r LoadTestTestResource) Update() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			id, err := parse.LoadTestTestID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			var config LoadTestTestResourceModel
+			if err := metadata.Decode(&config); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			loadTestId := loadtests.NewLoadTestID(id.SubscriptionId, id.ResourceGroup, id.LoadTestName)
+			client, err := metadata.Client.LoadTestService.DataPlaneClientForLoadTest(ctx, loadTestId)
+			if err != nil {
+				return fmt.Errorf("building Data Plane client for %s: %+v", *id, err)
+			}
+
+			existing, err := client.GetTest(ctx, id.TestName)
+			if err != nil {
+				return fmt.Errorf("retrieving %s: %+v", *id, err)
+			}
+			if existing.Model == nil {
+				return fmt.Errorf("retrieving %s: `model` was nil", *id)
+			}
+
+			// the Test is updated using a JSON Merge Patch, so only the changed properties are sent - and removed
+			// items within maps are sent with a `null` value
+			payload := azuresdkhacks.Test{}
+			d := metadata.ResourceData
+
+			if d.HasChange("display_name") {
+				payload.DisplayName = pointer.To(config.DisplayName)
+			}
+
+			if d.HasChange("description") {
+				payload.Description = pointer.To(config.Description)
+			}
+
+			if d.HasChanges("engine_instances", "split_csv_enabled") {
+				payload.LoadTestConfiguration = &azuresdkhacks.LoadTestConfiguration{
+					EngineInstances: pointer.To(config.EngineInstances),
+					SplitAllCSVs:    pointer.To(config.SplitCSVEnabled),
+				}
+			}
+
+			if d.HasChange("environment_variables") {
+				payload.EnvironmentVariables = expandLoadTestEnvironmentVariables(config.EnvironmentVariables, existing.Model.EnvironmentVariables)
+			}
+
+			if d.HasChange("secret") {
+				payload.Secrets = expandLoadTestSecrets(config.Secrets, existing.Model.Secrets)
+			}
+
+			if d.HasChange("key_vault_reference_identity_id") {
+				expandLoadTestKeyVaultReferenceIdentity(&payload, config.KeyVaultReferenceIdentityId)
+			}
+
+			if d.HasChange("pass_fail_criterion") {
+				payload.PassFailCriteria, err = expandLoadTestPassFailCriteria(config.PassFailCriteria, existing.Model.PassFailCriteria)
+				if err != nil {
+					return err
+				}
+			}
+
+			if _, err := client.CreateOrUpdateTest(ctx, id.TestName, payload); err != nil {
+				return fmt.Errorf("updating %s: %+v", *id, err)
+			}
+
+			if d.HasChange("test_script") {
+				oldRaw, _ := d.GetChange("test_script")
+				if oldScripts := oldRaw.([]interface{}); len(oldScripts) > 0 && oldScripts[0] != nil {
+					oldFileName := oldScripts[0].(map[string]interface{})["file_name"].(string)
+					if oldFileName != config.TestScript[0].FileName {
+						if resp, err := client.DeleteTestFile(ctx, id.TestName, oldFileName); err != nil && !response.WasNotFound(resp.HttpResponse) {
+							return fmt.Errorf("deleting test script %q for %s: %+v", oldFileName, *id, err)
+						}
+					}
+				}
+
+				if err := uploadLoadTestTestScript(ctx, client, *id, config.Kind, config.TestScript); err != nil {
+					return err
+				}
+			}
+
+			if d.HasChange("additional_file") {
+				newFileNames := make(map[string]struct{})
+				for _, file := range config.AdditionalFiles {
+					newFileNames[file.FileName] = struct{}{}
+				}
+
+				oldRaw, _ := d.GetChange("additional_file")
+				for _, raw := range oldRaw.([]interface{}) {
+					if raw == nil {
+						continue
+					}
+					oldFileName := raw.(map[string]interface{})["file_name"].(string)
+					if _, ok := newFileNames[oldFileName]; ok {
+						continue
+					}
+					if resp, err := client.DeleteTestFile(ctx, id.TestName, oldFileName); err != nil && !response.WasNotFound(resp.HttpResponse) {
+						return fmt.Errorf("deleting additional file %q for %s: %+v", oldFileName, *id, err)
+					}
+				}
+
+				for _, file := range config.AdditionalFiles {
+					if _, err := client.UploadTestFile(ctx, id.TestName, file.FileName, azuresdkhacks.FileTypeADDITIONALARTIFACTS, []byte(file.Content)); err != nil {
+						return fmt.Errorf("uploading additional file %q for %s: %+v", file.FileName, *id, err)
+					}
+				}
+			}
+
+			return nil
+		},
+	}
+}
+
+func (r LoadTestTestResource) Delete() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			id, err := parse.LoadTestTestID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			loadTestId := loadtests.NewLoadTestID(id.SubscriptionId, id.ResourceGroup, id.LoadTestName)
+			client, err := metadata.Client.LoadTestService.DataPlaneClientForLoadTest(ctx, loadTestId)
+			if err != nil {
+				return fmt.Errorf("building Data Plane client for %s: %+v", *id, err)
+			}
+
+			if resp, err := client.DeleteTest(ctx, id.TestName); err != nil && !response.WasNotFound(resp.HttpResponse) {
+				return fmt.Errorf("deleting %s: %+v", *id, err)
+			}
+
+			return nil
+		},
+	}
+}
+
+func loadTestTestFileSchema() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		Schema: map[string]*pluginsdk.Schema{
+			"file_name": {
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			"content": {
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+		},
+	}
+}
+
+// uploadLoadTestTestScript uploads the test script and then waits for the service to validate it, since a
+// Test can't be run until the test script has been validated successfully
+func uploadLoadTestTestScript(ctx context.Context, client *azuresdkhacks.LoadTestingClient, id parse.LoadTestTestId, kind string, input []LoadTestTestFileModel) error {
+	if len(input) == 0 {
+		return nil
+	}
+	script := input[0]
+
+	fileType := azuresdkhacks.FileTypeJMXFILE
+	if kind == string(azuresdkhacks.TestKindLocust) {
+		fileType = azuresdkhacks.FileTypeTESTSCRIPT
+	}
+
+	if _, err := client.UploadTestFile(ctx, id.TestName, script.FileName, fileType, []byte(script.Content)); err != nil {
+		return fmt.Errorf("uploading test script %q for %s: %+v", script.FileName, id, err)
+	}
+
+	pollerType := custompollers.NewLoadTestFileValidationPoller(client, id.TestName, script.FileName)
+	poller := pollers.NewPoller(pollerType, 10*time.Second, pollers.DefaultNumberOfDroppedConnectionsToAllow)
+	if err := poller.PollUntilDone(ctx); err != nil {
+		return fmt.Errorf("waiting for the test script %q for %s to be validated: %+v", script.FileName, id, err)
+	}
+
+	return nil
+}
+
+func expandLoadTestEnvironmentVariables(input map[string]string, existing *map[string]*string) *map[string]*string {
+	output := make(map[string]*string)
+	if existing != nil {
+		for k := range *existing {
+			output[k] = nil
+		}
+	}
+	for k, v := range input {
+		output[k] = pointer.To(v)
+	}
+	return &output
+}
+
+func flattenLoadTestEnvironmentVariables(input *map[string]*string) map[string]string {
+	output := make(map[string]string)
+	if input == nil {
+		return output
+	}
+	for k, v := range *input {
+		if v != nil {
+			output[k] = *v
+		}
+	}
+	return output
+}
+
+func expandLoadTestSecrets(input []LoadTestTestSecretModel, existing *map[string]*azuresdkhacks.Secret) *map[string]*azuresdkhacks.Secret {
+	output := make(map[string]*azuresdkhacks.Secret)
+	if existing != nil {
+		for k := range *existing {
+			output[k] = nil
+		}
+	}
+	for _, v := range input {
+		output[v.Name] = &azuresdkhacks.Secret{
+			Type:  pointer.To(azuresdkhacks.SecretTypeAKVSECRETURI),
+			Value: pointer.To(v.KeyVaultSecretId),
+		}
+	}
+	return &output
+}
+
+func flattenLoadTestSecrets(input *map[string]*azuresdkhacks.Secret) []LoadTestTestSecretModel {
+	output := make([]LoadTestTestSecretModel, 0)
+	if input == nil {
+		return output
+	}
+	for k, v := range *input {
+		if v == nil {
+			continue
+		}
+		output = append(output, LoadTestTestSecretModel{
+			Name:             k,
+			KeyVaultSecretId: pointer.From(v.Value),
+		})
+	}
+	return output
+}
+
+func expandLoadTestKeyVaultReferenceIdentity(payload *azuresdkhacks.Test, identityId string) {
+	if identityId == "" {
+		payload.KeyvaultReferenceIdentityType = pointer.To("SystemAssigned")
+		return
+	}
+	payload.KeyvaultReferenceIdentityType = pointer.To("UserAssigned")
+	payload.KeyvaultReferenceIdentityId = pointer.To(identityId)
+}
+
+func expandLoadTestPassFailCriteria(input []LoadTestPassFailCriterionModel, existing *azuresdkhacks.PassFailCriteria) (*azuresdkhacks.PassFailCriteria, error) {
+	metrics := make(map[string]*azuresdkhacks.PassFailMetric)
+	if existing != nil && existing.PassFailMetrics != nil {
+		for k := range *existing.PassFailMetrics {
+			metrics[k] = nil
+		}
+	}
+
+	// the criteria are keyed by an arbitrary unique identifier, so each criterion is recreated with a new key
+	for _, v := range input {
+		key, err := uuid.GenerateUUID()
+		if err != nil {
+			return nil, fmt.Errorf("generating a key for the pass/fail criterion: %+v", err)
+		}
+
+		metric := &azuresdkhacks.PassFailMetric{
+			Action:       pointer.To(azuresdkhacks.PFAction(v.Action)),
+			Aggregate:    pointer.To(azuresdkhacks.PFAgFunc(v.Aggregate)),
+			ClientMetric: pointer.To(azuresdkhacks.PFMetrics(v.ClientMetric)),
+			Condition:    pointer.To(v.Condition),
+			Value:        pointer.To(v.Value),
+		}
+		if v.RequestName != "" {
+			metric.RequestName = pointer.To(v.RequestName)
+		}
+		metrics[key] = metric
+	}
+
+	return &azuresdkhacks.PassFailCriteria{
+		PassFailMetrics: &metrics,
+	}, nil
+}
+
+func flattenLoadTestPassFailCriteria(input *azuresdkhacks.PassFailCriteria) []LoadTestPassFailCriterionModel {
+	output := make([]LoadTestPassFailCriterionModel, 0)
+	if input == nil || input.PassFailMetrics == nil {
+		return output
+	}
+
+	for _, v := range *input.PassFailMetrics {
+		if v == nil {
+			continue
+		}
+
+		action := string(azuresdkhacks.PFActionContinue)
+		if v.Action != nil {
+			action = string(*v.Action)
+		}
+
+		output = append(output, LoadTestPassFailCriterionModel{
+			Action:       action,
+			Aggregate:    string(pointer.From(v.Aggregate)),
+			ClientMetric: string(pointer.From(v.ClientMetric)),
+			Condition:    pointer.From(v.Condition),
+			RequestName:  pointer.From(v.RequestName),
+			Value:        pointer.From(v.Value),
+		})
+	}
+
+	return output
+}
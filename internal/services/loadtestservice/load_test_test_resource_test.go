@@ -0,0 +1,351 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package loadtestservice_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/go-azure-sdk/resource-manager/loadtestservice/2022-12-01/loadtests"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/check"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/loadtestservice/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+)
+
+type LoadTestTestTestResource struct{}
+
+func TestAccLoadTestTest_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_load_test_test", "test")
+	r := LoadTestTestTestResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep("test_script.0.content"),
+	})
+}
+
+func TestAccLoadTestTest_requiresImport(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_load_test_test", "test")
+	r := LoadTestTestTestResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.RequiresImportErrorStep(r.requiresImport),
+	})
+}
+
+func TestAccLoadTestTest_complete(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_load_test_test", "test")
+	r := LoadTestTestTestResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.complete(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep("test_script.0.content", "additional_file"),
+	})
+}
+
+func TestAccLoadTestTest_update(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_load_test_test", "test")
+	r := LoadTestTestTestResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep("test_script.0.content"),
+		{
+			Config: r.complete(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep("test_script.0.content", "additional_file"),
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep("test_script.0.content"),
+	})
+}
+
+func TestAccLoadTestTest_locust(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_load_test_test", "test")
+	r := LoadTestTestTestResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.locust(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("kind").HasValue("Locust"),
+			),
+		},
+		data.ImportStep("test_script.0.content"),
+	})
+}
+
+func (r LoadTestTestTestResource) Exists(ctx context.Context, clients *clients.Client, state *pluginsdk.InstanceState) (*bool, error) {
+	id, err := parse.LoadTestTestID(state.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := clients.LoadTestService.DataPlaneClientForLoadTest(ctx, loadtests.NewLoadTestID(id.SubscriptionId, id.ResourceGroup, id.LoadTestName))
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.GetTest(ctx, id.TestName)
+	if err != nil {
+		return nil, fmt.Errorf("retrieving %s: %+v", *id, err)
+	}
+
+	return utils.Bool(resp.Model != nil), nil
+}
+
+func (r LoadTestTestTestResource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_load_test_test" "test" {
+  name         = "acctest-%[2]d"
+  load_test_id = azurerm_load_test.test.id
+
+  test_script {
+    file_name = "test.jmx"
+    content   = local.jmx_script
+  }
+
+  depends_on = [azurerm_role_assignment.test]
+}
+`, r.template(data), data.RandomInteger)
+}
+
+func (r LoadTestTestTestResource) requiresImport(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_load_test_test" "import" {
+  name         = azurerm_load_test_test.test.name
+  load_test_id = azurerm_load_test_test.test.load_test_id
+
+  test_script {
+    file_name = "test.jmx"
+    content   = local.jmx_script
+  }
+}
+`, r.basic(data))
+}
+
+func (r LoadTestTestTestResource) complete(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_key_vault" "test" {
+  name                       = "acctestkv%[3]s"
+  location                   = azurerm_resource_group.test.location
+  resource_group_name        = azurerm_resource_group.test.name
+  tenant_id                  = data.azurerm_client_config.current.tenant_id
+  sku_name                   = "standard"
+  soft_delete_retention_days = 7
+
+  access_policy {
+    tenant_id          = data.azurerm_client_config.current.tenant_id
+    object_id          = data.azurerm_client_config.current.object_id
+    secret_permissions = ["Delete", "Get", "Purge", "Set"]
+  }
+
+  access_policy {
+    tenant_id          = data.azurerm_client_config.current.tenant_id
+    object_id          = azurerm_user_assigned_identity.test.principal_id
+    secret_permissions = ["Get"]
+  }
+}
+
+resource "azurerm_key_vault_secret" "test" {
+  name         = "api-key"
+  value        = "s3cr3t"
+  key_vault_id = azurerm_key_vault.test.id
+}
+
+resource "azurerm_load_test_test" "test" {
+  name             = "acctest-%[2]d"
+  load_test_id     = azurerm_load_test.test.id
+  display_name     = "Acceptance Test %[2]d"
+  description      = "Performance gate for the acceptance tests"
+  engine_instances = 2
+
+  split_csv_enabled = true
+
+  test_script {
+    file_name = "test.jmx"
+    content   = local.jmx_script
+  }
+
+  additional_file {
+    file_name = "users.csv"
+    content   = "user\nalice\nbob\n"
+  }
+
+  environment_variables = {
+    HOST = "example.com"
+  }
+
+  secret {
+    name                = "apiKey"
+    key_vault_secret_id = azurerm_key_vault_secret.test.versionless_id
+  }
+
+  key_vault_reference_identity_id = azurerm_user_assigned_identity.test.id
+
+  pass_fail_criterion {
+    client_metric = "response_time_ms"
+    aggregate     = "avg"
+    condition     = ">"
+    value         = 1000
+  }
+
+  pass_fail_criterion {
+    client_metric = "error"
+    aggregate     = "percentage"
+    condition     = ">"
+    value         = 20
+    request_name  = "homepage"
+    action        = "stop"
+  }
+
+  depends_on = [azurerm_role_assignment.test]
+}
+`, r.template(data), data.RandomInteger, data.RandomString)
+}
+
+func (r LoadTestTestTestResource) locust(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_load_test_test" "test" {
+  name         = "acctest-%[2]d"
+  load_test_id = azurerm_load_test.test.id
+  kind         = "Locust"
+
+  test_script {
+    file_name = "locustfile.py"
+    content   = <<SCRIPT
+from locust import HttpUser, task
+
+
+class ExampleUser(HttpUser):
+    @task
+    def homepage(self):
+        self.client.get("/")
+SCRIPT
+  }
+
+  environment_variables = {
+    LOCUST_HOST     = "https://example.com"
+    LOCUST_USERS    = "5"
+    LOCUST_RUN_TIME = "60"
+  }
+
+  depends_on = [azurerm_role_assignment.test]
+}
+`, r.template(data), data.RandomInteger)
+}
+
+func (LoadTestTestTestResource) template(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+data "azurerm_client_config" "current" {}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestrg-%[1]d"
+  location = %[2]q
+}
+
+resource "azurerm_user_assigned_identity" "test" {
+  name                = "acctest-%[1]d"
+  resource_group_name = azurerm_resource_group.test.name
+  location            = azurerm_resource_group.test.location
+}
+
+resource "azurerm_load_test" "test" {
+  name                = "acctestlt-%[3]s"
+  location            = azurerm_resource_group.test.location
+  resource_group_name = azurerm_resource_group.test.name
+
+  identity {
+    type         = "UserAssigned"
+    identity_ids = [azurerm_user_assigned_identity.test.id]
+  }
+}
+
+resource "azurerm_role_assignment" "test" {
+  scope                = azurerm_load_test.test.id
+  role_definition_name = "Load Test Contributor"
+  principal_id         = data.azurerm_client_config.current.object_id
+}
+
+locals {
+  jmx_script = <<SCRIPT
+<?xml version="1.0" encoding="UTF-8"?>
+<jmeterTestPlan version="1.2" properties="5.0" jmeter="5.5">
+  <hashTree>
+    <TestPlan guiclass="TestPlanGui" testclass="TestPlan" testname="Test Plan" enabled="true">
+      <elementProp name="TestPlan.user_defined_variables" elementType="Arguments" guiclass="ArgumentsPanel" testclass="Arguments" enabled="true">
+        <collectionProp name="Arguments.arguments"/>
+      </elementProp>
+    </TestPlan>
+    <hashTree>
+      <ThreadGroup guiclass="ThreadGroupGui" testclass="ThreadGroup" testname="Thread Group" enabled="true">
+        <stringProp name="ThreadGroup.on_sample_error">continue</stringProp>
+        <elementProp name="ThreadGroup.main_controller" elementType="LoopController" guiclass="LoopControlPanel" testclass="LoopController" enabled="true">
+          <stringProp name="LoopController.loops">1</stringProp>
+          <boolProp name="LoopController.continue_forever">false</boolProp>
+        </elementProp>
+        <stringProp name="ThreadGroup.num_threads">1</stringProp>
+        <stringProp name="ThreadGroup.ramp_time">1</stringProp>
+      </ThreadGroup>
+      <hashTree>
+        <HTTPSamplerProxy guiclass="HttpTestSampleGui" testclass="HTTPSamplerProxy" testname="homepage" enabled="true">
+          <stringProp name="HTTPSampler.domain">example.com</stringProp>
+          <stringProp name="HTTPSampler.protocol">https</stringProp>
+          <stringProp name="HTTPSampler.path">/</stringProp>
+          <stringProp name="HTTPSampler.method">GET</stringProp>
+        </HTTPSamplerProxy>
+        <hashTree/>
+      </hashTree>
+    </hashTree>
+  </hashTree>
+</jmeterTestPlan>
+SCRIPT
+}
+`, data.RandomInteger, data.Locations.Primary, data.RandomString)
+}
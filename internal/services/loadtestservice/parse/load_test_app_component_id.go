@@ -0,0 +1,58 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package parse
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/resourceids"
+)
+
+var _ resourceids.Id = LoadTestAppComponentId{}
+
+type LoadTestAppComponentId struct {
+	LoadTestTestId   LoadTestTestId
+	TargetResourceId string
+}
+
+func (id LoadTestAppComponentId) ID() string {
+	return fmt.Sprintf("%s|%s", id.LoadTestTestId.ID(), id.TargetResourceId)
+}
+
+func (id LoadTestAppComponentId) String() string {
+	components := []string{
+		fmt.Sprintf("LoadTestTestId %s", id.LoadTestTestId.ID()),
+		fmt.Sprintf("TargetResourceId %s", id.TargetResourceId),
+	}
+	return fmt.Sprintf("Load Test App Component: %s", strings.Join(components, " / "))
+}
+
+func NewLoadTestAppComponentID(testId LoadTestTestId, targetResourceId string) LoadTestAppComponentId {
+	return LoadTestAppComponentId{
+		LoadTestTestId:   testId,
+		TargetResourceId: targetResourceId,
+	}
+}
+
+func LoadTestAppComponentID(input string) (*LoadTestAppComponentId, error) {
+	splitId := strings.Split(input, "|")
+	if len(splitId) != 2 {
+		return nil, fmt.Errorf("expected ID to be in the format {LoadTestTestId}|{TargetResourceId} but got %q", input)
+	}
+
+	testId, err := LoadTestTestID(splitId[0])
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := resourceids.ParseAzureResourceID(splitId[1]); err != nil {
+		return nil, fmt.Errorf("parsing %q as a Resource ID: %+v", splitId[1], err)
+	}
+
+	return &LoadTestAppComponentId{
+		LoadTestTestId:   *testId,
+		TargetResourceId: splitId[1],
+	}, nil
+}
@@ -0,0 +1,68 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package parse
+
+import (
+	"testing"
+)
+
+func TestLoadTestAppComponentID(t *testing.T) {
+	testData := []struct {
+		Input    string
+		Error    bool
+		Expected *LoadTestAppComponentId
+	}{
+		{
+			// empty
+			Input: "",
+			Error: true,
+		},
+		{
+			// missing target resource
+			Input: "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/resGroup1/providers/Microsoft.LoadTestService/loadTests/loadTest1/tests/test1",
+			Error: true,
+		},
+		{
+			// invalid test
+			Input: "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/resGroup1/providers/Microsoft.LoadTestService/loadTests/loadTest1|/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/resGroup1/providers/Microsoft.Web/sites/site1",
+			Error: true,
+		},
+		{
+			// invalid target resource
+			Input: "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/resGroup1/providers/Microsoft.LoadTestService/loadTests/loadTest1/tests/test1|site1",
+			Error: true,
+		},
+		{
+			// valid
+			Input: "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/resGroup1/providers/Microsoft.LoadTestService/loadTests/loadTest1/tests/test1|/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/resGroup1/providers/Microsoft.Web/sites/site1",
+			Expected: &LoadTestAppComponentId{
+				LoadTestTestId:   NewLoadTestTestID("12345678-1234-9876-4563-123456789012", "resGroup1", "loadTest1", "test1"),
+				TargetResourceId: "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/resGroup1/providers/Microsoft.Web/sites/site1",
+			},
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Input)
+
+		actual, err := LoadTestAppComponentID(v.Input)
+		if err != nil {
+			if v.Error {
+				continue
+			}
+
+			t.Fatalf("Expect a value but got an error: %s", err)
+		}
+		if v.Error {
+			t.Fatal("Expect an error but didn't get one")
+		}
+
+		if actual.LoadTestTestId != v.Expected.LoadTestTestId {
+			t.Fatalf("Expected %+v but got %+v for LoadTestTestId", v.Expected.LoadTestTestId, actual.LoadTestTestId)
+		}
+		if actual.TargetResourceId != v.Expected.TargetResourceId {
+			t.Fatalf("Expected %q but got %q for TargetResourceId", v.Expected.TargetResourceId, actual.TargetResourceId)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package parse
+
+// NOTE: this file is generated via 'go:generate' - manual changes will be overwritten
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/resourceids"
+)
+
+type LoadTestRunId struct {
+	SubscriptionId string
+	ResourceGroup  string
+	LoadTestName   string
+	TestRunName    string
+}
+
+func NewLoadTestRunID(subscriptionId, resourceGroup, loadTestName, testRunName string) LoadTestRunId {
+	return LoadTestRunId{
+		SubscriptionId: subscriptionId,
+		ResourceGroup:  resourceGroup,
+		LoadTestName:   loadTestName,
+		TestRunName:    testRunName,
+	}
+}
+
+func (id LoadTestRunId) String() string {
+	segments := []string{
+		fmt.Sprintf("Test Run Name %q", id.TestRunName),
+		fmt.Sprintf("Load Test Name %q", id.LoadTestName),
+		fmt.Sprintf("Resource Group %q", id.ResourceGroup),
+	}
+	segmentsStr := strings.Join(segments, " / ")
+	return fmt.Sprintf("%s: (%s)", "Load Test Run", segmentsStr)
+}
+
+func (id LoadTestRunId) ID() string {
+	fmtString := "/subscriptions/%s/resourceGroups/%s/providers/Microsoft.LoadTestService/loadTests/%s/testRuns/%s"
+	return fmt.Sprintf(fmtString, id.SubscriptionId, id.ResourceGroup, id.LoadTestName, id.TestRunName)
+}
+
+// LoadTestRunID parses a LoadTestRun ID into an LoadTestRunId struct
+func LoadTestRunID(input string) (*LoadTestRunId, error) {
+	id, err := resourceids.ParseAzureResourceID(input)
+	if err != nil {
+		return nil, fmt.Errorf("parsing %q as an LoadTestRun ID: %+v", input, err)
+	}
+
+	resourceId := LoadTestRunId{
+		SubscriptionId: id.SubscriptionID,
+		ResourceGroup:  id.ResourceGroup,
+	}
+
+	if resourceId.SubscriptionId == "" {
+		return nil, fmt.Errorf("ID was missing the 'subscriptions' element")
+	}
+
+	if resourceId.ResourceGroup == "" {
+		return nil, fmt.Errorf("ID was missing the 'resourceGroups' element")
+	}
+
+	if resourceId.LoadTestName, err = id.PopSegment("loadTests"); err != nil {
+		return nil, err
+	}
+	if resourceId.TestRunName, err = id.PopSegment("testRuns"); err != nil {
+		return nil, err
+	}
+
+	if err := id.ValidateNoEmptySegments(input); err != nil {
+		return nil, err
+	}
+
+	return &resourceId, nil
+}
@@ -7,7 +7,10 @@ import (
 	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
 )
 
-var _ sdk.TypedServiceRegistrationWithAGitHubLabel = Registration{}
+var (
+	_ sdk.TypedServiceRegistrationWithAGitHubLabel = Registration{}
+	_ sdk.ServiceRegistrationWithResourceProviders = Registration{}
+)
 
 type Registration struct{}
 
@@ -25,6 +28,13 @@ func (r Registration) Name() string {
 	return "LoadTestService"
 }
 
+// ResourceProviders returns the Resource Providers required by the Resources within this Service
+func (r Registration) ResourceProviders() []string {
+	return []string{
+		"Microsoft.LoadTestService",
+	}
+}
+
 func (r Registration) DataSources() []sdk.DataSource {
 	return []sdk.DataSource{
 		LoadTestDataSource{},
@@ -34,5 +44,8 @@ func (r Registration) DataSources() []sdk.DataSource {
 func (r Registration) Resources() []sdk.Resource {
 	return []sdk.Resource{
 		LoadTestResource{},
+		LoadTestAppComponentResource{},
+		LoadTestRunResource{},
+		LoadTestTestResource{},
 	}
 }
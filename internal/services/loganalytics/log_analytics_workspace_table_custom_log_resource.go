@@ -0,0 +1,588 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package loganalytics
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/operationalinsights/2022-10-01/tables"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/operationalinsights/2022-10-01/workspaces"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/loganalytics/validate"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+)
+
+type LogAnalyticsWorkspaceTableCustomLogResource struct{}
+
+var (
+	_ sdk.ResourceWithUpdate        = LogAnalyticsWorkspaceTableCustomLogResource{}
+	_ sdk.ResourceWithCustomizeDiff = LogAnalyticsWorkspaceTableCustomLogResource{}
+)
+
+type LogAnalyticsWorkspaceTableCustomLogResourceModel struct {
+	Name                 string                                  `tfschema:"name"`
+	WorkspaceId          string                                  `tfschema:"workspace_id"`
+	DisplayName          string                                  `tfschema:"display_name"`
+	Description          string                                  `tfschema:"description"`
+	Categories           []string                                `tfschema:"categories"`
+	Labels               []string                                `tfschema:"labels"`
+	Plan                 string                                  `tfschema:"plan"`
+	RetentionInDays      int64                                   `tfschema:"retention_in_days"`
+	TotalRetentionInDays int64                                   `tfschema:"total_retention_in_days"`
+	Column               []LogAnalyticsWorkspaceTableColumnModel `tfschema:"column"`
+	StandardColumn       []LogAnalyticsWorkspaceTableColumnModel `tfschema:"standard_column"`
+	Solutions            []string                                `tfschema:"solutions"`
+}
+
+type LogAnalyticsWorkspaceTableColumnModel struct {
+	Name             string `tfschema:"name"`
+	Type             string `tfschema:"type"`
+	Description      string `tfschema:"description"`
+	DisplayName      string `tfschema:"display_name"`
+	TypeHint         string `tfschema:"type_hint"`
+	Hidden           bool   `tfschema:"hidden"`
+	DisplayByDefault bool   `tfschema:"display_by_default"`
+}
+
+func (r LogAnalyticsWorkspaceTableCustomLogResource) ResourceType() string {
+	return "azurerm_log_analytics_workspace_table_custom_log"
+}
+
+func (r LogAnalyticsWorkspaceTableCustomLogResource) ModelObject() interface{} {
+	return &LogAnalyticsWorkspaceTableCustomLogResourceModel{}
+}
+
+func (r LogAnalyticsWorkspaceTableCustomLogResource) IDValidationFunc() pluginsdk.SchemaValidateFunc {
+	return tables.ValidateTableID
+}
+
+func (r LogAnalyticsWorkspaceTableCustomLogResource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"name": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validate.LogAnalyticsWorkspaceTableCustomLogName,
+		},
+
+		"workspace_id": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: workspaces.ValidateWorkspaceID,
+		},
+
+		"column": {
+			Type:     pluginsdk.TypeList,
+			Required: true,
+			MinItems: 1,
+			Elem: &pluginsdk.Resource{
+				Schema: map[string]*pluginsdk.Schema{
+					"name": {
+						Type:         pluginsdk.TypeString,
+						Required:     true,
+						ValidateFunc: validate.LogAnalyticsWorkspaceTableColumnName,
+					},
+
+					"type": {
+						Type:         pluginsdk.TypeString,
+						Required:     true,
+						ValidateFunc: validation.StringInSlice(tables.PossibleValuesForColumnTypeEnum(), false),
+					},
+
+					"description": {
+						Type:         pluginsdk.TypeString,
+						Optional:     true,
+						ValidateFunc: validation.StringIsNotEmpty,
+					},
+
+					"display_name": {
+						Type:         pluginsdk.TypeString,
+						Optional:     true,
+						ValidateFunc: validation.StringIsNotEmpty,
+					},
+
+					"type_hint": {
+						Type:         pluginsdk.TypeString,
+						Optional:     true,
+						ValidateFunc: validation.StringInSlice(tables.PossibleValuesForColumnDataTypeHintEnum(), false),
+					},
+
+					"hidden": {
+						Type:     pluginsdk.TypeBool,
+						Optional: true,
+						Default:  false,
+					},
+
+					"display_by_default": {
+						Type:     pluginsdk.TypeBool,
+						Optional: true,
+						Default:  true,
+					},
+				},
+			},
+		},
+
+		"display_name": {
+			Type:         pluginsdk.TypeString,
+			Optional:     true,
+			Computed:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+		},
+
+		"description": {
+			Type:         pluginsdk.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+		},
+
+		"categories": {
+			Type:     pluginsdk.TypeList,
+			Optional: true,
+			Elem: &pluginsdk.Schema{
+				Type:         pluginsdk.TypeString,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+		},
+
+		"labels": {
+			Type:     pluginsdk.TypeList,
+			Optional: true,
+			Elem: &pluginsdk.Schema{
+				Type:         pluginsdk.TypeString,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+		},
+
+		"plan": {
+			Type:     pluginsdk.TypeString,
+			Optional: true,
+			Default:  string(tables.TablePlanEnumAnalytics),
+			ValidateFunc: validation.StringInSlice([]string{
+				string(tables.TablePlanEnumAnalytics),
+				string(tables.TablePlanEnumBasic),
+			}, false),
+		},
+
+		"retention_in_days": {
+			Type:         pluginsdk.TypeInt,
+			Optional:     true,
+			Computed:     true,
+			ValidateFunc: validation.IntBetween(4, 730),
+		},
+
+		"total_retention_in_days": {
+			Type:         pluginsdk.TypeInt,
+			Optional:     true,
+			Computed:     true,
+			ValidateFunc: validation.IntBetween(4, 4383),
+		},
+	}
+}
+
+func (r LogAnalyticsWorkspaceTableCustomLogResource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"solutions": {
+			Type:     pluginsdk.TypeList,
+			Computed: true,
+			Elem: &pluginsdk.Schema{
+				Type: pluginsdk.TypeString,
+			},
+		},
+
+		"standard_column": {
+			Type:     pluginsdk.TypeList,
+			Computed: true,
+			Elem: &pluginsdk.Resource{
+				Schema: map[string]*pluginsdk.Schema{
+					"name": {
+						Type:     pluginsdk.TypeString,
+						Computed: true,
+					},
+
+					"type": {
+						Type:     pluginsdk.TypeString,
+						Computed: true,
+					},
+
+					"description": {
+						Type:     pluginsdk.TypeString,
+						Computed: true,
+					},
+
+					"display_name": {
+						Type:     pluginsdk.TypeString,
+						Computed: true,
+					},
+
+					"type_hint": {
+						Type:     pluginsdk.TypeString,
+						Computed: true,
+					},
+
+					"hidden": {
+						Type:     pluginsdk.TypeBool,
+						Computed: true,
+					},
+
+					"display_by_default": {
+						Type:     pluginsdk.TypeBool,
+						Computed: true,
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r LogAnalyticsWorkspaceTableCustomLogResource) CustomizeDiff() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			rd := metadata.ResourceDiff
+
+			plan := rd.Get("plan").(string)
+			if plan != string(tables.TablePlanEnumAnalytics) {
+				// `retention_in_days` is fixed by the service for the `Basic` plan
+				if v, ok := rd.GetRawConfig().AsValueMap()["retention_in_days"]; ok && !v.IsNull() {
+					return fmt.Errorf("`retention_in_days` cannot be set when `plan` is `%s` since the interactive retention is fixed by the service", plan)
+				}
+			}
+
+			retention := rd.Get("retention_in_days").(int)
+			totalRetention := rd.Get("total_retention_in_days").(int)
+			if retention != 0 && totalRetention != 0 && totalRetention < retention {
+				return fmt.Errorf("`total_retention_in_days` (%d) must be greater than or equal to `retention_in_days` (%d)", totalRetention, retention)
+			}
+
+			hasTimeGenerated := false
+			names := make(map[string]struct{})
+			for _, raw := range rd.Get("column").([]interface{}) {
+				column, ok := raw.(map[string]interface{})
+				if !ok {
+					continue
+				}
+				name := column["name"].(string)
+				if name == "" {
+					// the value is unknown at plan time
+					continue
+				}
+				if _, exists := names[name]; exists {
+					return fmt.Errorf("the column %q is specified more than once", name)
+				}
+				names[name] = struct{}{}
+
+				if name == "TimeGenerated" {
+					if column["type"].(string) != string(tables.ColumnTypeEnumDateTime) {
+						return fmt.Errorf("the `TimeGenerated` column must be of type `%s`", tables.ColumnTypeEnumDateTime)
+					}
+					hasTimeGenerated = true
+				}
+			}
+
+			if len(names) > 0 && !hasTimeGenerated {
+				return fmt.Errorf("a `column` named `TimeGenerated` with the type `%s` is required for custom log tables", tables.ColumnTypeEnumDateTime)
+			}
+
+			// the service rejects changing the type of an existing column, so the table has to be recreated
+			if rd.Id() != "" && rd.HasChange("column") {
+				oldRaw, newRaw := rd.GetChange("column")
+				existingTypes := make(map[string]string)
+				for _, raw := range oldRaw.([]interface{}) {
+					if column, ok := raw.(map[string]interface{}); ok {
+						existingTypes[column["name"].(string)] = column["type"].(string)
+					}
+				}
+				for _, raw := range newRaw.([]interface{}) {
+					column, ok := raw.(map[string]interface{})
+					if !ok {
+						continue
+					}
+					if existingType, exists := existingTypes[column["name"].(string)]; exists && existingType != column["type"].(string) {
+						if err := rd.ForceNew("column"); err != nil {
+							return fmt.Errorf("forcing a new resource when changing the type of the column %q: %+v", column["name"].(string), err)
+						}
+						break
+					}
+				}
+			}
+
+			return nil
+		},
+	}
+}
+
+func (r LogAnalyticsWorkspaceTableCustomLogResource) Create() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.LogAnalytics.TablesClient
+
+			var config LogAnalyticsWorkspaceTableCustomLogResourceModel
+			if err := metadata.Decode(&config); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			workspaceId, err := workspaces.ParseWorkspaceID(config.WorkspaceId)
+			if err != nil {
+				return err
+			}
+
+			id := tables.NewTableID(workspaceId.SubscriptionId, workspaceId.ResourceGroupName, workspaceId.WorkspaceName, config.Name)
+
+			existing, err := client.Get(ctx, id)
+			if err != nil && !response.WasNotFound(existing.HttpResponse) {
+				return fmt.Errorf("checking for existing %s: %+v", id, err)
+			}
+			if !response.WasNotFound(existing.HttpResponse) {
+				return metadata.ResourceRequiresImport(r.ResourceType(), id)
+			}
+
+			payload := tables.Table{
+				Properties: &tables.TableProperties{
+					Plan: pointer.To(tables.TablePlanEnum(config.Plan)),
+					Schema: &tables.Schema{
+						Name:    pointer.To(config.Name),
+						Columns: expandLogAnalyticsWorkspaceTableColumns(config.Column),
+					},
+				},
+			}
+
+			if config.DisplayName != "" {
+				payload.Properties.Schema.DisplayName = pointer.To(config.DisplayName)
+			}
+			if config.Description != "" {
+				payload.Properties.Schema.Description = pointer.To(config.Description)
+			}
+			if len(config.Categories) > 0 {
+				payload.Properties.Schema.Categories = pointer.To(config.Categories)
+			}
+			if len(config.Labels) > 0 {
+				payload.Properties.Schema.Labels = pointer.To(config.Labels)
+			}
+			if config.Plan == string(tables.TablePlanEnumAnalytics) && config.RetentionInDays != 0 {
+				payload.Properties.RetentionInDays = pointer.To(config.RetentionInDays)
+			}
+			if config.TotalRetentionInDays != 0 {
+				payload.Properties.TotalRetentionInDays = pointer.To(config.TotalRetentionInDays)
+			}
+
+			if err := client.CreateOrUpdateThenPoll(ctx, id, payload); err != nil {
+				return fmt.Errorf("creating %s: %+v", id, err)
+			}
+
+			metadata.SetID(id)
+			return nil
+		},
+	}
+}
+
+func (r LogAnalyticsWorkspaceTableCustomLogResource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.LogAnalytics.TablesClient
+
+			id, err := tables.ParseTableID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			resp, err := client.Get(ctx, *id)
+			if err != nil {
+				if response.WasNotFound(resp.HttpResponse) {
+					return metadata.MarkAsGone(id)
+				}
+				return fmt.Errorf("retrieving %s: %+v", *id, err)
+			}
+
+			state := LogAnalyticsWorkspaceTableCustomLogResourceModel{
+				Name:        id.TableName,
+				WorkspaceId: workspaces.NewWorkspaceID(id.SubscriptionId, id.ResourceGroupName, id.WorkspaceName).ID(),
+			}
+
+			if model := resp.Model; model != nil {
+				if props := model.Properties; props != nil {
+					state.Plan = string(pointer.From(props.Plan))
+					state.RetentionInDays = pointer.From(props.RetentionInDays)
+					state.TotalRetentionInDays = pointer.From(props.TotalRetentionInDays)
+
+					if schema := props.Schema; schema != nil {
+						state.DisplayName = pointer.From(schema.DisplayName)
+						state.Description = pointer.From(schema.Description)
+						state.Categories = pointer.From(schema.Categories)
+						state.Labels = pointer.From(schema.Labels)
+						state.Solutions = pointer.From(schema.Solutions)
+						state.Column = flattenLogAnalyticsWorkspaceTableColumns(schema.Columns)
+						state.StandardColumn = flattenLogAnalyticsWorkspaceTableColumns(schema.StandardColumns)
+					}
+				}
+			}
+
+			return metadata.Encode(&state)
+		},
+	}
+}
+
+func (r LogAnalyticsWorkspaceTableCustomLogResource) Update() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.LogAnalytics.TablesClient
+
+			id, err := tables.ParseTableID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			var config LogAnalyticsWorkspaceTableCustomLogResourceModel
+			if err := metadata.Decode(&config); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			existing, err := client.Get(ctx, *id)
+			if err != nil {
+				return fmt.Errorf("retrieving %s: %+v", *id, err)
+			}
+			if existing.Model == nil || existing.Model.Properties == nil {
+				return fmt.Errorf("retrieving %s: `properties` was nil", *id)
+			}
+
+			// the read-only parts of the schema (e.g. `standardColumns`) are rejected by the API, so only send what we manage
+			payload := tables.Table{
+				Properties: &tables.TableProperties{
+					Plan:                 existing.Model.Properties.Plan,
+					RetentionInDays:      existing.Model.Properties.RetentionInDays,
+					TotalRetentionInDays: existing.Model.Properties.TotalRetentionInDays,
+					Schema: &tables.Schema{
+						Name:    pointer.To(id.TableName),
+						Columns: expandLogAnalyticsWorkspaceTableColumns(config.Column),
+					},
+				},
+			}
+
+			if schema := existing.Model.Properties.Schema; schema != nil {
+				payload.Properties.Schema.DisplayName = schema.DisplayName
+				payload.Properties.Schema.Description = schema.Description
+				payload.Properties.Schema.Categories = schema.Categories
+				payload.Properties.Schema.Labels = schema.Labels
+			}
+
+			if metadata.ResourceData.HasChange("display_name") {
+				payload.Properties.Schema.DisplayName = pointer.To(config.DisplayName)
+			}
+
+			if metadata.ResourceData.HasChange("description") {
+				payload.Properties.Schema.Description = pointer.To(config.Description)
+			}
+
+			if metadata.ResourceData.HasChange("categories") {
+				payload.Properties.Schema.Categories = pointer.To(config.Categories)
+			}
+
+			if metadata.ResourceData.HasChange("labels") {
+				payload.Properties.Schema.Labels = pointer.To(config.Labels)
+			}
+
+			if metadata.ResourceData.HasChange("plan") {
+				payload.Properties.Plan = pointer.To(tables.TablePlanEnum(config.Plan))
+			}
+
+			if config.Plan == string(tables.TablePlanEnumAnalytics) {
+				if metadata.ResourceData.HasChange("retention_in_days") {
+					payload.Properties.RetentionInDays = pointer.To(config.RetentionInDays)
+				}
+			} else {
+				payload.Properties.RetentionInDays = nil
+			}
+
+			if metadata.ResourceData.HasChange("total_retention_in_days") {
+				payload.Properties.TotalRetentionInDays = pointer.To(config.TotalRetentionInDays)
+			}
+
+			if err := client.CreateOrUpdateThenPoll(ctx, *id, payload); err != nil {
+				return fmt.Errorf("updating %s: %+v", *id, err)
+			}
+
+			return nil
+		},
+	}
+}
+
+func (r LogAnalyticsWorkspaceTableCustomLogResource) Delete() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.LogAnalytics.TablesClient
+
+			id, err := tables.ParseTableID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			if err := client.DeleteThenPoll(ctx, *id); err != nil {
+				return fmt.Errorf("deleting %s: %+v", *id, err)
+			}
+
+			return nil
+		},
+	}
+}
+
+func expandLogAnalyticsWorkspaceTableColumns(input []LogAnalyticsWorkspaceTableColumnModel) *[]tables.Column {
+	output := make([]tables.Column, 0)
+	for _, v := range input {
+		column := tables.Column{
+			Name:             pointer.To(v.Name),
+			Type:             pointer.To(tables.ColumnTypeEnum(v.Type)),
+			IsHidden:         pointer.To(v.Hidden),
+			IsDefaultDisplay: pointer.To(v.DisplayByDefault),
+		}
+
+		if v.Description != "" {
+			column.Description = pointer.To(v.Description)
+		}
+		if v.DisplayName != "" {
+			column.DisplayName = pointer.To(v.DisplayName)
+		}
+		if v.TypeHint != "" {
+			column.DataTypeHint = pointer.To(tables.ColumnDataTypeHintEnum(v.TypeHint))
+		}
+
+		output = append(output, column)
+	}
+
+	return &output
+}
+
+func flattenLogAnalyticsWorkspaceTableColumns(input *[]tables.Column) []LogAnalyticsWorkspaceTableColumnModel {
+	output := make([]LogAnalyticsWorkspaceTableColumnModel, 0)
+	if input == nil {
+		return output
+	}
+
+	for _, v := range *input {
+		displayByDefault := true
+		if v.IsDefaultDisplay != nil {
+			displayByDefault = *v.IsDefaultDisplay
+		}
+
+		output = append(output, LogAnalyticsWorkspaceTableColumnModel{
+			Name:             pointer.From(v.Name),
+			Type:             string(pointer.From(v.Type)),
+			Description:      pointer.From(v.Description),
+			DisplayName:      pointer.From(v.DisplayName),
+			TypeHint:         string(pointer.From(v.DataTypeHint)),
+			Hidden:           pointer.From(v.IsHidden),
+			DisplayByDefault: displayByDefault,
+		})
+	}
+
+	return output
+}
@@ -0,0 +1,377 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package loganalytics_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/operationalinsights/2022-10-01/tables"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/check"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+type LogAnalyticsWorkspaceTableCustomLogResource struct{}
+
+func TestAccLogAnalyticsWorkspaceTableCustomLog_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_log_analytics_workspace_table_custom_log", "test")
+	r := LogAnalyticsWorkspaceTableCustomLogResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccLogAnalyticsWorkspaceTableCustomLog_requiresImport(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_log_analytics_workspace_table_custom_log", "test")
+	r := LogAnalyticsWorkspaceTableCustomLogResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.RequiresImportErrorStep(r.requiresImport),
+	})
+}
+
+func TestAccLogAnalyticsWorkspaceTableCustomLog_complete(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_log_analytics_workspace_table_custom_log", "test")
+	r := LogAnalyticsWorkspaceTableCustomLogResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.complete(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("retention_in_days").HasValue("60"),
+				check.That(data.ResourceName).Key("total_retention_in_days").HasValue("180"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccLogAnalyticsWorkspaceTableCustomLog_update(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_log_analytics_workspace_table_custom_log", "test")
+	r := LogAnalyticsWorkspaceTableCustomLogResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.complete(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccLogAnalyticsWorkspaceTableCustomLog_updateColumnType(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_log_analytics_workspace_table_custom_log", "test")
+	r := LogAnalyticsWorkspaceTableCustomLogResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+		{
+			// changing the type of an existing column recreates the table
+			Config: r.columnType(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("column.1.type").HasValue("dynamic"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccLogAnalyticsWorkspaceTableCustomLog_basicPlan(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_log_analytics_workspace_table_custom_log", "test")
+	r := LogAnalyticsWorkspaceTableCustomLogResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basicPlan(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("plan").HasValue("Basic"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccLogAnalyticsWorkspaceTableCustomLog_dataCollectionRule(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_log_analytics_workspace_table_custom_log", "test")
+	r := LogAnalyticsWorkspaceTableCustomLogResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.dataCollectionRule(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func (t LogAnalyticsWorkspaceTableCustomLogResource) Exists(ctx context.Context, clients *clients.Client, state *pluginsdk.InstanceState) (*bool, error) {
+	id, err := tables.ParseTableID(state.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := clients.LogAnalytics.TablesClient.Get(ctx, *id)
+	if err != nil {
+		if response.WasNotFound(resp.HttpResponse) {
+			return pointer.To(false), nil
+		}
+		return nil, fmt.Errorf("retrieving %s: %+v", *id, err)
+	}
+
+	return pointer.To(resp.Model != nil), nil
+}
+
+func (LogAnalyticsWorkspaceTableCustomLogResource) template(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-law-%[1]d"
+  location = "%[2]s"
+}
+
+resource "azurerm_log_analytics_workspace" "test" {
+  name                = "acctestLAW-%[1]d"
+  location            = azurerm_resource_group.test.location
+  resource_group_name = azurerm_resource_group.test.name
+  retention_in_days   = 30
+}
+`, data.RandomInteger, data.Locations.Primary)
+}
+
+func (r LogAnalyticsWorkspaceTableCustomLogResource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_log_analytics_workspace_table_custom_log" "test" {
+  name         = "acctest%d_CL"
+  workspace_id = azurerm_log_analytics_workspace.test.id
+
+  column {
+    name = "TimeGenerated"
+    type = "dateTime"
+  }
+
+  column {
+    name = "Message"
+    type = "string"
+  }
+}
+`, r.template(data), data.RandomInteger)
+}
+
+func (r LogAnalyticsWorkspaceTableCustomLogResource) columnType(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_log_analytics_workspace_table_custom_log" "test" {
+  name         = "acctest%d_CL"
+  workspace_id = azurerm_log_analytics_workspace.test.id
+
+  column {
+    name = "TimeGenerated"
+    type = "dateTime"
+  }
+
+  column {
+    name = "Message"
+    type = "dynamic"
+  }
+}
+`, r.template(data), data.RandomInteger)
+}
+
+func (r LogAnalyticsWorkspaceTableCustomLogResource) requiresImport(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_log_analytics_workspace_table_custom_log" "import" {
+  name         = azurerm_log_analytics_workspace_table_custom_log.test.name
+  workspace_id = azurerm_log_analytics_workspace_table_custom_log.test.workspace_id
+
+  column {
+    name = "TimeGenerated"
+    type = "dateTime"
+  }
+
+  column {
+    name = "Message"
+    type = "string"
+  }
+}
+`, r.basic(data))
+}
+
+func (r LogAnalyticsWorkspaceTableCustomLogResource) complete(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_log_analytics_workspace_table_custom_log" "test" {
+  name                    = "acctest%d_CL"
+  workspace_id            = azurerm_log_analytics_workspace.test.id
+  display_name            = "Acceptance Test Table"
+  description             = "A custom table created by the acceptance tests"
+  categories              = ["Custom"]
+  labels                  = ["acctest"]
+  plan                    = "Analytics"
+  retention_in_days       = 60
+  total_retention_in_days = 180
+
+  column {
+    name        = "TimeGenerated"
+    type        = "dateTime"
+    description = "The time at which the record was generated"
+  }
+
+  column {
+    name         = "Message"
+    type         = "string"
+    display_name = "Log Message"
+  }
+
+  column {
+    name      = "SourceAddress"
+    type      = "string"
+    type_hint = "ip"
+  }
+
+  column {
+    name               = "Properties"
+    type               = "dynamic"
+    hidden             = true
+    display_by_default = false
+  }
+}
+`, r.template(data), data.RandomInteger)
+}
+
+func (r LogAnalyticsWorkspaceTableCustomLogResource) basicPlan(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_log_analytics_workspace_table_custom_log" "test" {
+  name                    = "acctest%d_CL"
+  workspace_id            = azurerm_log_analytics_workspace.test.id
+  plan                    = "Basic"
+  total_retention_in_days = 30
+
+  column {
+    name = "TimeGenerated"
+    type = "dateTime"
+  }
+
+  column {
+    name = "Message"
+    type = "string"
+  }
+}
+`, r.template(data), data.RandomInteger)
+}
+
+func (r LogAnalyticsWorkspaceTableCustomLogResource) dataCollectionRule(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "azurerm_log_analytics_workspace_table_custom_log" "test" {
+  name         = "acctest%[2]d_CL"
+  workspace_id = azurerm_log_analytics_workspace.test.id
+
+  column {
+    name = "TimeGenerated"
+    type = "dateTime"
+  }
+
+  column {
+    name = "Message"
+    type = "string"
+  }
+}
+
+resource "azurerm_monitor_data_collection_endpoint" "test" {
+  name                = "acctestmdce-%[2]d"
+  resource_group_name = azurerm_resource_group.test.name
+  location            = azurerm_resource_group.test.location
+}
+
+resource "azurerm_monitor_data_collection_rule" "test" {
+  name                        = "acctestmdcr-%[2]d"
+  resource_group_name         = azurerm_resource_group.test.name
+  location                    = azurerm_resource_group.test.location
+  data_collection_endpoint_id = azurerm_monitor_data_collection_endpoint.test.id
+
+  destinations {
+    log_analytics {
+      workspace_resource_id = azurerm_log_analytics_workspace.test.id
+      name                  = "test-destination-log"
+    }
+  }
+
+  data_flow {
+    streams       = ["Custom-${azurerm_log_analytics_workspace_table_custom_log.test.name}"]
+    destinations  = ["test-destination-log"]
+    output_stream = "Custom-${azurerm_log_analytics_workspace_table_custom_log.test.name}"
+    transform_kql = "source"
+  }
+
+  stream_declaration {
+    stream_name = "Custom-${azurerm_log_analytics_workspace_table_custom_log.test.name}"
+    column {
+      name = "TimeGenerated"
+      type = "datetime"
+    }
+    column {
+      name = "Message"
+      type = "string"
+    }
+  }
+}
+`, r.template(data), data.RandomInteger)
+}
@@ -30,6 +30,7 @@ func (r Registration) Resources() []sdk.Resource {
 		LogAnalyticsQueryPackQueryResource{},
 		LogAnalyticsSolutionResource{},
 		LogAnalyticsWorkspaceTableResource{},
+		LogAnalyticsWorkspaceTableCustomLogResource{},
 	}
 }
 
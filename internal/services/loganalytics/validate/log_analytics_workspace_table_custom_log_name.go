@@ -0,0 +1,55 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validate
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+func LogAnalyticsWorkspaceTableCustomLogName(i interface{}, k string) (warnings []string, errors []error) {
+	v, ok := i.(string)
+	if !ok {
+		errors = append(errors, fmt.Errorf("expected type of %s to be string", k))
+		return
+	}
+
+	if !strings.HasSuffix(v, "_CL") {
+		errors = append(errors, fmt.Errorf("%s must end with `_CL`, got %q", k, v))
+		return
+	}
+
+	if len(v) > 63 {
+		errors = append(errors, fmt.Errorf("length of %s should be less than or equal to %d, got %q", k, 63, v))
+		return
+	}
+
+	if !regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]*_CL$`).MatchString(v) {
+		errors = append(errors, fmt.Errorf("%s must start with a letter and can only contain letters, numbers and underscores, got %q", k, v))
+		return
+	}
+
+	return
+}
+
+func LogAnalyticsWorkspaceTableColumnName(i interface{}, k string) (warnings []string, errors []error) {
+	v, ok := i.(string)
+	if !ok {
+		errors = append(errors, fmt.Errorf("expected type of %s to be string", k))
+		return
+	}
+
+	if len(v) < 1 || len(v) > 45 {
+		errors = append(errors, fmt.Errorf("length of %s should be between 1 and 45, got %q", k, v))
+		return
+	}
+
+	if !regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]*$`).MatchString(v) {
+		errors = append(errors, fmt.Errorf("%s must start with a letter and can only contain letters, numbers and underscores, got %q", k, v))
+		return
+	}
+
+	return
+}
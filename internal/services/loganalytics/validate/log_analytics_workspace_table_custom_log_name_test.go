@@ -0,0 +1,99 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validate
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLogAnalyticsWorkspaceTableCustomLogName(t *testing.T) {
+	cases := []struct {
+		Value    string
+		ErrCount int
+	}{
+		{
+			Value:    "",
+			ErrCount: 1,
+		},
+		{
+			Value:    "MyTable",
+			ErrCount: 1,
+		},
+		{
+			Value:    "MyTable_cl",
+			ErrCount: 1,
+		},
+		{
+			Value:    "MyTable_CL",
+			ErrCount: 0,
+		},
+		{
+			Value:    "My_Table2_CL",
+			ErrCount: 0,
+		},
+		{
+			Value:    "1Table_CL",
+			ErrCount: 1,
+		},
+		{
+			Value:    "My-Table_CL",
+			ErrCount: 1,
+		},
+		{
+			Value:    strings.Repeat("a", 60) + "_CL",
+			ErrCount: 0,
+		},
+		{
+			Value:    strings.Repeat("a", 61) + "_CL",
+			ErrCount: 1,
+		},
+	}
+
+	for _, tc := range cases {
+		_, errors := LogAnalyticsWorkspaceTableCustomLogName(tc.Value, "name")
+		if len(errors) != tc.ErrCount {
+			t.Fatalf("expected %d errors for %q but got %d", tc.ErrCount, tc.Value, len(errors))
+		}
+	}
+}
+
+func TestLogAnalyticsWorkspaceTableColumnName(t *testing.T) {
+	cases := []struct {
+		Value    string
+		ErrCount int
+	}{
+		{
+			Value:    "",
+			ErrCount: 1,
+		},
+		{
+			Value:    "TimeGenerated",
+			ErrCount: 0,
+		},
+		{
+			Value:    "Computer_Name2",
+			ErrCount: 0,
+		},
+		{
+			Value:    "_Hidden",
+			ErrCount: 1,
+		},
+		{
+			Value:    "Computer Name",
+			ErrCount: 1,
+		},
+		{
+			Value:    strings.Repeat("a", 46),
+			ErrCount: 1,
+		},
+	}
+
+	for _, tc := range cases {
+		_, errors := LogAnalyticsWorkspaceTableColumnName(tc.Value, "name")
+		if len(errors) != tc.ErrCount {
+			t.Fatalf("expected %d errors for %q but got %d", tc.ErrCount, tc.Value, len(errors))
+		}
+	}
+}
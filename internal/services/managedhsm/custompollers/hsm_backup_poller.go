@@ -0,0 +1,60 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package custompollers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-sdk/sdk/client/pollers"
+	dataplane "github.com/tombuildsstuff/kermit/sdk/keyvault/7.4/keyvault"
+)
+
+var _ pollers.PollerType = &hsmBackupPoller{}
+
+func NewHSMBackupPoller(client *dataplane.BaseClient, baseUrl string, jobId string) pollers.PollerType {
+	return &hsmBackupPoller{
+		client:  client,
+		baseUrl: baseUrl,
+		jobId:   jobId,
+	}
+}
+
+type hsmBackupPoller struct {
+	client  *dataplane.BaseClient
+	baseUrl string
+	jobId   string
+}
+
+func (p *hsmBackupPoller) Poll(ctx context.Context) (*pollers.PollResult, error) {
+	res, err := p.client.FullBackupStatus(ctx, p.baseUrl, p.jobId)
+	if err != nil {
+		return nil, fmt.Errorf("retrieving the status of Full Backup %q within %s: %+v", p.jobId, p.baseUrl, err)
+	}
+
+	status := pointer.From(res.Status)
+	switch {
+	case strings.EqualFold(status, "Succeeded"), strings.EqualFold(status, string(dataplane.OperationStatusSuccess)):
+		return &pollers.PollResult{
+			Status:       pollers.PollingStatusSucceeded,
+			PollInterval: 10 * time.Second,
+		}, nil
+
+	case strings.EqualFold(status, string(dataplane.OperationStatusFailed)), strings.EqualFold(status, "Canceled"):
+		message := pointer.From(res.StatusDetails)
+		if res.Error != nil && res.Error.Message != nil {
+			message = *res.Error.Message
+		}
+		return nil, fmt.Errorf("Full Backup %q within %s finished with the status %q: %s", p.jobId, p.baseUrl, status, message)
+	}
+
+	// InProgress
+	return &pollers.PollResult{
+		Status:       pollers.PollingStatusInProgress,
+		PollInterval: 10 * time.Second,
+	}, nil
+}
@@ -0,0 +1,323 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package managedhsm
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/keyvault/2023-07-01/managedhsms"
+	"github.com/hashicorp/go-azure-sdk/sdk/client/pollers"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/managedhsm/custompollers"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/managedhsm/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/managedhsm/validate"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+	"github.com/tombuildsstuff/kermit/sdk/keyvault/7.4/keyvault"
+)
+
+type KeyVaultMHSMBackupResource struct{}
+
+var _ sdk.ResourceWithCustomizeDiff = KeyVaultMHSMBackupResource{}
+
+type KeyVaultMHSMBackupModel struct {
+	ManagedHSMID            string            `tfschema:"managed_hsm_id"`
+	StorageBlobContainerUri string            `tfschema:"storage_blob_container_uri"`
+	SasToken                string            `tfschema:"sas_token"`
+	UseManagedIdentity      bool              `tfschema:"use_managed_identity"`
+	Triggers                map[string]string `tfschema:"triggers"`
+	BackupFolderUri         string            `tfschema:"backup_folder_uri"`
+	StartTime               string            `tfschema:"start_time"`
+	EndTime                 string            `tfschema:"end_time"`
+}
+
+func (r KeyVaultMHSMBackupResource) ResourceType() string {
+	return "azurerm_key_vault_managed_hardware_security_module_backup"
+}
+
+func (r KeyVaultMHSMBackupResource) IDValidationFunc() pluginsdk.SchemaValidateFunc {
+	return validate.ManagedHSMDataPlaneBackupID
+}
+
+func (r KeyVaultMHSMBackupResource) ModelObject() interface{} {
+	return &KeyVaultMHSMBackupModel{}
+}
+
+func (r KeyVaultMHSMBackupResource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"managed_hsm_id": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: managedhsms.ValidateManagedHSMID,
+		},
+
+		"storage_blob_container_uri": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.IsURLWithHTTPS,
+		},
+
+		"sas_token": {
+			Type:         pluginsdk.TypeString,
+			Optional:     true,
+			ForceNew:     true,
+			Sensitive:    true,
+			ValidateFunc: validation.StringIsNotEmpty,
+		},
+
+		"use_managed_identity": {
+			Type:     pluginsdk.TypeBool,
+			Optional: true,
+			ForceNew: true,
+			Default:  false,
+		},
+
+		"triggers": {
+			Type:     pluginsdk.TypeMap,
+			Optional: true,
+			ForceNew: true,
+			Elem: &pluginsdk.Schema{
+				Type: pluginsdk.TypeString,
+			},
+		},
+	}
+}
+
+func (r KeyVaultMHSMBackupResource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"backup_folder_uri": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+
+		"start_time": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+
+		"end_time": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+	}
+}
+
+func (r KeyVaultMHSMBackupResource) CustomizeDiff() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			var config KeyVaultMHSMBackupModel
+			if err := metadata.DecodeDiff(&config); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			// the SAS Token may not be known until apply-time
+			if !metadata.ResourceDiff.NewValueKnown("sas_token") {
+				return nil
+			}
+
+			if config.UseManagedIdentity && config.SasToken != "" {
+				return fmt.Errorf("`sas_token` cannot be specified when `use_managed_identity` is enabled")
+			}
+			if !config.UseManagedIdentity && config.SasToken == "" {
+				return fmt.Errorf("one of `sas_token` or `use_managed_identity` must be specified")
+			}
+
+			return nil
+		},
+	}
+}
+
+func (r KeyVaultMHSMBackupResource) Create() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 60 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.ManagedHSMs.DataPlaneKeysClient
+			domainSuffix, ok := metadata.Client.Account.Environment.ManagedHSM.DomainSuffix()
+			if !ok {
+				return fmt.Errorf("could not determine Managed HSM domain suffix for environment %q", metadata.Client.Account.Environment.Name)
+			}
+
+			var config KeyVaultMHSMBackupModel
+			if err := metadata.Decode(&config); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			managedHsmId, err := managedhsms.ParseManagedHSMID(config.ManagedHSMID)
+			if err != nil {
+				return err
+			}
+			baseUri, err := metadata.Client.ManagedHSMs.BaseUriForManagedHSM(ctx, *managedHsmId)
+			if err != nil {
+				return fmt.Errorf("determining the Data Plane Endpoint for %s: %+v", *managedHsmId, err)
+			}
+			if baseUri == nil {
+				return fmt.Errorf("unable to determine the Data Plane Endpoint for %q", *managedHsmId)
+			}
+			endpoint, err := parse.ManagedHSMEndpoint(*baseUri, domainSuffix)
+			if err != nil {
+				return fmt.Errorf("parsing the Data Plane Endpoint %q: %+v", *baseUri, err)
+			}
+
+			parameters := managedHSMFullBackupParameters{
+				StorageResourceURI: pointer.To(config.StorageBlobContainerUri),
+			}
+			if config.UseManagedIdentity {
+				parameters.UseManagedIdentity = pointer.To(true)
+			} else {
+				parameters.Token = pointer.To(config.SasToken)
+			}
+
+			result, err := managedHSMFullBackup(ctx, *client, endpoint.BaseURI(), parameters)
+			if err != nil {
+				return fmt.Errorf("starting a Full Backup of %s: %+v", *managedHsmId, err)
+			}
+			if result.JobID == nil || *result.JobID == "" {
+				return fmt.Errorf("starting a Full Backup of %s: `jobId` was nil", *managedHsmId)
+			}
+
+			id := parse.NewManagedHSMDataPlaneBackupID(endpoint.ManagedHSMName, endpoint.DomainSuffix, *result.JobID)
+
+			pollerType := custompollers.NewHSMBackupPoller(client, id.BaseUri(), id.JobId)
+			poller := pollers.NewPoller(pollerType, 10*time.Second, pollers.DefaultNumberOfDroppedConnectionsToAllow)
+			if err := poller.PollUntilDone(ctx); err != nil {
+				return fmt.Errorf("waiting for %s to complete: %+v", id, err)
+			}
+
+			metadata.SetID(id)
+			return r.Read().Func(ctx, metadata)
+		},
+	}
+}
+
+func (r KeyVaultMHSMBackupResource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.ManagedHSMs.DataPlaneKeysClient
+			domainSuffix, ok := metadata.Client.Account.Environment.ManagedHSM.DomainSuffix()
+			if !ok {
+				return fmt.Errorf("could not determine Managed HSM domain suffix for environment %q", metadata.Client.Account.Environment.Name)
+			}
+
+			id, err := parse.ManagedHSMDataPlaneBackupID(metadata.ResourceData.Id(), domainSuffix)
+			if err != nil {
+				return err
+			}
+
+			var state KeyVaultMHSMBackupModel
+			if err := metadata.Decode(&state); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			subscriptionId := commonids.NewSubscriptionID(metadata.Client.Account.SubscriptionId)
+			resourceManagerId, err := metadata.Client.ManagedHSMs.ManagedHSMIDFromBaseUrl(ctx, subscriptionId, id.BaseUri(), domainSuffix)
+			if err != nil {
+				return fmt.Errorf("determining Resource Manager ID for %q: %+v", id, err)
+			}
+			if resourceManagerId == nil {
+				return metadata.MarkAsGone(*id)
+			}
+			state.ManagedHSMID = resourceManagerId.ID()
+
+			resp, err := client.FullBackupStatus(ctx, id.BaseUri(), id.JobId)
+			if err != nil {
+				if utils.ResponseWasNotFound(resp.Response) {
+					// the status of a Full Backup is only retained for a limited time, however the backup itself
+					// remains in the Storage Container - so there's nothing to refresh here
+					log.Printf("[DEBUG] the status of %s is no longer available - using the values from the state", *id)
+					return metadata.Encode(&state)
+				}
+				return fmt.Errorf("retrieving %s: %+v", *id, err)
+			}
+
+			if v := resp.AzureStorageBlobContainerURI; v != nil {
+				state.BackupFolderUri = *v
+			}
+			if v := resp.StartTime; v != nil {
+				state.StartTime = time.Time(*v).Format(time.RFC3339)
+			}
+			if v := resp.EndTime; v != nil {
+				state.EndTime = time.Time(*v).Format(time.RFC3339)
+			}
+
+			return metadata.Encode(&state)
+		},
+	}
+}
+
+func (r KeyVaultMHSMBackupResource) Delete() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			domainSuffix, ok := metadata.Client.Account.Environment.ManagedHSM.DomainSuffix()
+			if !ok {
+				return fmt.Errorf("could not determine Managed HSM domain suffix for environment %q", metadata.Client.Account.Environment.Name)
+			}
+
+			id, err := parse.ManagedHSMDataPlaneBackupID(metadata.ResourceData.Id(), domainSuffix)
+			if err != nil {
+				return err
+			}
+
+			// a Full Backup can't be deleted through the Managed HSM - the backup is intentionally left
+			// in the Storage Container so that it can be used to restore the Managed HSM
+			log.Printf("[DEBUG] removing %s from the state - the backup remains in the Storage Container", *id)
+			return nil
+		},
+	}
+}
+
+// managedHSMFullBackupParameters is the request body for a Full Backup, which (unlike `keyvault.SASTokenParameter`)
+// supports authenticating to the Storage Container using the Managed Identity of the Managed HSM
+type managedHSMFullBackupParameters struct {
+	StorageResourceURI *string `json:"storageResourceUri,omitempty"`
+	Token              *string `json:"token,omitempty"`
+	UseManagedIdentity *bool   `json:"useManagedIdentity,omitempty"`
+}
+
+// managedHSMFullBackup starts a Full Backup of a Managed HSM and returns the initial status of the operation
+func managedHSMFullBackup(ctx context.Context, client keyvault.BaseClient, vaultBaseUrl string, parameters managedHSMFullBackupParameters) (*keyvault.FullBackupOperation, error) {
+	urlParameters := map[string]interface{}{
+		"vaultBaseUrl": vaultBaseUrl,
+	}
+
+	// authenticating using a Managed Identity requires API Version 7.5, which is otherwise compatible with 7.4
+	queryParameters := map[string]interface{}{
+		"api-version": "7.5",
+	}
+
+	req, err := autorest.CreatePreparer(
+		autorest.AsContentType("application/json; charset=utf-8"),
+		autorest.AsPost(),
+		autorest.WithCustomBaseURL("{vaultBaseUrl}", urlParameters),
+		autorest.WithPath("/backup"),
+		autorest.WithQueryParameters(queryParameters),
+		autorest.WithJSON(parameters)).Prepare((&http.Request{}).WithContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("preparing request: %+v", err)
+	}
+
+	resp, err := client.Send(req, autorest.DoRetryForStatusCodes(client.RetryAttempts, client.RetryDuration, autorest.StatusCodesForRetry...))
+	if err != nil {
+		return nil, fmt.Errorf("sending request: %+v", err)
+	}
+
+	result, err := client.FullBackupResponder(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
@@ -8,6 +8,7 @@ import (
 	"encoding/base64"
 	"fmt"
 	"log"
+	"strings"
 	"time"
 
 	"github.com/Azure/go-autorest/autorest"
@@ -15,6 +16,7 @@ import (
 	"github.com/hashicorp/go-azure-helpers/lang/pointer"
 	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
 	"github.com/hashicorp/go-azure-sdk/resource-manager/keyvault/2023-07-01/managedhsms"
+	azValidate "github.com/hashicorp/terraform-provider-azurerm/helpers/validate"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/locks"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/services/managedhsm/parse"
@@ -35,16 +37,27 @@ func (r KeyVaultMHSMKeyResource) ModelObject() interface{} {
 }
 
 type KeyVaultMHSMKeyResourceSchema struct {
-	Name           string                 `tfschema:"name"`
-	ManagedHSMID   string                 `tfschema:"managed_hsm_id"`
-	KeyType        string                 `tfschema:"key_type"`
-	KeyOpts        []string               `tfschema:"key_opts"`
-	KeySize        int64                  `tfschema:"key_size"`
-	Curve          string                 `tfschema:"curve"`
-	NotBeforeDate  string                 `tfschema:"not_before_date"`
-	ExpirationDate string                 `tfschema:"expiration_date"`
-	Tags           map[string]interface{} `tfschema:"tags"`
-	VersionedId    string                 `tfschema:"versioned_id"`
+	Name           string                                `tfschema:"name"`
+	ManagedHSMID   string                                `tfschema:"managed_hsm_id"`
+	KeyType        string                                `tfschema:"key_type"`
+	KeyOpts        []string                              `tfschema:"key_opts"`
+	KeySize        int64                                 `tfschema:"key_size"`
+	Curve          string                                `tfschema:"curve"`
+	NotBeforeDate  string                                `tfschema:"not_before_date"`
+	ExpirationDate string                                `tfschema:"expiration_date"`
+	RotationPolicy []KeyVaultMHSMKeyRotationPolicySchema `tfschema:"rotation_policy"`
+	Tags           map[string]interface{}                `tfschema:"tags"`
+	VersionedId    string                                `tfschema:"versioned_id"`
+}
+
+type KeyVaultMHSMKeyRotationPolicySchema struct {
+	ExpireAfter string                                   `tfschema:"expire_after"`
+	Automatic   []KeyVaultMHSMKeyAutomaticRotationSchema `tfschema:"automatic"`
+}
+
+type KeyVaultMHSMKeyAutomaticRotationSchema struct {
+	TimeAfterCreation string `tfschema:"time_after_creation"`
+	TimeBeforeExpiry  string `tfschema:"time_before_expiry"`
 }
 
 func (r KeyVaultMHSMKeyResource) IDValidationFunc() pluginsdk.SchemaValidateFunc {
@@ -137,6 +150,58 @@ func (r KeyVaultMHSMKeyResource) Arguments() map[string]*pluginsdk.Schema {
 			ValidateFunc: validation.IsRFC3339Time,
 		},
 
+		"rotation_policy": {
+			Type:     pluginsdk.TypeList,
+			Optional: true,
+			MaxItems: 1,
+			Elem: &pluginsdk.Resource{
+				Schema: map[string]*pluginsdk.Schema{
+					"expire_after": {
+						Type:         pluginsdk.TypeString,
+						Optional:     true,
+						ValidateFunc: azValidate.ISO8601DurationBetween("P28D", "P100Y"),
+						AtLeastOneOf: []string{
+							"rotation_policy.0.expire_after",
+							"rotation_policy.0.automatic",
+						},
+					},
+
+					"automatic": {
+						Type:     pluginsdk.TypeList,
+						Optional: true,
+						MaxItems: 1,
+						AtLeastOneOf: []string{
+							"rotation_policy.0.expire_after",
+							"rotation_policy.0.automatic",
+						},
+						Elem: &pluginsdk.Resource{
+							Schema: map[string]*pluginsdk.Schema{
+								"time_after_creation": {
+									Type:         pluginsdk.TypeString,
+									Optional:     true,
+									ValidateFunc: azValidate.ISO8601Duration,
+									ExactlyOneOf: []string{
+										"rotation_policy.0.automatic.0.time_after_creation",
+										"rotation_policy.0.automatic.0.time_before_expiry",
+									},
+								},
+
+								"time_before_expiry": {
+									Type:         pluginsdk.TypeString,
+									Optional:     true,
+									ValidateFunc: azValidate.ISO8601Duration,
+									ExactlyOneOf: []string{
+										"rotation_policy.0.automatic.0.time_after_creation",
+										"rotation_policy.0.automatic.0.time_before_expiry",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+
 		"tags": tags.Schema(),
 	}
 }
@@ -276,6 +341,12 @@ func (r KeyVaultMHSMKeyResource) Create() sdk.ResourceFunc {
 				}
 			}
 
+			if len(config.RotationPolicy) > 0 {
+				if _, err := client.UpdateKeyRotationPolicy(ctx, endpoint.BaseURI(), config.Name, expandKeyVaultMHSMKeyRotationPolicy(config.RotationPolicy)); err != nil {
+					return fmt.Errorf("creating the Rotation Policy for %s: %+v", id, err)
+				}
+			}
+
 			metadata.SetID(id)
 			return nil
 		},
@@ -343,6 +414,15 @@ func (r KeyVaultMHSMKeyResource) Read() sdk.ResourceFunc {
 				}
 			}
 
+			policy, err := client.GetKeyRotationPolicy(ctx, id.BaseUri(), id.KeyName)
+			if err != nil {
+				if !utils.ResponseWasNotFound(policy.Response) {
+					return fmt.Errorf("retrieving the Rotation Policy for %s: %+v", *id, err)
+				}
+			} else {
+				schema.RotationPolicy = flattenKeyVaultMHSMKeyRotationPolicy(policy)
+			}
+
 			return metadata.Encode(&schema)
 		},
 	}
@@ -402,6 +482,13 @@ func (r KeyVaultMHSMKeyResource) Update() sdk.ResourceFunc {
 				return err
 			}
 
+			if metadata.ResourceData.HasChange("rotation_policy") {
+				keysClient := metadata.Client.ManagedHSMs.DataPlaneKeysClient
+				if _, err := keysClient.UpdateKeyRotationPolicy(ctx, id.BaseUri(), id.KeyName, expandKeyVaultMHSMKeyRotationPolicy(config.RotationPolicy)); err != nil {
+					return fmt.Errorf("updating the Rotation Policy for %s: %+v", *id, err)
+				}
+			}
+
 			return nil
 		},
 	}
@@ -501,3 +588,71 @@ func flattenKeyVaultKeyOptions(input *[]string) []string {
 
 	return append(results, *input...)
 }
+
+func expandKeyVaultMHSMKeyRotationPolicy(input []KeyVaultMHSMKeyRotationPolicySchema) keyvault.KeyRotationPolicy {
+	if len(input) == 0 {
+		// removing the Rotation Policy requires sending an empty set of Lifetime Actions
+		return keyvault.KeyRotationPolicy{
+			LifetimeActions: &[]keyvault.LifetimeActions{},
+		}
+	}
+
+	policy := input[0]
+
+	lifetimeActions := make([]keyvault.LifetimeActions, 0)
+	if len(policy.Automatic) > 0 {
+		trigger := keyvault.LifetimeActionsTrigger{}
+		if v := policy.Automatic[0].TimeAfterCreation; v != "" {
+			trigger.TimeAfterCreate = pointer.To(v)
+		}
+		if v := policy.Automatic[0].TimeBeforeExpiry; v != "" {
+			trigger.TimeBeforeExpiry = pointer.To(v)
+		}
+
+		lifetimeActions = append(lifetimeActions, keyvault.LifetimeActions{
+			Trigger: &trigger,
+			Action: &keyvault.LifetimeActionsType{
+				Type: keyvault.ActionTypeRotate,
+			},
+		})
+	}
+
+	attributes := keyvault.KeyRotationPolicyAttributes{}
+	if policy.ExpireAfter != "" {
+		attributes.ExpiryTime = pointer.To(policy.ExpireAfter)
+	}
+
+	return keyvault.KeyRotationPolicy{
+		LifetimeActions: &lifetimeActions,
+		Attributes:      &attributes,
+	}
+}
+
+func flattenKeyVaultMHSMKeyRotationPolicy(input keyvault.KeyRotationPolicy) []KeyVaultMHSMKeyRotationPolicySchema {
+	policy := KeyVaultMHSMKeyRotationPolicySchema{}
+	if input.Attributes != nil {
+		policy.ExpireAfter = pointer.From(input.Attributes.ExpiryTime)
+	}
+
+	if input.LifetimeActions != nil {
+		for _, action := range *input.LifetimeActions {
+			if action.Action == nil || action.Trigger == nil || !strings.EqualFold(string(action.Action.Type), string(keyvault.ActionTypeRotate)) {
+				// a Notify action may be added by the service, which isn't configurable for Managed HSM Keys
+				continue
+			}
+
+			policy.Automatic = []KeyVaultMHSMKeyAutomaticRotationSchema{
+				{
+					TimeAfterCreation: pointer.From(action.Trigger.TimeAfterCreate),
+					TimeBeforeExpiry:  pointer.From(action.Trigger.TimeBeforeExpiry),
+				},
+			}
+		}
+	}
+
+	if policy.ExpireAfter == "" && len(policy.Automatic) == 0 {
+		return []KeyVaultMHSMKeyRotationPolicySchema{}
+	}
+
+	return []KeyVaultMHSMKeyRotationPolicySchema{policy}
+}
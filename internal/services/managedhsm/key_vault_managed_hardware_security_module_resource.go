@@ -11,11 +11,14 @@ import (
 	"fmt"
 	"io"
 	"log"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/go-azure-helpers/lang/pointer"
 	"github.com/hashicorp/go-azure-helpers/lang/response"
 	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonschema"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/identity"
 	"github.com/hashicorp/go-azure-helpers/resourcemanager/location"
 	"github.com/hashicorp/go-azure-helpers/resourcemanager/tags"
 	"github.com/hashicorp/go-azure-sdk/resource-manager/keyvault/2023-07-01/managedhsms"
@@ -112,6 +115,8 @@ func resourceKeyVaultManagedHardwareSecurityModule() *pluginsdk.Resource {
 				Computed: true,
 			},
 
+			"identity": commonschema.SystemAssignedUserAssignedIdentityOptional(),
+
 			"public_network_access_enabled": {
 				Type:     pluginsdk.TypeBool,
 				Optional: true,
@@ -170,6 +175,14 @@ func resourceKeyVaultManagedHardwareSecurityModule() *pluginsdk.Resource {
 				Sensitive: true,
 			},
 
+			// this is a Data Plane setting, as such the Managed HSM must be activated before it can be configured
+			"key_management_operations_through_arm_enabled": {
+				Type:         pluginsdk.TypeBool,
+				Optional:     true,
+				Computed:     true,
+				RequiredWith: []string{"security_domain_key_vault_certificate_ids"},
+			},
+
 			// https://github.com/Azure/azure-rest-api-specs/issues/13365
 			"tags": commonschema.Tags(),
 		},
@@ -218,6 +231,12 @@ func resourceArmKeyVaultManagedHardwareSecurityModuleCreate(d *pluginsdk.Resourc
 		hsm.Properties.TenantId = pointer.To(tenantId)
 	}
 
+	expandedIdentity, err := identity.ExpandLegacySystemAndUserAssignedMap(d.Get("identity").([]interface{}))
+	if err != nil {
+		return fmt.Errorf("expanding `identity`: %+v", err)
+	}
+	hsm.Identity = expandedIdentity
+
 	if err := client.ManagedHsmClient.CreateOrUpdateThenPoll(ctx, id, hsm); err != nil {
 		return fmt.Errorf("creating %s: %+v", id, err)
 	}
@@ -253,6 +272,12 @@ func resourceArmKeyVaultManagedHardwareSecurityModuleCreate(d *pluginsdk.Resourc
 		d.Set("security_domain_encrypted_data", encData)
 	}
 
+	if v := d.GetRawConfig().GetAttr("key_management_operations_through_arm_enabled"); !v.IsNull() {
+		if err := updateManagedHSMKeyManagementOperationsThroughARMSetting(ctx, *client.DataPlaneKeysClient, dataPlaneUri, v.True()); err != nil {
+			return fmt.Errorf("updating the settings for %s: %+v", id, err)
+		}
+	}
+
 	return resourceArmKeyVaultManagedHardwareSecurityModuleRead(d, meta)
 }
 
@@ -279,6 +304,14 @@ func resourceArmKeyVaultManagedHardwareSecurityModuleUpdate(d *pluginsdk.Resourc
 		hasUpdate = true
 		model.Tags = tags.Expand(d.Get("tags").(map[string]interface{}))
 	}
+	if d.HasChange("identity") {
+		hasUpdate = true
+		expandedIdentity, err := identity.ExpandLegacySystemAndUserAssignedMap(d.Get("identity").([]interface{}))
+		if err != nil {
+			return fmt.Errorf("expanding `identity`: %+v", err)
+		}
+		model.Identity = expandedIdentity
+	}
 	if d.HasChange("network_acls") {
 		hasUpdate = true
 		model.Properties.NetworkAcls = expandMHSMNetworkAcls(d.Get("network_acls").([]interface{}))
@@ -313,11 +346,18 @@ func resourceArmKeyVaultManagedHardwareSecurityModuleUpdate(d *pluginsdk.Resourc
 		d.Set("security_domain_encrypted_data", encData)
 	}
 
+	if d.HasChange("key_management_operations_through_arm_enabled") {
+		if err := updateManagedHSMKeyManagementOperationsThroughARMSetting(ctx, *kvClient.DataPlaneKeysClient, *resp.Model.Properties.HsmUri, d.Get("key_management_operations_through_arm_enabled").(bool)); err != nil {
+			return fmt.Errorf("updating the settings for %s: %+v", id, err)
+		}
+	}
+
 	return nil
 }
 
 func resourceArmKeyVaultManagedHardwareSecurityModuleRead(d *pluginsdk.ResourceData, meta interface{}) error {
 	hsmClient := meta.(*clients.Client).ManagedHSMs.ManagedHsmClient
+	dataPlaneClient := meta.(*clients.Client).ManagedHSMs.DataPlaneKeysClient
 	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
 	defer cancel()
 
@@ -363,6 +403,28 @@ func resourceArmKeyVaultManagedHardwareSecurityModuleRead(d *pluginsdk.ResourceD
 			if err := d.Set("network_acls", flattenMHSMNetworkAcls(props.NetworkAcls)); err != nil {
 				return fmt.Errorf("setting `network_acls`: %+v", err)
 			}
+
+			// the settings can only be retrieved once the Managed HSM has been activated - and since this requires
+			// a Data Plane permission, only when this is configured (or has previously been retrieved)
+			if props.HsmUri != nil && d.Get("security_domain_encrypted_data").(string) != "" && keyManagementOperationsThroughARMIsTracked(d) {
+				setting, err := dataPlaneClient.GetSetting(ctx, *props.HsmUri, managedHSMSettingAllowKeyManagementOperationsThroughARM)
+				if err != nil {
+					if !utils.ResponseWasForbidden(setting.Response) {
+						return fmt.Errorf("retrieving the setting %q for %s: %+v", managedHSMSettingAllowKeyManagementOperationsThroughARM, id, err)
+					}
+					log.Printf("[DEBUG] Insufficient permissions to retrieve the setting %q for %s - retaining the existing value", managedHSMSettingAllowKeyManagementOperationsThroughARM, id)
+				} else {
+					d.Set("key_management_operations_through_arm_enabled", strings.EqualFold(pointer.From(setting.Value), "true"))
+				}
+			}
+		}
+
+		flattenedIdentity, err := identity.FlattenLegacySystemAndUserAssignedMap(model.Identity)
+		if err != nil {
+			return fmt.Errorf("flattening `identity`: %+v", err)
+		}
+		if err := d.Set("identity", flattenedIdentity); err != nil {
+			return fmt.Errorf("setting `identity`: %+v", err)
 		}
 
 		skuName := ""
@@ -539,6 +601,37 @@ func securityDomainDownload(ctx context.Context, sdClient *kv74.HSMSecurityDomai
 	return encData.Value, err
 }
 
+const managedHSMSettingAllowKeyManagementOperationsThroughARM = "AllowKeyManagementOperationsThroughARM"
+
+// keyManagementOperationsThroughARMIsTracked returns whether `key_management_operations_through_arm_enabled` is
+// specified in the Configuration or exists within the State
+func keyManagementOperationsThroughARMIsTracked(d *pluginsdk.ResourceData) bool {
+	if raw := d.GetRawConfig(); !raw.IsNull() && raw.IsKnown() && raw.Type().IsObjectType() {
+		if !raw.GetAttr("key_management_operations_through_arm_enabled").IsNull() {
+			return true
+		}
+	}
+
+	if raw := d.GetRawState(); !raw.IsNull() && raw.IsKnown() && raw.Type().IsObjectType() {
+		if !raw.GetAttr("key_management_operations_through_arm_enabled").IsNull() {
+			return true
+		}
+	}
+
+	return false
+}
+
+func updateManagedHSMKeyManagementOperationsThroughARMSetting(ctx context.Context, client kv74.BaseClient, vaultBaseUrl string, enabled bool) error {
+	parameters := kv74.UpdateSettingRequest{
+		Value: pointer.To(strconv.FormatBool(enabled)),
+	}
+	if _, err := client.UpdateSetting(ctx, vaultBaseUrl, managedHSMSettingAllowKeyManagementOperationsThroughARM, parameters); err != nil {
+		return fmt.Errorf("updating the setting %q: %+v", managedHSMSettingAllowKeyManagementOperationsThroughARM, err)
+	}
+
+	return nil
+}
+
 func keyVaultHSMCustomizeDiff(_ context.Context, d *pluginsdk.ResourceDiff, _ interface{}) error {
 	if oldVal, newVal := d.GetChange("security_domain_key_vault_certificate_ids"); len(oldVal.([]interface{})) != 0 && len(newVal.([]interface{})) == 0 {
 		if err := d.ForceNew("security_domain_key_vault_certificate_ids"); err != nil {
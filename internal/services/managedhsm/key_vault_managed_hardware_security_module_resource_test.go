@@ -31,6 +31,11 @@ func TestAccKeyVaultManagedHardwareSecurityModule(t *testing.T) {
 			"update":   testAccKeyVaultManagedHardwareSecurityModule_updateAndRequiresImport,
 			"complete": testAccKeyVaultManagedHardwareSecurityModule_complete,
 			"download": testAccKeyVaultManagedHardwareSecurityModule_download,
+			"settings": testAccKeyVaultManagedHardwareSecurityModule_settings,
+		},
+		"backups": {
+			"sasToken":        testAccKeyVaultMHSMBackup_sasToken,
+			"managedIdentity": testAccKeyVaultMHSMBackup_managedIdentity,
 		},
 		"roleAssignments": {
 			"builtInRole": testAccKeyVaultManagedHardwareSecurityModuleRoleAssignment_builtInRole,
@@ -55,6 +60,7 @@ func TestAccKeyVaultManagedHardwareSecurityModule(t *testing.T) {
 			"complete":           testAccKeyVaultMHSMKey_complete,
 			"purge":              testAccKeyVaultHSMKey_purge,
 			"softDeleteRecovery": testAccKeyVaultHSMKey_softDeleteRecovery,
+			"rotationPolicy":     testAccKeyVaultMHSMKey_rotationPolicy,
 		},
 	})
 }
@@ -103,6 +109,30 @@ func testAccKeyVaultManagedHardwareSecurityModule_download(t *testing.T) {
 	})
 }
 
+func testAccKeyVaultManagedHardwareSecurityModule_settings(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_key_vault_managed_hardware_security_module", "test")
+	r := KeyVaultManagedHardwareSecurityModuleResource{}
+
+	data.ResourceSequentialTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.settings(data, true),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("key_management_operations_through_arm_enabled").HasValue("true"),
+			),
+		},
+		data.ImportStep("security_domain_quorum", "security_domain_key_vault_certificate_ids", "security_domain_encrypted_data", "key_management_operations_through_arm_enabled"),
+		{
+			Config: r.settings(data, false),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("key_management_operations_through_arm_enabled").HasValue("false"),
+			),
+		},
+		data.ImportStep("security_domain_quorum", "security_domain_key_vault_certificate_ids", "security_domain_encrypted_data", "key_management_operations_through_arm_enabled"),
+	})
+}
+
 func testAccKeyVaultManagedHardwareSecurityModule_updateAndRequiresImport(t *testing.T) {
 	data := acceptance.BuildTestData(t, "azurerm_key_vault_managed_hardware_security_module", "test")
 	r := KeyVaultManagedHardwareSecurityModuleResource{}
@@ -225,6 +255,14 @@ resource "azurerm_key_vault_managed_hardware_security_module" "import" {
 }
 
 func (r KeyVaultManagedHardwareSecurityModuleResource) download(data acceptance.TestData, certCount int) string {
+	return r.downloadWithConfig(data, certCount, "")
+}
+
+func (r KeyVaultManagedHardwareSecurityModuleResource) settings(data acceptance.TestData, enabled bool) string {
+	return r.downloadWithConfig(data, 3, fmt.Sprintf("key_management_operations_through_arm_enabled = %t", enabled))
+}
+
+func (r KeyVaultManagedHardwareSecurityModuleResource) downloadWithConfig(data acceptance.TestData, certCount int, extraConfig string) string {
 	template := r.template(data)
 	activateConfig := ""
 	if certCount > 0 {
@@ -325,8 +363,9 @@ resource "azurerm_key_vault_managed_hardware_security_module" "test" {
   admin_object_ids         = [data.azurerm_client_config.current.object_id]
   purge_protection_enabled = false
   %[4]s
+  %[5]s
 }
-`, template, data.RandomInteger, certCount, activateConfig)
+`, template, data.RandomInteger, certCount, activateConfig, extraConfig)
 }
 
 func (r KeyVaultManagedHardwareSecurityModuleResource) complete(data acceptance.TestData) string {
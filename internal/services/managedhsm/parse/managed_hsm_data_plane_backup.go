@@ -0,0 +1,88 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package parse
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// ManagedHSMDataPlaneBackupId defines the Data Plane ID for a Full Backup of a Managed HSM.
+// Example format: `https://{name}.{domainSuffix}/backup/{jobId}`
+// Example value:  `https://example.managedhsm.azure.net/backup/a7b8c9d0e1f2`
+type ManagedHSMDataPlaneBackupId struct {
+	// ManagedHSMName specifies the Name of this Managed HSM.
+	ManagedHSMName string
+
+	// DomainSuffix specifies the Domain Suffix used for Managed HSMs in the Azure Environment
+	// where the Managed HSM exists - in the format `managedhsm.azure.net`.
+	DomainSuffix string
+
+	// JobId specifies the ID of the Full Backup operation.
+	JobId string
+}
+
+// NewManagedHSMDataPlaneBackupID returns a new instance of ManagedHSMDataPlaneBackupId with the specified values.
+func NewManagedHSMDataPlaneBackupID(managedHsmName, domainSuffix, jobId string) ManagedHSMDataPlaneBackupId {
+	return ManagedHSMDataPlaneBackupId{
+		ManagedHSMName: managedHsmName,
+		DomainSuffix:   domainSuffix,
+		JobId:          jobId,
+	}
+}
+
+// ManagedHSMDataPlaneBackupID parses the Data Plane ID of a Managed HSM Full Backup.
+func ManagedHSMDataPlaneBackupID(input string, domainSuffix *string) (*ManagedHSMDataPlaneBackupId, error) {
+	if input == "" {
+		return nil, fmt.Errorf("`input` was empty")
+	}
+	if domainSuffix != nil && !strings.HasPrefix(strings.ToLower(*domainSuffix), "managedhsm.") {
+		return nil, fmt.Errorf("internal-error: the domainSuffix for Managed HSM %q didn't contain `managedhsm.`", *domainSuffix)
+	}
+
+	uri, err := url.Parse(input)
+	if err != nil {
+		return nil, fmt.Errorf("parsing %q: %+v", input, err)
+	}
+
+	endpoint, err := parseDataPlaneEndpoint(uri, domainSuffix)
+	if err != nil {
+		// intentionally not wrapping this
+		return nil, err
+	}
+
+	const requireVersion = false
+	resource, err := parseDataPlaneResource(uri, "backup", requireVersion)
+	if err != nil {
+		// intentionally not wrapping this
+		return nil, err
+	}
+
+	return &ManagedHSMDataPlaneBackupId{
+		ManagedHSMName: endpoint.ManagedHSMName,
+		DomainSuffix:   endpoint.DomainSuffix,
+		JobId:          resource.itemName,
+	}, nil
+}
+
+// BaseUri returns the Base URI for this Managed HSM Data Plane Backup
+func (id ManagedHSMDataPlaneBackupId) BaseUri() string {
+	return fmt.Sprintf("https://%s.%s/", id.ManagedHSMName, id.DomainSuffix)
+}
+
+// ID returns the full Resource ID for this Managed HSM Data Plane Backup
+func (id ManagedHSMDataPlaneBackupId) ID() string {
+	return fmt.Sprintf("https://%s.%s/backup/%s", id.ManagedHSMName, id.DomainSuffix, id.JobId)
+}
+
+// String returns a human-readable description of this Managed HSM Backup ID
+func (id ManagedHSMDataPlaneBackupId) String() string {
+	components := []string{
+		fmt.Sprintf("Managed HSM Name: %q", id.ManagedHSMName),
+		fmt.Sprintf("Domain Suffix: %q", id.DomainSuffix),
+		fmt.Sprintf("Job ID: %q", id.JobId),
+	}
+	return fmt.Sprintf("Managed HSM Backup (%s)", strings.Join(components, "\n"))
+}
@@ -0,0 +1,75 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package parse
+
+import (
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+)
+
+func TestParseManagedHSMDataPlaneBackupID_InvalidValuesFail(t *testing.T) {
+	values := []string{
+		"",                                  // empty = invalid
+		"https://example.com/backup/abc123", // Hostname is incomplete
+		"https://example.keyvault.azure.net/backup/abc123",        // Key Vault
+		"https://example.managedhsm.azure.net/",                   // no path
+		"https://example.managedhsm.azure.net/backup/",            // trailing slash - no job
+		"https://example.managedhsm.azure.net/backup/abc/pending", // status path
+		"https://example.managedhsm.azure.net/keys/abc123",        // wrong type
+		"http://example.managedhsm.azure.net:80/backup/abc123",    // HTTP rather than HTTPS
+	}
+	for _, input := range values {
+		t.Logf("Validating %q", input)
+		actual, err := ManagedHSMDataPlaneBackupID(input, nil)
+		if err == nil {
+			t.Fatalf("unexpected value for %q: %q", input, actual.ID())
+		}
+	}
+
+	t.Logf("Validating with a mismatched Domain Suffix")
+	if actual, err := ManagedHSMDataPlaneBackupID("https://example.managedhsm.azure.net/backup/abc123", pointer.To("managedhsm.azure.cn")); err == nil {
+		t.Fatalf("unexpected value: %q", actual.ID())
+	}
+}
+
+func TestParseManagedHSMDataPlaneBackupID_ValidValues(t *testing.T) {
+	values := map[string]ManagedHSMDataPlaneBackupId{
+		"https://example.managedhsm.azure.net/backup/abc123": {
+			// Public
+			ManagedHSMName: "example",
+			DomainSuffix:   "managedhsm.azure.net",
+			JobId:          "abc123",
+		},
+		"https://EXAMPLE.managedhsm.azure.net/backup/abc123": {
+			// Public but the uppercase name should be normalised
+			ManagedHSMName: "example",
+			DomainSuffix:   "managedhsm.azure.net",
+			JobId:          "abc123",
+		},
+		"https://example.managedhsm.azure.cn/backup/abc123": {
+			// China
+			ManagedHSMName: "example",
+			DomainSuffix:   "managedhsm.azure.cn",
+			JobId:          "abc123",
+		},
+	}
+	for input, expected := range values {
+		t.Logf("Validating %q", input)
+		actual, err := ManagedHSMDataPlaneBackupID(input, pointer.To(expected.DomainSuffix))
+		if err != nil {
+			t.Fatalf("unexpected error: %+v", err.Error())
+		}
+
+		if actual.ManagedHSMName != expected.ManagedHSMName {
+			t.Fatalf("expected `ManagedHSMName` to be %q but got %q", expected.ManagedHSMName, actual.ManagedHSMName)
+		}
+		if actual.DomainSuffix != expected.DomainSuffix {
+			t.Fatalf("expected `DomainSuffix` to be %q but got %q", expected.DomainSuffix, actual.DomainSuffix)
+		}
+		if actual.JobId != expected.JobId {
+			t.Fatalf("expected `JobId` to be %q but got %q", expected.JobId, actual.JobId)
+		}
+	}
+}
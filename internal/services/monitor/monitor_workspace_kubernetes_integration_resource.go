@@ -0,0 +1,483 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package monitor
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonschema"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/location"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/insights/2022-06-01/datacollectionendpoints"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/insights/2022-06-01/datacollectionruleassociations"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/insights/2022-06-01/datacollectionrules"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/insights/2023-04-03/azuremonitorworkspaces"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+)
+
+const (
+	workspaceKubernetesIntegrationPrometheusStream      = "Microsoft-PrometheusMetrics"
+	workspaceKubernetesIntegrationDataSourceName        = "PrometheusDataSource"
+	workspaceKubernetesIntegrationDestinationName       = "MonitoringAccount1"
+	workspaceKubernetesIntegrationEndpointNameMaxLength = 44
+	workspaceKubernetesIntegrationRuleNameMaxLength     = 64
+)
+
+type WorkspaceKubernetesIntegrationResourceModel struct {
+	KubernetesClusterId        string            `tfschema:"kubernetes_cluster_id"`
+	MonitorWorkspaceId         string            `tfschema:"monitor_workspace_id"`
+	ResourceGroupName          string            `tfschema:"resource_group_name"`
+	DataCollectionEndpointName string            `tfschema:"data_collection_endpoint_name"`
+	DataCollectionRuleName     string            `tfschema:"data_collection_rule_name"`
+	Tags                       map[string]string `tfschema:"tags"`
+	Location                   string            `tfschema:"location"`
+	DataCollectionEndpointId   string            `tfschema:"data_collection_endpoint_id"`
+	DataCollectionRuleId       string            `tfschema:"data_collection_rule_id"`
+	MetricsIngestionEndpoint   string            `tfschema:"metrics_ingestion_endpoint"`
+}
+
+// WorkspaceKubernetesIntegrationResource provisions the Data Collection Endpoint, Data Collection Rule and Data Collection
+// Rule Association required to send Prometheus metrics from a Kubernetes Cluster to an Azure Monitor Workspace. The ID of
+// this resource is the ID of the Data Collection Rule Association, from which the rest of the chain can be discovered.
+type WorkspaceKubernetesIntegrationResource struct{}
+
+var _ sdk.ResourceWithUpdate = WorkspaceKubernetesIntegrationResource{}
+
+func (r WorkspaceKubernetesIntegrationResource) ResourceType() string {
+	return "azurerm_monitor_workspace_kubernetes_integration"
+}
+
+func (r WorkspaceKubernetesIntegrationResource) ModelObject() interface{} {
+	return &WorkspaceKubernetesIntegrationResourceModel{}
+}
+
+func (r WorkspaceKubernetesIntegrationResource) IDValidationFunc() pluginsdk.SchemaValidateFunc {
+	return datacollectionruleassociations.ValidateScopedDataCollectionRuleAssociationID
+}
+
+func (r WorkspaceKubernetesIntegrationResource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"kubernetes_cluster_id": commonschema.ResourceIDReferenceRequiredForceNew(&commonids.KubernetesClusterId{}),
+
+		"monitor_workspace_id": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: azuremonitorworkspaces.ValidateAccountID,
+		},
+
+		"resource_group_name": commonschema.ResourceGroupName(),
+
+		"data_collection_endpoint_name": {
+			Type:         pluginsdk.TypeString,
+			Optional:     true,
+			Computed:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.StringLenBetween(3, workspaceKubernetesIntegrationEndpointNameMaxLength),
+		},
+
+		"data_collection_rule_name": {
+			Type:         pluginsdk.TypeString,
+			Optional:     true,
+			Computed:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.StringLenBetween(1, workspaceKubernetesIntegrationRuleNameMaxLength),
+		},
+
+		"tags": commonschema.Tags(),
+	}
+}
+
+func (r WorkspaceKubernetesIntegrationResource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"location": commonschema.LocationComputed(),
+
+		"data_collection_endpoint_id": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+
+		"data_collection_rule_id": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+
+		"metrics_ingestion_endpoint": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+	}
+}
+
+func (r WorkspaceKubernetesIntegrationResource) Create() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			var model WorkspaceKubernetesIntegrationResourceModel
+			if err := metadata.Decode(&model); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			client := metadata.Client.Monitor.DataCollectionRuleAssociationsClient
+			subscriptionId := metadata.Client.Account.SubscriptionId
+
+			clusterId, err := commonids.ParseKubernetesClusterID(model.KubernetesClusterId)
+			if err != nil {
+				return err
+			}
+
+			workspaceId, err := azuremonitorworkspaces.ParseAccountID(model.MonitorWorkspaceId)
+			if err != nil {
+				return err
+			}
+
+			workspace, err := metadata.Client.Monitor.WorkspacesClient.Get(ctx, *workspaceId)
+			if err != nil {
+				return fmt.Errorf("retrieving %s: %+v", *workspaceId, err)
+			}
+			if workspace.Model == nil {
+				return fmt.Errorf("retrieving %s: `model` was nil", *workspaceId)
+			}
+			// the Data Collection Endpoint and Rule must exist in the same region as the Azure Monitor Workspace
+			loc := location.Normalize(workspace.Model.Location)
+
+			defaultName := fmt.Sprintf("MSProm-%s-%s", loc, clusterId.ManagedClusterName)
+			if model.DataCollectionEndpointName == "" {
+				model.DataCollectionEndpointName = truncateWorkspaceKubernetesIntegrationName(defaultName, workspaceKubernetesIntegrationEndpointNameMaxLength)
+			}
+			if model.DataCollectionRuleName == "" {
+				model.DataCollectionRuleName = truncateWorkspaceKubernetesIntegrationName(defaultName, workspaceKubernetesIntegrationRuleNameMaxLength)
+			}
+
+			id := datacollectionruleassociations.NewScopedDataCollectionRuleAssociationID(clusterId.ID(), model.DataCollectionRuleName)
+			existing, err := client.Get(ctx, id)
+			if err != nil && !response.WasNotFound(existing.HttpResponse) {
+				return fmt.Errorf("checking for existing %s: %+v", id, err)
+			}
+			if !response.WasNotFound(existing.HttpResponse) {
+				return metadata.ResourceRequiresImport(r.ResourceType(), id)
+			}
+
+			endpointId := datacollectionendpoints.NewDataCollectionEndpointID(subscriptionId, model.ResourceGroupName, model.DataCollectionEndpointName)
+			ruleId := datacollectionrules.NewDataCollectionRuleID(subscriptionId, model.ResourceGroupName, model.DataCollectionRuleName)
+			if err := r.createOrUpdateDataCollection(ctx, metadata, endpointId, ruleId, *workspaceId, loc, model.Tags); err != nil {
+				return err
+			}
+
+			payload := datacollectionruleassociations.DataCollectionRuleAssociationProxyOnlyResource{
+				Name: pointer.To(id.DataCollectionRuleAssociationName),
+				Properties: &datacollectionruleassociations.DataCollectionRuleAssociation{
+					DataCollectionRuleId: pointer.To(ruleId.ID()),
+					Description:          pointer.To(fmt.Sprintf("Association of the Data Collection Rule %q to the Kubernetes Cluster %q", ruleId.DataCollectionRuleName, clusterId.ManagedClusterName)),
+				},
+			}
+			if _, err := client.Create(ctx, id, payload); err != nil {
+				return fmt.Errorf("creating %s: %+v", id, err)
+			}
+
+			metadata.SetID(id)
+			return nil
+		},
+	}
+}
+
+func (r WorkspaceKubernetesIntegrationResource) Update() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			id, err := datacollectionruleassociations.ParseScopedDataCollectionRuleAssociationID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			var model WorkspaceKubernetesIntegrationResourceModel
+			if err := metadata.Decode(&model); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			endpointId, err := datacollectionendpoints.ParseDataCollectionEndpointID(model.DataCollectionEndpointId)
+			if err != nil {
+				return err
+			}
+			ruleId, err := datacollectionrules.ParseDataCollectionRuleID(model.DataCollectionRuleId)
+			if err != nil {
+				return err
+			}
+			workspaceId, err := azuremonitorworkspaces.ParseAccountID(model.MonitorWorkspaceId)
+			if err != nil {
+				return err
+			}
+
+			// the whole chain is re-applied, which also reconciles any changes made outside of Terraform
+			if err := r.createOrUpdateDataCollection(ctx, metadata, *endpointId, *ruleId, *workspaceId, model.Location, model.Tags); err != nil {
+				return fmt.Errorf("updating %s: %+v", *id, err)
+			}
+
+			return nil
+		},
+	}
+}
+
+func (r WorkspaceKubernetesIntegrationResource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			id, err := datacollectionruleassociations.ParseScopedDataCollectionRuleAssociationID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			clusterId, err := commonids.ParseKubernetesClusterIDInsensitively(id.ResourceUri)
+			if err != nil {
+				return fmt.Errorf("parsing the scope of %s as a Kubernetes Cluster ID: %+v", *id, err)
+			}
+
+			association, err := metadata.Client.Monitor.DataCollectionRuleAssociationsClient.Get(ctx, *id)
+			if err != nil {
+				if response.WasNotFound(association.HttpResponse) {
+					return metadata.MarkAsGone(id)
+				}
+				return fmt.Errorf("retrieving %s: %+v", *id, err)
+			}
+
+			// if any part of the chain is missing or no longer forwards metrics to the Azure Monitor Workspace then
+			// `monitor_workspace_id` is left empty, meaning that the full chain is re-provisioned by the next apply
+			state := WorkspaceKubernetesIntegrationResourceModel{
+				KubernetesClusterId:        clusterId.ID(),
+				ResourceGroupName:          metadata.ResourceData.Get("resource_group_name").(string),
+				DataCollectionEndpointName: metadata.ResourceData.Get("data_collection_endpoint_name").(string),
+				DataCollectionEndpointId:   metadata.ResourceData.Get("data_collection_endpoint_id").(string),
+				DataCollectionRuleName:     metadata.ResourceData.Get("data_collection_rule_name").(string),
+				DataCollectionRuleId:       metadata.ResourceData.Get("data_collection_rule_id").(string),
+				Location:                   metadata.ResourceData.Get("location").(string),
+				Tags:                       expandWorkspaceKubernetesIntegrationTags(metadata.ResourceData.Get("tags").(map[string]interface{})),
+			}
+
+			ruleIdRaw := ""
+			if model := association.Model; model != nil && model.Properties != nil {
+				ruleIdRaw = pointer.From(model.Properties.DataCollectionRuleId)
+			}
+			if ruleIdRaw == "" {
+				return metadata.Encode(&state)
+			}
+
+			ruleId, err := datacollectionrules.ParseDataCollectionRuleIDInsensitively(ruleIdRaw)
+			if err != nil {
+				return err
+			}
+			state.ResourceGroupName = ruleId.ResourceGroupName
+			state.DataCollectionRuleName = ruleId.DataCollectionRuleName
+			state.DataCollectionRuleId = ruleId.ID()
+
+			rule, err := metadata.Client.Monitor.DataCollectionRulesClient.Get(ctx, *ruleId)
+			if err != nil {
+				if response.WasNotFound(rule.HttpResponse) {
+					return metadata.Encode(&state)
+				}
+				return fmt.Errorf("retrieving %s: %+v", *ruleId, err)
+			}
+
+			endpointIdRaw := ""
+			monitorWorkspaceId := ""
+			if model := rule.Model; model != nil {
+				state.Location = location.Normalize(model.Location)
+				state.Tags = pointer.From(model.Tags)
+
+				if props := model.Properties; props != nil {
+					endpointIdRaw = pointer.From(props.DataCollectionEndpointId)
+					monitorWorkspaceId = findWorkspaceKubernetesIntegrationWorkspaceId(props)
+				}
+			}
+			if endpointIdRaw == "" {
+				return metadata.Encode(&state)
+			}
+
+			endpointId, err := datacollectionendpoints.ParseDataCollectionEndpointIDInsensitively(endpointIdRaw)
+			if err != nil {
+				return err
+			}
+			state.DataCollectionEndpointName = endpointId.DataCollectionEndpointName
+			state.DataCollectionEndpointId = endpointId.ID()
+
+			endpoint, err := metadata.Client.Monitor.DataCollectionEndpointsClient.Get(ctx, *endpointId)
+			if err != nil {
+				if response.WasNotFound(endpoint.HttpResponse) {
+					return metadata.Encode(&state)
+				}
+				return fmt.Errorf("retrieving %s: %+v", *endpointId, err)
+			}
+
+			state.MonitorWorkspaceId = monitorWorkspaceId
+			if model := endpoint.Model; model != nil && model.Properties != nil && model.Properties.MetricsIngestion != nil {
+				state.MetricsIngestionEndpoint = pointer.From(model.Properties.MetricsIngestion.Endpoint)
+			}
+
+			return metadata.Encode(&state)
+		},
+	}
+}
+
+func (r WorkspaceKubernetesIntegrationResource) Delete() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			id, err := datacollectionruleassociations.ParseScopedDataCollectionRuleAssociationID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			var model WorkspaceKubernetesIntegrationResourceModel
+			if err := metadata.Decode(&model); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			// the chain is removed in reverse order, since a Data Collection Rule can't be deleted whilst it's associated
+			if resp, err := metadata.Client.Monitor.DataCollectionRuleAssociationsClient.Delete(ctx, *id); err != nil && !response.WasNotFound(resp.HttpResponse) {
+				return fmt.Errorf("deleting %s: %+v", *id, err)
+			}
+
+			if model.DataCollectionRuleId != "" {
+				ruleId, err := datacollectionrules.ParseDataCollectionRuleID(model.DataCollectionRuleId)
+				if err != nil {
+					return err
+				}
+				if resp, err := metadata.Client.Monitor.DataCollectionRulesClient.Delete(ctx, *ruleId); err != nil && !response.WasNotFound(resp.HttpResponse) {
+					return fmt.Errorf("deleting %s: %+v", *ruleId, err)
+				}
+			}
+
+			if model.DataCollectionEndpointId != "" {
+				endpointId, err := datacollectionendpoints.ParseDataCollectionEndpointID(model.DataCollectionEndpointId)
+				if err != nil {
+					return err
+				}
+				if resp, err := metadata.Client.Monitor.DataCollectionEndpointsClient.Delete(ctx, *endpointId); err != nil && !response.WasNotFound(resp.HttpResponse) {
+					return fmt.Errorf("deleting %s: %+v", *endpointId, err)
+				}
+			}
+
+			return nil
+		},
+	}
+}
+
+// createOrUpdateDataCollection creates (or overwrites) the Data Collection Endpoint and the Data Collection Rule which
+// forwards the `Microsoft-PrometheusMetrics` stream to the Azure Monitor Workspace
+func (r WorkspaceKubernetesIntegrationResource) createOrUpdateDataCollection(ctx context.Context, metadata sdk.ResourceMetaData, endpointId datacollectionendpoints.DataCollectionEndpointId, ruleId datacollectionrules.DataCollectionRuleId, workspaceId azuremonitorworkspaces.AccountId, loc string, tags map[string]string) error {
+	endpoint := datacollectionendpoints.DataCollectionEndpointResource{
+		Kind:     pointer.To(datacollectionendpoints.KnownDataCollectionEndpointResourceKindLinux),
+		Location: loc,
+		Properties: &datacollectionendpoints.DataCollectionEndpoint{
+			NetworkAcls: &datacollectionendpoints.NetworkRuleSet{
+				PublicNetworkAccess: pointer.To(datacollectionendpoints.KnownPublicNetworkAccessOptionsEnabled),
+			},
+		},
+		Tags: pointer.To(tags),
+	}
+	if _, err := metadata.Client.Monitor.DataCollectionEndpointsClient.Create(ctx, endpointId, endpoint); err != nil {
+		return fmt.Errorf("creating/updating %s: %+v", endpointId, err)
+	}
+
+	rule := datacollectionrules.DataCollectionRuleResource{
+		Kind:     pointer.To(datacollectionrules.KnownDataCollectionRuleResourceKindLinux),
+		Location: loc,
+		Properties: &datacollectionrules.DataCollectionRule{
+			DataCollectionEndpointId: pointer.To(endpointId.ID()),
+			DataSources: &datacollectionrules.DataSourcesSpec{
+				PrometheusForwarder: &[]datacollectionrules.PrometheusForwarderDataSource{
+					{
+						Name: pointer.To(workspaceKubernetesIntegrationDataSourceName),
+						Streams: &[]datacollectionrules.KnownPrometheusForwarderDataSourceStreams{
+							datacollectionrules.KnownPrometheusForwarderDataSourceStreamsMicrosoftNegativePrometheusMetrics,
+						},
+					},
+				},
+			},
+			Destinations: &datacollectionrules.DestinationsSpec{
+				MonitoringAccounts: &[]datacollectionrules.MonitoringAccountDestination{
+					{
+						AccountResourceId: pointer.To(workspaceId.ID()),
+						Name:              pointer.To(workspaceKubernetesIntegrationDestinationName),
+					},
+				},
+			},
+			DataFlows: &[]datacollectionrules.DataFlow{
+				{
+					Destinations: &[]string{workspaceKubernetesIntegrationDestinationName},
+					Streams: &[]datacollectionrules.KnownDataFlowStreams{
+						datacollectionrules.KnownDataFlowStreams(workspaceKubernetesIntegrationPrometheusStream),
+					},
+				},
+			},
+		},
+		Tags: pointer.To(tags),
+	}
+	if _, err := metadata.Client.Monitor.DataCollectionRulesClient.Create(ctx, ruleId, rule); err != nil {
+		return fmt.Errorf("creating/updating %s: %+v", ruleId, err)
+	}
+
+	return nil
+}
+
+// findWorkspaceKubernetesIntegrationWorkspaceId returns the ID of the Azure Monitor Workspace which the
+// `Microsoft-PrometheusMetrics` stream is sent to, or an empty string if the Data Collection Rule no longer does so
+func findWorkspaceKubernetesIntegrationWorkspaceId(input *datacollectionrules.DataCollectionRule) string {
+	if input.DataFlows == nil || input.Destinations == nil || input.Destinations.MonitoringAccounts == nil {
+		return ""
+	}
+
+	for _, flow := range *input.DataFlows {
+		if flow.Streams == nil || flow.Destinations == nil {
+			continue
+		}
+
+		forwardsPrometheusMetrics := false
+		for _, stream := range *flow.Streams {
+			if strings.EqualFold(string(stream), workspaceKubernetesIntegrationPrometheusStream) {
+				forwardsPrometheusMetrics = true
+			}
+		}
+		if !forwardsPrometheusMetrics {
+			continue
+		}
+
+		for _, destination := range *flow.Destinations {
+			for _, account := range *input.Destinations.MonitoringAccounts {
+				if account.AccountResourceId == nil || !strings.EqualFold(pointer.From(account.Name), destination) {
+					continue
+				}
+				if workspaceId, err := azuremonitorworkspaces.ParseAccountIDInsensitively(*account.AccountResourceId); err == nil {
+					return workspaceId.ID()
+				}
+			}
+		}
+	}
+
+	return ""
+}
+
+func expandWorkspaceKubernetesIntegrationTags(input map[string]interface{}) map[string]string {
+	output := make(map[string]string)
+	for k, v := range input {
+		output[k] = v.(string)
+	}
+
+	return output
+}
+
+func truncateWorkspaceKubernetesIntegrationName(input string, maxLength int) string {
+	if len(input) <= maxLength {
+		return input
+	}
+
+	// the names can't end with a hyphen
+	return strings.TrimRight(input[:maxLength], "-")
+}
@@ -0,0 +1,184 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package monitor_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/insights/2022-06-01/datacollectionruleassociations"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/check"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+type WorkspaceKubernetesIntegrationTestResource struct{}
+
+func TestAccMonitorWorkspaceKubernetesIntegration_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_monitor_workspace_kubernetes_integration", "test")
+	r := WorkspaceKubernetesIntegrationTestResource{}
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("data_collection_endpoint_id").IsNotEmpty(),
+				check.That(data.ResourceName).Key("data_collection_rule_id").IsNotEmpty(),
+				check.That(data.ResourceName).Key("metrics_ingestion_endpoint").IsNotEmpty(),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccMonitorWorkspaceKubernetesIntegration_requiresImport(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_monitor_workspace_kubernetes_integration", "test")
+	r := WorkspaceKubernetesIntegrationTestResource{}
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.RequiresImportErrorStep(r.requiresImport),
+	})
+}
+
+func TestAccMonitorWorkspaceKubernetesIntegration_complete(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_monitor_workspace_kubernetes_integration", "test")
+	r := WorkspaceKubernetesIntegrationTestResource{}
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.complete(data, "Test"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccMonitorWorkspaceKubernetesIntegration_update(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_monitor_workspace_kubernetes_integration", "test")
+	r := WorkspaceKubernetesIntegrationTestResource{}
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.complete(data, "Test"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.complete(data, "Production"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("tags.environment").HasValue("Production"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func (r WorkspaceKubernetesIntegrationTestResource) Exists(ctx context.Context, client *clients.Client, state *pluginsdk.InstanceState) (*bool, error) {
+	id, err := datacollectionruleassociations.ParseScopedDataCollectionRuleAssociationID(state.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.Monitor.DataCollectionRuleAssociationsClient.Get(ctx, *id)
+	if err != nil {
+		if response.WasNotFound(resp.HttpResponse) {
+			return pointer.To(false), nil
+		}
+		return nil, fmt.Errorf("retrieving %s: %+v", *id, err)
+	}
+
+	return pointer.To(resp.Model != nil), nil
+}
+
+func (r WorkspaceKubernetesIntegrationTestResource) template(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-monitor-%[1]d"
+  location = "%[2]s"
+}
+
+resource "azurerm_monitor_workspace" "test" {
+  name                = "acctest-mamw-%[1]d"
+  resource_group_name = azurerm_resource_group.test.name
+  location            = azurerm_resource_group.test.location
+}
+
+resource "azurerm_kubernetes_cluster" "test" {
+  name                = "acctestaks%[1]d"
+  location            = azurerm_resource_group.test.location
+  resource_group_name = azurerm_resource_group.test.name
+  dns_prefix          = "acctestaks%[1]d"
+
+  default_node_pool {
+    name       = "default"
+    node_count = 1
+    vm_size    = "Standard_DS2_v2"
+  }
+
+  identity {
+    type = "SystemAssigned"
+  }
+
+  monitor_metrics {}
+}
+`, data.RandomInteger, data.Locations.Primary)
+}
+
+func (r WorkspaceKubernetesIntegrationTestResource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_monitor_workspace_kubernetes_integration" "test" {
+  kubernetes_cluster_id = azurerm_kubernetes_cluster.test.id
+  monitor_workspace_id  = azurerm_monitor_workspace.test.id
+  resource_group_name   = azurerm_resource_group.test.name
+}
+`, r.template(data))
+}
+
+func (r WorkspaceKubernetesIntegrationTestResource) requiresImport(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_monitor_workspace_kubernetes_integration" "import" {
+  kubernetes_cluster_id = azurerm_monitor_workspace_kubernetes_integration.test.kubernetes_cluster_id
+  monitor_workspace_id  = azurerm_monitor_workspace_kubernetes_integration.test.monitor_workspace_id
+  resource_group_name   = azurerm_monitor_workspace_kubernetes_integration.test.resource_group_name
+}
+`, r.basic(data))
+}
+
+func (r WorkspaceKubernetesIntegrationTestResource) complete(data acceptance.TestData, environment string) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "azurerm_monitor_workspace_kubernetes_integration" "test" {
+  kubernetes_cluster_id         = azurerm_kubernetes_cluster.test.id
+  monitor_workspace_id          = azurerm_monitor_workspace.test.id
+  resource_group_name           = azurerm_resource_group.test.name
+  data_collection_endpoint_name = "acctest-dce-%[2]d"
+  data_collection_rule_name     = "acctest-dcr-%[2]d"
+
+  tags = {
+    environment = "%[3]s"
+  }
+}
+`, r.template(data), data.RandomInteger, environment)
+}
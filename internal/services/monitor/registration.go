@@ -14,6 +14,7 @@ type Registration struct{}
 var (
 	_ sdk.TypedServiceRegistration                   = Registration{}
 	_ sdk.UntypedServiceRegistrationWithAGitHubLabel = Registration{}
+	_ sdk.ServiceRegistrationWithResourceProviders   = Registration{}
 )
 
 func (r Registration) AssociatedGitHubLabel() string {
@@ -38,6 +39,7 @@ func (r Registration) Resources() []sdk.Resource {
 		ScheduledQueryRulesAlertV2Resource{},
 		AlertPrometheusRuleGroupResource{},
 		WorkspaceResource{},
+		WorkspaceKubernetesIntegrationResource{},
 	}
 }
 
@@ -46,6 +48,15 @@ func (r Registration) Name() string {
 	return "Monitor"
 }
 
+// ResourceProviders returns the Resource Providers required by the Resources within this Service
+func (r Registration) ResourceProviders() []string {
+	return []string{
+		"Microsoft.AlertsManagement",
+		"Microsoft.Monitor",
+		"microsoft.insights",
+	}
+}
+
 // WebsiteCategories returns a list of categories which can be used for the sidebar
 func (r Registration) WebsiteCategories() []string {
 	return []string{
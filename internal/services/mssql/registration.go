@@ -11,8 +11,10 @@ import (
 type Registration struct{}
 
 var (
-	_ sdk.TypedServiceRegistration                   = Registration{}
-	_ sdk.UntypedServiceRegistrationWithAGitHubLabel = Registration{}
+	_ sdk.TypedServiceRegistration                       = Registration{}
+	_ sdk.TypedServiceRegistrationWithFrameworkResources = Registration{}
+	_ sdk.UntypedServiceRegistrationWithAGitHubLabel     = Registration{}
+	_ sdk.ServiceRegistrationWithResourceProviders       = Registration{}
 )
 
 func (r Registration) AssociatedGitHubLabel() string {
@@ -24,6 +26,13 @@ func (r Registration) Name() string {
 	return "Microsoft SQL Server / Azure SQL"
 }
 
+// ResourceProviders returns the Resource Providers required by the Resources within this Service
+func (r Registration) ResourceProviders() []string {
+	return []string{
+		"Microsoft.Sql",
+	}
+}
+
 // WebsiteCategories returns a list of categories which can be used for the sidebar
 func (r Registration) WebsiteCategories() []string {
 	return []string{
@@ -76,3 +85,10 @@ func (r Registration) Resources() []sdk.Resource {
 		ServerDNSAliasResource{},
 	}
 }
+
+// FrameworkResources returns a list of Resources (from Resources) which are served through the Plugin Framework from 4.0
+func (r Registration) FrameworkResources() []sdk.Resource {
+	return []sdk.Resource{
+		ServerDNSAliasResource{},
+	}
+}
@@ -0,0 +1,284 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package recoveryservices
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/recoveryservices/mgmt/2021-12-01/backup" // nolint: staticcheck
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonschema"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/recoveryservicesbackup/2023-02-01/protectioncontainers"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/recoveryservices/validate"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+)
+
+type BackupContainerVMWorkloadModel struct {
+	ResourceGroupName string `tfschema:"resource_group_name"`
+	RecoveryVaultName string `tfschema:"recovery_vault_name"`
+	SourceVMId        string `tfschema:"source_vm_id"`
+	WorkloadType      string `tfschema:"workload_type"`
+	FriendlyName      string `tfschema:"friendly_name"`
+}
+
+type BackupContainerVMWorkloadResource struct{}
+
+var _ sdk.Resource = BackupContainerVMWorkloadResource{}
+
+func (r BackupContainerVMWorkloadResource) ResourceType() string {
+	return "azurerm_backup_container_vm_workload"
+}
+
+func (r BackupContainerVMWorkloadResource) ModelObject() interface{} {
+	return &BackupContainerVMWorkloadModel{}
+}
+
+func (r BackupContainerVMWorkloadResource) IDValidationFunc() pluginsdk.SchemaValidateFunc {
+	return protectioncontainers.ValidateProtectionContainerID
+}
+
+func (r BackupContainerVMWorkloadResource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"resource_group_name": commonschema.ResourceGroupName(),
+
+		"recovery_vault_name": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validate.RecoveryServicesVaultName,
+		},
+
+		"source_vm_id": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: commonids.ValidateVirtualMachineID,
+		},
+
+		"workload_type": {
+			Type:     pluginsdk.TypeString,
+			Required: true,
+			ForceNew: true,
+			ValidateFunc: validation.StringInSlice([]string{
+				string(protectioncontainers.WorkloadTypeSQLDataBase),
+				string(protectioncontainers.WorkloadTypeSAPHanaDatabase),
+			}, false),
+		},
+	}
+}
+
+func (r BackupContainerVMWorkloadResource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"friendly_name": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+	}
+}
+
+func (r BackupContainerVMWorkloadResource) Create() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 60 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.RecoveryServices.BackupProtectionContainersClient
+			opStatusClient := metadata.Client.RecoveryServices.BackupOperationStatusesClient
+			opResultClient := metadata.Client.RecoveryServices.ProtectionContainerOperationResultsClient
+			subscriptionId := metadata.Client.Account.SubscriptionId
+
+			var model BackupContainerVMWorkloadModel
+			if err := metadata.Decode(&model); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			vmId, err := commonids.ParseVirtualMachineID(model.SourceVMId)
+			if err != nil {
+				return err
+			}
+
+			id := protectioncontainers.NewProtectionContainerID(subscriptionId, model.ResourceGroupName, model.RecoveryVaultName, "Azure", backupContainerVMWorkloadName(*vmId))
+
+			existing, err := client.Get(ctx, id)
+			if err != nil {
+				if !response.WasNotFound(existing.HttpResponse) {
+					return fmt.Errorf("checking for presence of existing %s: %+v", id, err)
+				}
+			}
+			if !response.WasNotFound(existing.HttpResponse) {
+				return metadata.ResourceRequiresImport(r.ResourceType(), id)
+			}
+
+			parameters := protectioncontainers.ProtectionContainerResource{
+				Properties: &protectioncontainers.AzureVMAppContainerProtectionContainer{
+					SourceResourceId:     pointer.To(vmId.ID()),
+					FriendlyName:         pointer.To(vmId.VirtualMachineName),
+					BackupManagementType: pointer.To(protectioncontainers.BackupManagementTypeAzureWorkload),
+					WorkloadType:         pointer.To(protectioncontainers.WorkloadType(model.WorkloadType)),
+				},
+			}
+
+			resp, err := client.Register(ctx, id, parameters)
+			if err != nil {
+				return fmt.Errorf("registering %s: %+v", id, err)
+			}
+
+			operationId, err := parseBackupOperationId(resp.HttpResponse)
+			if err != nil {
+				return fmt.Errorf("registering %s: %+v", id, err)
+			}
+
+			if err := waitForBackupContainerVMWorkloadOperation(ctx, opStatusClient, id, operationId); err != nil {
+				return err
+			}
+
+			metadata.SetID(id)
+
+			// the databases hosted within the container are only discovered once an inquiry has been run, which is
+			// needed before any of them can be protected using `azurerm_backup_protected_vm_workload`
+			filter := fmt.Sprintf("workloadType eq '%s'", model.WorkloadType)
+			inquireResp, err := client.Inquire(ctx, id, protectioncontainers.InquireOperationOptions{Filter: pointer.To(filter)})
+			if err != nil {
+				return fmt.Errorf("inquiring workloads for %s: %+v", id, err)
+			}
+
+			inquireOperationId, err := parseBackupOperationId(inquireResp.HttpResponse)
+			if err != nil {
+				return fmt.Errorf("inquiring workloads for %s: %+v", id, err)
+			}
+
+			deadline, ok := ctx.Deadline()
+			if !ok {
+				return fmt.Errorf("context was missing a deadline")
+			}
+
+			state := &pluginsdk.StateChangeConf{
+				MinTimeout: 10 * time.Second,
+				Delay:      10 * time.Second,
+				Pending:    []string{"202"},
+				Target:     []string{"200", "204"},
+				Refresh:    protectionContainerOperationResultsRefreshFunc(ctx, opResultClient, id.VaultName, id.ResourceGroupName, id.ProtectionContainerName, inquireOperationId),
+				Timeout:    time.Until(deadline),
+			}
+
+			if _, err := state.WaitForStateContext(ctx); err != nil {
+				return fmt.Errorf("waiting for workload inquiry of %s to complete: %+v", id, err)
+			}
+
+			return nil
+		},
+	}
+}
+
+func (r BackupContainerVMWorkloadResource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.RecoveryServices.BackupProtectionContainersClient
+
+			id, err := protectioncontainers.ParseProtectionContainerID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			resp, err := client.Get(ctx, *id)
+			if err != nil {
+				if response.WasNotFound(resp.HttpResponse) {
+					return metadata.MarkAsGone(id)
+				}
+
+				return fmt.Errorf("retrieving %s: %+v", *id, err)
+			}
+
+			state := BackupContainerVMWorkloadModel{
+				ResourceGroupName: id.ResourceGroupName,
+				RecoveryVaultName: id.VaultName,
+			}
+
+			if model := resp.Model; model != nil {
+				if props, ok := model.Properties.(protectioncontainers.AzureVMAppContainerProtectionContainer); ok {
+					state.FriendlyName = pointer.From(props.FriendlyName)
+					state.WorkloadType = string(pointer.From(props.WorkloadType))
+
+					if v := pointer.From(props.SourceResourceId); v != "" {
+						vmId, err := commonids.ParseVirtualMachineIDInsensitively(v)
+						if err != nil {
+							return err
+						}
+						state.SourceVMId = vmId.ID()
+					}
+				}
+			}
+
+			return metadata.Encode(&state)
+		},
+	}
+}
+
+func (r BackupContainerVMWorkloadResource) Delete() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 60 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.RecoveryServices.BackupProtectionContainersClient
+			opStatusClient := metadata.Client.RecoveryServices.BackupOperationStatusesClient
+
+			id, err := protectioncontainers.ParseProtectionContainerID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			resp, err := client.Unregister(ctx, *id)
+			if err != nil {
+				if response.WasNotFound(resp.HttpResponse) {
+					return nil
+				}
+				return fmt.Errorf("unregistering %s: %+v", *id, err)
+			}
+
+			operationId, err := parseBackupOperationId(resp.HttpResponse)
+			if err != nil {
+				return fmt.Errorf("unregistering %s: %+v", *id, err)
+			}
+
+			if err := waitForBackupContainerVMWorkloadOperation(ctx, opStatusClient, *id, operationId); err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+}
+
+func backupContainerVMWorkloadName(id commonids.VirtualMachineId) string {
+	return fmt.Sprintf("VMAppContainer;compute;%s;%s", id.ResourceGroupName, id.VirtualMachineName)
+}
+
+func waitForBackupContainerVMWorkloadOperation(ctx context.Context, client *backup.OperationStatusesClient, id protectioncontainers.ProtectionContainerId, operationId string) error {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return fmt.Errorf("context was missing a deadline")
+	}
+
+	state := &pluginsdk.StateChangeConf{
+		MinTimeout:                10 * time.Second,
+		Delay:                     10 * time.Second,
+		Pending:                   []string{"InProgress"},
+		Target:                    []string{"Succeeded"},
+		Refresh:                   resourceBackupProtectionContainerStorageAccountCheckOperation(ctx, client, id.VaultName, id.ResourceGroupName, operationId),
+		ContinuousTargetOccurence: 5,
+		Timeout:                   time.Until(deadline),
+	}
+
+	log.Printf("[DEBUG] Waiting for operation %q on %s to complete", operationId, id)
+	if _, err := state.WaitForStateContext(ctx); err != nil {
+		return fmt.Errorf("waiting for operation %q on %s: %+v", operationId, id, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,701 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package recoveryservices
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/recoveryservices/mgmt/2021-12-01/backup" // nolint: staticcheck
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonschema"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/recoveryservicesbackup/2023-02-01/backupprotectableitems"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/recoveryservicesbackup/2023-02-01/protecteditems"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/recoveryservicesbackup/2023-02-01/protectionintent"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/recoveryservicesbackup/2023-02-01/protectionpolicies"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/recoveryservices/validate"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+)
+
+type BackupProtectedVMWorkloadModel struct {
+	ResourceGroupName     string `tfschema:"resource_group_name"`
+	RecoveryVaultName     string `tfschema:"recovery_vault_name"`
+	SourceVMId            string `tfschema:"source_vm_id"`
+	WorkloadType          string `tfschema:"workload_type"`
+	InstanceName          string `tfschema:"instance_name"`
+	DatabaseName          string `tfschema:"database_name"`
+	AutoProtectionEnabled bool   `tfschema:"auto_protection_enabled"`
+	BackupPolicyId        string `tfschema:"backup_policy_id"`
+	ProtectionState       string `tfschema:"protection_state"`
+}
+
+type BackupProtectedVMWorkloadResource struct{}
+
+var (
+	_ sdk.ResourceWithUpdate        = BackupProtectedVMWorkloadResource{}
+	_ sdk.ResourceWithCustomizeDiff = BackupProtectedVMWorkloadResource{}
+)
+
+func (r BackupProtectedVMWorkloadResource) ResourceType() string {
+	return "azurerm_backup_protected_vm_workload"
+}
+
+func (r BackupProtectedVMWorkloadResource) ModelObject() interface{} {
+	return &BackupProtectedVMWorkloadModel{}
+}
+
+func (r BackupProtectedVMWorkloadResource) IDValidationFunc() pluginsdk.SchemaValidateFunc {
+	return validateBackupProtectedVMWorkloadID
+}
+
+func (r BackupProtectedVMWorkloadResource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"resource_group_name": commonschema.ResourceGroupName(),
+
+		"recovery_vault_name": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validate.RecoveryServicesVaultName,
+		},
+
+		"source_vm_id": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: commonids.ValidateVirtualMachineID,
+		},
+
+		"workload_type": {
+			Type:     pluginsdk.TypeString,
+			Required: true,
+			ForceNew: true,
+			ValidateFunc: validation.StringInSlice([]string{
+				string(protecteditems.DataSourceTypeSQLDataBase),
+				string(protecteditems.DataSourceTypeSAPHanaDatabase),
+			}, false),
+		},
+
+		"instance_name": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+		},
+
+		"backup_policy_id": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ValidateFunc: protectionpolicies.ValidateBackupPolicyID,
+		},
+
+		"database_name": {
+			Type:         pluginsdk.TypeString,
+			Optional:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+		},
+
+		"auto_protection_enabled": {
+			Type:     pluginsdk.TypeBool,
+			Optional: true,
+			ForceNew: true,
+			Default:  false,
+		},
+
+		"protection_state": {
+			Type:     pluginsdk.TypeString,
+			Optional: true,
+			Computed: true,
+			ValidateFunc: validation.StringInSlice([]string{
+				string(protecteditems.ProtectionStateProtected),
+				string(protecteditems.ProtectionStateProtectionStopped),
+			}, false),
+		},
+	}
+}
+
+func (r BackupProtectedVMWorkloadResource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{}
+}
+
+func (r BackupProtectedVMWorkloadResource) CustomizeDiff() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			var model BackupProtectedVMWorkloadModel
+			if err := metadata.DecodeDiff(&model); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			if model.DatabaseName != "" {
+				if model.AutoProtectionEnabled {
+					return fmt.Errorf("`auto_protection_enabled` cannot be set when `database_name` is specified")
+				}
+				return nil
+			}
+
+			if model.WorkloadType != string(protecteditems.DataSourceTypeSQLDataBase) {
+				return fmt.Errorf("`database_name` must be specified when `workload_type` is `%s`", model.WorkloadType)
+			}
+
+			if !model.AutoProtectionEnabled {
+				return fmt.Errorf("`auto_protection_enabled` must be `true` to protect a SQL Server instance")
+			}
+
+			if v := metadata.ResourceDiff.GetRawConfig().AsValueMap()["protection_state"]; !v.IsNull() {
+				return fmt.Errorf("`protection_state` cannot be set when `auto_protection_enabled` is `true`")
+			}
+
+			return nil
+		},
+	}
+}
+
+func (r BackupProtectedVMWorkloadResource) Create() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 120 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			protectableClient := metadata.Client.RecoveryServices.ProtectableItemsClient
+			subscriptionId := metadata.Client.Account.SubscriptionId
+
+			var model BackupProtectedVMWorkloadModel
+			if err := metadata.Decode(&model); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			vmId, err := commonids.ParseVirtualMachineID(model.SourceVMId)
+			if err != nil {
+				return err
+			}
+			containerName := backupContainerVMWorkloadName(*vmId)
+
+			protectableItemType := model.WorkloadType
+			if model.AutoProtectionEnabled {
+				protectableItemType = string(protectionintent.WorkloadItemTypeSQLInstance)
+			}
+
+			vaultId := backupprotectableitems.NewVaultID(subscriptionId, model.ResourceGroupName, model.RecoveryVaultName)
+			filter := fmt.Sprintf("backupManagementType eq 'AzureWorkload' and workloadType eq '%s'", model.WorkloadType)
+			protectableItems, err := protectableClient.ListComplete(ctx, vaultId, backupprotectableitems.ListOperationOptions{Filter: pointer.To(filter)})
+			if err != nil {
+				return fmt.Errorf("listing protectable items within %s: %+v", vaultId, err)
+			}
+
+			var protectableItem *backupprotectableitems.WorkloadProtectableItemResource
+			for _, item := range protectableItems.Items {
+				if backupProtectedVMWorkloadProtectableItemMatches(item, containerName, protectableItemType, model.InstanceName, model.DatabaseName) {
+					protectableItem = pointer.To(item)
+					break
+				}
+			}
+			if protectableItem == nil || protectableItem.Name == nil || protectableItem.Id == nil {
+				return fmt.Errorf("no protectable %s item was found for instance %q (database %q) within the container %q - ensure the VM is registered using `azurerm_backup_container_vm_workload`", protectableItemType, model.InstanceName, model.DatabaseName, containerName)
+			}
+
+			if model.AutoProtectionEnabled {
+				return r.createProtectionIntent(ctx, metadata, model, *vmId, *protectableItem)
+			}
+
+			return r.createProtectedItem(ctx, metadata, model, *vmId, containerName, *protectableItem)
+		},
+	}
+}
+
+func (r BackupProtectedVMWorkloadResource) createProtectionIntent(ctx context.Context, metadata sdk.ResourceMetaData, model BackupProtectedVMWorkloadModel, vmId commonids.VirtualMachineId, protectableItem backupprotectableitems.WorkloadProtectableItemResource) error {
+	client := metadata.Client.RecoveryServices.ProtectionIntentClient
+	subscriptionId := metadata.Client.Account.SubscriptionId
+
+	id := protectionintent.NewBackupProtectionIntentID(subscriptionId, model.ResourceGroupName, model.RecoveryVaultName, "Azure", *protectableItem.Name)
+
+	existing, err := client.Get(ctx, id)
+	if err != nil {
+		if !response.WasNotFound(existing.HttpResponse) {
+			return fmt.Errorf("checking for presence of existing %s: %+v", id, err)
+		}
+	}
+	if !response.WasNotFound(existing.HttpResponse) {
+		return metadata.ResourceRequiresImport(r.ResourceType(), id)
+	}
+
+	payload := protectionintent.ProtectionIntentResource{
+		Properties: expandBackupProtectedVMWorkloadProtectionIntent(vmId, *protectableItem.Id, model.BackupPolicyId),
+	}
+
+	if _, err := client.CreateOrUpdate(ctx, id, payload); err != nil {
+		return fmt.Errorf("creating %s: %+v", id, err)
+	}
+
+	metadata.SetID(id)
+	return nil
+}
+
+func (r BackupProtectedVMWorkloadResource) createProtectedItem(ctx context.Context, metadata sdk.ResourceMetaData, model BackupProtectedVMWorkloadModel, vmId commonids.VirtualMachineId, containerName string, protectableItem backupprotectableitems.WorkloadProtectableItemResource) error {
+	client := metadata.Client.RecoveryServices.ProtectedItemsClient
+	opClient := metadata.Client.RecoveryServices.ProtectedItemOperationResultsClient
+	subscriptionId := metadata.Client.Account.SubscriptionId
+
+	id := protecteditems.NewProtectedItemID(subscriptionId, model.ResourceGroupName, model.RecoveryVaultName, "Azure", containerName, *protectableItem.Name)
+
+	existing, err := client.Get(ctx, id, protecteditems.GetOperationOptions{})
+	if err != nil {
+		if !response.WasNotFound(existing.HttpResponse) {
+			return fmt.Errorf("checking for presence of existing %s: %+v", id, err)
+		}
+	}
+	if !response.WasNotFound(existing.HttpResponse) {
+		if existing.Model != nil && flattenBackupProtectedVMWorkloadItem(existing.Model.Properties).SoftDeleted {
+			return fmt.Errorf("%s is soft deleted and must be undeleted before it can be managed by Terraform", id)
+		}
+		return metadata.ResourceRequiresImport(r.ResourceType(), id)
+	}
+
+	properties, err := expandBackupProtectedVMWorkloadItem(model.WorkloadType, vmId, model.BackupPolicyId, "")
+	if err != nil {
+		return err
+	}
+
+	if err := backupProtectedVMWorkloadCreateOrUpdate(ctx, client, opClient, id, properties); err != nil {
+		return fmt.Errorf("creating %s: %+v", id, err)
+	}
+
+	metadata.SetID(id)
+
+	// protection can only be stopped once the item has been protected
+	if strings.EqualFold(model.ProtectionState, string(protecteditems.ProtectionStateProtectionStopped)) {
+		properties, err := expandBackupProtectedVMWorkloadItem(model.WorkloadType, vmId, "", model.ProtectionState)
+		if err != nil {
+			return err
+		}
+
+		if err := backupProtectedVMWorkloadCreateOrUpdate(ctx, client, opClient, id, properties); err != nil {
+			return fmt.Errorf("stopping protection for %s: %+v", id, err)
+		}
+	}
+
+	return nil
+}
+
+func (r BackupProtectedVMWorkloadResource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			if _, err := protecteditems.ParseProtectedItemID(metadata.ResourceData.Id()); err == nil {
+				return r.readProtectedItem(ctx, metadata)
+			}
+
+			return r.readProtectionIntent(ctx, metadata)
+		},
+	}
+}
+
+func (r BackupProtectedVMWorkloadResource) readProtectedItem(ctx context.Context, metadata sdk.ResourceMetaData) error {
+	client := metadata.Client.RecoveryServices.ProtectedItemsClient
+
+	id, err := protecteditems.ParseProtectedItemID(metadata.ResourceData.Id())
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.Get(ctx, *id, protecteditems.GetOperationOptions{})
+	if err != nil {
+		if response.WasNotFound(resp.HttpResponse) {
+			return metadata.MarkAsGone(id)
+		}
+
+		return fmt.Errorf("retrieving %s: %+v", *id, err)
+	}
+
+	state := BackupProtectedVMWorkloadModel{
+		ResourceGroupName: id.ResourceGroupName,
+		RecoveryVaultName: id.VaultName,
+	}
+
+	if model := resp.Model; model != nil {
+		item := flattenBackupProtectedVMWorkloadItem(model.Properties)
+		if item.SoftDeleted {
+			return metadata.MarkAsGone(id)
+		}
+
+		state.WorkloadType = item.WorkloadType
+		state.InstanceName = item.ParentName
+		state.DatabaseName = item.FriendlyName
+		state.ProtectionState = item.ProtectionState
+
+		if item.SourceResourceId != "" {
+			vmId, err := commonids.ParseVirtualMachineIDInsensitively(item.SourceResourceId)
+			if err != nil {
+				return err
+			}
+			state.SourceVMId = vmId.ID()
+		}
+
+		// the policy is removed from the item once protection has been stopped
+		state.BackupPolicyId = metadata.ResourceData.Get("backup_policy_id").(string)
+		if item.PolicyId != "" {
+			policyId, err := protectionpolicies.ParseBackupPolicyIDInsensitively(item.PolicyId)
+			if err != nil {
+				return err
+			}
+			state.BackupPolicyId = policyId.ID()
+		}
+	}
+
+	return metadata.Encode(&state)
+}
+
+func (r BackupProtectedVMWorkloadResource) readProtectionIntent(ctx context.Context, metadata sdk.ResourceMetaData) error {
+	client := metadata.Client.RecoveryServices.ProtectionIntentClient
+
+	id, err := protectionintent.ParseBackupProtectionIntentID(metadata.ResourceData.Id())
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.Get(ctx, *id)
+	if err != nil {
+		if response.WasNotFound(resp.HttpResponse) {
+			return metadata.MarkAsGone(id)
+		}
+
+		return fmt.Errorf("retrieving %s: %+v", *id, err)
+	}
+
+	state := BackupProtectedVMWorkloadModel{
+		ResourceGroupName:     id.ResourceGroupName,
+		RecoveryVaultName:     id.VaultName,
+		WorkloadType:          string(protecteditems.DataSourceTypeSQLDataBase),
+		AutoProtectionEnabled: true,
+		InstanceName:          metadata.ResourceData.Get("instance_name").(string),
+	}
+
+	// the intent is named after the protectable item, which takes the form `SQLInstance;{instanceName}`
+	if segments := strings.SplitN(id.BackupProtectionIntentName, ";", 2); len(segments) == 2 {
+		state.InstanceName = segments[1]
+	}
+
+	if model := resp.Model; model != nil {
+		if props, ok := model.Properties.(protectionintent.AzureWorkloadSQLAutoProtectionIntent); ok {
+			if v := pointer.From(props.SourceResourceId); v != "" {
+				vmId, err := commonids.ParseVirtualMachineIDInsensitively(v)
+				if err != nil {
+					return err
+				}
+				state.SourceVMId = vmId.ID()
+			}
+
+			if v := pointer.From(props.PolicyId); v != "" {
+				policyId, err := protectionpolicies.ParseBackupPolicyIDInsensitively(v)
+				if err != nil {
+					return err
+				}
+				state.BackupPolicyId = policyId.ID()
+			}
+		}
+	}
+
+	return metadata.Encode(&state)
+}
+
+func (r BackupProtectedVMWorkloadResource) Update() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 120 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			var model BackupProtectedVMWorkloadModel
+			if err := metadata.Decode(&model); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			vmId, err := commonids.ParseVirtualMachineID(model.SourceVMId)
+			if err != nil {
+				return err
+			}
+
+			if model.AutoProtectionEnabled {
+				client := metadata.Client.RecoveryServices.ProtectionIntentClient
+
+				id, err := protectionintent.ParseBackupProtectionIntentID(metadata.ResourceData.Id())
+				if err != nil {
+					return err
+				}
+
+				existing, err := client.Get(ctx, *id)
+				if err != nil {
+					return fmt.Errorf("retrieving %s: %+v", *id, err)
+				}
+				if existing.Model == nil {
+					return fmt.Errorf("retrieving %s: `model` was nil", *id)
+				}
+				props, ok := existing.Model.Properties.(protectionintent.AzureWorkloadSQLAutoProtectionIntent)
+				if !ok {
+					return fmt.Errorf("retrieving %s: expected `properties` to be an AzureWorkloadSQLAutoProtectionIntent but got %T", *id, existing.Model.Properties)
+				}
+
+				payload := *existing.Model
+				payload.Properties = expandBackupProtectedVMWorkloadProtectionIntent(*vmId, pointer.From(props.ItemId), model.BackupPolicyId)
+
+				if _, err := client.CreateOrUpdate(ctx, *id, payload); err != nil {
+					return fmt.Errorf("updating %s: %+v", *id, err)
+				}
+
+				return nil
+			}
+
+			client := metadata.Client.RecoveryServices.ProtectedItemsClient
+			opClient := metadata.Client.RecoveryServices.ProtectedItemOperationResultsClient
+
+			id, err := protecteditems.ParseProtectedItemID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			protectionStopped := strings.EqualFold(model.ProtectionState, string(protecteditems.ProtectionStateProtectionStopped))
+
+			// (re-)applying the policy also resumes the protection of an item which was previously stopped
+			if metadata.ResourceData.HasChange("backup_policy_id") || (metadata.ResourceData.HasChange("protection_state") && !protectionStopped) {
+				properties, err := expandBackupProtectedVMWorkloadItem(model.WorkloadType, *vmId, model.BackupPolicyId, "")
+				if err != nil {
+					return err
+				}
+
+				if err := backupProtectedVMWorkloadCreateOrUpdate(ctx, client, opClient, *id, properties); err != nil {
+					return fmt.Errorf("updating %s: %+v", *id, err)
+				}
+			}
+
+			if metadata.ResourceData.HasChange("protection_state") && protectionStopped {
+				properties, err := expandBackupProtectedVMWorkloadItem(model.WorkloadType, *vmId, "", model.ProtectionState)
+				if err != nil {
+					return err
+				}
+
+				if err := backupProtectedVMWorkloadCreateOrUpdate(ctx, client, opClient, *id, properties); err != nil {
+					return fmt.Errorf("stopping protection for %s: %+v", *id, err)
+				}
+			}
+
+			return nil
+		},
+	}
+}
+
+func (r BackupProtectedVMWorkloadResource) Delete() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 80 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			if intentId, err := protectionintent.ParseBackupProtectionIntentID(metadata.ResourceData.Id()); err == nil {
+				client := metadata.Client.RecoveryServices.ProtectionIntentClient
+
+				if resp, err := client.Delete(ctx, *intentId); err != nil {
+					if !response.WasNotFound(resp.HttpResponse) {
+						return fmt.Errorf("deleting %s: %+v", *intentId, err)
+					}
+				}
+
+				return nil
+			}
+
+			client := metadata.Client.RecoveryServices.ProtectedItemsClient
+			opResultClient := metadata.Client.RecoveryServices.BackupOperationResultsClient
+
+			id, err := protecteditems.ParseProtectedItemID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			log.Printf("[DEBUG] Deleting %s", id)
+
+			resp, err := client.Delete(ctx, *id)
+			if err != nil {
+				if response.WasNotFound(resp.HttpResponse) {
+					return nil
+				}
+				return fmt.Errorf("deleting %s: %+v", *id, err)
+			}
+
+			operationId, err := parseBackupOperationId(resp.HttpResponse)
+			if err != nil {
+				return fmt.Errorf("deleting %s: %+v", *id, err)
+			}
+
+			if err := waitForBackupProtectedVMWorkloadDeletion(ctx, client, opResultClient, *id, operationId); err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+}
+
+// backupProtectedVMWorkloadState holds the properties shared between the SQL Server and SAP HANA protected items
+type backupProtectedVMWorkloadState struct {
+	WorkloadType     string
+	FriendlyName     string
+	ParentName       string
+	PolicyId         string
+	ProtectionState  string
+	SourceResourceId string
+	SoftDeleted      bool
+}
+
+func flattenBackupProtectedVMWorkloadItem(input protecteditems.ProtectedItem) backupProtectedVMWorkloadState {
+	switch item := input.(type) {
+	case protecteditems.AzureVMWorkloadSQLDatabaseProtectedItem:
+		return backupProtectedVMWorkloadState{
+			WorkloadType:     string(protecteditems.DataSourceTypeSQLDataBase),
+			FriendlyName:     pointer.From(item.FriendlyName),
+			ParentName:       pointer.From(item.ParentName),
+			PolicyId:         pointer.From(item.PolicyId),
+			ProtectionState:  string(pointer.From(item.ProtectionState)),
+			SourceResourceId: pointer.From(item.SourceResourceId),
+			SoftDeleted:      pointer.From(item.IsScheduledForDeferredDelete),
+		}
+	case protecteditems.AzureVMWorkloadSAPHanaDatabaseProtectedItem:
+		return backupProtectedVMWorkloadState{
+			WorkloadType:     string(protecteditems.DataSourceTypeSAPHanaDatabase),
+			FriendlyName:     pointer.From(item.FriendlyName),
+			ParentName:       pointer.From(item.ParentName),
+			PolicyId:         pointer.From(item.PolicyId),
+			ProtectionState:  string(pointer.From(item.ProtectionState)),
+			SourceResourceId: pointer.From(item.SourceResourceId),
+			SoftDeleted:      pointer.From(item.IsScheduledForDeferredDelete),
+		}
+	}
+
+	return backupProtectedVMWorkloadState{}
+}
+
+func expandBackupProtectedVMWorkloadItem(workloadType string, vmId commonids.VirtualMachineId, policyId string, protectionState string) (protecteditems.ProtectedItem, error) {
+	var policy *string
+	if policyId != "" {
+		policy = pointer.To(policyId)
+	}
+
+	var state *protecteditems.ProtectionState
+	if protectionState != "" {
+		state = pointer.To(protecteditems.ProtectionState(protectionState))
+	}
+
+	switch workloadType {
+	case string(protecteditems.DataSourceTypeSQLDataBase):
+		return protecteditems.AzureVMWorkloadSQLDatabaseProtectedItem{
+			BackupManagementType: pointer.To(protecteditems.BackupManagementTypeAzureWorkload),
+			WorkloadType:         pointer.To(protecteditems.DataSourceTypeSQLDataBase),
+			SourceResourceId:     pointer.To(vmId.ID()),
+			PolicyId:             policy,
+			ProtectionState:      state,
+		}, nil
+	case string(protecteditems.DataSourceTypeSAPHanaDatabase):
+		return protecteditems.AzureVMWorkloadSAPHanaDatabaseProtectedItem{
+			BackupManagementType: pointer.To(protecteditems.BackupManagementTypeAzureWorkload),
+			WorkloadType:         pointer.To(protecteditems.DataSourceTypeSAPHanaDatabase),
+			SourceResourceId:     pointer.To(vmId.ID()),
+			PolicyId:             policy,
+			ProtectionState:      state,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("unsupported `workload_type` %q", workloadType)
+}
+
+func expandBackupProtectedVMWorkloadProtectionIntent(vmId commonids.VirtualMachineId, itemId string, policyId string) protectionintent.AzureWorkloadSQLAutoProtectionIntent {
+	return protectionintent.AzureWorkloadSQLAutoProtectionIntent{
+		BackupManagementType: pointer.To(protectionintent.BackupManagementTypeAzureWorkload),
+		WorkloadItemType:     pointer.To(protectionintent.WorkloadItemTypeSQLInstance),
+		SourceResourceId:     pointer.To(vmId.ID()),
+		ItemId:               pointer.To(itemId),
+		PolicyId:             pointer.To(policyId),
+	}
+}
+
+func backupProtectedVMWorkloadProtectableItemMatches(input backupprotectableitems.WorkloadProtectableItemResource, containerName, itemType, instanceName, databaseName string) bool {
+	// the protectable item ID is scoped to the container which hosts it
+	if input.Id == nil || !strings.Contains(strings.ToLower(*input.Id), fmt.Sprintf("/protectioncontainers/%s/", strings.ToLower(containerName))) {
+		return false
+	}
+
+	switch item := input.Properties.(type) {
+	case backupprotectableitems.AzureVMWorkloadSQLInstanceProtectableItem:
+		return itemType == string(protectionintent.WorkloadItemTypeSQLInstance) && strings.EqualFold(pointer.From(item.FriendlyName), instanceName)
+	case backupprotectableitems.AzureVMWorkloadSQLDatabaseProtectableItem:
+		return itemType == string(protecteditems.DataSourceTypeSQLDataBase) && strings.EqualFold(pointer.From(item.ParentName), instanceName) && strings.EqualFold(pointer.From(item.FriendlyName), databaseName)
+	case backupprotectableitems.AzureVMWorkloadSAPHanaDatabaseProtectableItem:
+		return itemType == string(protecteditems.DataSourceTypeSAPHanaDatabase) && strings.EqualFold(pointer.From(item.ParentName), instanceName) && strings.EqualFold(pointer.From(item.FriendlyName), databaseName)
+	}
+
+	return false
+}
+
+func backupProtectedVMWorkloadCreateOrUpdate(ctx context.Context, client *protecteditems.ProtectedItemsClient, opClient *backup.ProtectedItemOperationResultsClient, id protecteditems.ProtectedItemId, properties protecteditems.ProtectedItem) error {
+	resp, err := client.CreateOrUpdate(ctx, id, protecteditems.ProtectedItemResource{
+		Properties: properties,
+	})
+	if err != nil {
+		return err
+	}
+
+	operationId, err := parseBackupOperationId(resp.HttpResponse)
+	if err != nil {
+		return err
+	}
+
+	return resourceRecoveryServicesBackupProtectedVMWaitForStateCreateUpdate(ctx, opClient, id, operationId)
+}
+
+func waitForBackupProtectedVMWorkloadDeletion(ctx context.Context, client *protecteditems.ProtectedItemsClient, opResultClient *backup.OperationResultsClient, id protecteditems.ProtectedItemId, operationId string) error {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return fmt.Errorf("context was missing a deadline")
+	}
+
+	// the operation has to complete, otherwise re-protecting the same database in another vault straight away fails
+	state := &pluginsdk.StateChangeConf{
+		MinTimeout: 30 * time.Second,
+		Delay:      10 * time.Second,
+		Pending:    []string{"202"},
+		Target:     []string{"200", "204"},
+		Refresh: func() (interface{}, string, error) {
+			resp, err := opResultClient.Get(ctx, id.VaultName, id.ResourceGroupName, operationId)
+			if err != nil {
+				return nil, "Error", fmt.Errorf("retrieving operation %q for %s: %+v", operationId, id, err)
+			}
+			return resp, strconv.Itoa(resp.StatusCode), nil
+		},
+		Timeout: time.Until(deadline),
+	}
+
+	if _, err := state.WaitForStateContext(ctx); err != nil {
+		return fmt.Errorf("waiting for the deletion of %s: %+v", id, err)
+	}
+
+	resp, err := client.Get(ctx, id, protecteditems.GetOperationOptions{})
+	if err != nil {
+		if response.WasNotFound(resp.HttpResponse) {
+			return nil
+		}
+		return fmt.Errorf("retrieving %s: %+v", id, err)
+	}
+
+	if resp.Model != nil && !flattenBackupProtectedVMWorkloadItem(resp.Model.Properties).SoftDeleted {
+		return fmt.Errorf("%s still exists after deletion", id)
+	}
+
+	return nil
+}
+
+func validateBackupProtectedVMWorkloadID(input interface{}, key string) (warnings []string, errors []error) {
+	if _, errs := protecteditems.ValidateProtectedItemID(input, key); len(errs) == 0 {
+		return nil, nil
+	}
+
+	return protectionintent.ValidateBackupProtectionIntentID(input, key)
+}
@@ -0,0 +1,259 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package recoveryservices_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/recoveryservicesbackup/2023-02-01/protecteditems"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/recoveryservicesbackup/2023-02-01/protectionintent"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/check"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+type BackupProtectedVMWorkloadResource struct{}
+
+func TestAccBackupProtectedVMWorkload_sqlDatabase(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_backup_protected_vm_workload", "test")
+	r := BackupProtectedVMWorkloadResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.sqlDatabase(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("protection_state").Exists(),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccBackupProtectedVMWorkload_requiresImport(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_backup_protected_vm_workload", "test")
+	r := BackupProtectedVMWorkloadResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.sqlDatabase(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.RequiresImportErrorStep(r.requiresImport),
+	})
+}
+
+func TestAccBackupProtectedVMWorkload_protectionStopped(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_backup_protected_vm_workload", "test")
+	r := BackupProtectedVMWorkloadResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.sqlDatabase(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.protectionStopped(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("protection_state").HasValue("ProtectionStopped"),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.protectionResumed(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("protection_state").HasValue("Protected"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccBackupProtectedVMWorkload_sqlInstanceAutoProtection(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_backup_protected_vm_workload", "test")
+	r := BackupProtectedVMWorkloadResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.sqlInstanceAutoProtection(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func (BackupProtectedVMWorkloadResource) Exists(ctx context.Context, clients *clients.Client, state *pluginsdk.InstanceState) (*bool, error) {
+	if id, err := protectionintent.ParseBackupProtectionIntentID(state.ID); err == nil {
+		resp, err := clients.RecoveryServices.ProtectionIntentClient.Get(ctx, *id)
+		if err != nil {
+			if response.WasNotFound(resp.HttpResponse) {
+				return pointer.To(false), nil
+			}
+			return nil, fmt.Errorf("retrieving %s: %+v", *id, err)
+		}
+
+		return pointer.To(resp.Model != nil), nil
+	}
+
+	id, err := protecteditems.ParseProtectedItemID(state.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := clients.RecoveryServices.ProtectedItemsClient.Get(ctx, *id, protecteditems.GetOperationOptions{})
+	if err != nil {
+		if response.WasNotFound(resp.HttpResponse) {
+			return pointer.To(false), nil
+		}
+		return nil, fmt.Errorf("retrieving %s: %+v", *id, err)
+	}
+
+	return pointer.To(resp.Model != nil), nil
+}
+
+func (BackupProtectedVMWorkloadResource) template(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_backup_container_vm_workload" "test" {
+  resource_group_name = azurerm_resource_group.test.name
+  recovery_vault_name = azurerm_recovery_services_vault.test.name
+  source_vm_id        = azurerm_mssql_virtual_machine.test.virtual_machine_id
+  workload_type       = "SQLDataBase"
+}
+
+resource "azurerm_backup_policy_vm_workload" "test" {
+  name                = "acctest-bpvmw-%d"
+  resource_group_name = azurerm_resource_group.test.name
+  recovery_vault_name = azurerm_recovery_services_vault.test.name
+  workload_type       = "SQLDataBase"
+
+  settings {
+    time_zone           = "UTC"
+    compression_enabled = false
+  }
+
+  protection_policy {
+    policy_type = "Full"
+
+    backup {
+      frequency = "Daily"
+      time      = "15:00"
+    }
+
+    retention_daily {
+      count = 8
+    }
+  }
+
+  protection_policy {
+    policy_type = "Log"
+
+    backup {
+      frequency_in_minutes = 15
+    }
+
+    simple_retention {
+      count = 8
+    }
+  }
+}
+`, BackupContainerVMWorkloadResource{}.template(data), data.RandomInteger)
+}
+
+func (r BackupProtectedVMWorkloadResource) sqlDatabase(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_backup_protected_vm_workload" "test" {
+  resource_group_name = azurerm_resource_group.test.name
+  recovery_vault_name = azurerm_recovery_services_vault.test.name
+  source_vm_id        = azurerm_backup_container_vm_workload.test.source_vm_id
+  workload_type       = "SQLDataBase"
+  instance_name       = "MSSQLSERVER"
+  database_name       = "model"
+  backup_policy_id    = azurerm_backup_policy_vm_workload.test.id
+}
+`, r.template(data))
+}
+
+func (r BackupProtectedVMWorkloadResource) requiresImport(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_backup_protected_vm_workload" "import" {
+  resource_group_name = azurerm_backup_protected_vm_workload.test.resource_group_name
+  recovery_vault_name = azurerm_backup_protected_vm_workload.test.recovery_vault_name
+  source_vm_id        = azurerm_backup_protected_vm_workload.test.source_vm_id
+  workload_type       = azurerm_backup_protected_vm_workload.test.workload_type
+  instance_name       = azurerm_backup_protected_vm_workload.test.instance_name
+  database_name       = azurerm_backup_protected_vm_workload.test.database_name
+  backup_policy_id    = azurerm_backup_protected_vm_workload.test.backup_policy_id
+}
+`, r.sqlDatabase(data))
+}
+
+func (r BackupProtectedVMWorkloadResource) protectionStopped(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_backup_protected_vm_workload" "test" {
+  resource_group_name = azurerm_resource_group.test.name
+  recovery_vault_name = azurerm_recovery_services_vault.test.name
+  source_vm_id        = azurerm_backup_container_vm_workload.test.source_vm_id
+  workload_type       = "SQLDataBase"
+  instance_name       = "MSSQLSERVER"
+  database_name       = "model"
+  backup_policy_id    = azurerm_backup_policy_vm_workload.test.id
+  protection_state    = "ProtectionStopped"
+}
+`, r.template(data))
+}
+
+func (r BackupProtectedVMWorkloadResource) protectionResumed(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_backup_protected_vm_workload" "test" {
+  resource_group_name = azurerm_resource_group.test.name
+  recovery_vault_name = azurerm_recovery_services_vault.test.name
+  source_vm_id        = azurerm_backup_container_vm_workload.test.source_vm_id
+  workload_type       = "SQLDataBase"
+  instance_name       = "MSSQLSERVER"
+  database_name       = "model"
+  backup_policy_id    = azurerm_backup_policy_vm_workload.test.id
+  protection_state    = "Protected"
+}
+`, r.template(data))
+}
+
+func (r BackupProtectedVMWorkloadResource) sqlInstanceAutoProtection(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_backup_protected_vm_workload" "test" {
+  resource_group_name     = azurerm_resource_group.test.name
+  recovery_vault_name     = azurerm_recovery_services_vault.test.name
+  source_vm_id            = azurerm_backup_container_vm_workload.test.source_vm_id
+  workload_type           = "SQLDataBase"
+  instance_name           = "MSSQLSERVER"
+  auto_protection_enabled = true
+  backup_policy_id        = azurerm_backup_policy_vm_workload.test.id
+}
+`, r.template(data))
+}
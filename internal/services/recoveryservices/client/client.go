@@ -15,6 +15,7 @@ import (
 	"github.com/hashicorp/go-azure-sdk/resource-manager/recoveryservicesbackup/2023-02-01/backupresourcevaultconfigs"
 	"github.com/hashicorp/go-azure-sdk/resource-manager/recoveryservicesbackup/2023-02-01/protecteditems"
 	"github.com/hashicorp/go-azure-sdk/resource-manager/recoveryservicesbackup/2023-02-01/protectioncontainers"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/recoveryservicesbackup/2023-02-01/protectionintent"
 	"github.com/hashicorp/go-azure-sdk/resource-manager/recoveryservicesbackup/2023-02-01/protectionpolicies"
 	"github.com/hashicorp/go-azure-sdk/resource-manager/recoveryservicesbackup/2023-02-01/resourceguardproxy"
 	"github.com/hashicorp/go-azure-sdk/resource-manager/recoveryservicessiterecovery/2022-10-01/replicationfabrics"
@@ -37,6 +38,7 @@ type Client struct {
 	// the Swagger lack of LRO mark, so we are using track-1 sdk to get the LRO client. tracked on https://github.com/Azure/azure-rest-api-specs/issues/22758
 	ProtectedItemOperationResultsClient       *backup.ProtectedItemOperationResultsClient
 	ProtectedItemsGroupClient                 *backupprotecteditems.BackupProtectedItemsClient
+	ProtectionIntentClient                    *protectionintent.ProtectionIntentClient
 	ProtectionPoliciesClient                  *protectionpolicies.ProtectionPoliciesClient
 	ProtectionContainerOperationResultsClient *backup.ProtectionContainerOperationResultsClient
 	BackupProtectionContainersClient          *protectioncontainers.ProtectionContainersClient
@@ -91,6 +93,9 @@ func NewClient(o *common.ClientOptions) (*Client, error) {
 	protectedItemsGroupClient := backupprotecteditems.NewBackupProtectedItemsClientWithBaseURI(o.ResourceManagerEndpoint)
 	o.ConfigureClient(&protectedItemsGroupClient.Client, o.ResourceManagerAuthorizer)
 
+	protectionIntentClient := protectionintent.NewProtectionIntentClientWithBaseURI(o.ResourceManagerEndpoint)
+	o.ConfigureClient(&protectionIntentClient.Client, o.ResourceManagerAuthorizer)
+
 	protectionPoliciesClient := protectionpolicies.NewProtectionPoliciesClientWithBaseURI(o.ResourceManagerEndpoint)
 	o.ConfigureClient(&protectionPoliciesClient.Client, o.ResourceManagerAuthorizer)
 
@@ -173,6 +178,7 @@ func NewClient(o *common.ClientOptions) (*Client, error) {
 		ProtectableItemsClient:                    &protectableItemsClient,
 		ProtectedItemsClient:                      &protectedItemsClient,
 		ProtectedItemsGroupClient:                 &protectedItemsGroupClient,
+		ProtectionIntentClient:                    &protectionIntentClient,
 		ProtectionPoliciesClient:                  &protectionPoliciesClient,
 		ProtectionContainerOperationResultsClient: &backupProtectionContainerOperationResultsClient,
 		BackupProtectionContainersClient:          &backupProtectionContainersClient,
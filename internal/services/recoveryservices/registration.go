@@ -27,7 +27,9 @@ func (r Registration) DataSources() []sdk.DataSource {
 
 func (r Registration) Resources() []sdk.Resource {
 	return []sdk.Resource{
+		BackupContainerVMWorkloadResource{},
 		BackupProtectionPolicyVMWorkloadResource{},
+		BackupProtectedVMWorkloadResource{},
 		SiteRecoveryReplicationRecoveryPlanResource{},
 		ReplicationPolicyHyperVResource{},
 		HyperVSiteResource{},
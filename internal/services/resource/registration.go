@@ -10,8 +10,9 @@ import (
 )
 
 var (
-	_ sdk.TypedServiceRegistration   = Registration{}
-	_ sdk.UntypedServiceRegistration = Registration{}
+	_ sdk.TypedServiceRegistration                       = Registration{}
+	_ sdk.TypedServiceRegistrationWithFrameworkResources = Registration{}
+	_ sdk.UntypedServiceRegistration                     = Registration{}
 )
 
 type Registration struct{}
@@ -76,3 +77,10 @@ func (r Registration) Resources() []sdk.Resource {
 		ResourceDeploymentScriptAzureCliResource{},
 	}
 }
+
+// FrameworkResources returns a list of Resources (from Resources) which are served through the Plugin Framework from 4.0
+func (r Registration) FrameworkResources() []sdk.Resource {
+	return []sdk.Resource{
+		ResourceManagementPrivateLinkAssociationResource{},
+	}
+}
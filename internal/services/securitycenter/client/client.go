@@ -13,6 +13,7 @@ import (
 	"github.com/hashicorp/go-azure-sdk/resource-manager/security/2022-12-01-preview/defenderforstorage"
 	pricings_v2023_01_01 "github.com/hashicorp/go-azure-sdk/resource-manager/security/2023-01-01/pricings"
 	"github.com/hashicorp/go-azure-sdk/resource-manager/security/2023-05-01/servervulnerabilityassessmentssettings"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/security/2023-10-01-preview/securityconnectors"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/common"
 )
 
@@ -31,6 +32,7 @@ type Client struct {
 	ServerVulnerabilityAssessmentClient        *security.ServerVulnerabilityAssessmentClient
 	ServerVulnerabilityAssessmentSettingClient *servervulnerabilityassessmentssettings.ServerVulnerabilityAssessmentsSettingsClient
 	DefenderForStorageClient                   *defenderforstorage.DefenderForStorageClient
+	SecurityConnectorsClient                   *securityconnectors.SecurityConnectorsClient
 }
 
 func NewClient(o *common.ClientOptions) (*Client, error) {
@@ -96,6 +98,12 @@ func NewClient(o *common.ClientOptions) (*Client, error) {
 	}
 	o.Configure(DefenderForStorageClient.Client, o.Authorizers.ResourceManager)
 
+	SecurityConnectorsClient, err := securityconnectors.NewSecurityConnectorsClientWithBaseURI(o.Environment.ResourceManager)
+	if err != nil {
+		return nil, fmt.Errorf("building Security Connectors client : %+v", err)
+	}
+	o.Configure(SecurityConnectorsClient.Client, o.Authorizers.ResourceManager)
+
 	return &Client{
 		AssessmentsClient:                          &AssessmentsClient,
 		AssessmentsMetadataClient:                  AssessmentsMetadataClient,
@@ -111,5 +119,6 @@ func NewClient(o *common.ClientOptions) (*Client, error) {
 		ServerVulnerabilityAssessmentClient:        &ServerVulnerabilityAssessmentClient,
 		ServerVulnerabilityAssessmentSettingClient: ServerVulnerabilityAssessmentSettingClient,
 		DefenderForStorageClient:                   DefenderForStorageClient,
+		SecurityConnectorsClient:                   SecurityConnectorsClient,
 	}, nil
 }
@@ -66,6 +66,7 @@ func (r Registration) DataSources() []sdk.DataSource {
 
 func (r Registration) Resources() []sdk.Resource {
 	return []sdk.Resource{
+		SecurityConnectorResource{},
 		StorageDefenderResource{},
 	}
 }
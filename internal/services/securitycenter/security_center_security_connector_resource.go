@@ -0,0 +1,860 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package securitycenter
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonschema"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/location"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/security/2023-10-01-preview/securityconnectors"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+)
+
+type SecurityConnectorResource struct{}
+
+var (
+	_ sdk.ResourceWithUpdate        = SecurityConnectorResource{}
+	_ sdk.ResourceWithCustomizeDiff = SecurityConnectorResource{}
+)
+
+type SecurityConnectorModel struct {
+	Name                string                      `tfschema:"name"`
+	ResourceGroupName   string                      `tfschema:"resource_group_name"`
+	Location            string                      `tfschema:"location"`
+	HierarchyIdentifier string                      `tfschema:"hierarchy_identifier"`
+	ScanIntervalInHours int64                       `tfschema:"scan_interval_in_hours"`
+	Aws                 []SecurityConnectorAwsModel `tfschema:"aws"`
+	Gcp                 []SecurityConnectorGcpModel `tfschema:"gcp"`
+	Tags                map[string]string           `tfschema:"tags"`
+}
+
+type SecurityConnectorAwsModel struct {
+	Regions               []string                                      `tfschema:"regions"`
+	Cspm                  []SecurityConnectorAwsCspmModel               `tfschema:"cspm"`
+	DefenderForServers    []SecurityConnectorAwsServersOfferingModel    `tfschema:"defender_for_servers"`
+	DefenderForContainers []SecurityConnectorAwsContainersOfferingModel `tfschema:"defender_for_containers"`
+}
+
+type SecurityConnectorAwsCspmModel struct {
+	RoleArn string `tfschema:"role_arn"`
+}
+
+type SecurityConnectorAwsServersOfferingModel struct {
+	RoleArn                     string `tfschema:"role_arn"`
+	SubPlan                     string `tfschema:"sub_plan"`
+	ArcAutoProvisioningRoleArn  string `tfschema:"arc_auto_provisioning_role_arn"`
+	MdeAutoProvisioningEnabled  bool   `tfschema:"mde_auto_provisioning_enabled"`
+	VulnerabilityAssessmentType string `tfschema:"vulnerability_assessment_type"`
+}
+
+type SecurityConnectorAwsContainersOfferingModel struct {
+	KubernetesServiceRoleArn     string `tfschema:"kubernetes_service_role_arn"`
+	KubernetesScubaReaderRoleArn string `tfschema:"kubernetes_scuba_reader_role_arn"`
+	CloudWatchToKinesisRoleArn   string `tfschema:"cloud_watch_to_kinesis_role_arn"`
+	KinesisToS3RoleArn           string `tfschema:"kinesis_to_s3_role_arn"`
+	AutoProvisioningEnabled      bool   `tfschema:"auto_provisioning_enabled"`
+	KubeAuditRetentionTime       int64  `tfschema:"kube_audit_retention_time"`
+}
+
+type SecurityConnectorGcpModel struct {
+	ProjectId              string                                        `tfschema:"project_id"`
+	WorkloadIdentityPoolId string                                        `tfschema:"workload_identity_pool_id"`
+	Cspm                   []SecurityConnectorGcpCspmModel               `tfschema:"cspm"`
+	DefenderForServers     []SecurityConnectorGcpServersOfferingModel    `tfschema:"defender_for_servers"`
+	DefenderForContainers  []SecurityConnectorGcpContainersOfferingModel `tfschema:"defender_for_containers"`
+}
+
+type SecurityConnectorGcpCspmModel struct {
+	WorkloadIdentityProviderId string `tfschema:"workload_identity_provider_id"`
+	ServiceAccountEmailAddress string `tfschema:"service_account_email_address"`
+}
+
+type SecurityConnectorGcpServersOfferingModel struct {
+	WorkloadIdentityProviderId  string `tfschema:"workload_identity_provider_id"`
+	ServiceAccountEmailAddress  string `tfschema:"service_account_email_address"`
+	SubPlan                     string `tfschema:"sub_plan"`
+	ArcAutoProvisioningEnabled  bool   `tfschema:"arc_auto_provisioning_enabled"`
+	MdeAutoProvisioningEnabled  bool   `tfschema:"mde_auto_provisioning_enabled"`
+	VulnerabilityAssessmentType string `tfschema:"vulnerability_assessment_type"`
+}
+
+type SecurityConnectorGcpContainersOfferingModel struct {
+	WorkloadIdentityProviderId             string `tfschema:"workload_identity_provider_id"`
+	ServiceAccountEmailAddress             string `tfschema:"service_account_email_address"`
+	DataPipelineWorkloadIdentityProviderId string `tfschema:"data_pipeline_workload_identity_provider_id"`
+	DataPipelineServiceAccountEmailAddress string `tfschema:"data_pipeline_service_account_email_address"`
+	AuditLogsAutoProvisioningEnabled       bool   `tfschema:"audit_logs_auto_provisioning_enabled"`
+	DefenderAgentAutoProvisioningEnabled   bool   `tfschema:"defender_agent_auto_provisioning_enabled"`
+	PolicyAgentAutoProvisioningEnabled     bool   `tfschema:"policy_agent_auto_provisioning_enabled"`
+}
+
+func (r SecurityConnectorResource) ResourceType() string {
+	return "azurerm_security_center_security_connector"
+}
+
+func (r SecurityConnectorResource) ModelObject() interface{} {
+	return &SecurityConnectorModel{}
+}
+
+func (r SecurityConnectorResource) IDValidationFunc() pluginsdk.SchemaValidateFunc {
+	return securityconnectors.ValidateSecurityConnectorID
+}
+
+func (r SecurityConnectorResource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"name": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+		},
+
+		"resource_group_name": commonschema.ResourceGroupName(),
+
+		"location": commonschema.Location(),
+
+		"hierarchy_identifier": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+		},
+
+		"aws": {
+			Type:         pluginsdk.TypeList,
+			Optional:     true,
+			MaxItems:     1,
+			ExactlyOneOf: []string{"aws", "gcp"},
+			Elem: &pluginsdk.Resource{
+				Schema: map[string]*pluginsdk.Schema{
+					"regions": {
+						Type:     pluginsdk.TypeList,
+						Optional: true,
+						Elem: &pluginsdk.Schema{
+							Type:         pluginsdk.TypeString,
+							ValidateFunc: validation.StringIsNotEmpty,
+						},
+					},
+
+					"cspm": {
+						Type:         pluginsdk.TypeList,
+						Optional:     true,
+						MaxItems:     1,
+						AtLeastOneOf: []string{"aws.0.cspm", "aws.0.defender_for_servers", "aws.0.defender_for_containers"},
+						Elem: &pluginsdk.Resource{
+							Schema: map[string]*pluginsdk.Schema{
+								"role_arn": {
+									Type:         pluginsdk.TypeString,
+									Required:     true,
+									ValidateFunc: validation.StringIsNotEmpty,
+								},
+							},
+						},
+					},
+
+					"defender_for_servers": {
+						Type:         pluginsdk.TypeList,
+						Optional:     true,
+						MaxItems:     1,
+						AtLeastOneOf: []string{"aws.0.cspm", "aws.0.defender_for_servers", "aws.0.defender_for_containers"},
+						Elem: &pluginsdk.Resource{
+							Schema: map[string]*pluginsdk.Schema{
+								"role_arn": {
+									Type:         pluginsdk.TypeString,
+									Required:     true,
+									ValidateFunc: validation.StringIsNotEmpty,
+								},
+
+								"sub_plan": {
+									Type:         pluginsdk.TypeString,
+									Optional:     true,
+									Default:      string(securityconnectors.SubPlanPTwo),
+									ValidateFunc: validation.StringInSlice(securityconnectors.PossibleValuesForSubPlan(), false),
+								},
+
+								"arc_auto_provisioning_role_arn": {
+									Type:         pluginsdk.TypeString,
+									Optional:     true,
+									ValidateFunc: validation.StringIsNotEmpty,
+								},
+
+								"mde_auto_provisioning_enabled": {
+									Type:     pluginsdk.TypeBool,
+									Optional: true,
+									Default:  false,
+								},
+
+								"vulnerability_assessment_type": {
+									Type:         pluginsdk.TypeString,
+									Optional:     true,
+									ValidateFunc: validation.StringInSlice(securityconnectors.PossibleValuesForType(), false),
+								},
+							},
+						},
+					},
+
+					"defender_for_containers": {
+						Type:         pluginsdk.TypeList,
+						Optional:     true,
+						MaxItems:     1,
+						AtLeastOneOf: []string{"aws.0.cspm", "aws.0.defender_for_servers", "aws.0.defender_for_containers"},
+						Elem: &pluginsdk.Resource{
+							Schema: map[string]*pluginsdk.Schema{
+								"kubernetes_service_role_arn": {
+									Type:         pluginsdk.TypeString,
+									Required:     true,
+									ValidateFunc: validation.StringIsNotEmpty,
+								},
+
+								"kubernetes_scuba_reader_role_arn": {
+									Type:         pluginsdk.TypeString,
+									Required:     true,
+									ValidateFunc: validation.StringIsNotEmpty,
+								},
+
+								"cloud_watch_to_kinesis_role_arn": {
+									Type:         pluginsdk.TypeString,
+									Required:     true,
+									ValidateFunc: validation.StringIsNotEmpty,
+								},
+
+								"kinesis_to_s3_role_arn": {
+									Type:         pluginsdk.TypeString,
+									Required:     true,
+									ValidateFunc: validation.StringIsNotEmpty,
+								},
+
+								"auto_provisioning_enabled": {
+									Type:     pluginsdk.TypeBool,
+									Optional: true,
+									Default:  true,
+								},
+
+								"kube_audit_retention_time": {
+									Type:         pluginsdk.TypeInt,
+									Optional:     true,
+									Default:      30,
+									ValidateFunc: validation.IntAtLeast(1),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+
+		"gcp": {
+			Type:         pluginsdk.TypeList,
+			Optional:     true,
+			MaxItems:     1,
+			ExactlyOneOf: []string{"aws", "gcp"},
+			Elem: &pluginsdk.Resource{
+				Schema: map[string]*pluginsdk.Schema{
+					"project_id": {
+						Type:         pluginsdk.TypeString,
+						Required:     true,
+						ForceNew:     true,
+						ValidateFunc: validation.StringIsNotEmpty,
+					},
+
+					"workload_identity_pool_id": {
+						Type:     pluginsdk.TypeString,
+						Computed: true,
+					},
+
+					"cspm": {
+						Type:         pluginsdk.TypeList,
+						Optional:     true,
+						MaxItems:     1,
+						AtLeastOneOf: []string{"gcp.0.cspm", "gcp.0.defender_for_servers", "gcp.0.defender_for_containers"},
+						Elem: &pluginsdk.Resource{
+							Schema: map[string]*pluginsdk.Schema{
+								"workload_identity_provider_id": {
+									Type:         pluginsdk.TypeString,
+									Required:     true,
+									ValidateFunc: validation.StringIsNotEmpty,
+								},
+
+								"service_account_email_address": {
+									Type:         pluginsdk.TypeString,
+									Required:     true,
+									ValidateFunc: validation.StringIsNotEmpty,
+								},
+							},
+						},
+					},
+
+					"defender_for_servers": {
+						Type:         pluginsdk.TypeList,
+						Optional:     true,
+						MaxItems:     1,
+						AtLeastOneOf: []string{"gcp.0.cspm", "gcp.0.defender_for_servers", "gcp.0.defender_for_containers"},
+						Elem: &pluginsdk.Resource{
+							Schema: map[string]*pluginsdk.Schema{
+								"workload_identity_provider_id": {
+									Type:         pluginsdk.TypeString,
+									Required:     true,
+									ValidateFunc: validation.StringIsNotEmpty,
+								},
+
+								"service_account_email_address": {
+									Type:         pluginsdk.TypeString,
+									Required:     true,
+									ValidateFunc: validation.StringIsNotEmpty,
+								},
+
+								"sub_plan": {
+									Type:         pluginsdk.TypeString,
+									Optional:     true,
+									Default:      string(securityconnectors.SubPlanPTwo),
+									ValidateFunc: validation.StringInSlice(securityconnectors.PossibleValuesForSubPlan(), false),
+								},
+
+								"arc_auto_provisioning_enabled": {
+									Type:     pluginsdk.TypeBool,
+									Optional: true,
+									Default:  false,
+								},
+
+								"mde_auto_provisioning_enabled": {
+									Type:     pluginsdk.TypeBool,
+									Optional: true,
+									Default:  false,
+								},
+
+								"vulnerability_assessment_type": {
+									Type:         pluginsdk.TypeString,
+									Optional:     true,
+									ValidateFunc: validation.StringInSlice(securityconnectors.PossibleValuesForType(), false),
+								},
+							},
+						},
+					},
+
+					"defender_for_containers": {
+						Type:         pluginsdk.TypeList,
+						Optional:     true,
+						MaxItems:     1,
+						AtLeastOneOf: []string{"gcp.0.cspm", "gcp.0.defender_for_servers", "gcp.0.defender_for_containers"},
+						Elem: &pluginsdk.Resource{
+							Schema: map[string]*pluginsdk.Schema{
+								"workload_identity_provider_id": {
+									Type:         pluginsdk.TypeString,
+									Required:     true,
+									ValidateFunc: validation.StringIsNotEmpty,
+								},
+
+								"service_account_email_address": {
+									Type:         pluginsdk.TypeString,
+									Required:     true,
+									ValidateFunc: validation.StringIsNotEmpty,
+								},
+
+								"data_pipeline_workload_identity_provider_id": {
+									Type:         pluginsdk.TypeString,
+									Required:     true,
+									ValidateFunc: validation.StringIsNotEmpty,
+								},
+
+								"data_pipeline_service_account_email_address": {
+									Type:         pluginsdk.TypeString,
+									Required:     true,
+									ValidateFunc: validation.StringIsNotEmpty,
+								},
+
+								"audit_logs_auto_provisioning_enabled": {
+									Type:     pluginsdk.TypeBool,
+									Optional: true,
+									Default:  true,
+								},
+
+								"defender_agent_auto_provisioning_enabled": {
+									Type:     pluginsdk.TypeBool,
+									Optional: true,
+									Default:  true,
+								},
+
+								"policy_agent_auto_provisioning_enabled": {
+									Type:     pluginsdk.TypeBool,
+									Optional: true,
+									Default:  true,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+
+		"scan_interval_in_hours": {
+			Type:         pluginsdk.TypeInt,
+			Optional:     true,
+			Default:      12,
+			ValidateFunc: validation.IntBetween(1, 24),
+		},
+
+		"tags": commonschema.Tags(),
+	}
+}
+
+func (r SecurityConnectorResource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{}
+}
+
+func (r SecurityConnectorResource) CustomizeDiff() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			rd := metadata.ResourceDiff
+			if rd.Id() == "" {
+				return nil
+			}
+
+			// the offerings can be updated in-place, however moving the connector to another cloud requires recreating it
+			for _, key := range []string{"aws", "gcp"} {
+				oldRaw, newRaw := rd.GetChange(key)
+				if len(oldRaw.([]interface{})) != len(newRaw.([]interface{})) {
+					if err := rd.ForceNew(key); err != nil {
+						return err
+					}
+				}
+			}
+
+			return nil
+		},
+	}
+}
+
+func (r SecurityConnectorResource) Create() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.SecurityCenter.SecurityConnectorsClient
+			subscriptionId := metadata.Client.Account.SubscriptionId
+
+			var config SecurityConnectorModel
+			if err := metadata.Decode(&config); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			id := securityconnectors.NewSecurityConnectorID(subscriptionId, config.ResourceGroupName, config.Name)
+
+			existing, err := client.Get(ctx, id)
+			if err != nil && !response.WasNotFound(existing.HttpResponse) {
+				return fmt.Errorf("checking for existing %s: %+v", id, err)
+			}
+			if !response.WasNotFound(existing.HttpResponse) {
+				return metadata.ResourceRequiresImport(r.ResourceType(), id)
+			}
+
+			payload := securityconnectors.SecurityConnector{
+				Location:   pointer.To(location.Normalize(config.Location)),
+				Properties: expandSecurityConnectorProperties(config),
+				Tags:       pointer.To(config.Tags),
+			}
+
+			if _, err := client.CreateOrUpdate(ctx, id, payload); err != nil {
+				return fmt.Errorf("creating %s: %+v", id, err)
+			}
+
+			metadata.SetID(id)
+			return nil
+		},
+	}
+}
+
+func (r SecurityConnectorResource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.SecurityCenter.SecurityConnectorsClient
+
+			id, err := securityconnectors.ParseSecurityConnectorID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			resp, err := client.Get(ctx, *id)
+			if err != nil {
+				if response.WasNotFound(resp.HttpResponse) {
+					return metadata.MarkAsGone(id)
+				}
+				return fmt.Errorf("retrieving %s: %+v", *id, err)
+			}
+
+			state := SecurityConnectorModel{
+				Name:              id.SecurityConnectorName,
+				ResourceGroupName: id.ResourceGroupName,
+			}
+
+			if model := resp.Model; model != nil {
+				state.Location = location.NormalizeNilable(model.Location)
+				state.Tags = pointer.From(model.Tags)
+
+				if props := model.Properties; props != nil {
+					state.HierarchyIdentifier = pointer.From(props.HierarchyIdentifier)
+
+					switch v := props.EnvironmentData.(type) {
+					case securityconnectors.AwsEnvironmentData:
+						state.ScanIntervalInHours = pointer.From(v.ScanInterval)
+						state.Aws = flattenSecurityConnectorAws(v, props.Offerings)
+					case securityconnectors.GcpProjectEnvironmentData:
+						state.ScanIntervalInHours = pointer.From(v.ScanInterval)
+						state.Gcp = flattenSecurityConnectorGcp(v, props.Offerings)
+					}
+				}
+			}
+
+			return metadata.Encode(&state)
+		},
+	}
+}
+
+func (r SecurityConnectorResource) Update() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.SecurityCenter.SecurityConnectorsClient
+
+			id, err := securityconnectors.ParseSecurityConnectorID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			var config SecurityConnectorModel
+			if err := metadata.Decode(&config); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			existing, err := client.Get(ctx, *id)
+			if err != nil {
+				return fmt.Errorf("retrieving %s: %+v", *id, err)
+			}
+			if existing.Model == nil {
+				return fmt.Errorf("retrieving %s: `model` was nil", *id)
+			}
+
+			payload := *existing.Model
+
+			// the offerings and environment data are sent as a whole, since the API replaces them on every PUT
+			if metadata.ResourceData.HasChanges("aws", "gcp", "scan_interval_in_hours") {
+				payload.Properties = expandSecurityConnectorProperties(config)
+			}
+
+			if metadata.ResourceData.HasChange("tags") {
+				payload.Tags = pointer.To(config.Tags)
+			}
+
+			if _, err := client.CreateOrUpdate(ctx, *id, payload); err != nil {
+				return fmt.Errorf("updating %s: %+v", *id, err)
+			}
+
+			return nil
+		},
+	}
+}
+
+func (r SecurityConnectorResource) Delete() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.SecurityCenter.SecurityConnectorsClient
+
+			id, err := securityconnectors.ParseSecurityConnectorID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			if _, err := client.Delete(ctx, *id); err != nil {
+				return fmt.Errorf("deleting %s: %+v", *id, err)
+			}
+
+			return nil
+		},
+	}
+}
+
+func expandSecurityConnectorProperties(input SecurityConnectorModel) *securityconnectors.SecurityConnectorProperties {
+	props := &securityconnectors.SecurityConnectorProperties{
+		HierarchyIdentifier: pointer.To(input.HierarchyIdentifier),
+	}
+
+	offerings := make([]securityconnectors.CloudOffering, 0)
+
+	if len(input.Aws) > 0 {
+		aws := input.Aws[0]
+
+		props.EnvironmentName = pointer.To(securityconnectors.CloudNameAWS)
+		environmentData := securityconnectors.AwsEnvironmentData{
+			ScanInterval: pointer.To(input.ScanIntervalInHours),
+		}
+		if len(aws.Regions) > 0 {
+			environmentData.Regions = pointer.To(aws.Regions)
+		}
+		props.EnvironmentData = environmentData
+
+		if len(aws.Cspm) > 0 {
+			offerings = append(offerings, securityconnectors.CspmMonitorAwsOffering{
+				NativeCloudConnection: &securityconnectors.CspmMonitorAwsOfferingNativeCloudConnection{
+					CloudRoleArn: pointer.To(aws.Cspm[0].RoleArn),
+				},
+			})
+		}
+
+		if len(aws.DefenderForServers) > 0 {
+			v := aws.DefenderForServers[0]
+			offering := securityconnectors.DefenderForServersAwsOffering{
+				DefenderForServers: &securityconnectors.DefenderForServersAwsOfferingDefenderForServers{
+					CloudRoleArn: pointer.To(v.RoleArn),
+				},
+				ArcAutoProvisioning: &securityconnectors.DefenderForServersAwsOfferingArcAutoProvisioning{
+					Enabled: pointer.To(v.ArcAutoProvisioningRoleArn != ""),
+				},
+				MdeAutoProvisioning: &securityconnectors.DefenderForServersAwsOfferingMdeAutoProvisioning{
+					Enabled: pointer.To(v.MdeAutoProvisioningEnabled),
+				},
+				SubPlan: &securityconnectors.DefenderForServersAwsOfferingSubPlan{
+					Type: pointer.To(securityconnectors.SubPlan(v.SubPlan)),
+				},
+				VaAutoProvisioning: &securityconnectors.DefenderForServersAwsOfferingVaAutoProvisioning{
+					Enabled: pointer.To(v.VulnerabilityAssessmentType != ""),
+				},
+			}
+			if v.VulnerabilityAssessmentType != "" {
+				offering.VaAutoProvisioning.Configuration = &securityconnectors.DefenderForServersAwsOfferingVaAutoProvisioningConfiguration{
+					Type: pointer.To(securityconnectors.Type(v.VulnerabilityAssessmentType)),
+				}
+			}
+			if v.ArcAutoProvisioningRoleArn != "" {
+				offering.ArcAutoProvisioning.CloudRoleArn = pointer.To(v.ArcAutoProvisioningRoleArn)
+			}
+			offerings = append(offerings, offering)
+		}
+
+		if len(aws.DefenderForContainers) > 0 {
+			v := aws.DefenderForContainers[0]
+			offerings = append(offerings, securityconnectors.DefenderForContainersAwsOffering{
+				AutoProvisioning: pointer.To(v.AutoProvisioningEnabled),
+				CloudWatchToKinesis: &securityconnectors.DefenderForContainersAwsOfferingCloudWatchToKinesis{
+					CloudRoleArn: pointer.To(v.CloudWatchToKinesisRoleArn),
+				},
+				KinesisToS3: &securityconnectors.DefenderForContainersAwsOfferingKinesisToS3{
+					CloudRoleArn: pointer.To(v.KinesisToS3RoleArn),
+				},
+				KubeAuditRetentionTime: pointer.To(v.KubeAuditRetentionTime),
+				KubernetesScubaReader: &securityconnectors.DefenderForContainersAwsOfferingKubernetesScubaReader{
+					CloudRoleArn: pointer.To(v.KubernetesScubaReaderRoleArn),
+				},
+				KubernetesService: &securityconnectors.DefenderForContainersAwsOfferingKubernetesService{
+					CloudRoleArn: pointer.To(v.KubernetesServiceRoleArn),
+				},
+			})
+		}
+	}
+
+	if len(input.Gcp) > 0 {
+		gcp := input.Gcp[0]
+
+		props.EnvironmentName = pointer.To(securityconnectors.CloudNameGCP)
+		props.EnvironmentData = securityconnectors.GcpProjectEnvironmentData{
+			ProjectDetails: &securityconnectors.GcpProjectDetails{
+				ProjectId:     pointer.To(gcp.ProjectId),
+				ProjectNumber: pointer.To(input.HierarchyIdentifier),
+			},
+			ScanInterval: pointer.To(input.ScanIntervalInHours),
+		}
+
+		if len(gcp.Cspm) > 0 {
+			offerings = append(offerings, securityconnectors.CspmMonitorGcpOffering{
+				NativeCloudConnection: &securityconnectors.CspmMonitorGcpOfferingNativeCloudConnection{
+					ServiceAccountEmailAddress: pointer.To(gcp.Cspm[0].ServiceAccountEmailAddress),
+					WorkloadIdentityProviderId: pointer.To(gcp.Cspm[0].WorkloadIdentityProviderId),
+				},
+			})
+		}
+
+		if len(gcp.DefenderForServers) > 0 {
+			v := gcp.DefenderForServers[0]
+			offering := securityconnectors.DefenderForServersGcpOffering{
+				DefenderForServers: &securityconnectors.DefenderForServersGcpOfferingDefenderForServers{
+					ServiceAccountEmailAddress: pointer.To(v.ServiceAccountEmailAddress),
+					WorkloadIdentityProviderId: pointer.To(v.WorkloadIdentityProviderId),
+				},
+				ArcAutoProvisioning: &securityconnectors.DefenderForServersGcpOfferingArcAutoProvisioning{
+					Enabled: pointer.To(v.ArcAutoProvisioningEnabled),
+				},
+				MdeAutoProvisioning: &securityconnectors.DefenderForServersGcpOfferingMdeAutoProvisioning{
+					Enabled: pointer.To(v.MdeAutoProvisioningEnabled),
+				},
+				SubPlan: &securityconnectors.DefenderForServersGcpOfferingSubPlan{
+					Type: pointer.To(securityconnectors.SubPlan(v.SubPlan)),
+				},
+				VaAutoProvisioning: &securityconnectors.DefenderForServersGcpOfferingVaAutoProvisioning{
+					Enabled: pointer.To(v.VulnerabilityAssessmentType != ""),
+				},
+			}
+			if v.VulnerabilityAssessmentType != "" {
+				offering.VaAutoProvisioning.Configuration = &securityconnectors.DefenderForServersGcpOfferingVaAutoProvisioningConfiguration{
+					Type: pointer.To(securityconnectors.Type(v.VulnerabilityAssessmentType)),
+				}
+			}
+			offerings = append(offerings, offering)
+		}
+
+		if len(gcp.DefenderForContainers) > 0 {
+			v := gcp.DefenderForContainers[0]
+			offerings = append(offerings, securityconnectors.DefenderForContainersGcpOffering{
+				AuditLogsAutoProvisioningFlag: pointer.To(v.AuditLogsAutoProvisioningEnabled),
+				DataPipelineNativeCloudConnection: &securityconnectors.DefenderForContainersGcpOfferingDataPipelineNativeCloudConnection{
+					ServiceAccountEmailAddress: pointer.To(v.DataPipelineServiceAccountEmailAddress),
+					WorkloadIdentityProviderId: pointer.To(v.DataPipelineWorkloadIdentityProviderId),
+				},
+				DefenderAgentAutoProvisioningFlag: pointer.To(v.DefenderAgentAutoProvisioningEnabled),
+				NativeCloudConnection: &securityconnectors.DefenderForContainersGcpOfferingNativeCloudConnection{
+					ServiceAccountEmailAddress: pointer.To(v.ServiceAccountEmailAddress),
+					WorkloadIdentityProviderId: pointer.To(v.WorkloadIdentityProviderId),
+				},
+				PolicyAgentAutoProvisioningFlag: pointer.To(v.PolicyAgentAutoProvisioningEnabled),
+			})
+		}
+	}
+
+	props.Offerings = &offerings
+
+	return props
+}
+
+func flattenSecurityConnectorAws(input securityconnectors.AwsEnvironmentData, offerings *[]securityconnectors.CloudOffering) []SecurityConnectorAwsModel {
+	result := SecurityConnectorAwsModel{
+		Regions:               pointer.From(input.Regions),
+		Cspm:                  make([]SecurityConnectorAwsCspmModel, 0),
+		DefenderForServers:    make([]SecurityConnectorAwsServersOfferingModel, 0),
+		DefenderForContainers: make([]SecurityConnectorAwsContainersOfferingModel, 0),
+	}
+
+	if offerings != nil {
+		for _, offering := range *offerings {
+			switch v := offering.(type) {
+			case securityconnectors.CspmMonitorAwsOffering:
+				cspm := SecurityConnectorAwsCspmModel{}
+				if v.NativeCloudConnection != nil {
+					cspm.RoleArn = pointer.From(v.NativeCloudConnection.CloudRoleArn)
+				}
+				result.Cspm = append(result.Cspm, cspm)
+
+			case securityconnectors.DefenderForServersAwsOffering:
+				servers := SecurityConnectorAwsServersOfferingModel{}
+				if va := v.VaAutoProvisioning; va != nil && pointer.From(va.Enabled) && va.Configuration != nil {
+					servers.VulnerabilityAssessmentType = string(pointer.From(va.Configuration.Type))
+				}
+				if v.DefenderForServers != nil {
+					servers.RoleArn = pointer.From(v.DefenderForServers.CloudRoleArn)
+				}
+				if v.SubPlan != nil {
+					servers.SubPlan = string(pointer.From(v.SubPlan.Type))
+				}
+				if v.ArcAutoProvisioning != nil && pointer.From(v.ArcAutoProvisioning.Enabled) {
+					servers.ArcAutoProvisioningRoleArn = pointer.From(v.ArcAutoProvisioning.CloudRoleArn)
+				}
+				if v.MdeAutoProvisioning != nil {
+					servers.MdeAutoProvisioningEnabled = pointer.From(v.MdeAutoProvisioning.Enabled)
+				}
+				result.DefenderForServers = append(result.DefenderForServers, servers)
+
+			case securityconnectors.DefenderForContainersAwsOffering:
+				containers := SecurityConnectorAwsContainersOfferingModel{
+					AutoProvisioningEnabled: pointer.From(v.AutoProvisioning),
+					KubeAuditRetentionTime:  pointer.From(v.KubeAuditRetentionTime),
+				}
+				if v.KubernetesService != nil {
+					containers.KubernetesServiceRoleArn = pointer.From(v.KubernetesService.CloudRoleArn)
+				}
+				if v.KubernetesScubaReader != nil {
+					containers.KubernetesScubaReaderRoleArn = pointer.From(v.KubernetesScubaReader.CloudRoleArn)
+				}
+				if v.CloudWatchToKinesis != nil {
+					containers.CloudWatchToKinesisRoleArn = pointer.From(v.CloudWatchToKinesis.CloudRoleArn)
+				}
+				if v.KinesisToS3 != nil {
+					containers.KinesisToS3RoleArn = pointer.From(v.KinesisToS3.CloudRoleArn)
+				}
+				result.DefenderForContainers = append(result.DefenderForContainers, containers)
+			}
+		}
+	}
+
+	return []SecurityConnectorAwsModel{result}
+}
+
+func flattenSecurityConnectorGcp(input securityconnectors.GcpProjectEnvironmentData, offerings *[]securityconnectors.CloudOffering) []SecurityConnectorGcpModel {
+	result := SecurityConnectorGcpModel{
+		Cspm:                  make([]SecurityConnectorGcpCspmModel, 0),
+		DefenderForServers:    make([]SecurityConnectorGcpServersOfferingModel, 0),
+		DefenderForContainers: make([]SecurityConnectorGcpContainersOfferingModel, 0),
+	}
+
+	if details := input.ProjectDetails; details != nil {
+		result.ProjectId = pointer.From(details.ProjectId)
+		result.WorkloadIdentityPoolId = pointer.From(details.WorkloadIdentityPoolId)
+	}
+
+	if offerings != nil {
+		for _, offering := range *offerings {
+			switch v := offering.(type) {
+			case securityconnectors.CspmMonitorGcpOffering:
+				cspm := SecurityConnectorGcpCspmModel{}
+				if v.NativeCloudConnection != nil {
+					cspm.WorkloadIdentityProviderId = pointer.From(v.NativeCloudConnection.WorkloadIdentityProviderId)
+					cspm.ServiceAccountEmailAddress = pointer.From(v.NativeCloudConnection.ServiceAccountEmailAddress)
+				}
+				result.Cspm = append(result.Cspm, cspm)
+
+			case securityconnectors.DefenderForServersGcpOffering:
+				servers := SecurityConnectorGcpServersOfferingModel{}
+				if va := v.VaAutoProvisioning; va != nil && pointer.From(va.Enabled) && va.Configuration != nil {
+					servers.VulnerabilityAssessmentType = string(pointer.From(va.Configuration.Type))
+				}
+				if v.DefenderForServers != nil {
+					servers.WorkloadIdentityProviderId = pointer.From(v.DefenderForServers.WorkloadIdentityProviderId)
+					servers.ServiceAccountEmailAddress = pointer.From(v.DefenderForServers.ServiceAccountEmailAddress)
+				}
+				if v.SubPlan != nil {
+					servers.SubPlan = string(pointer.From(v.SubPlan.Type))
+				}
+				if v.ArcAutoProvisioning != nil {
+					servers.ArcAutoProvisioningEnabled = pointer.From(v.ArcAutoProvisioning.Enabled)
+				}
+				if v.MdeAutoProvisioning != nil {
+					servers.MdeAutoProvisioningEnabled = pointer.From(v.MdeAutoProvisioning.Enabled)
+				}
+				result.DefenderForServers = append(result.DefenderForServers, servers)
+
+			case securityconnectors.DefenderForContainersGcpOffering:
+				containers := SecurityConnectorGcpContainersOfferingModel{
+					AuditLogsAutoProvisioningEnabled:     pointer.From(v.AuditLogsAutoProvisioningFlag),
+					DefenderAgentAutoProvisioningEnabled: pointer.From(v.DefenderAgentAutoProvisioningFlag),
+					PolicyAgentAutoProvisioningEnabled:   pointer.From(v.PolicyAgentAutoProvisioningFlag),
+				}
+				if v.NativeCloudConnection != nil {
+					containers.WorkloadIdentityProviderId = pointer.From(v.NativeCloudConnection.WorkloadIdentityProviderId)
+					containers.ServiceAccountEmailAddress = pointer.From(v.NativeCloudConnection.ServiceAccountEmailAddress)
+				}
+				if v.DataPipelineNativeCloudConnection != nil {
+					containers.DataPipelineWorkloadIdentityProviderId = pointer.From(v.DataPipelineNativeCloudConnection.WorkloadIdentityProviderId)
+					containers.DataPipelineServiceAccountEmailAddress = pointer.From(v.DataPipelineNativeCloudConnection.ServiceAccountEmailAddress)
+				}
+				result.DefenderForContainers = append(result.DefenderForContainers, containers)
+			}
+		}
+	}
+
+	return []SecurityConnectorGcpModel{result}
+}
@@ -0,0 +1,260 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package securitycenter_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/security/2023-10-01-preview/securityconnectors"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/check"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+type SecurityCenterSecurityConnectorResource struct{}
+
+func (SecurityCenterSecurityConnectorResource) Exists(ctx context.Context, clients *clients.Client, state *pluginsdk.InstanceState) (*bool, error) {
+	id, err := securityconnectors.ParseSecurityConnectorID(state.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := clients.SecurityCenter.SecurityConnectorsClient.Get(ctx, *id)
+	if err != nil {
+		if response.WasNotFound(resp.HttpResponse) {
+			return pointer.To(false), nil
+		}
+		return nil, fmt.Errorf("retrieving %s: %+v", *id, err)
+	}
+
+	return pointer.To(resp.Model != nil), nil
+}
+
+func TestAccSecurityCenterSecurityConnector_awsBasic(t *testing.T) {
+	if os.Getenv("ARM_TEST_AWS_ACCOUNT_ID") == "" || os.Getenv("ARM_TEST_AWS_ROLE_ARN_PREFIX") == "" {
+		t.Skip("Skipping as ARM_TEST_AWS_ACCOUNT_ID or ARM_TEST_AWS_ROLE_ARN_PREFIX is not set")
+	}
+
+	data := acceptance.BuildTestData(t, "azurerm_security_center_security_connector", "test")
+	r := SecurityCenterSecurityConnectorResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.awsBasic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccSecurityCenterSecurityConnector_requiresImport(t *testing.T) {
+	if os.Getenv("ARM_TEST_AWS_ACCOUNT_ID") == "" || os.Getenv("ARM_TEST_AWS_ROLE_ARN_PREFIX") == "" {
+		t.Skip("Skipping as ARM_TEST_AWS_ACCOUNT_ID or ARM_TEST_AWS_ROLE_ARN_PREFIX is not set")
+	}
+
+	data := acceptance.BuildTestData(t, "azurerm_security_center_security_connector", "test")
+	r := SecurityCenterSecurityConnectorResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.awsBasic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.RequiresImportErrorStep(r.requiresImport),
+	})
+}
+
+func TestAccSecurityCenterSecurityConnector_awsUpdate(t *testing.T) {
+	if os.Getenv("ARM_TEST_AWS_ACCOUNT_ID") == "" || os.Getenv("ARM_TEST_AWS_ROLE_ARN_PREFIX") == "" {
+		t.Skip("Skipping as ARM_TEST_AWS_ACCOUNT_ID or ARM_TEST_AWS_ROLE_ARN_PREFIX is not set")
+	}
+
+	data := acceptance.BuildTestData(t, "azurerm_security_center_security_connector", "test")
+	r := SecurityCenterSecurityConnectorResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.awsBasic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.awsComplete(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.awsBasic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccSecurityCenterSecurityConnector_gcpComplete(t *testing.T) {
+	if os.Getenv("ARM_TEST_GCP_PROJECT_ID") == "" || os.Getenv("ARM_TEST_GCP_PROJECT_NUMBER") == "" {
+		t.Skip("Skipping as ARM_TEST_GCP_PROJECT_ID or ARM_TEST_GCP_PROJECT_NUMBER is not set")
+	}
+
+	data := acceptance.BuildTestData(t, "azurerm_security_center_security_connector", "test")
+	r := SecurityCenterSecurityConnectorResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.gcpComplete(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("gcp.0.workload_identity_pool_id").Exists(),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func (SecurityCenterSecurityConnectorResource) template(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-security-%d"
+  location = "%s"
+}
+`, data.RandomInteger, data.Locations.Primary)
+}
+
+func (r SecurityCenterSecurityConnectorResource) awsBasic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_security_center_security_connector" "test" {
+  name                 = "acctest-sc-%d"
+  resource_group_name  = azurerm_resource_group.test.name
+  location             = azurerm_resource_group.test.location
+  hierarchy_identifier = "%s"
+
+  aws {
+    cspm {
+      role_arn = "%sCspmMonitorAws"
+    }
+  }
+}
+`, r.template(data), data.RandomInteger, os.Getenv("ARM_TEST_AWS_ACCOUNT_ID"), os.Getenv("ARM_TEST_AWS_ROLE_ARN_PREFIX"))
+}
+
+func (r SecurityCenterSecurityConnectorResource) requiresImport(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_security_center_security_connector" "import" {
+  name                 = azurerm_security_center_security_connector.test.name
+  resource_group_name  = azurerm_security_center_security_connector.test.resource_group_name
+  location             = azurerm_security_center_security_connector.test.location
+  hierarchy_identifier = azurerm_security_center_security_connector.test.hierarchy_identifier
+
+  aws {
+    cspm {
+      role_arn = "%sCspmMonitorAws"
+    }
+  }
+}
+`, r.awsBasic(data), os.Getenv("ARM_TEST_AWS_ROLE_ARN_PREFIX"))
+}
+
+func (r SecurityCenterSecurityConnectorResource) awsComplete(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "azurerm_security_center_security_connector" "test" {
+  name                   = "acctest-sc-%[2]d"
+  resource_group_name    = azurerm_resource_group.test.name
+  location               = azurerm_resource_group.test.location
+  hierarchy_identifier   = "%[3]s"
+  scan_interval_in_hours = 6
+
+  aws {
+    regions = ["us-east-1", "eu-west-1"]
+
+    cspm {
+      role_arn = "%[4]sCspmMonitorAws"
+    }
+
+    defender_for_servers {
+      role_arn                       = "%[4]sDefenderForCloud-DefenderForServers"
+      sub_plan                       = "P2"
+      arc_auto_provisioning_role_arn = "%[4]sDefenderForCloud-ArcAutoProvisioning"
+      mde_auto_provisioning_enabled  = true
+      vulnerability_assessment_type  = "TVM"
+    }
+
+    defender_for_containers {
+      kubernetes_service_role_arn      = "%[4]sDefenderForCloud-Containers-K8s"
+      kubernetes_scuba_reader_role_arn = "%[4]sDefenderForCloud-DataCollection"
+      cloud_watch_to_kinesis_role_arn  = "%[4]sDefenderForCloud-Containers-K8s-cloudwatch-to-kinesis"
+      kinesis_to_s3_role_arn           = "%[4]sDefenderForCloud-Containers-K8s-kinesis-to-s3"
+      auto_provisioning_enabled        = true
+      kube_audit_retention_time        = 30
+    }
+  }
+
+  tags = {
+    ENV = "Test"
+  }
+}
+`, r.template(data), data.RandomInteger, os.Getenv("ARM_TEST_AWS_ACCOUNT_ID"), os.Getenv("ARM_TEST_AWS_ROLE_ARN_PREFIX"))
+}
+
+func (r SecurityCenterSecurityConnectorResource) gcpComplete(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "azurerm_security_center_security_connector" "test" {
+  name                 = "acctest-sc-%[2]d"
+  resource_group_name  = azurerm_resource_group.test.name
+  location             = azurerm_resource_group.test.location
+  hierarchy_identifier = "%[4]s"
+
+  gcp {
+    project_id = "%[3]s"
+
+    cspm {
+      workload_identity_provider_id = "cspm"
+      service_account_email_address = "microsoft-defender-cspm@%[3]s.iam.gserviceaccount.com"
+    }
+
+    defender_for_servers {
+      workload_identity_provider_id = "defender-for-servers"
+      service_account_email_address = "microsoft-defender-for-servers@%[3]s.iam.gserviceaccount.com"
+      sub_plan                      = "P1"
+      arc_auto_provisioning_enabled = true
+      mde_auto_provisioning_enabled = true
+    }
+
+    defender_for_containers {
+      workload_identity_provider_id               = "containers"
+      service_account_email_address               = "microsoft-defender-containers@%[3]s.iam.gserviceaccount.com"
+      data_pipeline_workload_identity_provider_id = "containers-streams"
+      data_pipeline_service_account_email_address = "ms-defender-containers-stream@%[3]s.iam.gserviceaccount.com"
+    }
+  }
+}
+`, r.template(data), data.RandomInteger, os.Getenv("ARM_TEST_GCP_PROJECT_ID"), os.Getenv("ARM_TEST_GCP_PROJECT_NUMBER"))
+}
@@ -7,27 +7,33 @@ import (
 	"fmt"
 
 	alertruletemplates "github.com/Azure/azure-sdk-for-go/services/preview/securityinsight/mgmt/2021-09-01-preview/securityinsight" // nolint: staticcheck
+	"github.com/hashicorp/go-azure-sdk/resource-manager/operationalinsights/2020-08-01/savedsearches"
 	"github.com/hashicorp/go-azure-sdk/resource-manager/securityinsights/2022-10-01-preview/alertrules"
 	"github.com/hashicorp/go-azure-sdk/resource-manager/securityinsights/2022-10-01-preview/automationrules"
 	"github.com/hashicorp/go-azure-sdk/resource-manager/securityinsights/2022-10-01-preview/metadata"
 	"github.com/hashicorp/go-azure-sdk/resource-manager/securityinsights/2022-11-01/sentinelonboardingstates"
 	"github.com/hashicorp/go-azure-sdk/resource-manager/securityinsights/2022-11-01/watchlistitems"
 	"github.com/hashicorp/go-azure-sdk/resource-manager/securityinsights/2022-11-01/watchlists"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/securityinsights/2024-03-01/contentpackages"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/securityinsights/2024-03-01/contentproductpackages"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/common"
 	securityinsight "github.com/tombuildsstuff/kermit/sdk/securityinsights/2022-10-01-preview/securityinsights"
 )
 
 type Client struct {
-	AlertRulesClient         *alertrules.AlertRulesClient
-	AlertRuleTemplatesClient *alertruletemplates.AlertRuleTemplatesClient
-	AutomationRulesClient    *automationrules.AutomationRulesClient
-	DataConnectorsClient     *securityinsight.DataConnectorsClient
-	WatchlistsClient         *watchlists.WatchlistsClient
-	WatchlistItemsClient     *watchlistitems.WatchlistItemsClient
-	OnboardingStatesClient   *sentinelonboardingstates.SentinelOnboardingStatesClient
-	AnalyticsSettingsClient  *securityinsight.SecurityMLAnalyticsSettingsClient
-	ThreatIntelligenceClient *securityinsight.ThreatIntelligenceIndicatorClient
-	MetadataClient           *metadata.MetadataClient
+	AlertRulesClient             *alertrules.AlertRulesClient
+	AlertRuleTemplatesClient     *alertruletemplates.AlertRuleTemplatesClient
+	AutomationRulesClient        *automationrules.AutomationRulesClient
+	ContentPackagesClient        *contentpackages.ContentPackagesClient
+	ContentProductPackagesClient *contentproductpackages.ContentProductPackagesClient
+	DataConnectorsClient         *securityinsight.DataConnectorsClient
+	WatchlistsClient             *watchlists.WatchlistsClient
+	WatchlistItemsClient         *watchlistitems.WatchlistItemsClient
+	OnboardingStatesClient       *sentinelonboardingstates.SentinelOnboardingStatesClient
+	AnalyticsSettingsClient      *securityinsight.SecurityMLAnalyticsSettingsClient
+	SavedSearchesClient          *savedsearches.SavedSearchesClient
+	ThreatIntelligenceClient     *securityinsight.ThreatIntelligenceIndicatorClient
+	MetadataClient               *metadata.MetadataClient
 }
 
 func NewClient(o *common.ClientOptions) (*Client, error) {
@@ -46,6 +52,18 @@ func NewClient(o *common.ClientOptions) (*Client, error) {
 	}
 	o.Configure(automationRulesClient.Client, o.Authorizers.ResourceManager)
 
+	contentPackagesClient, err := contentpackages.NewContentPackagesClientWithBaseURI(o.Environment.ResourceManager)
+	if err != nil {
+		return nil, fmt.Errorf("building Content Packages Client: %+v", err)
+	}
+	o.Configure(contentPackagesClient.Client, o.Authorizers.ResourceManager)
+
+	contentProductPackagesClient, err := contentproductpackages.NewContentProductPackagesClientWithBaseURI(o.Environment.ResourceManager)
+	if err != nil {
+		return nil, fmt.Errorf("building Content Product Packages Client: %+v", err)
+	}
+	o.Configure(contentProductPackagesClient.Client, o.Authorizers.ResourceManager)
+
 	dataConnectorsClient := securityinsight.NewDataConnectorsClientWithBaseURI(o.ResourceManagerEndpoint, o.SubscriptionId)
 	o.ConfigureClient(&dataConnectorsClient.Client, o.ResourceManagerAuthorizer)
 
@@ -70,6 +88,12 @@ func NewClient(o *common.ClientOptions) (*Client, error) {
 	analyticsSettingsClient := securityinsight.NewSecurityMLAnalyticsSettingsClientWithBaseURI(o.ResourceManagerEndpoint, o.SubscriptionId)
 	o.ConfigureClient(&analyticsSettingsClient.Client, o.ResourceManagerAuthorizer)
 
+	savedSearchesClient, err := savedsearches.NewSavedSearchesClientWithBaseURI(o.Environment.ResourceManager)
+	if err != nil {
+		return nil, fmt.Errorf("building Saved Searches Client: %+v", err)
+	}
+	o.Configure(savedSearchesClient.Client, o.Authorizers.ResourceManager)
+
 	threatIntelligenceClient := securityinsight.NewThreatIntelligenceIndicatorClientWithBaseURI(o.ResourceManagerEndpoint, o.SubscriptionId)
 	o.ConfigureClient(&threatIntelligenceClient.Client, o.ResourceManagerAuthorizer)
 
@@ -80,15 +104,18 @@ func NewClient(o *common.ClientOptions) (*Client, error) {
 	o.Configure(metadataClient.Client, o.Authorizers.ResourceManager)
 
 	return &Client{
-		AlertRulesClient:         alertRulesClient,
-		AlertRuleTemplatesClient: &alertRuleTemplatesClient,
-		AutomationRulesClient:    automationRulesClient,
-		DataConnectorsClient:     &dataConnectorsClient,
-		WatchlistsClient:         watchListsClient,
-		WatchlistItemsClient:     watchListItemsClient,
-		OnboardingStatesClient:   onboardingStatesClient,
-		AnalyticsSettingsClient:  &analyticsSettingsClient,
-		ThreatIntelligenceClient: &threatIntelligenceClient,
-		MetadataClient:           metadataClient,
+		AlertRulesClient:             alertRulesClient,
+		AlertRuleTemplatesClient:     &alertRuleTemplatesClient,
+		AutomationRulesClient:        automationRulesClient,
+		ContentPackagesClient:        contentPackagesClient,
+		ContentProductPackagesClient: contentProductPackagesClient,
+		DataConnectorsClient:         &dataConnectorsClient,
+		WatchlistsClient:             watchListsClient,
+		WatchlistItemsClient:         watchListItemsClient,
+		OnboardingStatesClient:       onboardingStatesClient,
+		AnalyticsSettingsClient:      &analyticsSettingsClient,
+		SavedSearchesClient:          savedSearchesClient,
+		ThreatIntelligenceClient:     &threatIntelligenceClient,
+		MetadataClient:               metadataClient,
 	}, nil
 }
@@ -10,7 +10,10 @@ import (
 
 type Registration struct{}
 
-var _ sdk.UntypedServiceRegistrationWithAGitHubLabel = Registration{}
+var (
+	_ sdk.UntypedServiceRegistrationWithAGitHubLabel = Registration{}
+	_ sdk.ServiceRegistrationWithResourceProviders   = Registration{}
+)
 
 func (r Registration) AssociatedGitHubLabel() string {
 	return "service/sentinel"
@@ -21,6 +24,15 @@ func (r Registration) Name() string {
 	return "Sentinel"
 }
 
+// ResourceProviders returns the Resource Providers required by the Resources within this Service
+func (r Registration) ResourceProviders() []string {
+	return []string{
+		"Microsoft.OperationalInsights",
+		"Microsoft.OperationsManagement",
+		"Microsoft.SecurityInsights",
+	}
+}
+
 // WebsiteCategories returns a list of categories which can be used for the sidebar
 func (r Registration) WebsiteCategories() []string {
 	return []string{
@@ -82,5 +94,7 @@ func (r Registration) Resources() []sdk.Resource {
 		MetadataResource{},
 		AlertRuleAnomalyDuplicateResource{},
 		ThreatIntelligenceIndicator{},
+		ContentPackageResource{},
+		HuntingQueryResource{},
 	}
 }
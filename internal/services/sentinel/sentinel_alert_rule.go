@@ -6,7 +6,11 @@ package sentinel
 import (
 	"context"
 	"fmt"
+	"log"
+	"strconv"
+	"strings"
 
+	alertruletemplates "github.com/Azure/azure-sdk-for-go/services/preview/securityinsight/mgmt/2021-09-01-preview/securityinsight" // nolint: staticcheck
 	"github.com/hashicorp/go-azure-sdk/resource-manager/securityinsights/2022-10-01-preview/alertrules"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
@@ -467,3 +471,69 @@ func flattenAlertRuleSentinelEntityMapping(input *[]alertrules.SentinelEntityMap
 
 	return output
 }
+
+// fetchAlertRuleTemplateVersion returns the latest version of the specified Alert Rule Template, only Scheduled
+// and NRT templates are versioned - as such an empty string is returned for other kinds, if the template no longer exists
+// or if the caller doesn't have permission to read it.
+func fetchAlertRuleTemplateVersion(ctx context.Context, client *alertruletemplates.AlertRuleTemplatesClient, id alertrules.AlertRuleId, templateName string) (string, error) {
+	resp, err := client.Get(ctx, id.ResourceGroupName, id.WorkspaceName, templateName)
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			return "", nil
+		}
+		// reading the Alert Rule Templates requires an additional permission - so when this is unavailable the
+		// latest version is left empty, rather than preventing the Alert Rule from being read
+		if utils.ResponseWasForbidden(resp.Response) {
+			log.Printf("[DEBUG] Insufficient permissions to retrieve Alert Rule Template %q (Workspace %q / Resource Group %q) - leaving the latest version empty", templateName, id.WorkspaceName, id.ResourceGroupName)
+			return "", nil
+		}
+		return "", fmt.Errorf("retrieving Alert Rule Template %q (Workspace %q / Resource Group %q): %+v", templateName, id.WorkspaceName, id.ResourceGroupName, err)
+	}
+
+	switch template := resp.Value.(type) {
+	case alertruletemplates.ScheduledAlertRuleTemplate:
+		if template.ScheduledAlertRuleTemplateProperties != nil && template.ScheduledAlertRuleTemplateProperties.Version != nil {
+			return *template.ScheduledAlertRuleTemplateProperties.Version, nil
+		}
+	case alertruletemplates.NrtAlertRuleTemplate:
+		if template.NrtAlertRuleTemplateProperties != nil && template.NrtAlertRuleTemplateProperties.Version != nil {
+			return *template.NrtAlertRuleTemplateProperties.Version, nil
+		}
+	}
+
+	return "", nil
+}
+
+// alertRuleTemplateVersionOutdated compares two template versions in the format `a.b.c` and returns whether the
+// current version is older than the latest version.
+func alertRuleTemplateVersionOutdated(current, latest string) bool {
+	if current == "" || latest == "" {
+		return false
+	}
+
+	currentSegments := strings.Split(current, ".")
+	latestSegments := strings.Split(latest, ".")
+	for i := 0; i < len(currentSegments) || i < len(latestSegments); i++ {
+		var c, l int
+		if i < len(currentSegments) {
+			v, err := strconv.Atoi(currentSegments[i])
+			if err != nil {
+				return current != latest
+			}
+			c = v
+		}
+		if i < len(latestSegments) {
+			v, err := strconv.Atoi(latestSegments[i])
+			if err != nil {
+				return current != latest
+			}
+			l = v
+		}
+
+		if c != l {
+			return c < l
+		}
+	}
+
+	return false
+}
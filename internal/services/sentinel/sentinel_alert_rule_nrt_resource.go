@@ -8,6 +8,7 @@ import (
 	"log"
 	"time"
 
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
 	"github.com/hashicorp/go-azure-helpers/lang/response"
 	"github.com/hashicorp/go-azure-sdk/resource-manager/securityinsights/2022-10-01-preview/alertrules"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -74,6 +75,16 @@ func resourceSentinelAlertRuleNrt() *pluginsdk.Resource {
 				ForceNew: true,
 			},
 
+			"template_version": {
+				Type:     pluginsdk.TypeString,
+				Computed: true,
+			},
+
+			"template_outdated": {
+				Type:     pluginsdk.TypeBool,
+				Computed: true,
+			},
+
 			"description": {
 				Type:         pluginsdk.TypeString,
 				Optional:     true,
@@ -475,6 +486,16 @@ func resourceSentinelAlertRuleNrtRead(d *pluginsdk.ResourceData, meta interface{
 			d.Set("alert_rule_template_guid", prop.AlertRuleTemplateName)
 			d.Set("alert_rule_template_version", prop.TemplateVersion)
 
+			templateVersion := ""
+			if templateName := pointer.From(prop.AlertRuleTemplateName); templateName != "" {
+				templateVersion, err = fetchAlertRuleTemplateVersion(ctx, meta.(*clients.Client).Sentinel.AlertRuleTemplatesClient, *id, templateName)
+				if err != nil {
+					return fmt.Errorf("retrieving the latest template version for %s: %+v", id, err)
+				}
+			}
+			d.Set("template_version", templateVersion)
+			d.Set("template_outdated", alertRuleTemplateVersionOutdated(pointer.From(prop.TemplateVersion), templateVersion))
+
 			if err := d.Set("event_grouping", flattenAlertRuleScheduledEventGroupingSetting(prop.EventGroupingSettings)); err != nil {
 				return fmt.Errorf("setting `event_grouping`: %+v", err)
 			}
@@ -76,6 +76,16 @@ func resourceSentinelAlertRuleScheduled() *pluginsdk.Resource {
 				Optional: true,
 			},
 
+			"template_version": {
+				Type:     pluginsdk.TypeString,
+				Computed: true,
+			},
+
+			"template_outdated": {
+				Type:     pluginsdk.TypeBool,
+				Computed: true,
+			},
+
 			"description": {
 				Type:         pluginsdk.TypeString,
 				Optional:     true,
@@ -627,6 +637,16 @@ func resourceSentinelAlertRuleScheduledRead(d *pluginsdk.ResourceData, meta inte
 			d.Set("alert_rule_template_guid", prop.AlertRuleTemplateName)
 			d.Set("alert_rule_template_version", prop.TemplateVersion)
 
+			templateVersion := ""
+			if templateName := pointer.From(prop.AlertRuleTemplateName); templateName != "" {
+				templateVersion, err = fetchAlertRuleTemplateVersion(ctx, meta.(*clients.Client).Sentinel.AlertRuleTemplatesClient, *id, templateName)
+				if err != nil {
+					return fmt.Errorf("retrieving the latest template version for %s: %+v", id, err)
+				}
+			}
+			d.Set("template_version", templateVersion)
+			d.Set("template_outdated", alertRuleTemplateVersionOutdated(pointer.From(prop.TemplateVersion), templateVersion))
+
 			if err := d.Set("event_grouping", flattenAlertRuleScheduledEventGroupingSetting(prop.EventGroupingSettings)); err != nil {
 				return fmt.Errorf("setting `event_grouping`: %+v", err)
 			}
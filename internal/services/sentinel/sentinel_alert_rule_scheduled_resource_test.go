@@ -43,6 +43,7 @@ func TestAccSentinelAlertRuleScheduled_upgrade(t *testing.T) {
 			Config: r.upgradeVersion(data, "1.0.4"),
 			Check: acceptance.ComposeTestCheckFunc(
 				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("template_version").IsSet(),
 			),
 		},
 		data.ImportStep(),
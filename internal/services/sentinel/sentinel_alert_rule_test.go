@@ -0,0 +1,108 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package sentinel
+
+import "testing"
+
+func TestAlertRuleTemplateVersionOutdated(t *testing.T) {
+	testData := []struct {
+		name     string
+		current  string
+		latest   string
+		expected bool
+	}{
+		{
+			name:     "empty current version",
+			current:  "",
+			latest:   "1.0.0",
+			expected: false,
+		},
+		{
+			name:     "empty latest version",
+			current:  "1.0.0",
+			latest:   "",
+			expected: false,
+		},
+		{
+			name:     "equal versions",
+			current:  "1.2.3",
+			latest:   "1.2.3",
+			expected: false,
+		},
+		{
+			name:     "older patch version",
+			current:  "1.2.3",
+			latest:   "1.2.4",
+			expected: true,
+		},
+		{
+			name:     "older minor version",
+			current:  "1.2.3",
+			latest:   "1.10.0",
+			expected: true,
+		},
+		{
+			name:     "older major version",
+			current:  "1.9.9",
+			latest:   "2.0.0",
+			expected: true,
+		},
+		{
+			name:     "newer version",
+			current:  "2.0.1",
+			latest:   "2.0.0",
+			expected: false,
+		},
+		{
+			name:     "fewer segments but equal",
+			current:  "1.0",
+			latest:   "1.0.0",
+			expected: false,
+		},
+		{
+			name:     "fewer segments and older",
+			current:  "1.0",
+			latest:   "1.0.1",
+			expected: true,
+		},
+		{
+			name:     "more segments and newer",
+			current:  "1.0.0.1",
+			latest:   "1.0.0",
+			expected: false,
+		},
+		{
+			name:     "more segments and older",
+			current:  "1.0.0.1",
+			latest:   "1.1",
+			expected: true,
+		},
+		{
+			name:     "non-numeric segment with equal versions",
+			current:  "1.0.0-preview",
+			latest:   "1.0.0-preview",
+			expected: false,
+		},
+		{
+			name:     "non-numeric segment with different versions",
+			current:  "1.0.0-preview",
+			latest:   "1.0.0",
+			expected: true,
+		},
+		{
+			name:     "non-numeric segment only in the latest version",
+			current:  "1.0.0",
+			latest:   "1.0.x",
+			expected: true,
+		},
+	}
+
+	for _, v := range testData {
+		t.Run(v.name, func(t *testing.T) {
+			if actual := alertRuleTemplateVersionOutdated(v.current, v.latest); actual != v.expected {
+				t.Fatalf("expected %t for current %q and latest %q but got %t", v.expected, v.current, v.latest, actual)
+			}
+		})
+	}
+}
@@ -0,0 +1,293 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package sentinel
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/operationalinsights/2022-10-01/workspaces"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/securityinsights/2024-03-01/contentpackages"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/securityinsights/2024-03-01/contentproductpackages"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+)
+
+type ContentPackageModel struct {
+	WorkspaceId      string `tfschema:"workspace_id"`
+	ContentId        string `tfschema:"content_id"`
+	Version          string `tfschema:"version"`
+	DisplayName      string `tfschema:"display_name"`
+	ContentKind      string `tfschema:"content_kind"`
+	ContentProductId string `tfschema:"content_product_id"`
+	LatestVersion    string `tfschema:"latest_version"`
+}
+
+type ContentPackageResource struct{}
+
+var _ sdk.ResourceWithUpdate = ContentPackageResource{}
+
+func (r ContentPackageResource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"workspace_id": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: workspaces.ValidateWorkspaceID,
+		},
+
+		"content_id": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+		},
+
+		"version": {
+			Type:         pluginsdk.TypeString,
+			Optional:     true,
+			Computed:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+		},
+	}
+}
+
+func (r ContentPackageResource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"display_name": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+
+		"content_kind": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+
+		"content_product_id": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+
+		"latest_version": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+	}
+}
+
+func (r ContentPackageResource) ModelObject() interface{} {
+	return &ContentPackageModel{}
+}
+
+func (r ContentPackageResource) ResourceType() string {
+	return "azurerm_sentinel_content_package"
+}
+
+func (r ContentPackageResource) IDValidationFunc() pluginsdk.SchemaValidateFunc {
+	return contentpackages.ValidateContentPackageID
+}
+
+func (r ContentPackageResource) Create() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			var plan ContentPackageModel
+			if err := metadata.Decode(&plan); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+			client := metadata.Client.Sentinel.ContentPackagesClient
+
+			workspaceId, err := workspaces.ParseWorkspaceID(plan.WorkspaceId)
+			if err != nil {
+				return err
+			}
+
+			id := contentpackages.NewContentPackageID(workspaceId.SubscriptionId, workspaceId.ResourceGroupName, workspaceId.WorkspaceName, plan.ContentId)
+
+			existing, err := client.Get(ctx, id)
+			if err != nil {
+				if !response.WasNotFound(existing.HttpResponse) {
+					return fmt.Errorf("checking for presence of existing %s: %+v", id, err)
+				}
+			}
+			if !response.WasNotFound(existing.HttpResponse) {
+				return metadata.ResourceRequiresImport(r.ResourceType(), id)
+			}
+
+			input, err := expandContentPackageFromCatalog(ctx, metadata.Client.Sentinel.ContentProductPackagesClient, id, plan.Version)
+			if err != nil {
+				return err
+			}
+
+			if _, err := client.ContentPackageInstall(ctx, id, *input); err != nil {
+				return fmt.Errorf("installing %s: %+v", id, err)
+			}
+
+			metadata.SetID(id)
+			return nil
+		},
+	}
+}
+
+func (r ContentPackageResource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Sentinel.ContentPackagesClient
+
+			id, err := contentpackages.ParseContentPackageID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			resp, err := client.Get(ctx, *id)
+			if err != nil {
+				if response.WasNotFound(resp.HttpResponse) {
+					return metadata.MarkAsGone(id)
+				}
+				return fmt.Errorf("retrieving %s: %+v", id, err)
+			}
+
+			state := ContentPackageModel{
+				WorkspaceId: workspaces.NewWorkspaceID(id.SubscriptionId, id.ResourceGroupName, id.WorkspaceName).ID(),
+				ContentId:   id.PackageId,
+			}
+
+			if model := resp.Model; model != nil {
+				if props := model.Properties; props != nil {
+					if props.ContentId != nil {
+						state.ContentId = *props.ContentId
+					}
+					state.Version = pointer.From(props.Version)
+					state.DisplayName = pointer.From(props.DisplayName)
+					state.ContentKind = string(pointer.From(props.ContentKind))
+					state.ContentProductId = pointer.From(props.ContentProductId)
+				}
+			}
+
+			catalogPackage, err := findContentProductPackage(ctx, metadata.Client.Sentinel.ContentProductPackagesClient, *id)
+			if err != nil {
+				return err
+			}
+			if catalogPackage != nil && catalogPackage.Properties != nil {
+				state.LatestVersion = pointer.From(catalogPackage.Properties.Version)
+			}
+
+			return metadata.Encode(&state)
+		},
+	}
+}
+
+func (r ContentPackageResource) Update() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Sentinel.ContentPackagesClient
+
+			id, err := contentpackages.ParseContentPackageID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			var plan ContentPackageModel
+			if err := metadata.Decode(&plan); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			if metadata.ResourceData.HasChange("version") {
+				input, err := expandContentPackageFromCatalog(ctx, metadata.Client.Sentinel.ContentProductPackagesClient, *id, plan.Version)
+				if err != nil {
+					return err
+				}
+
+				if _, err := client.ContentPackageInstall(ctx, *id, *input); err != nil {
+					return fmt.Errorf("updating %s: %+v", id, err)
+				}
+			}
+
+			return nil
+		},
+	}
+}
+
+func (r ContentPackageResource) Delete() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Sentinel.ContentPackagesClient
+
+			id, err := contentpackages.ParseContentPackageID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			if _, err := client.ContentPackageUninstall(ctx, *id); err != nil {
+				return fmt.Errorf("uninstalling %s: %+v", id, err)
+			}
+
+			return nil
+		},
+	}
+}
+
+// findContentProductPackage looks up the Content Hub catalog entry for the Content ID of the specified Content Package,
+// returning nil if the package is no longer published.
+func findContentProductPackage(ctx context.Context, client *contentproductpackages.ContentProductPackagesClient, id contentpackages.ContentPackageId) (*contentproductpackages.ProductPackageModel, error) {
+	workspaceId := contentproductpackages.NewWorkspaceID(id.SubscriptionId, id.ResourceGroupName, id.WorkspaceName)
+
+	options := contentproductpackages.ProductPackagesListOperationOptions{
+		Filter: pointer.To(fmt.Sprintf("properties/contentId eq '%s'", id.PackageId)),
+	}
+	resp, err := client.ProductPackagesListComplete(ctx, workspaceId, options)
+	if err != nil {
+		return nil, fmt.Errorf("listing the Content Hub packages for %s: %+v", workspaceId, err)
+	}
+
+	for _, item := range resp.Items {
+		if item.Properties != nil && strings.EqualFold(pointer.From(item.Properties.ContentId), id.PackageId) {
+			return &item, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// expandContentPackageFromCatalog builds the install payload for a Content Package from its Content Hub catalog entry,
+// since the API expects the full package metadata (e.g. the kind, product ID and source) rather than just the Content ID.
+func expandContentPackageFromCatalog(ctx context.Context, client *contentproductpackages.ContentProductPackagesClient, id contentpackages.ContentPackageId, version string) (*contentpackages.PackageModel, error) {
+	catalogPackage, err := findContentProductPackage(ctx, client, id)
+	if err != nil {
+		return nil, err
+	}
+	if catalogPackage == nil || catalogPackage.Properties == nil {
+		return nil, fmt.Errorf("the Content ID %q for %s was not found in the Content Hub", id.PackageId, id)
+	}
+
+	// the catalog and installed package share the same schema, other than the catalog only fields (such as
+	// `packagedContent`) which are dropped when unmarshalling into the installed package properties
+	raw, err := json.Marshal(catalogPackage.Properties)
+	if err != nil {
+		return nil, fmt.Errorf("marshalling the Content Hub package %q: %+v", id.PackageId, err)
+	}
+
+	props := contentpackages.PackageBaseProperties{}
+	if err := json.Unmarshal(raw, &props); err != nil {
+		return nil, fmt.Errorf("unmarshalling the Content Hub package %q: %+v", id.PackageId, err)
+	}
+
+	if version != "" {
+		props.Version = pointer.To(version)
+	}
+
+	return &contentpackages.PackageModel{
+		Properties: &props,
+	}, nil
+}
@@ -0,0 +1,327 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package sentinel
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/operationalinsights/2020-08-01/savedsearches"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/securityinsights/2022-10-01-preview/alertrules"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+)
+
+// Sentinel Hunting Queries are Log Analytics Saved Searches within this category, with the
+// description, tactics and techniques stored as tags on the Saved Search
+const (
+	huntingQueryCategory       = "Hunting Queries"
+	huntingQueryTagDescription = "description"
+	huntingQueryTagTactics     = "tactics"
+	huntingQueryTagTechniques  = "techniques"
+)
+
+type HuntingQueryModel struct {
+	Name                    string   `tfschema:"name"`
+	LogAnalyticsWorkspaceId string   `tfschema:"log_analytics_workspace_id"`
+	DisplayName             string   `tfschema:"display_name"`
+	Query                   string   `tfschema:"query"`
+	Description             string   `tfschema:"description"`
+	Tactics                 []string `tfschema:"tactics"`
+	Techniques              []string `tfschema:"techniques"`
+}
+
+type HuntingQueryResource struct{}
+
+var (
+	_ sdk.ResourceWithUpdate         = HuntingQueryResource{}
+	_ sdk.ResourceWithCustomImporter = HuntingQueryResource{}
+)
+
+func (r HuntingQueryResource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"name": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+		},
+
+		"log_analytics_workspace_id": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: savedsearches.ValidateWorkspaceID,
+		},
+
+		"display_name": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+		},
+
+		"query": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+		},
+
+		"description": {
+			Type:         pluginsdk.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+		},
+
+		"tactics": {
+			Type:     pluginsdk.TypeSet,
+			Optional: true,
+			Elem: &pluginsdk.Schema{
+				Type:         pluginsdk.TypeString,
+				ValidateFunc: validation.StringInSlice(alertrules.PossibleValuesForAttackTactic(), false),
+			},
+		},
+
+		"techniques": {
+			Type:     pluginsdk.TypeSet,
+			Optional: true,
+			Elem: &pluginsdk.Schema{
+				Type:         pluginsdk.TypeString,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+		},
+	}
+}
+
+func (r HuntingQueryResource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{}
+}
+
+func (r HuntingQueryResource) ModelObject() interface{} {
+	return &HuntingQueryModel{}
+}
+
+func (r HuntingQueryResource) ResourceType() string {
+	return "azurerm_sentinel_hunting_query"
+}
+
+func (r HuntingQueryResource) IDValidationFunc() pluginsdk.SchemaValidateFunc {
+	return savedsearches.ValidateSavedSearchID
+}
+
+func (r HuntingQueryResource) CustomImporter() sdk.ResourceRunFunc {
+	return func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+		id, err := savedsearches.ParseSavedSearchID(metadata.ResourceData.Id())
+		if err != nil {
+			return err
+		}
+
+		resp, err := metadata.Client.Sentinel.SavedSearchesClient.Get(ctx, *id)
+		if err != nil {
+			return fmt.Errorf("retrieving %s: %+v", id, err)
+		}
+
+		if resp.Model == nil || !strings.EqualFold(resp.Model.Properties.Category, huntingQueryCategory) {
+			return fmt.Errorf("%s is not a Sentinel Hunting Query, expected the category to be %q", id, huntingQueryCategory)
+		}
+
+		return nil
+	}
+}
+
+func (r HuntingQueryResource) Create() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			var plan HuntingQueryModel
+			if err := metadata.Decode(&plan); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+			client := metadata.Client.Sentinel.SavedSearchesClient
+
+			workspaceId, err := savedsearches.ParseWorkspaceID(plan.LogAnalyticsWorkspaceId)
+			if err != nil {
+				return err
+			}
+
+			id := savedsearches.NewSavedSearchID(workspaceId.SubscriptionId, workspaceId.ResourceGroupName, workspaceId.WorkspaceName, plan.Name)
+
+			existing, err := client.Get(ctx, id)
+			if err != nil {
+				if !response.WasNotFound(existing.HttpResponse) {
+					return fmt.Errorf("checking for presence of existing %s: %+v", id, err)
+				}
+			}
+			if !response.WasNotFound(existing.HttpResponse) {
+				return metadata.ResourceRequiresImport(r.ResourceType(), id)
+			}
+
+			if _, err := client.CreateOrUpdate(ctx, id, expandHuntingQuery(plan)); err != nil {
+				return fmt.Errorf("creating %s: %+v", id, err)
+			}
+
+			metadata.SetID(id)
+			return nil
+		},
+	}
+}
+
+func (r HuntingQueryResource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Sentinel.SavedSearchesClient
+
+			id, err := savedsearches.ParseSavedSearchID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			resp, err := client.Get(ctx, *id)
+			if err != nil {
+				if response.WasNotFound(resp.HttpResponse) {
+					return metadata.MarkAsGone(id)
+				}
+				return fmt.Errorf("retrieving %s: %+v", id, err)
+			}
+
+			state := HuntingQueryModel{
+				Name:                    id.SavedSearchId,
+				LogAnalyticsWorkspaceId: savedsearches.NewWorkspaceID(id.SubscriptionId, id.ResourceGroupName, id.WorkspaceName).ID(),
+			}
+
+			if model := resp.Model; model != nil {
+				props := model.Properties
+				state.DisplayName = props.DisplayName
+				state.Query = props.Query
+
+				if props.Tags != nil {
+					for _, tag := range *props.Tags {
+						switch {
+						case strings.EqualFold(tag.Name, huntingQueryTagDescription):
+							state.Description = tag.Value
+						case strings.EqualFold(tag.Name, huntingQueryTagTactics):
+							state.Tactics = flattenHuntingQueryTagValues(tag.Value)
+						case strings.EqualFold(tag.Name, huntingQueryTagTechniques):
+							state.Techniques = flattenHuntingQueryTagValues(tag.Value)
+						}
+					}
+				}
+			}
+
+			return metadata.Encode(&state)
+		},
+	}
+}
+
+func (r HuntingQueryResource) Update() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Sentinel.SavedSearchesClient
+
+			id, err := savedsearches.ParseSavedSearchID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			var plan HuntingQueryModel
+			if err := metadata.Decode(&plan); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			existing, err := client.Get(ctx, *id)
+			if err != nil {
+				return fmt.Errorf("retrieving %s: %+v", id, err)
+			}
+			if existing.Model == nil {
+				return fmt.Errorf("retrieving %s: `model` was nil", id)
+			}
+
+			// the current ETag must be supplied when updating an existing Saved Search
+			payload := expandHuntingQuery(plan)
+			payload.Etag = existing.Model.Etag
+
+			if _, err := client.CreateOrUpdate(ctx, *id, payload); err != nil {
+				return fmt.Errorf("updating %s: %+v", id, err)
+			}
+
+			return nil
+		},
+	}
+}
+
+func (r HuntingQueryResource) Delete() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Sentinel.SavedSearchesClient
+
+			id, err := savedsearches.ParseSavedSearchID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			if _, err := client.Delete(ctx, *id); err != nil {
+				return fmt.Errorf("deleting %s: %+v", id, err)
+			}
+
+			return nil
+		},
+	}
+}
+
+func expandHuntingQuery(input HuntingQueryModel) savedsearches.SavedSearch {
+	tags := make([]savedsearches.Tag, 0)
+	if input.Description != "" {
+		tags = append(tags, savedsearches.Tag{
+			Name:  huntingQueryTagDescription,
+			Value: input.Description,
+		})
+	}
+	if len(input.Tactics) > 0 {
+		tags = append(tags, savedsearches.Tag{
+			Name:  huntingQueryTagTactics,
+			Value: expandHuntingQueryTagValues(input.Tactics),
+		})
+	}
+	if len(input.Techniques) > 0 {
+		tags = append(tags, savedsearches.Tag{
+			Name:  huntingQueryTagTechniques,
+			Value: expandHuntingQueryTagValues(input.Techniques),
+		})
+	}
+
+	return savedsearches.SavedSearch{
+		Properties: savedsearches.SavedSearchProperties{
+			Category:    huntingQueryCategory,
+			DisplayName: input.DisplayName,
+			Query:       input.Query,
+			Tags:        pointer.To(tags),
+		},
+	}
+}
+
+// expandHuntingQueryTagValues joins the values into the comma separated format used by the Sentinel portal
+func expandHuntingQueryTagValues(input []string) string {
+	values := make([]string, len(input))
+	copy(values, input)
+	sort.Strings(values)
+	return strings.Join(values, ",")
+}
+
+func flattenHuntingQueryTagValues(input string) []string {
+	output := make([]string, 0)
+	for _, v := range strings.Split(input, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			output = append(output, v)
+		}
+	}
+	return output
+}
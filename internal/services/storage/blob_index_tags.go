@@ -0,0 +1,146 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package storage
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/hashicorp/go-azure-sdk/resource-manager/storage/2023-01-01/storageaccounts"
+	"github.com/hashicorp/go-azure-sdk/sdk/client"
+	"github.com/hashicorp/go-azure-sdk/sdk/odata"
+	storageClient "github.com/hashicorp/terraform-provider-azurerm/internal/services/storage/client"
+	"github.com/tombuildsstuff/giovanni/storage/2023-11-03/blob/blobs"
+)
+
+// TODO: move the Blob Index Tags operations into Giovanni
+
+type blobIndexTags struct {
+	XMLName xml.Name         `xml:"Tags"`
+	TagSet  blobIndexTagsSet `xml:"TagSet"`
+}
+
+type blobIndexTagsSet struct {
+	Tags []blobIndexTag `xml:"Tag"`
+}
+
+type blobIndexTag struct {
+	Key   string `xml:"Key"`
+	Value string `xml:"Value"`
+}
+
+type blobIndexTagsOptions struct{}
+
+func (blobIndexTagsOptions) ToHeaders() *client.Headers {
+	return nil
+}
+
+func (blobIndexTagsOptions) ToOData() *odata.Query {
+	return nil
+}
+
+func (blobIndexTagsOptions) ToQuery() *client.QueryParams {
+	out := &client.QueryParams{}
+	out.Append("comp", "tags")
+	return out
+}
+
+// blobIndexTagsSupported returns whether Blob Index Tags can be used within the specified Storage Account
+func blobIndexTagsSupported(account storageClient.AccountDetails) bool {
+	if account.IsHnsEnabled {
+		return false
+	}
+	return account.Kind == storageaccounts.KindStorageVTwo || account.Kind == storageaccounts.KindBlobStorage
+}
+
+// getBlobIndexTags retrieves the Index Tags assigned to the specified Blob
+func getBlobIndexTags(ctx context.Context, blobsClient *blobs.Client, containerName, blobName string) (map[string]string, *http.Response, error) {
+	opts := client.RequestOptions{
+		ContentType: "application/xml; charset=utf-8",
+		ExpectedStatusCodes: []int{
+			http.StatusOK,
+		},
+		HttpMethod:    http.MethodGet,
+		OptionsObject: blobIndexTagsOptions{},
+		Path:          fmt.Sprintf("/%s/%s", containerName, blobName),
+	}
+
+	req, err := blobsClient.Client.NewRequest(ctx, opts)
+	if err != nil {
+		return nil, nil, fmt.Errorf("building request: %+v", err)
+	}
+
+	resp, err := req.Execute(ctx)
+	var httpResponse *http.Response
+	if resp != nil {
+		httpResponse = resp.Response
+	}
+	if err != nil {
+		return nil, httpResponse, fmt.Errorf("executing request: %+v", err)
+	}
+
+	var model blobIndexTags
+	if err := resp.Unmarshal(&model); err != nil {
+		return nil, httpResponse, fmt.Errorf("unmarshalling response: %+v", err)
+	}
+
+	output := make(map[string]string)
+	for _, tag := range model.TagSet.Tags {
+		output[tag.Key] = tag.Value
+	}
+	return output, httpResponse, nil
+}
+
+// setBlobIndexTags replaces the Index Tags assigned to the specified Blob
+func setBlobIndexTags(ctx context.Context, blobsClient *blobs.Client, containerName, blobName string, input map[string]string) error {
+	opts := client.RequestOptions{
+		ContentType: "application/xml; charset=utf-8",
+		ExpectedStatusCodes: []int{
+			http.StatusNoContent,
+		},
+		HttpMethod:    http.MethodPut,
+		OptionsObject: blobIndexTagsOptions{},
+		Path:          fmt.Sprintf("/%s/%s", containerName, blobName),
+	}
+
+	req, err := blobsClient.Client.NewRequest(ctx, opts)
+	if err != nil {
+		return fmt.Errorf("building request: %+v", err)
+	}
+
+	if err := req.Marshal(expandBlobIndexTags(input)); err != nil {
+		return fmt.Errorf("marshalling request: %+v", err)
+	}
+
+	if _, err := req.Execute(ctx); err != nil {
+		return fmt.Errorf("executing request: %+v", err)
+	}
+
+	return nil
+}
+
+func expandBlobIndexTags(input map[string]string) blobIndexTags {
+	keys := make([]string, 0, len(input))
+	for k := range input {
+		keys = append(keys, k)
+	}
+	// sorted to give a consistent request body
+	sort.Strings(keys)
+
+	output := blobIndexTags{
+		TagSet: blobIndexTagsSet{
+			Tags: make([]blobIndexTag, 0, len(keys)),
+		},
+	}
+	for _, k := range keys {
+		output.TagSet.Tags = append(output.TagSet.Tags, blobIndexTag{
+			Key:   k,
+			Value: input[k],
+		})
+	}
+	return output
+}
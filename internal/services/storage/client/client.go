@@ -7,6 +7,7 @@ import (
 	"fmt"
 
 	storage_v2023_01_01 "github.com/hashicorp/go-azure-sdk/resource-manager/storage/2023-01-01"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/storage/2023-05-01/storagetaskassignments"
 	"github.com/hashicorp/go-azure-sdk/resource-manager/storagesync/2020-03-01/cloudendpointresource"
 	"github.com/hashicorp/go-azure-sdk/resource-manager/storagesync/2020-03-01/registeredserverresource"
 	"github.com/hashicorp/go-azure-sdk/resource-manager/storagesync/2020-03-01/serverendpointresource"
@@ -30,6 +31,7 @@ type Client struct {
 	SyncRegisteredServerClient *registeredserverresource.RegisteredServerResourceClient
 	SyncServerEndpointsClient  *serverendpointresource.ServerEndpointResourceClient
 	SyncServiceClient          *storagesyncservicesresource.StorageSyncServicesResourceClient
+	TaskAssignmentsClient      *storagetaskassignments.StorageTaskAssignmentsClient
 
 	authConfigForAzureAD *auth.Credentials
 }
@@ -80,6 +82,12 @@ func NewClient(o *common.ClientOptions) (*Client, error) {
 	}
 	o.Configure(syncGroupsClient.Client, o.Authorizers.ResourceManager)
 
+	taskAssignmentsClient, err := storagetaskassignments.NewStorageTaskAssignmentsClientWithBaseURI(o.Environment.ResourceManager)
+	if err != nil {
+		return nil, fmt.Errorf("building StorageTaskAssignments client: %+v", err)
+	}
+	o.Configure(taskAssignmentsClient.Client, o.Authorizers.ResourceManager)
+
 	// TODO: switch Storage Containers to using the storage.BlobContainersClient
 	// (which should fix #2977) when the storage clients have been moved in here
 	client := Client{
@@ -89,6 +97,7 @@ func NewClient(o *common.ClientOptions) (*Client, error) {
 		SyncServerEndpointsClient:  syncServerEndpointClient,
 		SyncServiceClient:          syncServiceClient,
 		SyncGroupsClient:           syncGroupsClient,
+		TaskAssignmentsClient:      taskAssignmentsClient,
 
 		StorageDomainSuffix: *storageSuffix,
 	}
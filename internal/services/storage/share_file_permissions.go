@@ -0,0 +1,364 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package storage
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/go-azure-sdk/sdk/client"
+	"github.com/hashicorp/go-azure-sdk/sdk/client/dataplane/storage"
+	"github.com/hashicorp/go-azure-sdk/sdk/odata"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/tombuildsstuff/giovanni/storage/2023-11-03/file/files"
+)
+
+// TODO: move the File Permission operations into Giovanni, which currently hard-codes the permission to `inherit`
+
+const shareFilePermissionKeyHeader = "x-ms-file-permission-key"
+
+type shareFilePermission struct {
+	Permission string `json:"permission"`
+}
+
+type shareFilePermissionOptions struct {
+	permissionKey string
+}
+
+func (o shareFilePermissionOptions) ToHeaders() *client.Headers {
+	if o.permissionKey == "" {
+		return nil
+	}
+	headers := &client.Headers{}
+	headers.Append(shareFilePermissionKeyHeader, o.permissionKey)
+	return headers
+}
+
+func (o shareFilePermissionOptions) ToOData() *odata.Query {
+	return nil
+}
+
+func (o shareFilePermissionOptions) ToQuery() *client.QueryParams {
+	out := &client.QueryParams{}
+	out.Append("restype", "share")
+	out.Append("comp", "filepermission")
+	return out
+}
+
+// createShareFilePermission stores the specified SDDL permission at the Share level, returning the key which
+// can be used to assign it to Directories and Files within the Share
+func createShareFilePermission(ctx context.Context, c *storage.Client, shareName, permission string) (string, error) {
+	opts := client.RequestOptions{
+		ContentType: "application/json; charset=utf-8",
+		ExpectedStatusCodes: []int{
+			http.StatusCreated,
+		},
+		HttpMethod:    http.MethodPut,
+		OptionsObject: shareFilePermissionOptions{},
+		Path:          fmt.Sprintf("/%s", shareName),
+	}
+
+	req, err := c.NewRequest(ctx, opts)
+	if err != nil {
+		return "", fmt.Errorf("building request: %+v", err)
+	}
+
+	if err := req.Marshal(shareFilePermission{Permission: permission}); err != nil {
+		return "", fmt.Errorf("marshalling request: %+v", err)
+	}
+
+	resp, err := req.Execute(ctx)
+	if err != nil {
+		return "", fmt.Errorf("executing request: %+v", err)
+	}
+
+	key := shareFilePermissionKey(resp.Response)
+	if key == "" {
+		return "", fmt.Errorf("`%s` was nil", shareFilePermissionKeyHeader)
+	}
+	return key, nil
+}
+
+type getShareFilePermissionResponse struct {
+	HttpResponse *http.Response
+	Permission   string
+}
+
+// getShareFilePermission retrieves the SDDL permission with the specified key from the Share
+func getShareFilePermission(ctx context.Context, c *storage.Client, shareName, permissionKey string) (result getShareFilePermissionResponse, err error) {
+	opts := client.RequestOptions{
+		ContentType: "application/json; charset=utf-8",
+		ExpectedStatusCodes: []int{
+			http.StatusOK,
+		},
+		HttpMethod: http.MethodGet,
+		OptionsObject: shareFilePermissionOptions{
+			permissionKey: permissionKey,
+		},
+		Path: fmt.Sprintf("/%s", shareName),
+	}
+
+	req, err := c.NewRequest(ctx, opts)
+	if err != nil {
+		err = fmt.Errorf("building request: %+v", err)
+		return
+	}
+
+	resp, err := req.Execute(ctx)
+	if resp != nil {
+		result.HttpResponse = resp.Response
+	}
+	if err != nil {
+		err = fmt.Errorf("executing request: %+v", err)
+		return
+	}
+
+	var model shareFilePermission
+	if err = resp.Unmarshal(&model); err != nil {
+		err = fmt.Errorf("unmarshalling response: %+v", err)
+		return
+	}
+	result.Permission = model.Permission
+	return
+}
+
+// readShareFilePermission returns the permission to set into the state for a Directory or File, which is only
+// retrieved when a permission is configured - the configured permission is retained when the permission
+// assigned in Azure is equivalent to it (since Azure normalises the permission), or when the permission
+// can't be retrieved due to insufficient permissions
+func readShareFilePermission(ctx context.Context, c *storage.Client, shareName string, props *http.Response, configured string) (string, error) {
+	if configured == "" {
+		return "", nil
+	}
+
+	permissionKey := shareFilePermissionKey(props)
+	if permissionKey == "" {
+		return "", nil
+	}
+
+	resp, err := getShareFilePermission(ctx, c, shareName, permissionKey)
+	if err != nil {
+		if resp.HttpResponse != nil && resp.HttpResponse.StatusCode == http.StatusForbidden {
+			log.Printf("[DEBUG] Insufficient permissions to retrieve the File Permission - retaining the existing value")
+			return configured, nil
+		}
+		return "", err
+	}
+
+	if shareFilePermissionsAreEquivalent(configured, resp.Permission) {
+		return configured, nil
+	}
+	return resp.Permission, nil
+}
+
+// shareFilePermissionDiffSuppress suppresses the differences between permissions which Azure considers to be
+// equivalent, for example when Azure appends a SACL (`S:NO_ACCESS_CONTROL`) to the permission
+func shareFilePermissionDiffSuppress(_, old, new string, _ *pluginsdk.ResourceData) bool {
+	if old == "" || new == "" {
+		return false
+	}
+	return shareFilePermissionsAreEquivalent(new, old)
+}
+
+// shareFilePermissionSecurityDescriptor is the owner, primary group and DACL of a Security Descriptor in SDDL format
+type shareFilePermissionSecurityDescriptor struct {
+	owner     string
+	group     string
+	daclFlags string
+	dacl      []string
+}
+
+// parseShareFilePermission parses a Security Descriptor in SDDL format, any SACL is ignored since this isn't
+// managed by Terraform
+func parseShareFilePermission(input string) shareFilePermissionSecurityDescriptor {
+	components := make(map[byte]string)
+
+	input = strings.ToUpper(strings.TrimSpace(input))
+	depth := 0
+	current := byte(0)
+	start := 0
+	for i := 0; i < len(input); i++ {
+		switch input[i] {
+		case '(':
+			depth++
+			continue
+		case ')':
+			depth--
+			continue
+		}
+
+		// each component starts with the component type (`O`, `G`, `D` or `S`) followed by a `:`
+		if depth == 0 && i+1 < len(input) && input[i+1] == ':' && strings.IndexByte("OGDS", input[i]) != -1 {
+			if current != 0 {
+				components[current] = input[start:i]
+			}
+			current = input[i]
+			start = i + 2
+			i++
+		}
+	}
+	if current != 0 {
+		components[current] = input[start:]
+	}
+
+	output := shareFilePermissionSecurityDescriptor{
+		owner: components['O'],
+		group: components['G'],
+		dacl:  make([]string, 0),
+	}
+
+	dacl := components['D']
+	if i := strings.IndexByte(dacl, '('); i != -1 {
+		output.daclFlags = dacl[:i]
+		output.dacl = append(output.dacl, strings.Split(strings.Trim(dacl[i:], "()"), ")(")...)
+	} else {
+		output.daclFlags = dacl
+	}
+
+	// Azure can return the ACEs in canonical order (e.g. Access Denied ACEs first)
+	sort.Strings(output.dacl)
+
+	return output
+}
+
+// shareFilePermissionsAreEquivalent returns whether the permission assigned in Azure has the same owner, primary
+// group and DACL as the configured permission - the owner and primary group are only compared when configured,
+// since Azure assigns a default owner and primary group when these are omitted
+func shareFilePermissionsAreEquivalent(configured, actual string) bool {
+	c := parseShareFilePermission(configured)
+	a := parseShareFilePermission(actual)
+
+	if c.owner != "" && c.owner != a.owner {
+		return false
+	}
+	if c.group != "" && c.group != a.group {
+		return false
+	}
+	if c.daclFlags != a.daclFlags || len(c.dacl) != len(a.dacl) {
+		return false
+	}
+	for i := range c.dacl {
+		if c.dacl[i] != a.dacl[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// shareFilePermissionKey returns the key of the permission assigned to the Directory or File in the response
+func shareFilePermissionKey(resp *http.Response) string {
+	if resp == nil {
+		return ""
+	}
+	return resp.Header.Get(shareFilePermissionKeyHeader)
+}
+
+type shareFileSmbPropertiesOptions struct {
+	restype       string
+	permissionKey string
+
+	// the Content properties of a File are cleared when they're omitted, so the existing values are sent as-is
+	fileProperties *files.GetResponse
+}
+
+func (o shareFileSmbPropertiesOptions) ToHeaders() *client.Headers {
+	headers := &client.Headers{}
+	headers.Append(shareFilePermissionKeyHeader, o.permissionKey)
+	headers.Append("x-ms-file-attributes", "preserve")
+	headers.Append("x-ms-file-creation-time", "preserve")
+	headers.Append("x-ms-file-last-write-time", "preserve")
+
+	if props := o.fileProperties; props != nil {
+		contentProperties := map[string]string{
+			"x-ms-cache-control":       props.CacheControl,
+			"x-ms-content-disposition": props.ContentDisposition,
+			"x-ms-content-encoding":    props.ContentEncoding,
+			"x-ms-content-language":    props.ContentLanguage,
+			"x-ms-content-md5":         props.ContentMD5,
+			"x-ms-content-type":        props.ContentType,
+		}
+		for k, v := range contentProperties {
+			if v != "" {
+				headers.Append(k, v)
+			}
+		}
+	}
+
+	return headers
+}
+
+func (o shareFileSmbPropertiesOptions) ToOData() *odata.Query {
+	return nil
+}
+
+func (o shareFileSmbPropertiesOptions) ToQuery() *client.QueryParams {
+	out := &client.QueryParams{}
+	if o.restype != "" {
+		out.Append("restype", o.restype)
+	}
+	out.Append("comp", "properties")
+	return out
+}
+
+// setShareDirectoryPermission assigns the permission with the specified key to a Directory within the Share
+func setShareDirectoryPermission(ctx context.Context, c *storage.Client, shareName, path, permissionKey string) error {
+	opts := client.RequestOptions{
+		ContentType: "application/xml; charset=utf-8",
+		ExpectedStatusCodes: []int{
+			http.StatusOK,
+		},
+		HttpMethod: http.MethodPut,
+		OptionsObject: shareFileSmbPropertiesOptions{
+			restype:       "directory",
+			permissionKey: permissionKey,
+		},
+		Path: fmt.Sprintf("/%s/%s", shareName, path),
+	}
+
+	return executeShareFileSmbPropertiesRequest(ctx, c, opts)
+}
+
+// setShareFilePermission assigns the permission with the specified key to a File within the Share
+func setShareFilePermission(ctx context.Context, filesClient *files.Client, shareName, path, fileName, permissionKey string) error {
+	props, err := filesClient.GetProperties(ctx, shareName, path, fileName)
+	if err != nil {
+		return fmt.Errorf("retrieving properties: %+v", err)
+	}
+
+	if path != "" {
+		path = fmt.Sprintf("%s/", path)
+	}
+
+	opts := client.RequestOptions{
+		ContentType: "application/xml; charset=utf-8",
+		ExpectedStatusCodes: []int{
+			http.StatusOK,
+		},
+		HttpMethod: http.MethodPut,
+		OptionsObject: shareFileSmbPropertiesOptions{
+			permissionKey:  permissionKey,
+			fileProperties: &props,
+		},
+		Path: fmt.Sprintf("/%s/%s%s", shareName, path, fileName),
+	}
+
+	return executeShareFileSmbPropertiesRequest(ctx, filesClient.Client, opts)
+}
+
+func executeShareFileSmbPropertiesRequest(ctx context.Context, c *storage.Client, opts client.RequestOptions) error {
+	req, err := c.NewRequest(ctx, opts)
+	if err != nil {
+		return fmt.Errorf("building request: %+v", err)
+	}
+
+	if _, err = req.Execute(ctx); err != nil {
+		return fmt.Errorf("executing request: %+v", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,92 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package storage
+
+import (
+	"testing"
+)
+
+func TestShareFilePermissionsAreEquivalent(t *testing.T) {
+	testData := []struct {
+		name       string
+		configured string
+		actual     string
+		expected   bool
+	}{
+		{
+			name:       "identical",
+			configured: "O:BAG:SYD:(A;;FA;;;BA)(A;;FA;;;SY)",
+			actual:     "O:BAG:SYD:(A;;FA;;;BA)(A;;FA;;;SY)",
+			expected:   true,
+		},
+		{
+			name:       "sacl appended",
+			configured: "O:BAG:SYD:(A;;FA;;;BA)(A;;FA;;;SY)",
+			actual:     "O:BAG:SYD:(A;;FA;;;BA)(A;;FA;;;SY)S:NO_ACCESS_CONTROL",
+			expected:   true,
+		},
+		{
+			name:       "casing",
+			configured: "O:BAG:SYD:(A;OICI;0x1200a9;;;BU)",
+			actual:     "O:BAG:SYD:(A;OICI;0x1200A9;;;BU)",
+			expected:   true,
+		},
+		{
+			name:       "aces reordered",
+			configured: "O:BAG:SYD:(A;;FA;;;SY)(D;;FA;;;BG)",
+			actual:     "O:BAG:SYD:(D;;FA;;;BG)(A;;FA;;;SY)",
+			expected:   true,
+		},
+		{
+			name:       "default owner and group",
+			configured: "D:(A;;FA;;;BA)",
+			actual:     "O:S-1-5-21-1-2-3-500G:S-1-5-21-1-2-3-513D:(A;;FA;;;BA)",
+			expected:   true,
+		},
+		{
+			name:       "owner with a two letter alias ending in the component type",
+			configured: "O:BAG:DDD:(A;;FA;;;BA)",
+			actual:     "O:BAG:DDD:(A;;FA;;;BA)",
+			expected:   true,
+		},
+		{
+			name:       "different owner",
+			configured: "O:BAG:SYD:(A;;FA;;;BA)",
+			actual:     "O:SYG:SYD:(A;;FA;;;BA)",
+			expected:   false,
+		},
+		{
+			name:       "different group",
+			configured: "O:BAG:SYD:(A;;FA;;;BA)",
+			actual:     "O:BAG:BAD:(A;;FA;;;BA)",
+			expected:   false,
+		},
+		{
+			name:       "different dacl",
+			configured: "O:BAG:SYD:(A;;FA;;;BA)(A;;FA;;;SY)",
+			actual:     "O:BAG:SYD:(A;;FA;;;BA)(A;;0x1200a9;;;BU)",
+			expected:   false,
+		},
+		{
+			name:       "additional ace",
+			configured: "O:BAG:SYD:(A;;FA;;;BA)",
+			actual:     "O:BAG:SYD:(A;;FA;;;BA)(A;;FA;;;SY)",
+			expected:   false,
+		},
+		{
+			name:       "different dacl flags",
+			configured: "O:BAG:SYD:P(A;;FA;;;BA)",
+			actual:     "O:BAG:SYD:(A;;FA;;;BA)",
+			expected:   false,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q..", v.name)
+
+		if actual := shareFilePermissionsAreEquivalent(v.configured, v.actual); actual != v.expected {
+			t.Fatalf("expected %t but got %t for %q and %q", v.expected, actual, v.configured, v.actual)
+		}
+	}
+}
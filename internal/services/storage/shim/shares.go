@@ -11,12 +11,16 @@ import (
 
 type StorageShareWrapper interface {
 	Create(ctx context.Context, shareName string, input shares.CreateInput) error
+	CreateSnapshot(ctx context.Context, shareName string, metaData map[string]string) (*string, error)
 	Delete(ctx context.Context, shareName string) error
+	DeleteSnapshot(ctx context.Context, shareName, snapshot string) error
 	Exists(ctx context.Context, shareName string) (*bool, error)
 	Get(ctx context.Context, shareName string) (*StorageShareProperties, error)
+	GetSnapshot(ctx context.Context, shareName, snapshot string) (*StorageShareSnapshotProperties, error)
 	UpdateACLs(ctx context.Context, shareName string, input shares.SetAclInput) error
 	UpdateMetaData(ctx context.Context, shareName string, metaData map[string]string) error
 	UpdateQuota(ctx context.Context, shareName string, quotaGB int) error
+	UpdateRootSquash(ctx context.Context, shareName string, rootSquash string) error
 	UpdateTier(ctx context.Context, shareName string, tier shares.AccessTier) error
 }
 
@@ -26,4 +30,9 @@ type StorageShareProperties struct {
 	QuotaGB         int
 	EnabledProtocol shares.ShareProtocol
 	AccessTier      *shares.AccessTier
+	RootSquash      string
+}
+
+type StorageShareSnapshotProperties struct {
+	MetaData map[string]string
 }
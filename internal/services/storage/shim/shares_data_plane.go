@@ -29,6 +29,20 @@ func (w DataPlaneStorageShareWrapper) Create(ctx context.Context, shareName stri
 	return nil
 }
 
+func (w DataPlaneStorageShareWrapper) CreateSnapshot(ctx context.Context, shareName string, metaData map[string]string) (*string, error) {
+	input := shares.CreateSnapshotInput{
+		MetaData: metaData,
+	}
+	resp, err := w.client.CreateSnapshot(ctx, shareName, input)
+	if err != nil {
+		return nil, fmt.Errorf("creating snapshot: %+v", err)
+	}
+	if resp.SnapshotDateTime == "" {
+		return nil, fmt.Errorf("creating snapshot: `x-ms-snapshot` was nil")
+	}
+	return pointer.To(resp.SnapshotDateTime), nil
+}
+
 func (w DataPlaneStorageShareWrapper) Delete(ctx context.Context, shareName string) error {
 	input := shares.DeleteInput{
 		DeleteSnapshots: true,
@@ -37,6 +51,14 @@ func (w DataPlaneStorageShareWrapper) Delete(ctx context.Context, shareName stri
 	return err
 }
 
+func (w DataPlaneStorageShareWrapper) DeleteSnapshot(ctx context.Context, shareName, snapshot string) error {
+	resp, err := w.client.DeleteSnapshot(ctx, "", shareName, snapshot)
+	if err != nil && !response.WasNotFound(resp.HttpResponse) {
+		return err
+	}
+	return nil
+}
+
 func (w DataPlaneStorageShareWrapper) Exists(ctx context.Context, shareName string) (*bool, error) {
 	existing, err := w.client.GetProperties(ctx, shareName)
 	if err != nil {
@@ -58,6 +80,12 @@ func (w DataPlaneStorageShareWrapper) Get(ctx context.Context, shareName string)
 		return nil, err
 	}
 
+	// TODO: expose the Root Squash setting in Giovanni
+	rootSquash := ""
+	if props.HttpResponse != nil {
+		rootSquash = props.HttpResponse.Header.Get("x-ms-root-squash")
+	}
+
 	acls, err := w.client.GetACL(ctx, shareName)
 	if err != nil {
 		return nil, err
@@ -69,6 +97,21 @@ func (w DataPlaneStorageShareWrapper) Get(ctx context.Context, shareName string)
 		ACLs:            acls.SignedIdentifiers,
 		EnabledProtocol: props.EnabledProtocol,
 		AccessTier:      props.AccessTier,
+		RootSquash:      rootSquash,
+	}, nil
+}
+
+func (w DataPlaneStorageShareWrapper) GetSnapshot(ctx context.Context, shareName, snapshot string) (*StorageShareSnapshotProperties, error) {
+	props, err := getShareSnapshot(ctx, w.client, shareName, snapshot)
+	if err != nil {
+		if response.WasNotFound(props.HttpResponse) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &StorageShareSnapshotProperties{
+		MetaData: props.MetaData,
 	}, nil
 }
 
@@ -99,3 +142,7 @@ func (w DataPlaneStorageShareWrapper) UpdateTier(ctx context.Context, shareName
 	_, err := w.client.SetProperties(ctx, shareName, props)
 	return err
 }
+
+func (w DataPlaneStorageShareWrapper) UpdateRootSquash(ctx context.Context, shareName string, rootSquash string) error {
+	return setShareRootSquash(ctx, w.client, shareName, rootSquash)
+}
@@ -0,0 +1,139 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package shim
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/go-azure-sdk/sdk/client"
+	"github.com/hashicorp/go-azure-sdk/sdk/odata"
+	"github.com/tombuildsstuff/giovanni/storage/2023-11-03/file/shares"
+)
+
+// TODO: move the Root Squash and Get Snapshot operations into Giovanni - the latter exists but the
+// `snapshotShare` field on the `GetSnapshotPropertiesInput` isn't exported, so it can't be used
+
+type getShareSnapshotResult struct {
+	HttpResponse *http.Response
+
+	MetaData map[string]string
+}
+
+type shareSnapshotOptions struct {
+	snapshot string
+}
+
+func (o shareSnapshotOptions) ToHeaders() *client.Headers {
+	return nil
+}
+
+func (o shareSnapshotOptions) ToOData() *odata.Query {
+	return nil
+}
+
+func (o shareSnapshotOptions) ToQuery() *client.QueryParams {
+	out := &client.QueryParams{}
+	out.Append("restype", "share")
+	out.Append("sharesnapshot", o.snapshot)
+	return out
+}
+
+// getShareSnapshot retrieves the properties of the specified Snapshot of a Storage Share
+func getShareSnapshot(ctx context.Context, c *shares.Client, shareName, snapshot string) (result getShareSnapshotResult, err error) {
+	opts := client.RequestOptions{
+		ContentType: "application/xml; charset=utf-8",
+		ExpectedStatusCodes: []int{
+			http.StatusOK,
+		},
+		HttpMethod: http.MethodGet,
+		OptionsObject: shareSnapshotOptions{
+			snapshot: snapshot,
+		},
+		Path: fmt.Sprintf("/%s", shareName),
+	}
+
+	req, err := c.Client.NewRequest(ctx, opts)
+	if err != nil {
+		err = fmt.Errorf("building request: %+v", err)
+		return
+	}
+
+	var resp *client.Response
+	resp, err = req.Execute(ctx)
+	if resp != nil && resp.Response != nil {
+		result.HttpResponse = resp.Response
+
+		if err == nil && resp.Header != nil {
+			result.MetaData = parseMetaDataFromHeaders(resp.Header)
+		}
+	}
+	if err != nil {
+		err = fmt.Errorf("executing request: %+v", err)
+		return
+	}
+
+	return
+}
+
+type shareRootSquashOptions struct {
+	rootSquash string
+}
+
+func (o shareRootSquashOptions) ToHeaders() *client.Headers {
+	headers := &client.Headers{}
+	headers.Append("x-ms-root-squash", o.rootSquash)
+	return headers
+}
+
+func (o shareRootSquashOptions) ToOData() *odata.Query {
+	return nil
+}
+
+func (o shareRootSquashOptions) ToQuery() *client.QueryParams {
+	out := &client.QueryParams{}
+	out.Append("restype", "share")
+	out.Append("comp", "properties")
+	return out
+}
+
+// setShareRootSquash updates the Root Squash setting of the specified (NFS) Storage Share
+func setShareRootSquash(ctx context.Context, c *shares.Client, shareName, rootSquash string) error {
+	opts := client.RequestOptions{
+		ContentType: "application/xml; charset=utf-8",
+		ExpectedStatusCodes: []int{
+			http.StatusOK,
+		},
+		HttpMethod: http.MethodPut,
+		OptionsObject: shareRootSquashOptions{
+			rootSquash: rootSquash,
+		},
+		Path: fmt.Sprintf("/%s", shareName),
+	}
+
+	req, err := c.Client.NewRequest(ctx, opts)
+	if err != nil {
+		return fmt.Errorf("building request: %+v", err)
+	}
+
+	if _, err = req.Execute(ctx); err != nil {
+		return fmt.Errorf("executing request: %+v", err)
+	}
+
+	return nil
+}
+
+func parseMetaDataFromHeaders(headers http.Header) map[string]string {
+	output := make(map[string]string)
+	for key, values := range headers {
+		lowered := strings.ToLower(key)
+		if !strings.HasPrefix(lowered, "x-ms-meta-") || len(values) == 0 {
+			continue
+		}
+		output[strings.TrimPrefix(lowered, "x-ms-meta-")] = values[0]
+	}
+	return output
+}
@@ -0,0 +1,436 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package storage
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/storage/2023-05-01/storagetaskassignments"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/storage/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/storage/validate"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/suppress"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+)
+
+type StorageAccountTaskAssignmentResource struct{}
+
+var (
+	_ sdk.ResourceWithUpdate        = StorageAccountTaskAssignmentResource{}
+	_ sdk.ResourceWithCustomizeDiff = StorageAccountTaskAssignmentResource{}
+)
+
+type StorageAccountTaskAssignmentModel struct {
+	Name                string                                 `tfschema:"name"`
+	StorageAccountId    string                                 `tfschema:"storage_account_id"`
+	StorageTaskId       string                                 `tfschema:"storage_task_id"`
+	Description         string                                 `tfschema:"description"`
+	Enabled             bool                                   `tfschema:"enabled"`
+	TargetPrefix        []string                               `tfschema:"target_prefix"`
+	TargetExcludePrefix []string                               `tfschema:"target_exclude_prefix"`
+	Schedule            []StorageAccountTaskAssignmentSchedule `tfschema:"schedule"`
+	ReportPrefix        string                                 `tfschema:"report_prefix"`
+}
+
+type StorageAccountTaskAssignmentSchedule struct {
+	Type      string `tfschema:"type"`
+	StartOn   string `tfschema:"start_on"`
+	StartFrom string `tfschema:"start_from"`
+	EndBy     string `tfschema:"end_by"`
+	Interval  int64  `tfschema:"interval_in_days"`
+}
+
+func (r StorageAccountTaskAssignmentResource) ResourceType() string {
+	return "azurerm_storage_account_task_assignment"
+}
+
+func (r StorageAccountTaskAssignmentResource) IDValidationFunc() pluginsdk.SchemaValidateFunc {
+	return storagetaskassignments.ValidateStorageTaskAssignmentID
+}
+
+func (r StorageAccountTaskAssignmentResource) ModelObject() interface{} {
+	return &StorageAccountTaskAssignmentModel{}
+}
+
+func (r StorageAccountTaskAssignmentResource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"name": {
+			Type:     pluginsdk.TypeString,
+			Required: true,
+			ForceNew: true,
+			ValidateFunc: validation.StringMatch(
+				regexp.MustCompile(`^[a-z0-9]{3,24}$`),
+				"`name` must be between 3 and 24 characters long and can only contain lowercase letters and numbers",
+			),
+		},
+
+		"storage_account_id": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: commonids.ValidateStorageAccountID,
+		},
+
+		// the Storage Task can't be changed once it's been assigned
+		"storage_task_id": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validate.StorageTaskID,
+		},
+
+		"description": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+		},
+
+		"schedule": {
+			Type:     pluginsdk.TypeList,
+			Required: true,
+			MaxItems: 1,
+			Elem: &pluginsdk.Resource{
+				Schema: map[string]*pluginsdk.Schema{
+					"type": {
+						Type:         pluginsdk.TypeString,
+						Required:     true,
+						ValidateFunc: validation.StringInSlice(storagetaskassignments.PossibleValuesForTriggerType(), false),
+					},
+
+					"start_on": {
+						Type:             pluginsdk.TypeString,
+						Optional:         true,
+						ValidateFunc:     validation.IsRFC3339Time,
+						DiffSuppressFunc: suppress.RFC3339Time,
+					},
+
+					"start_from": {
+						Type:             pluginsdk.TypeString,
+						Optional:         true,
+						ValidateFunc:     validation.IsRFC3339Time,
+						DiffSuppressFunc: suppress.RFC3339Time,
+					},
+
+					"end_by": {
+						Type:             pluginsdk.TypeString,
+						Optional:         true,
+						ValidateFunc:     validation.IsRFC3339Time,
+						DiffSuppressFunc: suppress.RFC3339Time,
+					},
+
+					"interval_in_days": {
+						Type:         pluginsdk.TypeInt,
+						Optional:     true,
+						ValidateFunc: validation.IntAtLeast(1),
+					},
+				},
+			},
+		},
+
+		// the path (including the Container) within the Storage Account where the execution reports are written
+		"report_prefix": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+		},
+
+		"enabled": {
+			Type:     pluginsdk.TypeBool,
+			Optional: true,
+			Default:  true,
+		},
+
+		"target_prefix": {
+			Type:     pluginsdk.TypeList,
+			Optional: true,
+			Elem: &pluginsdk.Schema{
+				Type:         pluginsdk.TypeString,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+		},
+
+		"target_exclude_prefix": {
+			Type:     pluginsdk.TypeList,
+			Optional: true,
+			Elem: &pluginsdk.Schema{
+				Type:         pluginsdk.TypeString,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+		},
+	}
+}
+
+func (r StorageAccountTaskAssignmentResource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{}
+}
+
+func (r StorageAccountTaskAssignmentResource) CustomizeDiff() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			var config StorageAccountTaskAssignmentModel
+			if err := metadata.DecodeDiff(&config); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			if len(config.Schedule) == 0 {
+				return nil
+			}
+			schedule := config.Schedule[0]
+
+			switch storagetaskassignments.TriggerType(schedule.Type) {
+			case storagetaskassignments.TriggerTypeRunOnce:
+				if schedule.StartFrom != "" || schedule.EndBy != "" || schedule.Interval != 0 {
+					return fmt.Errorf("`start_from`, `end_by` and `interval_in_days` cannot be specified when the `schedule` `type` is `%s`", storagetaskassignments.TriggerTypeRunOnce)
+				}
+			case storagetaskassignments.TriggerTypeOnSchedule:
+				if schedule.StartOn != "" {
+					return fmt.Errorf("`start_on` cannot be specified when the `schedule` `type` is `%s`", storagetaskassignments.TriggerTypeOnSchedule)
+				}
+				// the start time is commonly computed (e.g. from `timestamp()`), in which case it's unknown at plan time
+				startFromKnown := metadata.ResourceDiff.NewValueKnown("schedule.0.start_from")
+				if (startFromKnown && schedule.StartFrom == "") || schedule.Interval == 0 {
+					return fmt.Errorf("`start_from` and `interval_in_days` must be specified when the `schedule` `type` is `%s`", storagetaskassignments.TriggerTypeOnSchedule)
+				}
+			}
+
+			return nil
+		},
+	}
+}
+
+func (r StorageAccountTaskAssignmentResource) Create() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Storage.TaskAssignmentsClient
+
+			var config StorageAccountTaskAssignmentModel
+			if err := metadata.Decode(&config); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			accountId, err := commonids.ParseStorageAccountID(config.StorageAccountId)
+			if err != nil {
+				return err
+			}
+
+			id := storagetaskassignments.NewStorageTaskAssignmentID(accountId.SubscriptionId, accountId.ResourceGroupName, accountId.StorageAccountName, config.Name)
+
+			existing, err := client.Get(ctx, id)
+			if err != nil {
+				if !response.WasNotFound(existing.HttpResponse) {
+					return fmt.Errorf("checking for the presence of an existing %s: %+v", id, err)
+				}
+			}
+			if !response.WasNotFound(existing.HttpResponse) {
+				return metadata.ResourceRequiresImport(r.ResourceType(), id)
+			}
+
+			payload := storagetaskassignments.StorageTaskAssignment{
+				Properties: storagetaskassignments.StorageTaskAssignmentProperties{
+					Description: config.Description,
+					Enabled:     config.Enabled,
+					ExecutionContext: storagetaskassignments.StorageTaskAssignmentExecutionContext{
+						Target:  r.expandTarget(config),
+						Trigger: r.expandTrigger(config.Schedule),
+					},
+					Report: storagetaskassignments.StorageTaskAssignmentReport{
+						Prefix: config.ReportPrefix,
+					},
+					TaskId: config.StorageTaskId,
+				},
+			}
+
+			if err := client.CreateThenPoll(ctx, id, payload); err != nil {
+				return fmt.Errorf("creating %s: %+v", id, err)
+			}
+
+			metadata.SetID(id)
+			return nil
+		},
+	}
+}
+
+func (r StorageAccountTaskAssignmentResource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Storage.TaskAssignmentsClient
+
+			id, err := storagetaskassignments.ParseStorageTaskAssignmentID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			resp, err := client.Get(ctx, *id)
+			if err != nil {
+				if response.WasNotFound(resp.HttpResponse) {
+					return metadata.MarkAsGone(id)
+				}
+				return fmt.Errorf("retrieving %s: %+v", id, err)
+			}
+
+			state := StorageAccountTaskAssignmentModel{
+				Name:             id.StorageTaskAssignmentName,
+				StorageAccountId: commonids.NewStorageAccountID(id.SubscriptionId, id.ResourceGroupName, id.StorageAccountName).ID(),
+			}
+
+			if model := resp.Model; model != nil {
+				props := model.Properties
+				state.Description = props.Description
+				state.Enabled = props.Enabled
+				state.ReportPrefix = props.Report.Prefix
+
+				taskId, err := parse.StorageTaskIDInsensitively(props.TaskId)
+				if err != nil {
+					return err
+				}
+				state.StorageTaskId = taskId.ID()
+
+				if target := props.ExecutionContext.Target; target != nil {
+					state.TargetPrefix = pointer.From(target.Prefix)
+					state.TargetExcludePrefix = pointer.From(target.ExcludePrefix)
+				}
+				state.Schedule = r.flattenTrigger(props.ExecutionContext.Trigger)
+			}
+
+			return metadata.Encode(&state)
+		},
+	}
+}
+
+func (r StorageAccountTaskAssignmentResource) Update() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Storage.TaskAssignmentsClient
+
+			id, err := storagetaskassignments.ParseStorageTaskAssignmentID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			var config StorageAccountTaskAssignmentModel
+			if err := metadata.Decode(&config); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			props := storagetaskassignments.StorageTaskAssignmentUpdateProperties{}
+
+			if metadata.ResourceData.HasChange("description") {
+				props.Description = pointer.To(config.Description)
+			}
+
+			if metadata.ResourceData.HasChange("enabled") {
+				props.Enabled = pointer.To(config.Enabled)
+			}
+
+			if metadata.ResourceData.HasChanges("schedule", "target_prefix", "target_exclude_prefix") {
+				trigger := r.expandTrigger(config.Schedule)
+				props.ExecutionContext = &storagetaskassignments.StorageTaskAssignmentUpdateExecutionContext{
+					// omitting the Target removes any prefixes, so it's always sent
+					Target: r.expandTarget(config),
+					Trigger: &storagetaskassignments.ExecutionTriggerUpdate{
+						Parameters: &storagetaskassignments.TriggerParametersUpdate{
+							EndBy:        trigger.Parameters.EndBy,
+							Interval:     trigger.Parameters.Interval,
+							IntervalUnit: trigger.Parameters.IntervalUnit,
+							StartFrom:    trigger.Parameters.StartFrom,
+							StartOn:      trigger.Parameters.StartOn,
+						},
+						Type: pointer.To(trigger.Type),
+					},
+				}
+			}
+
+			if metadata.ResourceData.HasChange("report_prefix") {
+				props.Report = &storagetaskassignments.StorageTaskAssignmentUpdateReport{
+					Prefix: pointer.To(config.ReportPrefix),
+				}
+			}
+
+			payload := storagetaskassignments.StorageTaskAssignmentUpdateParameters{
+				Properties: &props,
+			}
+			if err := client.UpdateThenPoll(ctx, *id, payload); err != nil {
+				return fmt.Errorf("updating %s: %+v", id, err)
+			}
+
+			return nil
+		},
+	}
+}
+
+func (r StorageAccountTaskAssignmentResource) Delete() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Storage.TaskAssignmentsClient
+
+			id, err := storagetaskassignments.ParseStorageTaskAssignmentID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			if err := client.DeleteThenPoll(ctx, *id); err != nil {
+				return fmt.Errorf("deleting %s: %+v", id, err)
+			}
+
+			return nil
+		},
+	}
+}
+
+func (r StorageAccountTaskAssignmentResource) expandTarget(input StorageAccountTaskAssignmentModel) *storagetaskassignments.ExecutionTarget {
+	return &storagetaskassignments.ExecutionTarget{
+		ExcludePrefix: pointer.To(input.TargetExcludePrefix),
+		Prefix:        pointer.To(input.TargetPrefix),
+	}
+}
+
+func (r StorageAccountTaskAssignmentResource) expandTrigger(input []StorageAccountTaskAssignmentSchedule) storagetaskassignments.ExecutionTrigger {
+	output := storagetaskassignments.ExecutionTrigger{}
+	if len(input) == 0 {
+		return output
+	}
+
+	schedule := input[0]
+	output.Type = storagetaskassignments.TriggerType(schedule.Type)
+
+	if schedule.StartOn != "" {
+		output.Parameters.StartOn = pointer.To(schedule.StartOn)
+	}
+	if schedule.StartFrom != "" {
+		output.Parameters.StartFrom = pointer.To(schedule.StartFrom)
+	}
+	if schedule.EndBy != "" {
+		output.Parameters.EndBy = pointer.To(schedule.EndBy)
+	}
+	if schedule.Interval != 0 {
+		output.Parameters.Interval = pointer.To(schedule.Interval)
+		output.Parameters.IntervalUnit = pointer.To(storagetaskassignments.IntervalUnitDays)
+	}
+
+	return output
+}
+
+func (r StorageAccountTaskAssignmentResource) flattenTrigger(input storagetaskassignments.ExecutionTrigger) []StorageAccountTaskAssignmentSchedule {
+	return []StorageAccountTaskAssignmentSchedule{
+		{
+			Type:      string(input.Type),
+			StartOn:   pointer.From(input.Parameters.StartOn),
+			StartFrom: pointer.From(input.Parameters.StartFrom),
+			EndBy:     pointer.From(input.Parameters.EndBy),
+			Interval:  pointer.From(input.Parameters.Interval),
+		},
+	}
+}
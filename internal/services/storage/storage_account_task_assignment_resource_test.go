@@ -0,0 +1,313 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package storage_test
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/storage/2023-05-01/storagetaskassignments"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/check"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+)
+
+type StorageAccountTaskAssignmentResource struct{}
+
+func TestAccStorageAccountTaskAssignment_runOnce(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_storage_account_task_assignment", "test")
+	r := StorageAccountTaskAssignmentResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.runOnce(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccStorageAccountTaskAssignment_requiresImport(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_storage_account_task_assignment", "test")
+	r := StorageAccountTaskAssignmentResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.runOnce(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.RequiresImportErrorStep(r.requiresImport),
+	})
+}
+
+func TestAccStorageAccountTaskAssignment_onSchedule(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_storage_account_task_assignment", "test")
+	r := StorageAccountTaskAssignmentResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.onSchedule(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccStorageAccountTaskAssignment_update(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_storage_account_task_assignment", "test")
+	r := StorageAccountTaskAssignmentResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.onSchedule(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.onScheduleUpdated(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccStorageAccountTaskAssignment_invalidSchedule(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_storage_account_task_assignment", "test")
+	r := StorageAccountTaskAssignmentResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config:      r.invalidSchedule(data),
+			ExpectError: regexp.MustCompile("`start_on` cannot be specified when the `schedule` `type` is `OnSchedule`"),
+		},
+	})
+}
+
+func (r StorageAccountTaskAssignmentResource) Exists(ctx context.Context, clients *clients.Client, state *pluginsdk.InstanceState) (*bool, error) {
+	id, err := storagetaskassignments.ParseStorageTaskAssignmentID(state.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := clients.Storage.TaskAssignmentsClient.Get(ctx, *id)
+	if err != nil {
+		if response.WasNotFound(resp.HttpResponse) {
+			return utils.Bool(false), nil
+		}
+		return nil, fmt.Errorf("retrieving %s: %+v", id, err)
+	}
+
+	return utils.Bool(true), nil
+}
+
+func (r StorageAccountTaskAssignmentResource) runOnce(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_storage_account_task_assignment" "test" {
+  name               = "acctestsata%s"
+  storage_account_id = azurerm_storage_account.test.id
+  storage_task_id    = local.storage_task.id.value
+  description        = "acctest"
+  report_prefix      = "${azurerm_storage_container.test.name}/reports"
+
+  schedule {
+    type     = "RunOnce"
+    start_on = "%s"
+  }
+
+  depends_on = [azurerm_role_assignment.test]
+}
+`, r.template(data), data.RandomString, r.startTime())
+}
+
+func (r StorageAccountTaskAssignmentResource) requiresImport(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_storage_account_task_assignment" "import" {
+  name               = azurerm_storage_account_task_assignment.test.name
+  storage_account_id = azurerm_storage_account_task_assignment.test.storage_account_id
+  storage_task_id    = azurerm_storage_account_task_assignment.test.storage_task_id
+  description        = azurerm_storage_account_task_assignment.test.description
+  report_prefix      = azurerm_storage_account_task_assignment.test.report_prefix
+
+  schedule {
+    type     = "RunOnce"
+    start_on = azurerm_storage_account_task_assignment.test.schedule.0.start_on
+  }
+}
+`, r.runOnce(data))
+}
+
+func (r StorageAccountTaskAssignmentResource) onSchedule(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_storage_account_task_assignment" "test" {
+  name               = "acctestsata%s"
+  storage_account_id = azurerm_storage_account.test.id
+  storage_task_id    = local.storage_task.id.value
+  description        = "acctest"
+  report_prefix      = "${azurerm_storage_container.test.name}/reports"
+  target_prefix      = ["${azurerm_storage_container.test.name}/logs"]
+
+  schedule {
+    type             = "OnSchedule"
+    start_from       = "%s"
+    interval_in_days = 7
+  }
+
+  depends_on = [azurerm_role_assignment.test]
+}
+`, r.template(data), data.RandomString, r.startTime())
+}
+
+func (r StorageAccountTaskAssignmentResource) onScheduleUpdated(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_storage_account_task_assignment" "test" {
+  name                  = "acctestsata%s"
+  storage_account_id    = azurerm_storage_account.test.id
+  storage_task_id       = local.storage_task.id.value
+  description           = "acctest updated"
+  enabled               = false
+  report_prefix         = "${azurerm_storage_container.test.name}/other-reports"
+  target_prefix         = ["${azurerm_storage_container.test.name}/logs", "${azurerm_storage_container.test.name}/audit"]
+  target_exclude_prefix = ["${azurerm_storage_container.test.name}/logs/keep"]
+
+  schedule {
+    type             = "OnSchedule"
+    start_from       = "%s"
+    end_by           = "%s"
+    interval_in_days = 14
+  }
+
+  depends_on = [azurerm_role_assignment.test]
+}
+`, r.template(data), data.RandomString, r.startTime(), time.Now().UTC().Add(90*24*time.Hour).Format(time.RFC3339))
+}
+
+func (r StorageAccountTaskAssignmentResource) invalidSchedule(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_storage_account_task_assignment" "test" {
+  name               = "acctestsata%s"
+  storage_account_id = azurerm_storage_account.test.id
+  storage_task_id    = local.storage_task.id.value
+  description        = "acctest"
+  report_prefix      = "${azurerm_storage_container.test.name}/reports"
+
+  schedule {
+    type             = "OnSchedule"
+    start_on         = "%[3]s"
+    start_from       = "%[3]s"
+    interval_in_days = 7
+  }
+}
+`, r.template(data), data.RandomString, r.startTime())
+}
+
+func (r StorageAccountTaskAssignmentResource) startTime() string {
+	return time.Now().UTC().Add(time.Hour).Truncate(time.Hour).Format(time.RFC3339)
+}
+
+func (r StorageAccountTaskAssignmentResource) template(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-storage-%[1]d"
+  location = "%[2]s"
+}
+
+resource "azurerm_storage_account" "test" {
+  name                     = "acctestsa%[3]s"
+  resource_group_name      = azurerm_resource_group.test.name
+  location                 = azurerm_resource_group.test.location
+  account_tier             = "Standard"
+  account_replication_type = "LRS"
+}
+
+resource "azurerm_storage_container" "test" {
+  name                 = "acctestcontainer"
+  storage_account_name = azurerm_storage_account.test.name
+}
+
+resource "azurerm_resource_group_template_deployment" "test" {
+  name                = "acctestdeploy-%[1]d"
+  resource_group_name = azurerm_resource_group.test.name
+  deployment_mode     = "Incremental"
+
+  template_content = jsonencode({
+    "$schema"      = "https://schema.management.azure.com/schemas/2019-04-01/deploymentTemplate.json#"
+    contentVersion = "1.0.0.0"
+    resources = [{
+      type       = "Microsoft.StorageActions/storageTasks"
+      apiVersion = "2023-01-01"
+      name       = "acctestst%[3]s"
+      location   = azurerm_resource_group.test.location
+      identity = {
+        type = "SystemAssigned"
+      }
+      properties = {
+        description = "acctest"
+        enabled     = true
+        action = {
+          if = {
+            condition = "[[[endsWith(Name, '.log')]]"
+            operations = [{
+              name       = "SetBlobTier"
+              parameters = { tier = "Cool" }
+              onSuccess  = "continue"
+              onFailure  = "break"
+            }]
+          }
+        }
+      }
+    }]
+    outputs = {
+      id = {
+        type  = "string"
+        value = "[resourceId('Microsoft.StorageActions/storageTasks', 'acctestst%[3]s')]"
+      }
+      principalId = {
+        type  = "string"
+        value = "[reference(resourceId('Microsoft.StorageActions/storageTasks', 'acctestst%[3]s'), '2023-01-01', 'full').identity.principalId]"
+      }
+    }
+  })
+}
+
+locals {
+  storage_task = jsondecode(azurerm_resource_group_template_deployment.test.output_content)
+}
+
+resource "azurerm_role_assignment" "test" {
+  scope                = azurerm_storage_account.test.id
+  role_definition_name = "Storage Blob Data Owner"
+  principal_id         = local.storage_task.principalId.value
+}
+`, data.RandomInteger, data.Locations.Primary, data.RandomString)
+}
@@ -0,0 +1,620 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"mime"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/storage/validate"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+	"github.com/tombuildsstuff/giovanni/storage/2023-11-03/blob/blobs"
+	"github.com/tombuildsstuff/giovanni/storage/2023-11-03/blob/containers"
+)
+
+type StorageBlobDirectorySyncResource struct{}
+
+var (
+	_ sdk.ResourceWithUpdate        = StorageBlobDirectorySyncResource{}
+	_ sdk.ResourceWithCustomizeDiff = StorageBlobDirectorySyncResource{}
+)
+
+type StorageBlobDirectorySyncModel struct {
+	StorageContainerId string            `tfschema:"storage_container_id"`
+	SourceDirectory    string            `tfschema:"source_directory"`
+	Prefix             string            `tfschema:"prefix"`
+	CacheControl       string            `tfschema:"cache_control"`
+	ContentTypes       map[string]string `tfschema:"content_types"`
+	Parallelism        int64             `tfschema:"parallelism"`
+	Files              map[string]string `tfschema:"files"`
+}
+
+func (r StorageBlobDirectorySyncResource) ResourceType() string {
+	return "azurerm_storage_blob_directory_sync"
+}
+
+func (r StorageBlobDirectorySyncResource) IDValidationFunc() pluginsdk.SchemaValidateFunc {
+	return validate.StorageBlobDirectorySyncID
+}
+
+func (r StorageBlobDirectorySyncResource) ModelObject() interface{} {
+	return &StorageBlobDirectorySyncModel{}
+}
+
+func (r StorageBlobDirectorySyncResource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"storage_container_id": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validate.StorageContainerDataPlaneID,
+		},
+
+		"source_directory": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+		},
+
+		"prefix": {
+			Type:         pluginsdk.TypeString,
+			Optional:     true,
+			ForceNew:     true,
+			ValidateFunc: validate.StorageBlobDirectorySyncPrefix,
+		},
+
+		"cache_control": {
+			Type:     pluginsdk.TypeString,
+			Optional: true,
+		},
+
+		"content_types": {
+			Type:         pluginsdk.TypeMap,
+			Optional:     true,
+			ValidateFunc: validate.StorageBlobDirectorySyncContentTypes,
+			Elem: &pluginsdk.Schema{
+				Type: pluginsdk.TypeString,
+			},
+		},
+
+		"parallelism": {
+			Type:         pluginsdk.TypeInt,
+			Optional:     true,
+			Default:      8,
+			ValidateFunc: validation.IntBetween(1, 64),
+		},
+	}
+}
+
+func (r StorageBlobDirectorySyncResource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"files": {
+			Type:     pluginsdk.TypeMap,
+			Computed: true,
+			Elem: &pluginsdk.Schema{
+				Type: pluginsdk.TypeString,
+			},
+		},
+	}
+}
+
+func (r StorageBlobDirectorySyncResource) CustomizeDiff() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			diff := metadata.ResourceDiff
+
+			if !diff.NewValueKnown("source_directory") || !diff.NewValueKnown("prefix") {
+				return diff.SetNewComputed("files")
+			}
+
+			// the `source_directory` can be populated by another Resource (or a provisioner) during the apply
+			if _, err := os.Stat(diff.Get("source_directory").(string)); errors.Is(err, fs.ErrNotExist) {
+				return diff.SetNewComputed("files")
+			}
+
+			files, err := blobDirectorySyncLocalFiles(diff.Get("source_directory").(string), diff.Get("prefix").(string))
+			if err != nil {
+				return err
+			}
+
+			existing := diff.Get("files").(map[string]interface{})
+			changed := len(existing) != len(files)
+			for name, contentMD5 := range files {
+				if existing[name] != contentMD5 {
+					changed = true
+					break
+				}
+			}
+			if !changed {
+				return nil
+			}
+
+			return diff.SetNew("files", files)
+		},
+	}
+}
+
+func (r StorageBlobDirectorySyncResource) Create() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 60 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			storageClient := metadata.Client.Storage
+
+			var config StorageBlobDirectorySyncModel
+			if err := metadata.Decode(&config); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			containerId, err := containers.ParseContainerID(config.StorageContainerId, storageClient.StorageDomainSuffix)
+			if err != nil {
+				return err
+			}
+			id := newBlobDirectorySyncId(*containerId, config.Prefix)
+
+			blobsClient, err := r.blobsClient(ctx, metadata, id)
+			if err != nil {
+				return err
+			}
+
+			files, err := blobDirectorySyncLocalFiles(config.SourceDirectory, config.Prefix)
+			if err != nil {
+				return err
+			}
+
+			syncer := blobDirectorySync{
+				client:        blobsClient,
+				id:            id,
+				config:        config,
+				localFiles:    files,
+				existingFiles: map[string]string{},
+			}
+
+			// any Blobs within the prefix which aren't uploaded from `source_directory` are left as-is, however
+			// Blobs which would be overwritten by the files within `source_directory` need to be imported
+			existing, err := syncer.existingBlobs(ctx)
+			if err != nil {
+				return fmt.Errorf("checking for existing Blobs for %s: %+v", id, err)
+			}
+			if len(existing) > 0 {
+				log.Printf("[DEBUG] Found %d existing Blobs for %s: %s", len(existing), id, strings.Join(existing, ", "))
+				return metadata.ResourceRequiresImport(r.ResourceType(), id)
+			}
+
+			if err := syncer.apply(ctx, true); err != nil {
+				return fmt.Errorf("creating %s: %+v", id, err)
+			}
+
+			metadata.SetID(id)
+			config.Files = files
+			return metadata.Encode(&config)
+		},
+	}
+}
+
+func (r StorageBlobDirectorySyncResource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			storageClient := metadata.Client.Storage
+
+			id, err := parseBlobDirectorySyncId(metadata.ResourceData.Id(), storageClient.StorageDomainSuffix)
+			if err != nil {
+				return err
+			}
+
+			var state StorageBlobDirectorySyncModel
+			if err := metadata.Decode(&state); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			account, err := storageClient.FindAccount(ctx, metadata.Client.Account.SubscriptionId, id.ContainerId.AccountId.AccountName)
+			if err != nil {
+				return fmt.Errorf("retrieving Account %q for %s: %v", id.ContainerId.AccountId.AccountName, id, err)
+			}
+			if account == nil {
+				log.Printf("[DEBUG] Unable to locate Account %q for %s - assuming removed & removing from state!", id.ContainerId.AccountId.AccountName, id)
+				return metadata.MarkAsGone(id)
+			}
+
+			blobsClient, err := storageClient.BlobsDataPlaneClient(ctx, *account, storageClient.DataPlaneOperationSupportingAnyAuthMethod())
+			if err != nil {
+				return fmt.Errorf("building Blobs Client: %v", err)
+			}
+
+			// only the Blobs previously uploaded by this resource are checked, any other Blobs within the prefix are
+			// left as-is - Blobs which no longer exist (or whose content has changed) are uploaded during the next apply
+			files := make(map[string]string)
+			for name, contentMD5 := range state.Files {
+				props, err := blobsClient.GetProperties(ctx, id.ContainerId.ContainerName, name, blobs.GetPropertiesInput{})
+				if err != nil {
+					if response.WasNotFound(props.HttpResponse) {
+						log.Printf("[DEBUG] Blob %q was not found in %s - removing from state", name, id)
+						continue
+					}
+					return fmt.Errorf("retrieving properties for Blob %q in %s: %v", name, id, err)
+				}
+
+				if props.ContentMD5 != "" {
+					if contentMD5, err = convertBase64ToHexEncoding(props.ContentMD5); err != nil {
+						return fmt.Errorf("converting the Content MD5 of Blob %q: %v", name, err)
+					}
+				}
+				files[name] = contentMD5
+			}
+
+			state.StorageContainerId = id.ContainerId.ID()
+			state.Prefix = id.Prefix
+			state.Files = files
+			return metadata.Encode(&state)
+		},
+	}
+}
+
+func (r StorageBlobDirectorySyncResource) Update() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 60 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			storageClient := metadata.Client.Storage
+
+			id, err := parseBlobDirectorySyncId(metadata.ResourceData.Id(), storageClient.StorageDomainSuffix)
+			if err != nil {
+				return err
+			}
+
+			var config StorageBlobDirectorySyncModel
+			if err := metadata.Decode(&config); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			blobsClient, err := r.blobsClient(ctx, metadata, *id)
+			if err != nil {
+				return err
+			}
+
+			files, err := blobDirectorySyncLocalFiles(config.SourceDirectory, config.Prefix)
+			if err != nil {
+				return err
+			}
+
+			existing := make(map[string]string)
+			old, _ := metadata.ResourceData.GetChange("files")
+			for k, v := range old.(map[string]interface{}) {
+				existing[k] = v.(string)
+			}
+
+			// the properties of every Blob need updating when these change, so all files are re-uploaded
+			uploadAll := metadata.ResourceData.HasChanges("cache_control", "content_types")
+
+			syncer := blobDirectorySync{
+				client:        blobsClient,
+				id:            *id,
+				config:        config,
+				localFiles:    files,
+				existingFiles: existing,
+			}
+			if err := syncer.apply(ctx, uploadAll); err != nil {
+				return fmt.Errorf("updating %s: %+v", id, err)
+			}
+
+			config.Files = files
+			return metadata.Encode(&config)
+		},
+	}
+}
+
+func (r StorageBlobDirectorySyncResource) Delete() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 60 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			storageClient := metadata.Client.Storage
+
+			id, err := parseBlobDirectorySyncId(metadata.ResourceData.Id(), storageClient.StorageDomainSuffix)
+			if err != nil {
+				return err
+			}
+
+			var state StorageBlobDirectorySyncModel
+			if err := metadata.Decode(&state); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			blobsClient, err := r.blobsClient(ctx, metadata, *id)
+			if err != nil {
+				return err
+			}
+
+			syncer := blobDirectorySync{
+				client:        blobsClient,
+				id:            *id,
+				config:        state,
+				localFiles:    map[string]string{},
+				existingFiles: state.Files,
+			}
+			if err := syncer.apply(ctx, false); err != nil {
+				return fmt.Errorf("deleting %s: %+v", id, err)
+			}
+
+			return nil
+		},
+	}
+}
+
+func (r StorageBlobDirectorySyncResource) blobsClient(ctx context.Context, metadata sdk.ResourceMetaData, id blobDirectorySyncId) (*blobs.Client, error) {
+	storageClient := metadata.Client.Storage
+
+	account, err := storageClient.FindAccount(ctx, metadata.Client.Account.SubscriptionId, id.ContainerId.AccountId.AccountName)
+	if err != nil {
+		return nil, fmt.Errorf("retrieving Account %q for %s: %v", id.ContainerId.AccountId.AccountName, id, err)
+	}
+	if account == nil {
+		return nil, fmt.Errorf("locating Storage Account %q", id.ContainerId.AccountId.AccountName)
+	}
+
+	blobsClient, err := storageClient.BlobsDataPlaneClient(ctx, *account, storageClient.DataPlaneOperationSupportingAnyAuthMethod())
+	if err != nil {
+		return nil, fmt.Errorf("building Blobs Client: %v", err)
+	}
+
+	return blobsClient, nil
+}
+
+// blobDirectorySyncId is the ID of a Storage Blob Directory Sync, which is the ID of the Storage Container
+// when no prefix is specified, else the ID of the Storage Container suffixed with the prefix
+type blobDirectorySyncId struct {
+	ContainerId containers.ContainerId
+	Prefix      string
+}
+
+func newBlobDirectorySyncId(containerId containers.ContainerId, prefix string) blobDirectorySyncId {
+	return blobDirectorySyncId{
+		ContainerId: containerId,
+		Prefix:      prefix,
+	}
+}
+
+func (id blobDirectorySyncId) ID() string {
+	if id.Prefix == "" {
+		return id.ContainerId.ID()
+	}
+	return fmt.Sprintf("%s/%s", id.ContainerId.ID(), id.Prefix)
+}
+
+func (id blobDirectorySyncId) String() string {
+	return fmt.Sprintf("Storage Blob Directory Sync (Prefix %q / %s)", id.Prefix, id.ContainerId.String())
+}
+
+func parseBlobDirectorySyncId(input, domainSuffix string) (*blobDirectorySyncId, error) {
+	if containerId, err := containers.ParseContainerID(input, domainSuffix); err == nil {
+		return pointer.To(newBlobDirectorySyncId(*containerId, "")), nil
+	}
+
+	blobId, err := blobs.ParseBlobID(input, domainSuffix)
+	if err != nil {
+		return nil, fmt.Errorf("parsing %q as a Storage Blob Directory Sync ID: %+v", input, err)
+	}
+
+	containerId := containers.NewContainerID(blobId.AccountId, blobId.ContainerName)
+	return pointer.To(newBlobDirectorySyncId(containerId, blobId.BlobName)), nil
+}
+
+// blobDirectorySyncLocalFiles returns the hex-encoded MD5 of each file within sourceDirectory, keyed by
+// the name of the Blob which the file is uploaded to
+func blobDirectorySyncLocalFiles(sourceDirectory, prefix string) (map[string]string, error) {
+	output := make(map[string]string)
+
+	err := filepath.WalkDir(sourceDirectory, func(filePath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			return nil
+		}
+
+		relativePath, err := filepath.Rel(sourceDirectory, filePath)
+		if err != nil {
+			return err
+		}
+
+		contentMD5, err := fileMD5Hex(filePath)
+		if err != nil {
+			return err
+		}
+
+		output[blobDirectorySyncBlobName(prefix, relativePath)] = contentMD5
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("reading the files within `source_directory` %q: %+v", sourceDirectory, err)
+	}
+
+	return output, nil
+}
+
+// blobDirectorySyncBlobName returns the name of the Blob which the file at relativePath is uploaded to
+func blobDirectorySyncBlobName(prefix, relativePath string) string {
+	return path.Join(prefix, filepath.ToSlash(relativePath))
+}
+
+// blobDirectorySyncContentType returns the Content Type for the Blob name, using any overrides specified in
+// `content_types` before falling back to the Content Type registered for the file extension
+func blobDirectorySyncContentType(name string, overrides map[string]string) string {
+	extension := strings.ToLower(path.Ext(name))
+	for k, v := range overrides {
+		if strings.EqualFold(k, extension) {
+			return v
+		}
+	}
+
+	if v := mime.TypeByExtension(extension); v != "" {
+		return v
+	}
+
+	return "application/octet-stream"
+}
+
+type blobDirectorySync struct {
+	client *blobs.Client
+	id     blobDirectorySyncId
+	config StorageBlobDirectorySyncModel
+
+	// localFiles and existingFiles are the hex-encoded MD5 of each file, keyed by the name of the Blob
+	localFiles    map[string]string
+	existingFiles map[string]string
+}
+
+// apply uploads any new or changed files (or all files when uploadAll is true) and then deletes the
+// Blobs for any files which no longer exist locally
+func (s blobDirectorySync) apply(ctx context.Context, uploadAll bool) error {
+	uploads := make([]string, 0)
+	for name, contentMD5 := range s.localFiles {
+		if existing, ok := s.existingFiles[name]; uploadAll || !ok || existing != contentMD5 {
+			uploads = append(uploads, name)
+		}
+	}
+	deletions := make([]string, 0)
+	for name := range s.existingFiles {
+		if _, ok := s.localFiles[name]; !ok {
+			deletions = append(deletions, name)
+		}
+	}
+	sort.Strings(uploads)
+	sort.Strings(deletions)
+
+	log.Printf("[DEBUG] Uploading %d and deleting %d Blobs for %s..", len(uploads), len(deletions), s.id)
+	if err := s.forEach(ctx, uploads, s.upload); err != nil {
+		return err
+	}
+	return s.forEach(ctx, deletions, s.delete)
+}
+
+// forEach runs action for each of the Blob names, using up to `parallelism` concurrent workers
+func (s blobDirectorySync) forEach(ctx context.Context, names []string, action func(ctx context.Context, name string) error) error {
+	workerCount := int(s.config.Parallelism)
+	if workerCount < 1 {
+		workerCount = 1
+	}
+
+	queue := make(chan string, len(names))
+	for _, name := range names {
+		queue <- name
+	}
+	close(queue)
+
+	errors := make(chan error, len(names))
+	wg := &sync.WaitGroup{}
+	for i := 0; i < workerCount; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for name := range queue {
+				if err := action(ctx, name); err != nil {
+					errors <- err
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	close(errors)
+
+	if err, ok := <-errors; ok {
+		return err
+	}
+	return nil
+}
+
+// existingBlobs returns the names of the Blobs for the local files which already exist
+func (s blobDirectorySync) existingBlobs(ctx context.Context) ([]string, error) {
+	names := make([]string, 0)
+	for name := range s.localFiles {
+		names = append(names, name)
+	}
+
+	existing := make([]string, 0)
+	lock := &sync.Mutex{}
+	err := s.forEach(ctx, names, func(ctx context.Context, name string) error {
+		resp, err := s.client.GetProperties(ctx, s.id.ContainerId.ContainerName, name, blobs.GetPropertiesInput{})
+		if err != nil {
+			if response.WasNotFound(resp.HttpResponse) {
+				return nil
+			}
+			return fmt.Errorf("retrieving properties for Blob %q: %+v", name, err)
+		}
+
+		lock.Lock()
+		defer lock.Unlock()
+		existing = append(existing, name)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Strings(existing)
+	return existing, nil
+}
+
+func (s blobDirectorySync) upload(ctx context.Context, name string) error {
+	relativePath := strings.TrimPrefix(strings.TrimPrefix(name, s.config.Prefix), "/")
+
+	contentMD5, err := convertHexToBase64Encoding(s.localFiles[name])
+	if err != nil {
+		return fmt.Errorf("encoding the Content MD5 for Blob %q: %+v", name, err)
+	}
+
+	input := BlobUpload{
+		AccountName:   s.id.ContainerId.AccountId.AccountName,
+		ContainerName: s.id.ContainerId.ContainerName,
+		BlobName:      name,
+		Client:        s.client,
+
+		BlobType:     "Block",
+		CacheControl: s.config.CacheControl,
+		ContentType:  blobDirectorySyncContentType(name, s.config.ContentTypes),
+		ContentMD5:   contentMD5,
+		MetaData:     map[string]string{},
+		Parallelism:  1,
+		Source:       filepath.Join(s.config.SourceDirectory, filepath.FromSlash(relativePath)),
+	}
+	if err := input.Create(ctx); err != nil {
+		return fmt.Errorf("uploading Blob %q: %+v", name, err)
+	}
+
+	if s.config.CacheControl != "" {
+		// the Cache Control isn't set when uploading a Block Blob, so this is set separately
+		props := blobs.SetPropertiesInput{
+			CacheControl: pointer.To(s.config.CacheControl),
+			ContentMD5:   pointer.To(contentMD5),
+			ContentType:  pointer.To(input.ContentType),
+		}
+		if _, err := s.client.SetProperties(ctx, s.id.ContainerId.ContainerName, name, props); err != nil {
+			return fmt.Errorf("updating Properties for Blob %q: %+v", name, err)
+		}
+	}
+
+	return nil
+}
+
+func (s blobDirectorySync) delete(ctx context.Context, name string) error {
+	resp, err := s.client.Delete(ctx, s.id.ContainerId.ContainerName, name, blobs.DeleteInput{DeleteSnapshots: true})
+	if err != nil && !response.WasNotFound(resp.HttpResponse) {
+		return fmt.Errorf("deleting Blob %q: %+v", name, err)
+	}
+	return nil
+}
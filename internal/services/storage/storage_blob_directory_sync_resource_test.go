@@ -0,0 +1,243 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package storage_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/check"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+	"github.com/tombuildsstuff/giovanni/storage/2023-11-03/blob/blobs"
+	"github.com/tombuildsstuff/giovanni/storage/2023-11-03/blob/containers"
+)
+
+type StorageBlobDirectorySyncResource struct{}
+
+func TestAccStorageBlobDirectorySync_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_storage_blob_directory_sync", "test")
+	r := StorageBlobDirectorySyncResource{}
+	sourceDirectory := r.sourceDirectory(t)
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data, sourceDirectory),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("files.%").HasValue("3"),
+			),
+		},
+		data.ImportStep("source_directory", "files"),
+	})
+}
+
+func TestAccStorageBlobDirectorySync_requiresImport(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_storage_blob_directory_sync", "test")
+	r := StorageBlobDirectorySyncResource{}
+	sourceDirectory := r.sourceDirectory(t)
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data, sourceDirectory),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.RequiresImportErrorStep(func(data acceptance.TestData) string {
+			return r.requiresImport(data, sourceDirectory)
+		}),
+	})
+}
+
+func TestAccStorageBlobDirectorySync_complete(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_storage_blob_directory_sync", "test")
+	r := StorageBlobDirectorySyncResource{}
+	sourceDirectory := r.sourceDirectory(t)
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.complete(data, sourceDirectory),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("files.%").HasValue("3"),
+				check.That(data.ResourceName).Key("files.site/index.html").Exists(),
+				check.That(data.ResourceName).Key("files.site/assets/app.js").Exists(),
+			),
+		},
+		data.ImportStep("source_directory", "files", "cache_control", "content_types", "parallelism"),
+	})
+}
+
+func TestAccStorageBlobDirectorySync_update(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_storage_blob_directory_sync", "test")
+	r := StorageBlobDirectorySyncResource{}
+	sourceDirectory := r.sourceDirectory(t)
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data, sourceDirectory),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("files.%").HasValue("3"),
+			),
+		},
+		{
+			PreConfig: func() {
+				// modify one file, remove another and add a third
+				r.writeFile(t, sourceDirectory, "index.html", "<html><body>updated</body></html>")
+				if err := os.Remove(filepath.Join(sourceDirectory, "robots.txt")); err != nil {
+					t.Fatalf("removing `robots.txt`: %+v", err)
+				}
+				r.writeFile(t, sourceDirectory, "assets/app.css", "body { color: red; }")
+			},
+			Config: r.basic(data, sourceDirectory),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("files.%").HasValue("3"),
+				check.That(data.ResourceName).Key("files.assets/app.css").Exists(),
+				check.That(data.ResourceName).Key("files.robots.txt").DoesNotExist(),
+			),
+		},
+		{
+			Config: r.complete(data, sourceDirectory),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+	})
+}
+
+func (r StorageBlobDirectorySyncResource) Exists(ctx context.Context, client *clients.Client, state *pluginsdk.InstanceState) (*bool, error) {
+	containerId, err := containers.ParseContainerID(state.Attributes["storage_container_id"], client.Storage.StorageDomainSuffix)
+	if err != nil {
+		return nil, err
+	}
+
+	account, err := client.Storage.FindAccount(ctx, client.Account.SubscriptionId, containerId.AccountId.AccountName)
+	if err != nil {
+		return nil, fmt.Errorf("retrieving Account %q for %s: %+v", containerId.AccountId.AccountName, containerId, err)
+	}
+	if account == nil {
+		return nil, fmt.Errorf("unable to locate Storage Account %q", containerId.AccountId.AccountName)
+	}
+
+	blobsClient, err := client.Storage.BlobsDataPlaneClient(ctx, *account, client.Storage.DataPlaneOperationSupportingAnyAuthMethod())
+	if err != nil {
+		return nil, fmt.Errorf("building Blobs Client: %+v", err)
+	}
+
+	for key := range state.Attributes {
+		name, ok := strings.CutPrefix(key, "files.")
+		if !ok || name == "%" {
+			continue
+		}
+
+		resp, err := blobsClient.GetProperties(ctx, containerId.ContainerName, name, blobs.GetPropertiesInput{})
+		if err != nil {
+			if response.WasNotFound(resp.HttpResponse) {
+				return utils.Bool(false), nil
+			}
+			return nil, fmt.Errorf("retrieving Blob %q (%s): %+v", name, containerId, err)
+		}
+	}
+
+	return utils.Bool(true), nil
+}
+
+func (r StorageBlobDirectorySyncResource) sourceDirectory(t *testing.T) string {
+	sourceDirectory := t.TempDir()
+	r.writeFile(t, sourceDirectory, "index.html", "<html><body>hello world</body></html>")
+	r.writeFile(t, sourceDirectory, "robots.txt", "User-agent: *")
+	r.writeFile(t, sourceDirectory, "assets/app.js", "console.log('hello world');")
+	return sourceDirectory
+}
+
+func (r StorageBlobDirectorySyncResource) writeFile(t *testing.T, sourceDirectory, name, content string) {
+	filePath := filepath.Join(sourceDirectory, filepath.FromSlash(name))
+	if err := os.MkdirAll(filepath.Dir(filePath), 0o700); err != nil {
+		t.Fatalf("creating the directory for %q: %+v", name, err)
+	}
+	if err := os.WriteFile(filePath, []byte(content), 0o600); err != nil {
+		t.Fatalf("writing %q: %+v", name, err)
+	}
+}
+
+func (r StorageBlobDirectorySyncResource) basic(data acceptance.TestData, sourceDirectory string) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+%s
+
+resource "azurerm_storage_blob_directory_sync" "test" {
+  storage_container_id = azurerm_storage_container.test.id
+  source_directory     = %q
+}
+`, r.template(data), sourceDirectory)
+}
+
+func (r StorageBlobDirectorySyncResource) requiresImport(data acceptance.TestData, sourceDirectory string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_storage_blob_directory_sync" "import" {
+  storage_container_id = azurerm_storage_blob_directory_sync.test.storage_container_id
+  source_directory     = azurerm_storage_blob_directory_sync.test.source_directory
+}
+`, r.basic(data, sourceDirectory))
+}
+
+func (r StorageBlobDirectorySyncResource) complete(data acceptance.TestData, sourceDirectory string) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+%s
+
+resource "azurerm_storage_blob_directory_sync" "test" {
+  storage_container_id = azurerm_storage_container.test.id
+  source_directory     = %q
+  prefix               = "site"
+  cache_control        = "public, max-age=3600"
+  parallelism          = 4
+
+  content_types = {
+    ".js" = "text/javascript; charset=utf-8"
+  }
+}
+`, r.template(data), sourceDirectory)
+}
+
+func (r StorageBlobDirectorySyncResource) template(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-%d"
+  location = "%s"
+}
+
+resource "azurerm_storage_account" "test" {
+  name                     = "acctestacc%s"
+  resource_group_name      = azurerm_resource_group.test.name
+  location                 = azurerm_resource_group.test.location
+  account_tier             = "Standard"
+  account_replication_type = "LRS"
+}
+
+resource "azurerm_storage_container" "test" {
+  name                  = "test"
+  storage_account_name  = azurerm_storage_account.test.name
+  container_access_type = "private"
+}
+`, data.RandomInteger, data.Locations.Primary, data.RandomString)
+}
@@ -7,6 +7,8 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"net/http"
+	"os"
 	"strings"
 	"time"
 
@@ -142,6 +144,7 @@ func resourceStorageBlob() *pluginsdk.Resource {
 			"content_md5": {
 				Type:          pluginsdk.TypeString,
 				Optional:      true,
+				Computed:      true,
 				ForceNew:      true,
 				ConflictsWith: []string{"source_uri"},
 			},
@@ -161,6 +164,15 @@ func resourceStorageBlob() *pluginsdk.Resource {
 			},
 
 			"metadata": MetaDataComputedSchema(),
+
+			"index_tags": {
+				Type:         pluginsdk.TypeMap,
+				Optional:     true,
+				ValidateFunc: validate.StorageBlobIndexTags,
+				Elem: &pluginsdk.Schema{
+					Type: pluginsdk.TypeString,
+				},
+			},
 		},
 
 		CustomizeDiff: func(ctx context.Context, diff *pluginsdk.ResourceDiff, i interface{}) error {
@@ -169,6 +181,32 @@ func resourceStorageBlob() *pluginsdk.Resource {
 					return fmt.Errorf(`"source" must be aligned to 512-byte boundary for "type" set to "Page"`)
 				}
 			}
+
+			// when a Block Blob is uploaded from a local file without specifying `content_md5`, the MD5 of the
+			// file is computed so that changes to the file cause the Blob to be re-uploaded
+			if diff.Get("type") != "Block" || !diff.NewValueKnown("source") || !diff.GetRawConfig().GetAttr("content_md5").IsNull() {
+				return nil
+			}
+			source := diff.Get("source").(string)
+			if source == "" {
+				return nil
+			}
+			if _, err := os.Stat(source); err != nil {
+				// the file may be created during the apply, in which case the MD5 is computed when it's uploaded
+				log.Printf("[DEBUG] Unable to compute the MD5 of `source` %q: %+v", source, err)
+				return nil
+			}
+
+			contentMD5, err := fileMD5Hex(source)
+			if err != nil {
+				return fmt.Errorf("computing the MD5 of `source` %q: %+v", source, err)
+			}
+			if diff.Get("content_md5").(string) != contentMD5 {
+				if err := diff.SetNew("content_md5", contentMD5); err != nil {
+					return fmt.Errorf("setting `content_md5`: %+v", err)
+				}
+			}
+
 			return nil
 		},
 	}
@@ -218,10 +256,16 @@ func resourceStorageBlobCreate(d *pluginsdk.ResourceData, meta interface{}) erro
 	}
 
 	contentMD5Raw := d.Get("content_md5").(string)
+	if contentMD5Raw == "" && d.Get("type").(string) == "Block" && d.Get("source").(string) != "" {
+		// the MD5 couldn't be computed during the plan (e.g. the file was created during the apply)
+		if contentMD5Raw, err = fileMD5Hex(d.Get("source").(string)); err != nil {
+			return fmt.Errorf("computing the MD5 of `source`: %+v", err)
+		}
+	}
 	contentMD5 := ""
 	if contentMD5Raw != "" {
 		// Azure uses a Base64 encoded representation of the standard MD5 sum of the file
-		contentMD5, err = convertHexToBase64Encoding(d.Get("content_md5").(string))
+		contentMD5, err = convertHexToBase64Encoding(contentMD5Raw)
 		if err != nil {
 			return fmt.Errorf("failed to base64 encode `content_md5` value: %s", err)
 		}
@@ -335,6 +379,15 @@ func resourceStorageBlobUpdate(d *pluginsdk.ResourceData, meta interface{}) erro
 		log.Printf("[DEBUG] Updated MetaData for %s", id)
 	}
 
+	if d.HasChange("index_tags") {
+		log.Printf("[DEBUG] Updating Index Tags for %s...", id)
+		indexTags := ExpandMetaData(d.Get("index_tags").(map[string]interface{}))
+		if err := setBlobIndexTags(ctx, blobsClient, id.ContainerName, id.BlobName, indexTags); err != nil {
+			return fmt.Errorf("updating Index Tags for %s: %v", id, err)
+		}
+		log.Printf("[DEBUG] Updated Index Tags for %s", id)
+	}
+
 	if d.HasChange("access_tier") {
 		// this is only applicable for Gen2/BlobStorage accounts
 		log.Printf("[DEBUG] Updating Access Tier for %s...", id)
@@ -415,6 +468,21 @@ func resourceStorageBlobRead(d *pluginsdk.ResourceData, meta interface{}) error
 	if err = d.Set("metadata", FlattenMetaData(props.MetaData)); err != nil {
 		return fmt.Errorf("setting `metadata`: %v", err)
 	}
+
+	if blobIndexTagsSupported(*account) {
+		indexTags, resp, err := getBlobIndexTags(ctx, blobsClient, id.ContainerName, id.BlobName)
+		if err != nil {
+			// reading Index Tags requires an additional permission (e.g. `Storage Blob Data Owner`) - so when this
+			// is unavailable the existing value is retained, rather than preventing the Blob from being read
+			if resp == nil || resp.StatusCode != http.StatusForbidden {
+				return fmt.Errorf("retrieving Index Tags for %s: %v", id, err)
+			}
+			log.Printf("[DEBUG] Insufficient permissions to retrieve the Index Tags for %s - retaining the existing value", id)
+		} else if err = d.Set("index_tags", FlattenMetaData(indexTags)); err != nil {
+			return fmt.Errorf("setting `index_tags`: %v", err)
+		}
+	}
+
 	// The CopySource is only returned if the blob hasn't been modified (e.g. metadata configured etc)
 	// as such, we need to conditionally set this to ensure it's trackable if possible
 	if props.CopySource != "" {
@@ -543,6 +543,81 @@ func TestAccStorageBlob_archive(t *testing.T) {
 	})
 }
 
+func TestAccStorageBlob_indexTags(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_storage_blob", "test")
+	r := StorageBlobResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.indexTags(data, "production"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("index_tags.%").HasValue("2"),
+				check.That(data.ResourceName).Key("index_tags.environment").HasValue("production"),
+			),
+		},
+		data.ImportStep("parallelism", "size", "type", "source_content"),
+		{
+			Config: r.indexTags(data, "staging"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("index_tags.environment").HasValue("staging"),
+			),
+		},
+		data.ImportStep("parallelism", "size", "type", "source_content"),
+		{
+			Config: r.blockFromInlineContent(data, "Wubba Lubba Dub Dub"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("index_tags.%").HasValue("0"),
+			),
+		},
+		data.ImportStep("parallelism", "size", "type", "source_content"),
+	})
+}
+
+func TestAccStorageBlob_blockFromLocalFileChanged(t *testing.T) {
+	sourceBlob, err := os.CreateTemp("", "")
+	if err != nil {
+		t.Fatalf("Failed to create local source blob file")
+	}
+	if _, err := sourceBlob.WriteString("first"); err != nil {
+		t.Fatalf("Error populating temp file: %s", err)
+	}
+	sourceBlob.Close()
+	defer os.Remove(sourceBlob.Name())
+
+	data := acceptance.BuildTestData(t, "azurerm_storage_blob", "test")
+	r := StorageBlobResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.blockFromLocalBlob(data, sourceBlob.Name()),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				// the MD5 of "first"
+				check.That(data.ResourceName).Key("content_md5").HasValue("8b04d5e3775d298e78455efc5ca404d5"),
+				data.CheckWithClient(r.blobMatchesFile(blobs.BlockBlob, sourceBlob.Name())),
+			),
+		},
+		{
+			PreConfig: func() {
+				if err := os.WriteFile(sourceBlob.Name(), []byte("second"), 0o600); err != nil {
+					t.Fatalf("Error updating temp file: %s", err)
+				}
+			},
+			Config: r.blockFromLocalBlob(data, sourceBlob.Name()),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				// the MD5 of "second"
+				check.That(data.ResourceName).Key("content_md5").HasValue("a9f0e61a137d86aa9db53465e0801612"),
+				data.CheckWithClient(r.blobMatchesFile(blobs.BlockBlob, sourceBlob.Name())),
+			),
+		},
+		data.ImportStep("parallelism", "size", "source", "type"),
+	})
+}
+
 func (r StorageBlobResource) Exists(ctx context.Context, client *clients.Client, state *pluginsdk.InstanceState) (*bool, error) {
 	id, err := blobs.ParseBlobID(state.ID, client.Storage.StorageDomainSuffix)
 	if err != nil {
@@ -1270,6 +1345,32 @@ resource "azurerm_storage_blob" "import" {
 `, template)
 }
 
+func (r StorageBlobResource) indexTags(data acceptance.TestData, environment string) string {
+	template := r.template(data, "blob")
+	return fmt.Sprintf(`
+%s
+
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_storage_blob" "test" {
+  name                   = "rick.morty"
+  storage_account_name   = azurerm_storage_account.test.name
+  storage_container_name = azurerm_storage_container.test.name
+  type                   = "Block"
+  source_content         = <<EOT
+Wubba Lubba Dub Dub
+EOT
+
+  index_tags = {
+    environment = "%s"
+    project     = "site-01/assets"
+  }
+}
+`, template, environment)
+}
+
 func (r StorageBlobResource) update(data acceptance.TestData) string {
 	template := r.template(data, "private")
 	return fmt.Sprintf(`
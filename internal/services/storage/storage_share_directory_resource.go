@@ -84,6 +84,14 @@ func resourceStorageShareDirectory() *pluginsdk.Resource {
 				ValidateFunc:  validation.StringIsNotEmpty,
 			},
 
+			"file_permission": {
+				Type:             pluginsdk.TypeString,
+				Optional:         true,
+				Computed:         true,
+				ValidateFunc:     storageValidate.StorageShareFilePermission,
+				DiffSuppressFunc: shareFilePermissionDiffSuppress,
+			},
+
 			"metadata": MetaDataSchema(),
 		},
 	}
@@ -194,6 +202,12 @@ func resourceStorageShareDirectoryCreate(d *pluginsdk.ResourceData, meta interfa
 
 	d.SetId(id.ID())
 
+	if v := d.Get("file_permission").(string); v != "" {
+		if err = updateStorageShareDirectoryFilePermission(ctx, client, id, v); err != nil {
+			return err
+		}
+	}
+
 	return resourceStorageShareDirectoryRead(d, meta)
 }
 
@@ -228,6 +242,12 @@ func resourceStorageShareDirectoryUpdate(d *pluginsdk.ResourceData, meta interfa
 		return fmt.Errorf("updating Metadata for %s: %v", id, err)
 	}
 
+	if d.HasChange("file_permission") {
+		if err = updateStorageShareDirectoryFilePermission(ctx, client, *id, d.Get("file_permission").(string)); err != nil {
+			return err
+		}
+	}
+
 	return resourceStorageShareDirectoryRead(d, meta)
 }
 
@@ -285,6 +305,12 @@ func resourceStorageShareDirectoryRead(d *pluginsdk.ResourceData, meta interface
 		return fmt.Errorf("setting `metadata`: %v", err)
 	}
 
+	filePermission, err := readShareFilePermission(ctx, client.Client, id.ShareName, props.HttpResponse, d.Get("file_permission").(string))
+	if err != nil {
+		return fmt.Errorf("retrieving File Permission for %s: %v", id, err)
+	}
+	d.Set("file_permission", filePermission)
+
 	return nil
 }
 
@@ -319,6 +345,20 @@ func resourceStorageShareDirectoryDelete(d *pluginsdk.ResourceData, meta interfa
 	return nil
 }
 
+func updateStorageShareDirectoryFilePermission(ctx context.Context, client *directories.Client, id directories.DirectoryId, filePermission string) error {
+	// permissions are stored at the Share level and then assigned to the Directory using the returned key
+	permissionKey, err := createShareFilePermission(ctx, client.Client, id.ShareName, filePermission)
+	if err != nil {
+		return fmt.Errorf("creating File Permission for %s: %v", id, err)
+	}
+
+	if err = setShareDirectoryPermission(ctx, client.Client, id.ShareName, id.DirectoryPath, permissionKey); err != nil {
+		return fmt.Errorf("updating File Permission for %s: %v", id, err)
+	}
+
+	return nil
+}
+
 func storageShareDirectoryRefreshFunc(ctx context.Context, client *directories.Client, id directories.DirectoryId) pluginsdk.StateRefreshFunc {
 	return func() (interface{}, string, error) {
 		res, err := client.Get(ctx, id.ShareName, id.DirectoryPath)
@@ -162,6 +162,31 @@ func TestAccStorageShareDirectory_update(t *testing.T) {
 	})
 }
 
+func TestAccStorageShareDirectory_filePermission(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_storage_share_directory", "test")
+	r := StorageShareDirectoryResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.filePermission(data, "O:BAG:SYD:(A;OICI;FA;;;BA)(A;OICI;FA;;;SY)"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("file_permission").HasValue("O:BAG:SYD:(A;OICI;FA;;;BA)(A;OICI;FA;;;SY)"),
+			),
+		},
+		// the permission is only retrieved when it's configured
+		data.ImportStep("file_permission"),
+		{
+			Config: r.filePermission(data, "O:BAG:SYD:(A;OICI;FA;;;BA)(A;OICI;0x1200a9;;;BU)"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("file_permission").HasValue("O:BAG:SYD:(A;OICI;FA;;;BA)(A;OICI;0x1200a9;;;BU)"),
+			),
+		},
+		data.ImportStep("file_permission"),
+	})
+}
+
 func TestAccStorageShareDirectory_nested(t *testing.T) {
 	data := acceptance.BuildTestData(t, "azurerm_storage_share_directory", "parent")
 	r := StorageShareDirectoryResource{}
@@ -338,6 +363,19 @@ resource "azurerm_storage_share_directory" "test" {
 `, template)
 }
 
+func (r StorageShareDirectoryResource) filePermission(data acceptance.TestData, filePermission string) string {
+	template := r.template(data)
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_storage_share_directory" "test" {
+  name             = "dir"
+  storage_share_id = azurerm_storage_share.test.id
+  file_permission  = "%s"
+}
+`, template, filePermission)
+}
+
 func (r StorageShareDirectoryResource) nested(data acceptance.TestData) string {
 	template := r.template(data)
 	return fmt.Sprintf(`
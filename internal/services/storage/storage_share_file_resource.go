@@ -4,6 +4,7 @@
 package storage
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"os"
@@ -101,6 +102,14 @@ func resourceStorageShareFile() *pluginsdk.Resource {
 				Computed: true,
 			},
 
+			"file_permission": {
+				Type:             pluginsdk.TypeString,
+				Optional:         true,
+				Computed:         true,
+				ValidateFunc:     storageValidate.StorageShareFilePermission,
+				DiffSuppressFunc: shareFilePermissionDiffSuppress,
+			},
+
 			"metadata": MetaDataSchema(),
 		},
 	}
@@ -193,6 +202,12 @@ func resourceStorageShareFileCreate(d *pluginsdk.ResourceData, meta interface{})
 
 	d.SetId(id.ID())
 
+	if v := d.Get("file_permission").(string); v != "" {
+		if err = updateStorageShareFileFilePermission(ctx, client, id, v); err != nil {
+			return err
+		}
+	}
+
 	return resourceStorageShareFileRead(d, meta)
 }
 
@@ -227,7 +242,8 @@ func resourceStorageShareFileUpdate(d *pluginsdk.ResourceData, meta interface{})
 		}
 	}
 
-	if d.HasChange("content_type") || d.HasChange("content_encoding") || d.HasChange("content_disposition") {
+	propertiesChanged := d.HasChange("content_type") || d.HasChange("content_encoding") || d.HasChange("content_disposition")
+	if propertiesChanged {
 		input := files.SetPropertiesInput{
 			ContentType:        utils.String(d.Get("content_type").(string)),
 			ContentEncoding:    utils.String(d.Get("content_encoding").(string)),
@@ -245,6 +261,13 @@ func resourceStorageShareFileUpdate(d *pluginsdk.ResourceData, meta interface{})
 		}
 	}
 
+	// setting the properties resets the permission to `inherit`, so it's (re-)applied after any change to these
+	if v := d.Get("file_permission").(string); v != "" && (propertiesChanged || d.HasChange("file_permission")) {
+		if err = updateStorageShareFileFilePermission(ctx, client, *id, v); err != nil {
+			return err
+		}
+	}
+
 	return resourceStorageShareFileRead(d, meta)
 }
 
@@ -299,6 +322,12 @@ func resourceStorageShareFileRead(d *pluginsdk.ResourceData, meta interface{}) e
 
 	d.Set("content_length", int(*props.ContentLength))
 
+	filePermission, err := readShareFilePermission(ctx, client.Client, id.ShareName, props.HttpResponse, d.Get("file_permission").(string))
+	if err != nil {
+		return fmt.Errorf("retrieving File Permission for %s: %v", id, err)
+	}
+	d.Set("file_permission", filePermission)
+
 	return nil
 }
 
@@ -332,3 +361,17 @@ func resourceStorageShareFileDelete(d *pluginsdk.ResourceData, meta interface{})
 
 	return nil
 }
+
+func updateStorageShareFileFilePermission(ctx context.Context, client *files.Client, id files.FileId, filePermission string) error {
+	// permissions are stored at the Share level and then assigned to the File using the returned key
+	permissionKey, err := createShareFilePermission(ctx, client.Client, id.ShareName, filePermission)
+	if err != nil {
+		return fmt.Errorf("creating File Permission for %s: %v", id, err)
+	}
+
+	if err = setShareFilePermission(ctx, client, id.ShareName, id.DirectoryPath, id.FileName, permissionKey); err != nil {
+		return fmt.Errorf("updating File Permission for %s: %v", id, err)
+	}
+
+	return nil
+}
@@ -111,6 +111,41 @@ func TestAccAzureRMStorageShareFile_update(t *testing.T) {
 	})
 }
 
+func TestAccAzureRMStorageShareFile_filePermission(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_storage_share_file", "test")
+	r := StorageShareFileResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.filePermission(data, "text/plain", "O:BAG:SYD:(A;;FA;;;BA)(A;;FA;;;SY)"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("file_permission").HasValue("O:BAG:SYD:(A;;FA;;;BA)(A;;FA;;;SY)"),
+			),
+		},
+		// the permission is only retrieved when it's configured
+		data.ImportStep("file_permission"),
+		{
+			// changing the content properties mustn't reset the permission
+			Config: r.filePermission(data, "application/json", "O:BAG:SYD:(A;;FA;;;BA)(A;;FA;;;SY)"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("file_permission").HasValue("O:BAG:SYD:(A;;FA;;;BA)(A;;FA;;;SY)"),
+			),
+		},
+		data.ImportStep("file_permission"),
+		{
+			Config: r.filePermission(data, "application/json", "O:BAG:SYD:(A;;FA;;;BA)(A;;0x1200a9;;;BU)"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("file_permission").HasValue("O:BAG:SYD:(A;;FA;;;BA)(A;;0x1200a9;;;BU)"),
+				check.That(data.ResourceName).Key("content_type").HasValue("application/json"),
+			),
+		},
+		data.ImportStep("file_permission"),
+	})
+}
+
 func TestAccAzureRMStorageShareFile_withFile(t *testing.T) {
 	sourceBlob, err := os.CreateTemp("", "")
 	if err != nil {
@@ -341,6 +376,19 @@ resource "azurerm_storage_share_file" "test" {
 `, r.template(data))
 }
 
+func (r StorageShareFileResource) filePermission(data acceptance.TestData, contentType, filePermission string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_storage_share_file" "test" {
+  name             = "file"
+  storage_share_id = azurerm_storage_share.test.id
+  content_type     = "%s"
+  file_permission  = "%s"
+}
+`, r.template(data), contentType, filePermission)
+}
+
 func (r StorageShareFileResource) withFile(data acceptance.TestData, fileName string) string {
 	return fmt.Sprintf(`
 %s
@@ -8,6 +8,7 @@ import (
 	"log"
 	"time"
 
+	"github.com/hashicorp/go-azure-sdk/resource-manager/storage/2023-01-01/fileshares"
 	"github.com/hashicorp/go-azure-sdk/resource-manager/storage/2023-01-01/storageaccounts"
 	"github.com/hashicorp/terraform-provider-azurerm/helpers/tf"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
@@ -118,6 +119,13 @@ func resourceStorageShare() *pluginsdk.Resource {
 				Default: string(shares.SMB),
 			},
 
+			"root_squash": {
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringInSlice(fileshares.PossibleValuesForRootSquashType(), false),
+			},
+
 			"resource_manager_id": {
 				Type:     pluginsdk.TypeString,
 				Computed: true,
@@ -189,6 +197,8 @@ func resourceStorageShareCreate(d *pluginsdk.ResourceData, meta interface{}) err
 		if account.Kind != storageaccounts.KindFileStorage {
 			return fmt.Errorf("NFS File Share is only supported for Storage Account with kind %q but got `%s`", string(storageaccounts.KindFileStorage), account.Kind)
 		}
+	} else if d.Get("root_squash").(string) != "" {
+		return fmt.Errorf("`root_squash` can only be specified when `enabled_protocol` is set to %q", string(shares.NFS))
 	}
 
 	// The files API does not support bearer tokens (@manicminer, 2024-02-15)
@@ -227,6 +237,12 @@ func resourceStorageShareCreate(d *pluginsdk.ResourceData, meta interface{}) err
 		return fmt.Errorf("setting ACLs for %s: %v", id, err)
 	}
 
+	if rootSquash := d.Get("root_squash").(string); rootSquash != "" {
+		if err = client.UpdateRootSquash(ctx, shareName, rootSquash); err != nil {
+			return fmt.Errorf("setting Root Squash for %s: %v", id, err)
+		}
+	}
+
 	return resourceStorageShareRead(d, meta)
 }
 
@@ -272,6 +288,7 @@ func resourceStorageShareRead(d *pluginsdk.ResourceData, meta interface{}) error
 	d.Set("quota", props.QuotaGB)
 	d.Set("url", id.ID())
 	d.Set("enabled_protocol", string(props.EnabledProtocol))
+	d.Set("root_squash", props.RootSquash)
 
 	accessTier := ""
 	if props.AccessTier != nil {
@@ -355,6 +372,16 @@ func resourceStorageShareUpdate(d *pluginsdk.ResourceData, meta interface{}) err
 		log.Printf("[DEBUG] Updated ACLs for %s", id)
 	}
 
+	if d.HasChange("root_squash") {
+		log.Printf("[DEBUG] Updating Root Squash for %s", id)
+
+		if err = client.UpdateRootSquash(ctx, id.ShareName, d.Get("root_squash").(string)); err != nil {
+			return fmt.Errorf("updating Root Squash for %s: %v", id, err)
+		}
+
+		log.Printf("[DEBUG] Updated Root Squash for %s", id)
+	}
+
 	if d.HasChange("access_tier") {
 		log.Printf("[DEBUG] Updating Access Tier for %s", id)
 
@@ -270,6 +270,30 @@ func TestAccStorageShare_protocolUpdate(t *testing.T) {
 	})
 }
 
+func TestAccStorageShare_nfsRootSquash(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_storage_share", "test")
+	r := StorageShareResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.nfsRootSquash(data, "RootSquash"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("root_squash").HasValue("RootSquash"),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.nfsRootSquash(data, "AllSquash"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("root_squash").HasValue("AllSquash"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
 func (r StorageShareResource) Exists(ctx context.Context, client *clients.Client, state *pluginsdk.InstanceState) (*bool, error) {
 	id, err := shares.ParseShareID(state.ID, client.Storage.StorageDomainSuffix)
 	if err != nil {
@@ -625,6 +649,36 @@ resource "azurerm_storage_share" "test" {
 `, data.RandomInteger, data.Locations.Primary, data.RandomString, data.RandomString, protocol)
 }
 
+func (r StorageShareResource) nfsRootSquash(data acceptance.TestData, rootSquash string) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-%d"
+  location = "%s"
+}
+
+resource "azurerm_storage_account" "test" {
+  name                     = "acctestacc%s"
+  resource_group_name      = azurerm_resource_group.test.name
+  location                 = azurerm_resource_group.test.location
+  account_kind             = "FileStorage"
+  account_tier             = "Premium"
+  account_replication_type = "LRS"
+}
+
+resource "azurerm_storage_share" "test" {
+  name                 = "testshare%s"
+  storage_account_name = azurerm_storage_account.test.name
+  enabled_protocol     = "NFS"
+  root_squash          = "%s"
+  quota                = 100
+}
+`, data.RandomInteger, data.Locations.Primary, data.RandomString, data.RandomString, rootSquash)
+}
+
 func (r StorageShareResource) template(data acceptance.TestData) string {
 	return fmt.Sprintf(`
 provider "azurerm" {
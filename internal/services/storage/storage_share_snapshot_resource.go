@@ -0,0 +1,241 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package storage
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/storage/shim"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/storage/validate"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/tombuildsstuff/giovanni/storage/2023-11-03/file/shares"
+)
+
+type StorageShareSnapshotResource struct{}
+
+var _ sdk.Resource = StorageShareSnapshotResource{}
+
+type StorageShareSnapshotModel struct {
+	StorageShareId string            `tfschema:"storage_share_id"`
+	MetaData       map[string]string `tfschema:"metadata"`
+	Snapshot       string            `tfschema:"snapshot"`
+}
+
+func (r StorageShareSnapshotResource) ResourceType() string {
+	return "azurerm_storage_share_snapshot"
+}
+
+func (r StorageShareSnapshotResource) IDValidationFunc() pluginsdk.SchemaValidateFunc {
+	return validate.StorageShareSnapshotID
+}
+
+func (r StorageShareSnapshotResource) ModelObject() interface{} {
+	return &StorageShareSnapshotModel{}
+}
+
+func (r StorageShareSnapshotResource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"storage_share_id": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validate.StorageShareDataPlaneID,
+		},
+
+		// the MetaData of a Snapshot can't be changed once it's been taken
+		"metadata": {
+			Type:         pluginsdk.TypeMap,
+			Optional:     true,
+			ForceNew:     true,
+			ValidateFunc: validate.MetaDataKeys,
+			Elem: &pluginsdk.Schema{
+				Type: pluginsdk.TypeString,
+			},
+		},
+	}
+}
+
+func (r StorageShareSnapshotResource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"snapshot": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+	}
+}
+
+func (r StorageShareSnapshotResource) Create() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			storageClient := metadata.Client.Storage
+
+			var config StorageShareSnapshotModel
+			if err := metadata.Decode(&config); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			shareId, err := shares.ParseShareID(config.StorageShareId, storageClient.StorageDomainSuffix)
+			if err != nil {
+				return err
+			}
+
+			client, err := r.sharesClient(ctx, metadata, *shareId)
+			if err != nil {
+				return err
+			}
+
+			snapshot, err := client.CreateSnapshot(ctx, shareId.ShareName, config.MetaData)
+			if err != nil {
+				return fmt.Errorf("creating Snapshot of %s: %v", shareId, err)
+			}
+
+			id := newShareSnapshotId(*shareId, *snapshot)
+			metadata.SetID(id)
+
+			return nil
+		},
+	}
+}
+
+func (r StorageShareSnapshotResource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			storageClient := metadata.Client.Storage
+
+			id, err := parseShareSnapshotId(metadata.ResourceData.Id(), storageClient.StorageDomainSuffix)
+			if err != nil {
+				return err
+			}
+
+			account, err := storageClient.FindAccount(ctx, metadata.Client.Account.SubscriptionId, id.ShareId.AccountId.AccountName)
+			if err != nil {
+				return fmt.Errorf("retrieving Account %q for %s: %v", id.ShareId.AccountId.AccountName, id, err)
+			}
+			if account == nil {
+				log.Printf("[DEBUG] Unable to locate Account %q for %s - assuming removed & removing from state!", id.ShareId.AccountId.AccountName, id)
+				return metadata.MarkAsGone(id)
+			}
+
+			// The files API does not support bearer tokens (@manicminer, 2024-02-15)
+			client, err := storageClient.FileSharesDataPlaneClient(ctx, *account, storageClient.DataPlaneOperationSupportingOnlySharedKeyAuth())
+			if err != nil {
+				return fmt.Errorf("building File Share Client for %s: %v", account.StorageAccountId, err)
+			}
+
+			props, err := client.GetSnapshot(ctx, id.ShareId.ShareName, id.Snapshot)
+			if err != nil {
+				return fmt.Errorf("retrieving %s: %v", id, err)
+			}
+			if props == nil {
+				return metadata.MarkAsGone(id)
+			}
+
+			state := StorageShareSnapshotModel{
+				StorageShareId: id.ShareId.ID(),
+				MetaData:       props.MetaData,
+				Snapshot:       id.Snapshot,
+			}
+			return metadata.Encode(&state)
+		},
+	}
+}
+
+func (r StorageShareSnapshotResource) Delete() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			storageClient := metadata.Client.Storage
+
+			id, err := parseShareSnapshotId(metadata.ResourceData.Id(), storageClient.StorageDomainSuffix)
+			if err != nil {
+				return err
+			}
+
+			client, err := r.sharesClient(ctx, metadata, id.ShareId)
+			if err != nil {
+				return err
+			}
+
+			if err = client.DeleteSnapshot(ctx, id.ShareId.ShareName, id.Snapshot); err != nil {
+				return fmt.Errorf("deleting %s: %v", id, err)
+			}
+
+			return nil
+		},
+	}
+}
+
+func (r StorageShareSnapshotResource) sharesClient(ctx context.Context, metadata sdk.ResourceMetaData, shareId shares.ShareId) (shim.StorageShareWrapper, error) {
+	storageClient := metadata.Client.Storage
+
+	account, err := storageClient.FindAccount(ctx, metadata.Client.Account.SubscriptionId, shareId.AccountId.AccountName)
+	if err != nil {
+		return nil, fmt.Errorf("retrieving Account %q for %s: %v", shareId.AccountId.AccountName, shareId, err)
+	}
+	if account == nil {
+		return nil, fmt.Errorf("locating Storage Account %q", shareId.AccountId.AccountName)
+	}
+
+	// The files API does not support bearer tokens (@manicminer, 2024-02-15)
+	client, err := storageClient.FileSharesDataPlaneClient(ctx, *account, storageClient.DataPlaneOperationSupportingOnlySharedKeyAuth())
+	if err != nil {
+		return nil, fmt.Errorf("building File Share Client for %s: %v", account.StorageAccountId, err)
+	}
+
+	return client, nil
+}
+
+// shareSnapshotId is the ID of a Storage Share Snapshot, which is the ID of the Storage Share
+// with the timestamp of the Snapshot in the `sharesnapshot` query string parameter
+type shareSnapshotId struct {
+	ShareId  shares.ShareId
+	Snapshot string
+}
+
+func newShareSnapshotId(shareId shares.ShareId, snapshot string) shareSnapshotId {
+	return shareSnapshotId{
+		ShareId:  shareId,
+		Snapshot: snapshot,
+	}
+}
+
+func (id shareSnapshotId) ID() string {
+	return fmt.Sprintf("%s?sharesnapshot=%s", id.ShareId.ID(), id.Snapshot)
+}
+
+func (id shareSnapshotId) String() string {
+	return fmt.Sprintf("Storage Share Snapshot (Snapshot %q / %s)", id.Snapshot, id.ShareId.String())
+}
+
+func parseShareSnapshotId(input, domainSuffix string) (*shareSnapshotId, error) {
+	rawShareId, rawQuery, found := strings.Cut(input, "?")
+	if !found {
+		return nil, fmt.Errorf("parsing %q as a Storage Share Snapshot ID: expected a `sharesnapshot` query string parameter", input)
+	}
+
+	shareId, err := shares.ParseShareID(rawShareId, domainSuffix)
+	if err != nil {
+		return nil, fmt.Errorf("parsing %q as a Storage Share Snapshot ID: %+v", input, err)
+	}
+
+	query, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return nil, fmt.Errorf("parsing the query string of %q: %+v", input, err)
+	}
+	snapshot := query.Get("sharesnapshot")
+	if snapshot == "" {
+		return nil, fmt.Errorf("parsing %q as a Storage Share Snapshot ID: expected a `sharesnapshot` query string parameter", input)
+	}
+
+	return pointer.To(newShareSnapshotId(*shareId, snapshot)), nil
+}
@@ -0,0 +1,162 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package storage_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/check"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+	"github.com/tombuildsstuff/giovanni/storage/2023-11-03/file/shares"
+)
+
+type StorageShareSnapshotResource struct{}
+
+func TestAccStorageShareSnapshot_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_storage_share_snapshot", "test")
+	r := StorageShareSnapshotResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("snapshot").IsNotEmpty(),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccStorageShareSnapshot_complete(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_storage_share_snapshot", "test")
+	r := StorageShareSnapshotResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.complete(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("metadata.%").HasValue("2"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccStorageShareSnapshot_multiple(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_storage_share_snapshot", "test")
+	r := StorageShareSnapshotResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.multiple(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That("azurerm_storage_share_snapshot.second").ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func (r StorageShareSnapshotResource) Exists(ctx context.Context, client *clients.Client, state *pluginsdk.InstanceState) (*bool, error) {
+	shareId, err := shares.ParseShareID(state.Attributes["storage_share_id"], client.Storage.StorageDomainSuffix)
+	if err != nil {
+		return nil, err
+	}
+
+	account, err := client.Storage.FindAccount(ctx, client.Account.SubscriptionId, shareId.AccountId.AccountName)
+	if err != nil {
+		return nil, fmt.Errorf("retrieving Account %q for %s: %+v", shareId.AccountId.AccountName, shareId, err)
+	}
+	if account == nil {
+		return nil, fmt.Errorf("unable to locate Storage Account %q", shareId.AccountId.AccountName)
+	}
+
+	sharesClient, err := client.Storage.FileSharesDataPlaneClient(ctx, *account, client.Storage.DataPlaneOperationSupportingOnlySharedKeyAuth())
+	if err != nil {
+		return nil, fmt.Errorf("building File Share Client for %s: %+v", account.StorageAccountId, err)
+	}
+
+	props, err := sharesClient.GetSnapshot(ctx, shareId.ShareName, state.Attributes["snapshot"])
+	if err != nil {
+		return nil, fmt.Errorf("retrieving Snapshot %q of %s: %+v", state.Attributes["snapshot"], shareId, err)
+	}
+
+	return utils.Bool(props != nil), nil
+}
+
+func (r StorageShareSnapshotResource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_storage_share_snapshot" "test" {
+  storage_share_id = azurerm_storage_share.test.id
+}
+`, r.template(data))
+}
+
+func (r StorageShareSnapshotResource) complete(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_storage_share_snapshot" "test" {
+  storage_share_id = azurerm_storage_share.test.id
+
+  metadata = {
+    retention = "30d"
+    reason    = "pre-migration"
+  }
+}
+`, r.template(data))
+}
+
+func (r StorageShareSnapshotResource) multiple(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_storage_share_snapshot" "test" {
+  storage_share_id = azurerm_storage_share.test.id
+}
+
+resource "azurerm_storage_share_snapshot" "second" {
+  storage_share_id = azurerm_storage_share.test.id
+
+  depends_on = [azurerm_storage_share_snapshot.test]
+}
+`, r.template(data))
+}
+
+func (r StorageShareSnapshotResource) template(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-%d"
+  location = "%s"
+}
+
+resource "azurerm_storage_account" "test" {
+  name                     = "acctestacc%s"
+  resource_group_name      = azurerm_resource_group.test.name
+  location                 = azurerm_resource_group.test.location
+  account_tier             = "Standard"
+  account_replication_type = "LRS"
+}
+
+resource "azurerm_storage_share" "test" {
+  name                 = "testshare%s"
+  storage_account_name = azurerm_storage_account.test.name
+  quota                = 50
+}
+`, data.RandomInteger, data.Locations.Primary, data.RandomString, data.RandomString)
+}
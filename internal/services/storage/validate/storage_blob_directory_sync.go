@@ -0,0 +1,87 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validate
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/storage/client"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+	"github.com/tombuildsstuff/giovanni/storage/2023-11-03/blob/blobs"
+	"github.com/tombuildsstuff/giovanni/storage/2023-11-03/blob/containers"
+)
+
+// StorageBlobDirectorySyncID validates the ID of a Storage Blob Directory Sync, which is either the
+// Data Plane ID of a Storage Container, or the Data Plane ID of a Storage Container suffixed with a prefix
+func StorageBlobDirectorySyncID(input interface{}, key string) (warnings []string, errors []error) {
+	v, ok := input.(string)
+	if !ok {
+		errors = append(errors, fmt.Errorf("expected %q to be a string", key))
+		return
+	}
+
+	if client.StorageDomainSuffix == nil {
+		return validation.IsURLWithPath(input, key)
+	}
+
+	if _, err := containers.ParseContainerID(v, *client.StorageDomainSuffix); err == nil {
+		return
+	}
+	if _, err := blobs.ParseBlobID(v, *client.StorageDomainSuffix); err != nil {
+		errors = append(errors, err)
+	}
+
+	return
+}
+
+// StorageBlobDirectorySyncPrefix validates the prefix (virtual directory) which files are synced into
+func StorageBlobDirectorySyncPrefix(input interface{}, key string) (warnings []string, errors []error) {
+	v, ok := input.(string)
+	if !ok {
+		errors = append(errors, fmt.Errorf("expected %q to be a string", key))
+		return
+	}
+
+	if strings.HasPrefix(v, "/") || strings.HasSuffix(v, "/") {
+		errors = append(errors, fmt.Errorf("%q cannot start or end with a `/`: %q", key, v))
+	}
+	if strings.Contains(v, "//") {
+		errors = append(errors, fmt.Errorf("%q cannot contain empty path segments: %q", key, v))
+	}
+	for _, segment := range strings.Split(v, "/") {
+		if segment == "." || segment == ".." {
+			errors = append(errors, fmt.Errorf("%q cannot contain relative path segments: %q", key, v))
+			break
+		}
+	}
+	if len(v) > 1024 {
+		errors = append(errors, fmt.Errorf("%q must be at most 1024 characters: %q", key, v))
+	}
+
+	return
+}
+
+var storageBlobDirectorySyncFileExtension = regexp.MustCompile(`^\.[^./\\]+$`)
+
+// StorageBlobDirectorySyncContentTypes validates the Content Types to use for files, keyed by the file extension
+func StorageBlobDirectorySyncContentTypes(input interface{}, key string) (warnings []string, errors []error) {
+	v, ok := input.(map[string]interface{})
+	if !ok {
+		errors = append(errors, fmt.Errorf("expected %q to be a map", key))
+		return
+	}
+
+	for extension, raw := range v {
+		if !storageBlobDirectorySyncFileExtension.MatchString(extension) {
+			errors = append(errors, fmt.Errorf("the keys within %q must be a file extension starting with a `.` (e.g. `.html`) but got %q", key, extension))
+		}
+		if contentType, ok := raw.(string); !ok || strings.TrimSpace(contentType) == "" {
+			errors = append(errors, fmt.Errorf("the Content Type for the file extension %q within %q cannot be empty", extension, key))
+		}
+	}
+
+	return
+}
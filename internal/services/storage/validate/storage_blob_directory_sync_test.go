@@ -0,0 +1,93 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validate
+
+import (
+	"testing"
+)
+
+func TestStorageBlobDirectorySyncPrefix(t *testing.T) {
+	testCases := []struct {
+		input string
+		valid bool
+	}{
+		{
+			input: "",
+			valid: true,
+		},
+		{
+			input: "site",
+			valid: true,
+		},
+		{
+			input: "site/assets",
+			valid: true,
+		},
+		{
+			input: "/site",
+			valid: false,
+		},
+		{
+			input: "site/",
+			valid: false,
+		},
+		{
+			input: "site//assets",
+			valid: false,
+		},
+		{
+			input: "site/../assets",
+			valid: false,
+		},
+	}
+	for _, tc := range testCases {
+		_, errors := StorageBlobDirectorySyncPrefix(tc.input, "prefix")
+		if valid := len(errors) == 0; valid != tc.valid {
+			t.Fatalf("expected %q to be valid %t but got %t: %+v", tc.input, tc.valid, valid, errors)
+		}
+	}
+}
+
+func TestStorageBlobDirectorySyncContentTypes(t *testing.T) {
+	testCases := []struct {
+		input map[string]interface{}
+		valid bool
+	}{
+		{
+			input: map[string]interface{}{},
+			valid: true,
+		},
+		{
+			input: map[string]interface{}{
+				".html":        "text/html; charset=utf-8",
+				".webmanifest": "application/manifest+json",
+			},
+			valid: true,
+		},
+		{
+			input: map[string]interface{}{
+				"html": "text/html",
+			},
+			valid: false,
+		},
+		{
+			input: map[string]interface{}{
+				".tar.gz": "application/gzip",
+			},
+			valid: false,
+		},
+		{
+			input: map[string]interface{}{
+				".html": "",
+			},
+			valid: false,
+		},
+	}
+	for _, tc := range testCases {
+		_, errors := StorageBlobDirectorySyncContentTypes(tc.input, "content_types")
+		if valid := len(errors) == 0; valid != tc.valid {
+			t.Fatalf("expected %+v to be valid %t but got %t: %+v", tc.input, tc.valid, valid, errors)
+		}
+	}
+}
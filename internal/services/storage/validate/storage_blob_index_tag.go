@@ -5,6 +5,7 @@ package validate
 
 import (
 	"fmt"
+	"regexp"
 )
 
 func StorageBlobIndexTagName(v interface{}, k string) (warnings []string, errors []error) {
@@ -24,3 +25,38 @@ func StorageBlobIndexTagValue(v interface{}, k string) (warnings []string, error
 	}
 	return warnings, errors
 }
+
+var storageBlobIndexTagCharacters = regexp.MustCompile(`^[a-zA-Z0-9 +\-./:=_]*$`)
+
+// StorageBlobIndexTags validates the Index Tags which can be assigned to a single Blob
+func StorageBlobIndexTags(v interface{}, k string) (warnings []string, errors []error) {
+	tagsMap, ok := v.(map[string]interface{})
+	if !ok {
+		errors = append(errors, fmt.Errorf("expected %q to be a map", k))
+		return warnings, errors
+	}
+
+	if len(tagsMap) > 10 {
+		errors = append(errors, fmt.Errorf("a maximum of 10 Index Tags can be assigned to a Blob but %q contains %d", k, len(tagsMap)))
+	}
+
+	for name, raw := range tagsMap {
+		value, ok := raw.(string)
+		if !ok {
+			errors = append(errors, fmt.Errorf("expected the value for the Index Tag %q to be a string", name))
+			continue
+		}
+
+		if _, errs := StorageBlobIndexTagName(name, k); len(errs) > 0 {
+			errors = append(errors, errs...)
+		}
+		if _, errs := StorageBlobIndexTagValue(value, k); len(errs) > 0 {
+			errors = append(errors, errs...)
+		}
+		if !storageBlobIndexTagCharacters.MatchString(name) || !storageBlobIndexTagCharacters.MatchString(value) {
+			errors = append(errors, fmt.Errorf("the name and value of the Index Tag %q can only contain alphanumeric characters, spaces and the characters `+-./:=_`", name))
+		}
+	}
+
+	return warnings, errors
+}
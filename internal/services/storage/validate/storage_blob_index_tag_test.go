@@ -4,6 +4,7 @@
 package validate
 
 import (
+	"fmt"
 	"strings"
 	"testing"
 )
@@ -54,3 +55,63 @@ func TestStorageBlobIndexTagValue(t *testing.T) {
 		}
 	}
 }
+
+func TestStorageBlobIndexTags(t *testing.T) {
+	tooMany := make(map[string]interface{})
+	for i := 0; i < 11; i++ {
+		tooMany[fmt.Sprintf("tag%d", i)] = "value"
+	}
+
+	testCases := []struct {
+		input map[string]interface{}
+		valid bool
+	}{
+		{
+			input: map[string]interface{}{},
+			valid: true,
+		},
+		{
+			input: map[string]interface{}{
+				"environment": "production",
+				"Project":     "site-01/assets",
+				"path":        "a+b=c:d_e.f",
+				"empty":       "",
+			},
+			valid: true,
+		},
+		{
+			input: map[string]interface{}{
+				"": "value",
+			},
+			valid: false,
+		},
+		{
+			input: map[string]interface{}{
+				"invalid#name": "value",
+			},
+			valid: false,
+		},
+		{
+			input: map[string]interface{}{
+				"name": "invalid&value",
+			},
+			valid: false,
+		},
+		{
+			input: map[string]interface{}{
+				"name": strings.Repeat("w", 257),
+			},
+			valid: false,
+		},
+		{
+			input: tooMany,
+			valid: false,
+		},
+	}
+	for _, tc := range testCases {
+		_, errors := StorageBlobIndexTags(tc.input, "index_tags")
+		if valid := len(errors) == 0; valid != tc.valid {
+			t.Fatalf("expected %+v to be valid %t but got %t: %+v", tc.input, tc.valid, valid, errors)
+		}
+	}
+}
@@ -0,0 +1,40 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validate
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// storageShareFilePermissionRegex matches a Security Descriptor in SDDL format, comprised of an (optional) owner,
+// primary group, DACL and SACL - see https://learn.microsoft.com/windows/win32/secauthz/security-descriptor-string-format
+var storageShareFilePermissionRegex = regexp.MustCompile(`^(O:([A-Z]{2}|S(-[0-9]+)+))?(G:([A-Z]{2}|S(-[0-9]+)+))?(D:[A-Z]*(\([^()]*\))*)?(S:[A-Z]*(\([^()]*\))*)?$`)
+
+// StorageShareFilePermission validates the permission of a Directory or File within a Storage Share, which is
+// a Security Descriptor in SDDL format
+func StorageShareFilePermission(input interface{}, key string) (warnings []string, errors []error) {
+	v, ok := input.(string)
+	if !ok {
+		errors = append(errors, fmt.Errorf("expected %q to be a string", key))
+		return
+	}
+
+	if v == "" {
+		errors = append(errors, fmt.Errorf("%q cannot be an empty string", key))
+		return
+	}
+
+	// permissions larger than 8KiB can't be stored at the Share level
+	if len(v) > 8*1024 {
+		errors = append(errors, fmt.Errorf("%q cannot be longer than 8192 bytes", key))
+		return
+	}
+
+	if !storageShareFilePermissionRegex.MatchString(v) {
+		errors = append(errors, fmt.Errorf("%q must be a Security Descriptor in SDDL format (e.g. `O:BAG:SYD:(A;;FA;;;BA)`): %q", key, v))
+	}
+
+	return
+}
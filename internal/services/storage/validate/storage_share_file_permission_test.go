@@ -0,0 +1,38 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validate
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestStorageShareFilePermission(t *testing.T) {
+	valid := []string{
+		"O:BAG:SYD:(A;;FA;;;BA)",
+		"O:SYG:SYD:PAI(A;OICI;FA;;;SY)(A;OICI;FA;;;BA)(A;OICI;0x1200a9;;;BU)",
+		"O:S-1-5-21-1234567890-1234567890-1234567890-500G:DUD:AI(A;ID;FA;;;SY)",
+		"D:(A;;FA;;;WD)",
+		"D:P(A;;FA;;;BA)S:(AU;SAFA;FA;;;WD)",
+	}
+	for _, v := range valid {
+		if _, errors := StorageShareFilePermission(v, "file_permission"); len(errors) != 0 {
+			t.Fatalf("%q should be a valid File Permission: %q", v, errors)
+		}
+	}
+
+	invalid := []string{
+		"",
+		"inherit",
+		"O:BA G:SY",
+		"D:(A;;FA;;;BA",
+		"X:BA",
+		"D:" + strings.Repeat("(A;;FA;;;BA)", 700),
+	}
+	for _, v := range invalid {
+		if _, errors := StorageShareFilePermission(v, "file_permission"); len(errors) == 0 {
+			t.Fatalf("%q should be an invalid File Permission", v)
+		}
+	}
+}
@@ -0,0 +1,50 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validate
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/storage/client"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+	"github.com/tombuildsstuff/giovanni/storage/2023-11-03/file/shares"
+)
+
+// StorageShareSnapshotID validates the ID of a Storage Share Snapshot, which is the Data Plane ID of the
+// Storage Share with the `sharesnapshot` query string parameter
+func StorageShareSnapshotID(input interface{}, key string) (warnings []string, errors []error) {
+	v, ok := input.(string)
+	if !ok {
+		errors = append(errors, fmt.Errorf("expected %q to be a string", key))
+		return
+	}
+
+	shareId, rawQuery, found := strings.Cut(v, "?")
+	if !found {
+		errors = append(errors, fmt.Errorf("%q must contain the `sharesnapshot` query string parameter: %q", key, v))
+		return
+	}
+
+	query, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		errors = append(errors, fmt.Errorf("parsing the query string of %q: %+v", key, err))
+		return
+	}
+	if query.Get("sharesnapshot") == "" {
+		errors = append(errors, fmt.Errorf("%q must contain the `sharesnapshot` query string parameter: %q", key, v))
+		return
+	}
+
+	if client.StorageDomainSuffix == nil {
+		return validation.IsURLWithPath(shareId, key)
+	}
+
+	if _, err := shares.ParseShareID(shareId, *client.StorageDomainSuffix); err != nil {
+		errors = append(errors, err)
+	}
+
+	return
+}
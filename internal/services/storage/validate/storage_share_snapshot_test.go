@@ -0,0 +1,42 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validate
+
+import (
+	"testing"
+)
+
+func TestStorageShareSnapshotID(t *testing.T) {
+	testCases := []struct {
+		input string
+		valid bool
+	}{
+		{
+			input: "",
+			valid: false,
+		},
+		{
+			input: "https://account1.file.core.windows.net/share1",
+			valid: false,
+		},
+		{
+			input: "https://account1.file.core.windows.net/share1?snapshot=2024-01-01T00:00:00.0000000Z",
+			valid: false,
+		},
+		{
+			input: "https://account1.file.core.windows.net/share1?sharesnapshot=",
+			valid: false,
+		},
+		{
+			input: "https://account1.file.core.windows.net/share1?sharesnapshot=2024-01-01T00:00:00.0000000Z",
+			valid: true,
+		},
+	}
+	for _, tc := range testCases {
+		_, errors := StorageShareSnapshotID(tc.input, "id")
+		if valid := len(errors) == 0; valid != tc.valid {
+			t.Fatalf("expected %q to be valid %t but got %t: %+v", tc.input, tc.valid, valid, errors)
+		}
+	}
+}
@@ -17,12 +17,22 @@ import (
 )
 
 func main() {
+	sdkPackage := flag.String("sdk-package", "", "The import path of the go-azure-sdk package to generate the Typed Resource from, e.g. `github.com/hashicorp/go-azure-sdk/resource-manager/loadtestservice/2022-12-01/loadtests`")
+	servicePackagePath := flag.String("path", "", "The relative path to the service package to write the Typed Resource to, when using `-sdk-package`")
+	client := flag.String("client", "", "The path to the SDK Client within `clients.Client`, e.g. `LoadTestService.V20221201.LoadTests`, when using `-sdk-package`")
 	flag.Parse()
 	if len(flag.Args()) != 1 {
-		fmt.Fprintln(os.Stderr, "Usage: generator-typed-model <resource_type>")
+		fmt.Fprintln(os.Stderr, "Usage: generator-typed-model [-sdk-package <import_path> -path <service_package_path> -client <client>] <resource_type>")
 		os.Exit(1)
 	}
 	rt := flag.Args()[0]
+
+	if *sdkPackage != "" {
+		if err := runScaffold(*sdkPackage, *servicePackagePath, *client, rt); err != nil {
+			log.Fatalf("generating %q: %+v", rt, err)
+		}
+		return
+	}
 	resource, ok := provider.AzureProvider().ResourcesMap[rt]
 	if !ok {
 		log.Fatalf("unknown resource type: %s", rt)
@@ -0,0 +1,676 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+	"log"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+type propertyKind int
+
+const (
+	kindScalar propertyKind = iota
+	kindEnum
+	kindList
+	kindEnumList
+	kindMap
+	kindBlock
+	kindBlockList
+	kindLocation
+	kindTags
+	kindIdentity
+)
+
+// property is a property in the Schema for the Resource, mapped to a field in the SDK Model
+type property struct {
+	schemaName string
+	fieldName  string
+	kind       propertyKind
+
+	// sdkName and sdkType are the name and type of the field in the SDK Model
+	sdkName    string
+	sdkType    string
+	sdkPointer bool
+
+	// elemType is the Go type of the value (or the values in a list/map) for scalar properties, e.g. `string`
+	elemType string
+
+	// enum is the name of the constant type for enum properties
+	enum string
+
+	// nested is the model for the nested block for block properties
+	nested *nestedModel
+
+	// identity is the type of the Identity for identity properties
+	identity *identityType
+
+	// withinProperties specifies whether the field is within the `Properties` of the SDK Model, rather than top-level
+	withinProperties bool
+
+	required bool
+	forceNew bool
+}
+
+// nestedModel is a nested block within the Schema, mapped to a struct in the SDK
+type nestedModel struct {
+	name       string
+	sdkType    string
+	properties []*property
+}
+
+// identityType describes how an Identity within the SDK is exposed in the Schema
+type identityType struct {
+	schemaFunc  string
+	modelType   string
+	expandFunc  string
+	flattenFunc string
+
+	// flattenReturnsPointer specifies whether the flatten function returns a pointer to the models
+	flattenReturnsPointer bool
+
+	// flattenReturnsError specifies whether the flatten function returns an error
+	flattenReturnsError bool
+}
+
+var identityTypes = map[string]identityType{
+	"LegacySystemAndUserAssignedMap": {"SystemAssignedUserAssignedIdentityOptional", "ModelSystemAssignedUserAssigned", "ExpandLegacySystemAndUserAssignedMapFromModel", "FlattenLegacySystemAndUserAssignedMapToModel", false, true},
+	"SystemAndUserAssignedList":      {"SystemAssignedUserAssignedIdentityOptional", "ModelSystemAssignedUserAssigned", "ExpandSystemAndUserAssignedListFromModel", "FlattenSystemAndUserAssignedListToModel", true, true},
+	"SystemAndUserAssignedMap":       {"SystemAssignedUserAssignedIdentityOptional", "ModelSystemAssignedUserAssigned", "ExpandSystemAndUserAssignedMapFromModel", "FlattenSystemAndUserAssignedMapToModel", true, true},
+	"SystemAssigned":                 {"SystemAssignedIdentityOptional", "ModelSystemAssigned", "ExpandSystemAssignedFromModel", "FlattenSystemAssignedToModel", false, false},
+	"SystemOrUserAssignedList":       {"SystemOrUserAssignedIdentityOptional", "ModelSystemAssignedUserAssigned", "ExpandSystemOrUserAssignedListFromModel", "FlattenSystemAssignedOrUserAssignedListToModel", true, true},
+	"SystemOrUserAssignedMap":        {"SystemOrUserAssignedIdentityOptional", "ModelSystemAssignedUserAssigned", "ExpandSystemOrUserAssignedMapFromModel", "FlattenSystemOrUserAssignedMapToModel", true, true},
+	"UserAssignedList":               {"UserAssignedIdentityOptional", "ModelUserAssigned", "ExpandUserAssignedListFromModel", "FlattenUserAssignedListToModel", true, true},
+	"UserAssignedMap":                {"UserAssignedIdentityOptional", "ModelUserAssigned", "ExpandUserAssignedMapFromModel", "FlattenUserAssignedMapToModel", true, true},
+}
+
+// readOnlyFields are fields within the (top-level) SDK Model which are only returned from the API, so aren't exposed
+// as arguments - with the `provisioningState` being returned at any level
+var readOnlyFields = map[string]struct{}{
+	"Etag":       {},
+	"Id":         {},
+	"Name":       {},
+	"SystemData": {},
+	"Type":       {},
+}
+
+// resourceId is the Resource ID used for the Resource
+type resourceId struct {
+	packageName string
+	name        string
+	segments    []string
+}
+
+func (id resourceId) function(prefix string) string {
+	return fmt.Sprintf("%s.%s%sID", id.packageName, prefix, strings.TrimSuffix(id.name, "Id"))
+}
+
+// resourceDefinition is the Typed Resource generated from the SDK package
+type resourceDefinition struct {
+	resourceType string
+	name         string
+	client       string
+
+	sdk *sdkPackage
+	id  resourceId
+
+	// parentId is the Resource ID of the parent Resource, which is exposed as a `_id` property, when one exists
+	parentId *resourceId
+
+	// model is the SDK Model returned from the API, with properties being the (top-level) properties mapped to
+	// it, and propertiesType being the struct the `Properties` field of the model (if any)
+	model          string
+	properties     []*property
+	propertiesType string
+	propertiesPtr  bool
+
+	// updateModel is the SDK Model sent to the Update Operation, when this differs from model, alongside the
+	// (update) properties type
+	updateModel          string
+	updatePropertiesType string
+	updatePropertiesPtr  bool
+
+	nested []*nestedModel
+
+	create *sdkOperation
+	read   *sdkOperation
+	update *sdkOperation
+	delete *sdkOperation
+
+	// unsupported are the SDK fields which couldn't be mapped to the Schema, so need to be added manually
+	unsupported []string
+}
+
+// buildResourceDefinition builds the Typed Resource for the SDK package, where commonIds is the `commonids` package which
+// contains the Resource IDs shared between SDK packages
+func buildResourceDefinition(pkg *sdkPackage, commonIds *sdkPackage, resourceType, client string) (*resourceDefinition, error) {
+	if pkg.clientName == "" {
+		return nil, fmt.Errorf("no Client was found in %q", pkg.importPath)
+	}
+
+	r := resourceDefinition{
+		resourceType: resourceType,
+		name:         snake2Camel(strings.TrimPrefix(resourceType, "azurerm_")),
+		client:       client,
+		sdk:          pkg,
+		read:         pkg.operation("Get"),
+		create:       pkg.operation("CreateOrUpdate", "Create", "Put"),
+		update:       pkg.operation("Update", "Patch"),
+		delete:       pkg.operation("Delete"),
+	}
+	if r.read == nil || r.create == nil || r.delete == nil {
+		return nil, fmt.Errorf("%q must support Get, Create and Delete operations", pkg.clientName)
+	}
+	if r.update == nil && r.create.name == "CreateOrUpdate" {
+		r.update = r.create
+	}
+
+	idImportPath, idName, err := pkg.idType(r.read.name)
+	if err != nil {
+		return nil, err
+	}
+	idPackage := pkg
+	if idImportPath == commonIds.importPath {
+		idPackage = commonIds
+	}
+	idStruct, ok := idPackage.structs[idName]
+	if !ok {
+		return nil, fmt.Errorf("the Resource ID %q was not found in %q", idName, idPackage.importPath)
+	}
+	r.id = resourceId{
+		packageName: idPackage.name,
+		name:        idName,
+		segments:    fieldNames(idStruct),
+	}
+	r.parentId = findParentId(r.id, pkg, commonIds)
+
+	if r.model, err = pkg.responseModel(r.read.name); err != nil {
+		return nil, err
+	}
+	if err := r.buildProperties(); err != nil {
+		return nil, err
+	}
+	if r.update != nil && r.update.payloadType != r.model {
+		r.buildUpdateModel()
+	}
+
+	return &r, nil
+}
+
+// findParentId returns the Resource ID for the parent Resource, when one exists
+func findParentId(id resourceId, packages ...*sdkPackage) *resourceId {
+	if len(id.segments) < 2 {
+		return nil
+	}
+
+	parentSegments := id.segments[:len(id.segments)-1]
+	switch strings.Join(parentSegments, ",") {
+	case "SubscriptionId", "SubscriptionId,ResourceGroupName":
+		// these are exposed as `resource_group_name` (if needed) rather than a parent ID
+		return nil
+	}
+
+	for _, pkg := range packages {
+		names := make([]string, 0)
+		for name := range pkg.structs {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			if !strings.HasSuffix(name, "Id") || name == id.name {
+				continue
+			}
+			if reflect.DeepEqual(fieldNames(pkg.structs[name]), parentSegments) {
+				return &resourceId{
+					packageName: pkg.name,
+					name:        name,
+					segments:    parentSegments,
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func (r *resourceDefinition) buildProperties() error {
+	model, ok := r.sdk.structs[r.model]
+	if !ok {
+		return fmt.Errorf("the Model %q was not found", r.model)
+	}
+
+	forceNew := r.update == nil
+	for _, field := range model.Fields.List {
+		for _, name := range field.Names {
+			switch name.Name {
+			case "Location":
+				r.properties = append(r.properties, r.topLevelProperty("location", name.Name, kindLocation, field))
+				continue
+
+			case "Tags":
+				r.properties = append(r.properties, r.topLevelProperty("tags", name.Name, kindTags, field))
+				continue
+
+			case "Identity":
+				if selector, ok := derefType(field.Type).(*ast.SelectorExpr); ok && identName(selector.X) == "identity" {
+					if identity, ok := identityTypes[selector.Sel.Name]; ok {
+						p := r.topLevelProperty("identity", name.Name, kindIdentity, field)
+						p.identity = &identity
+						r.properties = append(r.properties, p)
+						continue
+					}
+				}
+
+			case "Properties":
+				if propertiesType := identName(field.Type); r.sdk.structs[propertiesType] != nil {
+					r.propertiesType = propertiesType
+					_, r.propertiesPtr = field.Type.(*ast.StarExpr)
+					for _, p := range r.propertiesFor(propertiesType, "properties", forceNew, nil) {
+						p.withinProperties = true
+						r.properties = append(r.properties, p)
+					}
+					continue
+				}
+			}
+
+			if _, ok := readOnlyFields[name.Name]; ok || name.Name == "ProvisioningState" {
+				continue
+			}
+			if p := r.propertyFor(name.Name, field, name.Name, forceNew, nil); p != nil {
+				r.properties = append(r.properties, p)
+			}
+		}
+	}
+
+	// the Name, Resource Group and Parent ID are exposed from the Resource ID, so can't be duplicated
+	reserved := map[string]struct{}{}
+	for _, p := range r.idProperties() {
+		reserved[p.schemaName] = struct{}{}
+	}
+	properties := make([]*property, 0)
+	for _, p := range r.properties {
+		if _, ok := reserved[p.schemaName]; ok {
+			r.unsupported = append(r.unsupported, fmt.Sprintf("%s (conflicts with the property from the Resource ID)", p.sdkName))
+			continue
+		}
+		properties = append(properties, p)
+	}
+	r.properties = properties
+
+	return nil
+}
+
+func (r *resourceDefinition) topLevelProperty(schemaName, sdkName string, kind propertyKind, field *ast.Field) *property {
+	_, isPointer := field.Type.(*ast.StarExpr)
+	return &property{
+		schemaName: schemaName,
+		fieldName:  snake2Camel(schemaName),
+		kind:       kind,
+		sdkName:    sdkName,
+		sdkType:    types.ExprString(field.Type),
+		sdkPointer: isPointer,
+		required:   kind == kindLocation,
+	}
+}
+
+// propertiesFor returns the properties for each field within the SDK struct, where path is the path to this struct
+// (used to describe unsupported fields) and stack contains the structs being mapped, to detect recursive models
+func (r *resourceDefinition) propertiesFor(structName, path string, forceNew bool, stack []string) []*property {
+	output := make([]*property, 0)
+	for _, field := range r.sdk.structs[structName].Fields.List {
+		for _, name := range field.Names {
+			if name.Name == "ProvisioningState" {
+				continue
+			}
+			if p := r.propertyFor(name.Name, field, fmt.Sprintf("%s.%s", path, name.Name), forceNew, append(stack, structName)); p != nil {
+				output = append(output, p)
+			}
+		}
+	}
+	return output
+}
+
+// propertyFor returns the property for the field within an SDK struct, or nil if this isn't supported
+func (r *resourceDefinition) propertyFor(sdkName string, field *ast.Field, path string, forceNew bool, stack []string) *property {
+	_, isPointer := field.Type.(*ast.StarExpr)
+	p := property{
+		schemaName: convertToSnakeCase(sdkName),
+		sdkName:    sdkName,
+		sdkType:    types.ExprString(field.Type),
+		sdkPointer: isPointer,
+		forceNew:   forceNew,
+	}
+	p.fieldName = snake2Camel(p.schemaName)
+
+	// fields which aren't pointers and are always sent are Required by the API
+	if !isPointer && field.Tag != nil {
+		tag := reflect.StructTag(strings.Trim(field.Tag.Value, "`"))
+		p.required = !strings.Contains(tag.Get("json"), "omitempty")
+	}
+
+	unsupported := func() *property {
+		r.unsupported = append(r.unsupported, fmt.Sprintf("%s (%s)", path, p.sdkType))
+		return nil
+	}
+
+	switch v := derefType(field.Type).(type) {
+	case *ast.Ident:
+		switch {
+		case isScalar(v.Name):
+			p.kind = kindScalar
+			p.elemType = v.Name
+		case r.isEnum(v.Name):
+			p.kind = kindEnum
+			p.enum = v.Name
+		case r.sdk.structs[v.Name] != nil:
+			nested := r.nestedModelFor(v.Name, path, forceNew, stack)
+			if nested == nil {
+				return unsupported()
+			}
+			p.kind = kindBlock
+			p.nested = nested
+		default:
+			return unsupported()
+		}
+
+	case *ast.ArrayType:
+		elem, ok := v.Elt.(*ast.Ident)
+		if !ok {
+			return unsupported()
+		}
+		switch {
+		case isScalar(elem.Name):
+			p.kind = kindList
+			p.elemType = elem.Name
+		case r.isEnum(elem.Name):
+			p.kind = kindEnumList
+			p.enum = elem.Name
+		case r.sdk.structs[elem.Name] != nil:
+			nested := r.nestedModelFor(elem.Name, path, forceNew, stack)
+			if nested == nil {
+				return unsupported()
+			}
+			p.kind = kindBlockList
+			p.nested = nested
+		default:
+			return unsupported()
+		}
+
+	case *ast.MapType:
+		key, keyOk := v.Key.(*ast.Ident)
+		value, valueOk := v.Value.(*ast.Ident)
+		if !keyOk || !valueOk || key.Name != "string" || !isScalar(value.Name) {
+			return unsupported()
+		}
+		p.kind = kindMap
+		p.elemType = value.Name
+
+	default:
+		return unsupported()
+	}
+
+	return &p
+}
+
+// nestedModelFor returns the model for the nested SDK struct, which is shared when the struct is used multiple times
+func (r *resourceDefinition) nestedModelFor(structName, path string, forceNew bool, stack []string) *nestedModel {
+	for _, v := range stack {
+		if v == structName {
+			// recursive models can't be represented in the Schema
+			return nil
+		}
+	}
+
+	for _, nested := range r.nested {
+		if nested.sdkType == structName {
+			return nested
+		}
+	}
+
+	nested := &nestedModel{
+		name:    fmt.Sprintf("%s%sModel", r.name, strings.TrimPrefix(structName, r.name)),
+		sdkType: structName,
+	}
+	r.nested = append(r.nested, nested)
+	nested.properties = r.propertiesFor(structName, path, forceNew, stack)
+	if len(nested.properties) == 0 {
+		r.nested = r.nested[:len(r.nested)-1]
+		return nil
+	}
+
+	return nested
+}
+
+// buildUpdateModel determines the properties which can be updated using the Update Model (for PATCH requests), with
+// the remaining properties being ForceNew
+func (r *resourceDefinition) buildUpdateModel() {
+	r.updateModel = r.update.payloadType
+	updatable := make(map[*property]struct{})
+
+	if model, ok := r.sdk.structs[r.updateModel]; ok {
+		for _, field := range model.Fields.List {
+			for _, name := range field.Names {
+				if name.Name == "Properties" && r.sdk.structs[identName(field.Type)] != nil {
+					r.updatePropertiesType = identName(field.Type)
+					_, r.updatePropertiesPtr = field.Type.(*ast.StarExpr)
+
+					for _, updateField := range r.sdk.structs[r.updatePropertiesType].Fields.List {
+						for _, updateName := range updateField.Names {
+							if p := r.matchingProperty(updateName.Name, updateField, true); p != nil {
+								updatable[p] = struct{}{}
+							}
+						}
+					}
+					continue
+				}
+
+				if p := r.matchingProperty(name.Name, field, false); p != nil {
+					updatable[p] = struct{}{}
+				}
+			}
+		}
+	}
+
+	for _, p := range r.properties {
+		if _, ok := updatable[p]; ok {
+			continue
+		}
+		setForceNew(p)
+	}
+}
+
+// matchingProperty returns the property mapped to a field of the same name and type within the Model (or Properties)
+func (r *resourceDefinition) matchingProperty(sdkName string, field *ast.Field, withinProperties bool) *property {
+	for _, p := range r.properties {
+		if p.sdkName == sdkName && p.sdkType == types.ExprString(field.Type) && p.withinProperties == withinProperties {
+			return p
+		}
+	}
+	return nil
+}
+
+func setForceNew(p *property) {
+	p.forceNew = true
+	if p.nested != nil {
+		for _, nested := range p.nested.properties {
+			if !nested.forceNew {
+				setForceNew(nested)
+			}
+		}
+	}
+}
+
+// updatable returns the properties which are sent in the Update Model, rather than the Model
+func (r *resourceDefinition) updatable(withinProperties bool) []*property {
+	if r.updateModel == "" {
+		return nil
+	}
+
+	output := make([]*property, 0)
+	for _, p := range r.properties {
+		if p.forceNew || p.kind == kindLocation {
+			continue
+		}
+		if p.withinProperties != withinProperties {
+			continue
+		}
+		output = append(output, p)
+	}
+	return output
+}
+
+// idProperties returns the properties which make up the Resource ID
+func (r *resourceDefinition) idProperties() []*property {
+	output := []*property{
+		{
+			schemaName: "name",
+			fieldName:  "Name",
+			kind:       kindScalar,
+			elemType:   "string",
+			sdkName:    r.id.segments[len(r.id.segments)-1],
+			required:   true,
+			forceNew:   true,
+		},
+	}
+
+	if r.parentId != nil {
+		schemaName := convertToSnakeCase(r.parentId.name)
+		output = append(output, &property{
+			schemaName: schemaName,
+			fieldName:  snake2Camel(schemaName),
+			kind:       kindScalar,
+			elemType:   "string",
+			required:   true,
+			forceNew:   true,
+		})
+		return output
+	}
+
+	for _, segment := range r.id.segments[:len(r.id.segments)-1] {
+		if segment == "SubscriptionId" {
+			continue
+		}
+		schemaName := convertToSnakeCase(segment)
+		output = append(output, &property{
+			schemaName: schemaName,
+			fieldName:  snake2Camel(schemaName),
+			kind:       kindScalar,
+			elemType:   "string",
+			sdkName:    segment,
+			required:   true,
+			forceNew:   true,
+		})
+	}
+	return output
+}
+
+func (r *resourceDefinition) isEnum(name string) bool {
+	_, ok := r.sdk.constants[name]
+	return ok
+}
+
+func (r *resourceDefinition) usesIdentity() bool {
+	for _, p := range r.properties {
+		if p.kind == kindIdentity {
+			return true
+		}
+	}
+	return false
+}
+
+func isScalar(typeName string) bool {
+	switch typeName {
+	case "bool", "float64", "int64", "string":
+		return true
+	}
+	return false
+}
+
+func derefType(expr ast.Expr) ast.Expr {
+	if star, ok := expr.(*ast.StarExpr); ok {
+		return star.X
+	}
+	return expr
+}
+
+// convertToSnakeCase converts a Go identifier to snake_case, treating acronyms as a single word (e.g. `DataPlaneURI`
+// becomes `data_plane_uri`)
+func convertToSnakeCase(input string) string {
+	runes := []rune(input)
+	output := make([]rune, 0, len(runes)+5)
+	for i, r := range runes {
+		if i > 0 && unicode.IsUpper(r) {
+			previous := runes[i-1]
+			nextIsLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(previous) || unicode.IsDigit(previous) || (unicode.IsUpper(previous) && nextIsLower) {
+				output = append(output, '_')
+			}
+		}
+		output = append(output, unicode.ToLower(r))
+	}
+	return string(output)
+}
+
+// runScaffold generates the Typed Resource (and Acceptance Tests) for the SDK package into the Service Package
+func runScaffold(importPath, servicePackagePath, client, resourceType string) error {
+	if servicePackagePath == "" || client == "" {
+		return fmt.Errorf("`-path` and `-client` must be specified when using `-sdk-package`")
+	}
+
+	pkg, err := loadSdkPackage(importPath)
+	if err != nil {
+		return err
+	}
+	commonIds, err := loadSdkPackage(commonIdsImportPath)
+	if err != nil {
+		return err
+	}
+
+	definition, err := buildResourceDefinition(pkg, commonIds, resourceType, client)
+	if err != nil {
+		return err
+	}
+
+	packageName := filepath.Base(servicePackagePath)
+	resourceCode, err := definition.ResourceCode(packageName)
+	if err != nil {
+		return err
+	}
+	testCode, err := definition.TestCode(packageName)
+	if err != nil {
+		return err
+	}
+
+	fileName := filepath.Join(servicePackagePath, strings.TrimPrefix(resourceType, "azurerm_")+"_resource")
+	files := map[string]string{
+		fileName + ".go":      resourceCode,
+		fileName + "_test.go": testCode,
+	}
+	for path := range files {
+		if _, err := os.Stat(path); err == nil {
+			return fmt.Errorf("%q already exists", path)
+		}
+	}
+	for path, code := range files {
+		if err := os.WriteFile(path, []byte(code), 0o644); err != nil {
+			return fmt.Errorf("writing %q: %+v", path, err)
+		}
+		log.Printf("[DEBUG] Generated %q", path)
+	}
+
+	for _, v := range definition.unsupported {
+		log.Printf("[WARN] %q couldn't be mapped to the Schema and needs to be added manually", v)
+	}
+
+	return nil
+}
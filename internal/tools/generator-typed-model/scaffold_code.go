@@ -0,0 +1,881 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package main
+
+import (
+	"fmt"
+	"go/format"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// knownImports are the packages which can be referenced from the generated code, keyed by the package name
+var knownImports = map[string]string{
+	"acceptance":   "github.com/hashicorp/terraform-provider-azurerm/internal/acceptance",
+	"check":        "github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/check",
+	"clients":      "github.com/hashicorp/terraform-provider-azurerm/internal/clients",
+	"commonids":    commonIdsImportPath,
+	"commonschema": "github.com/hashicorp/go-azure-helpers/resourcemanager/commonschema",
+	"context":      "context",
+	"fmt":          "fmt",
+	"identity":     "github.com/hashicorp/go-azure-helpers/resourcemanager/identity",
+	"location":     "github.com/hashicorp/go-azure-helpers/resourcemanager/location",
+	"pluginsdk":    "github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk",
+	"pointer":      "github.com/hashicorp/go-azure-helpers/lang/pointer",
+	"response":     "github.com/hashicorp/go-azure-helpers/lang/response",
+	"sdk":          "github.com/hashicorp/terraform-provider-azurerm/internal/sdk",
+	"tags":         "github.com/hashicorp/go-azure-helpers/resourcemanager/tags",
+	"testing":      "testing",
+	"time":         "time",
+	"validation":   "github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation",
+}
+
+// withImports prepends the package declaration and the imports for the packages referenced in the code, then formats it
+func (r resourceDefinition) withImports(packageName, code string) (string, error) {
+	imports := map[string]string{
+		r.sdk.name: r.sdk.importPath,
+	}
+	for name, importPath := range knownImports {
+		imports[name] = importPath
+	}
+
+	standard := make([]string, 0)
+	external := make([]string, 0)
+	for name, importPath := range imports {
+		if !regexp.MustCompile(`(^|[^\w.])` + name + `\.`).MatchString(code) {
+			continue
+		}
+		if strings.Contains(importPath, ".") {
+			external = append(external, fmt.Sprintf("%q", importPath))
+		} else {
+			standard = append(standard, fmt.Sprintf("%q", importPath))
+		}
+	}
+	sort.Strings(standard)
+	sort.Strings(external)
+
+	output := fmt.Sprintf(`// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package %s
+
+import (
+	%s
+
+	%s
+)
+%s`, packageName, strings.Join(standard, "\n"), strings.Join(external, "\n"), code)
+
+	formatted, err := format.Source([]byte(output))
+	if err != nil {
+		return "", fmt.Errorf("formatting the generated code: %+v\n\n%s", err, output)
+	}
+	return string(formatted), nil
+}
+
+// ResourceCode returns the code for the Typed Resource
+func (r resourceDefinition) ResourceCode(packageName string) (string, error) {
+	sections := []string{
+		r.codeForResource(),
+		r.codeForModels(),
+		r.codeForSchema(),
+		r.codeForCreate(),
+		r.codeForRead(),
+		r.codeForUpdate(),
+		r.codeForDelete(),
+		r.codeForExpandAndFlatten(),
+	}
+
+	return r.withImports(packageName, strings.Join(sections, "\n"))
+}
+
+func (r resourceDefinition) codeForResource() string {
+	interfaces := "var _ sdk.Resource = %[1]sResource{}"
+	if r.update != nil {
+		interfaces = "var (\n_ sdk.Resource = %[1]sResource{}\n_ sdk.ResourceWithUpdate = %[1]sResource{}\n)"
+	}
+
+	return fmt.Sprintf(`
+`+interfaces+`
+
+type %[1]sResource struct{}
+
+func (r %[1]sResource) ResourceType() string {
+	return %[2]q
+}
+
+func (r %[1]sResource) ModelObject() interface{} {
+	return &%[1]sModel{}
+}
+
+func (r %[1]sResource) IDValidationFunc() pluginsdk.SchemaValidateFunc {
+	return %[3]s
+}
+`, r.name, r.resourceType, r.id.function("Validate"))
+}
+
+func (r resourceDefinition) codeForModels() string {
+	output := []string{
+		r.codeForModel(r.name+"Model", append(r.idProperties(), r.properties...)),
+	}
+	for _, nested := range r.nested {
+		output = append(output, r.codeForModel(nested.name, nested.properties))
+	}
+	return strings.Join(output, "\n")
+}
+
+func (r resourceDefinition) codeForModel(name string, properties []*property) string {
+	fields := make([]string, 0)
+	for _, p := range properties {
+		fields = append(fields, fmt.Sprintf("%s %s `tfschema:%q`", p.fieldName, r.modelType(p), p.schemaName))
+	}
+
+	return fmt.Sprintf(`
+type %s struct {
+	%s
+}
+`, name, strings.Join(fields, "\n"))
+}
+
+func (r resourceDefinition) modelType(p *property) string {
+	switch p.kind {
+	case kindEnum:
+		return "string"
+	case kindList:
+		return "[]" + p.elemType
+	case kindEnumList:
+		return "[]string"
+	case kindMap:
+		return "map[string]" + p.elemType
+	case kindBlock, kindBlockList:
+		return "[]" + p.nested.name
+	case kindLocation:
+		return "string"
+	case kindTags:
+		return "map[string]interface{}"
+	case kindIdentity:
+		return "[]identity." + p.identity.modelType
+	}
+	return p.elemType
+}
+
+func (r resourceDefinition) codeForSchema() string {
+	arguments := make([]string, 0)
+	for _, p := range r.idProperties() {
+		arguments = append(arguments, r.codeForIdProperty(p))
+	}
+
+	// Required arguments are output before Optional arguments, with Tags being output last
+	properties := make([]*property, len(r.properties))
+	copy(properties, r.properties)
+	sort.SliceStable(properties, func(i, j int) bool {
+		if (properties[i].kind == kindLocation) != (properties[j].kind == kindLocation) {
+			return properties[i].kind == kindLocation
+		}
+		if (properties[i].kind == kindTags) != (properties[j].kind == kindTags) {
+			return properties[j].kind == kindTags
+		}
+		if properties[i].required != properties[j].required {
+			return properties[i].required
+		}
+		return properties[i].schemaName < properties[j].schemaName
+	})
+	for _, p := range properties {
+		arguments = append(arguments, fmt.Sprintf("%q: %s,", p.schemaName, r.codeForProperty(p)))
+	}
+
+	todo := ""
+	if len(r.unsupported) > 0 {
+		todo = "\n// TODO: the following fields couldn't be mapped and need to be added manually:\n"
+		for _, v := range r.unsupported {
+			todo += fmt.Sprintf("// * %s\n", v)
+		}
+	}
+
+	return fmt.Sprintf(`
+func (r %[1]sResource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		%[2]s
+%[3]s	}
+}
+
+func (r %[1]sResource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{}
+}
+`, r.name, strings.Join(arguments, "\n\n"), todo)
+}
+
+func (r resourceDefinition) codeForIdProperty(p *property) string {
+	validateFunc := "validation.StringIsNotEmpty"
+	switch {
+	case p.schemaName == "resource_group_name":
+		return `"resource_group_name": commonschema.ResourceGroupName(),`
+	case r.parentId != nil && p.sdkName == "":
+		validateFunc = r.parentId.function("Validate")
+	}
+
+	return fmt.Sprintf(`%q: {
+	Type:         pluginsdk.TypeString,
+	Required:     true,
+	ForceNew:     true,
+	ValidateFunc: %s,
+},`, p.schemaName, validateFunc)
+}
+
+func (r resourceDefinition) codeForProperty(p *property) string {
+	switch p.kind {
+	case kindLocation:
+		return "commonschema.Location()"
+	case kindTags:
+		return "commonschema.Tags()"
+	case kindIdentity:
+		return "commonschema." + p.identity.schemaFunc + "()"
+	}
+
+	lines := make([]string, 0)
+	switch p.kind {
+	case kindScalar, kindEnum:
+		lines = append(lines, "Type: "+schemaType(p.elemType))
+	case kindMap:
+		lines = append(lines, "Type: pluginsdk.TypeMap")
+	default:
+		lines = append(lines, "Type: pluginsdk.TypeList")
+	}
+
+	if p.required {
+		lines = append(lines, "Required: true")
+	} else {
+		lines = append(lines, "Optional: true")
+	}
+	if p.forceNew {
+		lines = append(lines, "ForceNew: true")
+	}
+	if p.kind == kindBlock {
+		lines = append(lines, "MaxItems: 1")
+	}
+
+	switch p.kind {
+	case kindScalar:
+		if p.elemType == "string" {
+			lines = append(lines, "ValidateFunc: validation.StringIsNotEmpty")
+		}
+	case kindEnum:
+		lines = append(lines, fmt.Sprintf("ValidateFunc: validation.StringInSlice(%s.PossibleValuesFor%s(), false)", r.sdk.name, p.enum))
+	case kindList, kindMap:
+		elem := "Type: " + schemaType(p.elemType) + ","
+		if p.elemType == "string" {
+			elem += "\nValidateFunc: validation.StringIsNotEmpty,"
+		}
+		lines = append(lines, fmt.Sprintf("Elem: &pluginsdk.Schema{\n%s\n}", elem))
+	case kindEnumList:
+		lines = append(lines, fmt.Sprintf("Elem: &pluginsdk.Schema{\nType: pluginsdk.TypeString,\nValidateFunc: validation.StringInSlice(%s.PossibleValuesFor%s(), false),\n}", r.sdk.name, p.enum))
+	case kindBlock, kindBlockList:
+		nested := make([]string, 0)
+		for _, v := range p.nested.properties {
+			nested = append(nested, fmt.Sprintf("%q: %s,", v.schemaName, r.codeForProperty(v)))
+		}
+		lines = append(lines, fmt.Sprintf("Elem: &pluginsdk.Resource{\nSchema: map[string]*pluginsdk.Schema{\n%s\n},\n}", strings.Join(nested, "\n\n")))
+	}
+
+	return fmt.Sprintf("{\n%s,\n}", strings.Join(lines, ",\n"))
+}
+
+func schemaType(elemType string) string {
+	switch elemType {
+	case "bool":
+		return "pluginsdk.TypeBool"
+	case "float64":
+		return "pluginsdk.TypeFloat"
+	case "int64":
+		return "pluginsdk.TypeInt"
+	}
+	return "pluginsdk.TypeString"
+}
+
+// callOperation returns the code calling the Operation, returning an error using the description if this fails
+func (r resourceDefinition) callOperation(op *sdkOperation, id, payload, description string) string {
+	args := []string{"ctx", id}
+	if op.payloadType != "" {
+		args = append(args, payload)
+	}
+	if op.hasOptions {
+		args = append(args, r.operationOptions(op.name))
+	}
+
+	call := fmt.Sprintf("_, err := client.%s(%s); err != nil", op.name, strings.Join(args, ", "))
+	if op.longRunning {
+		call = fmt.Sprintf("err := client.%sThenPoll(%s); err != nil", op.name, strings.Join(args, ", "))
+	}
+
+	return fmt.Sprintf(`if %s {
+	return fmt.Errorf("%s %%s: %%+v", %s, err)
+}`, call, description, id)
+}
+
+func (r resourceDefinition) operationOptions(operation string) string {
+	if _, ok := r.sdk.functions[fmt.Sprintf("Default%sOperationOptions", operation)]; ok {
+		return fmt.Sprintf("%s.Default%sOperationOptions()", r.sdk.name, operation)
+	}
+	return fmt.Sprintf("%s.%sOperationOptions{}", r.sdk.name, operation)
+}
+
+func (r resourceDefinition) getArgs(id string) string {
+	if r.read.hasOptions {
+		return fmt.Sprintf("ctx, %s, %s", id, r.operationOptions(r.read.name))
+	}
+	return "ctx, " + id
+}
+
+func (r resourceDefinition) codeForCreate() string {
+	idArgs := make([]string, 0)
+	parseParent := ""
+	usesSubscriptionId := false
+	if r.parentId != nil {
+		for _, segment := range r.parentId.segments {
+			idArgs = append(idArgs, "parentId."+segment)
+		}
+		parseParent = fmt.Sprintf(`
+			parentId, err := %s(config.%s)
+			if err != nil {
+				return err
+			}
+`, r.parentId.function("Parse"), snake2Camel(convertToSnakeCase(r.parentId.name)))
+	} else {
+		for _, segment := range r.id.segments[:len(r.id.segments)-1] {
+			if segment == "SubscriptionId" {
+				usesSubscriptionId = true
+				idArgs = append(idArgs, "subscriptionId")
+				continue
+			}
+			idArgs = append(idArgs, "config."+snake2Camel(convertToSnakeCase(segment)))
+		}
+	}
+	idArgs = append(idArgs, "config.Name")
+
+	subscriptionId := ""
+	if usesSubscriptionId {
+		subscriptionId = "\nsubscriptionId := metadata.Client.Account.SubscriptionId"
+	}
+
+	return fmt.Sprintf(`
+func (r %[1]sResource) Create() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.%[2]s%[3]s
+
+			var config %[1]sModel
+			if err := metadata.Decode(&config); err != nil {
+				return fmt.Errorf("decoding: %%+v", err)
+			}
+%[4]s
+			id := %[5]s(%[6]s)
+
+			existing, err := client.Get(%[7]s)
+			if err != nil {
+				if !response.WasNotFound(existing.HttpResponse) {
+					return fmt.Errorf("checking for presence of existing %%s: %%+v", id, err)
+				}
+			}
+			if !response.WasNotFound(existing.HttpResponse) {
+				return metadata.ResourceRequiresImport(r.ResourceType(), id)
+			}
+
+			%[8]s
+
+			%[9]s
+
+			metadata.SetID(id)
+			return nil
+		},
+	}
+}
+`, r.name, r.client, subscriptionId, parseParent, r.id.function("New"), strings.Join(idArgs, ", "), r.getArgs("id"), r.codeForExpandCall(r.model, "id"), r.callOperation(r.create, "id", "payload", "creating"))
+}
+
+// codeForExpandCall returns the code expanding the config into the payload for the specified SDK Model
+func (r resourceDefinition) codeForExpandCall(model, id string) string {
+	if r.usesIdentity() {
+		return fmt.Sprintf(`payload, err := r.expand%[1]s(config)
+if err != nil {
+	return fmt.Errorf("expanding %%s: %%+v", %[2]s, err)
+}`, model, id)
+	}
+	return fmt.Sprintf("payload := r.expand%s(config)", model)
+}
+
+func (r resourceDefinition) codeForRead() string {
+	state := []string{
+		fmt.Sprintf("Name: id.%s,", r.id.segments[len(r.id.segments)-1]),
+	}
+	if r.parentId != nil {
+		args := make([]string, 0)
+		for _, segment := range r.parentId.segments {
+			args = append(args, "id."+segment)
+		}
+		state = append(state, fmt.Sprintf("%s: %s(%s).ID(),", snake2Camel(convertToSnakeCase(r.parentId.name)), r.parentId.function("New"), strings.Join(args, ", ")))
+	} else {
+		for _, segment := range r.id.segments[:len(r.id.segments)-1] {
+			if segment != "SubscriptionId" {
+				state = append(state, fmt.Sprintf("%s: id.%s,", snake2Camel(convertToSnakeCase(segment)), segment))
+			}
+		}
+	}
+
+	flatten := "r.flatten%[1]s(*model, &state)"
+	if r.usesIdentity() {
+		flatten = `if err := r.flatten%[1]s(*model, &state); err != nil {
+	return fmt.Errorf("flattening %%s: %%+v", *id, err)
+}`
+	}
+
+	return fmt.Sprintf(`
+func (r %[1]sResource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.%[2]s
+
+			id, err := %[3]s(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			resp, err := client.Get(%[4]s)
+			if err != nil {
+				if response.WasNotFound(resp.HttpResponse) {
+					return metadata.MarkAsGone(*id)
+				}
+				return fmt.Errorf("retrieving %%s: %%+v", *id, err)
+			}
+
+			state := %[1]sModel{
+				%[5]s
+			}
+
+			if model := resp.Model; model != nil {
+				%[6]s
+			}
+
+			return metadata.Encode(&state)
+		},
+	}
+}
+`, r.name, r.client, r.id.function("Parse"), r.getArgs("*id"), strings.Join(state, "\n"), fmt.Sprintf(flatten, r.model))
+}
+
+func (r resourceDefinition) codeForUpdate() string {
+	if r.update == nil {
+		return ""
+	}
+
+	model := r.model
+	if r.updateModel != "" {
+		model = r.updateModel
+	}
+
+	return fmt.Sprintf(`
+func (r %[1]sResource) Update() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.%[2]s
+
+			id, err := %[3]s(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			var config %[1]sModel
+			if err := metadata.Decode(&config); err != nil {
+				return fmt.Errorf("decoding: %%+v", err)
+			}
+
+			%[4]s
+
+			%[5]s
+
+			return nil
+		},
+	}
+}
+`, r.name, r.client, r.id.function("Parse"), r.codeForExpandCall(model, "*id"), r.callOperation(r.update, "*id", "payload", "updating"))
+}
+
+func (r resourceDefinition) codeForDelete() string {
+	return fmt.Sprintf(`
+func (r %[1]sResource) Delete() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.%[2]s
+
+			id, err := %[3]s(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			%[4]s
+
+			return nil
+		},
+	}
+}
+`, r.name, r.client, r.id.function("Parse"), r.callOperation(r.delete, "*id", "", "deleting"))
+}
+
+func (r resourceDefinition) codeForExpandAndFlatten() string {
+	output := []string{
+		r.codeForExpandModel(r.model, r.propertiesType, r.propertiesPtr, r.topLevel(false), r.topLevel(true)),
+	}
+	if r.updateModel != "" {
+		output = append(output, r.codeForExpandModel(r.updateModel, r.updatePropertiesType, r.updatePropertiesPtr, r.updatable(false), r.updatable(true)))
+	}
+	output = append(output, r.codeForFlattenModel())
+
+	for _, nested := range r.nested {
+		output = append(output, fmt.Sprintf(`
+func (r %[1]sResource) expand%[2]s(input %[3]s) %[4]s.%[2]s {
+	output := %[4]s.%[2]s{}
+	%[5]s
+	return output
+}
+
+func (r %[1]sResource) flatten%[2]s(input %[4]s.%[2]s) %[3]s {
+	output := %[3]s{}
+	%[6]s
+	return output
+}
+`, r.name, nested.sdkType, nested.name, r.sdk.name, r.expandStatements(nested.properties, "input", "output"), r.flattenStatements(nested.properties, "input", "output")))
+	}
+
+	return strings.Join(output, "\n")
+}
+
+func (r resourceDefinition) topLevel(withinProperties bool) []*property {
+	output := make([]*property, 0)
+	for _, p := range r.properties {
+		if p.withinProperties == withinProperties {
+			output = append(output, p)
+		}
+	}
+	return output
+}
+
+func (r resourceDefinition) codeForExpandModel(model, propertiesType string, propertiesPtr bool, topLevel, properties []*property) string {
+	statements := r.expandStatements(topLevel, "input", "output")
+	if propertiesType != "" {
+		assign := "output.Properties = properties"
+		if propertiesPtr {
+			assign = "output.Properties = &properties"
+		}
+		statements += fmt.Sprintf(`
+
+properties := %s.%s{}
+%s
+%s
+`, r.sdk.name, propertiesType, r.expandStatements(properties, "input", "properties"), assign)
+	}
+
+	if r.usesIdentity() {
+		return fmt.Sprintf(`
+func (r %[1]sResource) expand%[2]s(input %[1]sModel) (%[3]s.%[2]s, error) {
+	output := %[3]s.%[2]s{}
+	%[4]s
+	return output, nil
+}
+`, r.name, model, r.sdk.name, statements)
+	}
+
+	return fmt.Sprintf(`
+func (r %[1]sResource) expand%[2]s(input %[1]sModel) %[3]s.%[2]s {
+	output := %[3]s.%[2]s{}
+	%[4]s
+	return output
+}
+`, r.name, model, r.sdk.name, statements)
+}
+
+func (r resourceDefinition) codeForFlattenModel() string {
+	statements := r.flattenStatements(r.topLevel(false), "input", "output")
+	if r.propertiesType != "" {
+		props := "if props := input.Properties; props != nil {\n%s\n}"
+		if !r.propertiesPtr {
+			props = "props := input.Properties\n%s"
+		}
+		statements += "\n\n" + fmt.Sprintf(props, r.flattenStatements(r.topLevel(true), "props", "output"))
+	}
+
+	if r.usesIdentity() {
+		return fmt.Sprintf(`
+func (r %[1]sResource) flatten%[2]s(input %[3]s.%[2]s, output *%[1]sModel) error {
+	%[4]s
+	return nil
+}
+`, r.name, r.model, r.sdk.name, statements)
+	}
+
+	return fmt.Sprintf(`
+func (r %[1]sResource) flatten%[2]s(input %[3]s.%[2]s, output *%[1]sModel) {
+	%[4]s
+}
+`, r.name, r.model, r.sdk.name, statements)
+}
+
+// expandStatements returns the statements assigning the properties from the Schema model (in) to the SDK model (out)
+func (r resourceDefinition) expandStatements(properties []*property, in, out string) string {
+	output := make([]string, 0)
+	for _, p := range properties {
+		value := fmt.Sprintf("%s.%s", in, p.fieldName)
+		target := fmt.Sprintf("%s.%s", out, p.sdkName)
+		items := lowerFirst(p.sdkName) + "Items"
+
+		switch p.kind {
+		case kindScalar, kindList, kindMap:
+			output = append(output, fmt.Sprintf("%s = %s", target, wrapPointer(value, p.sdkPointer)))
+		case kindEnum:
+			output = append(output, fmt.Sprintf("%s = %s", target, wrapPointer(fmt.Sprintf("%s.%s(%s)", r.sdk.name, p.enum, value), p.sdkPointer)))
+		case kindEnumList:
+			output = append(output, fmt.Sprintf(`%[1]s := make([]%[2]s.%[3]s, 0)
+for _, v := range %[4]s {
+	%[1]s = append(%[1]s, %[2]s.%[3]s(v))
+}
+%[5]s = %[6]s`, items, r.sdk.name, p.enum, value, target, wrapAddress(items, p.sdkPointer)))
+		case kindBlock:
+			output = append(output, fmt.Sprintf(`if len(%[1]s) > 0 {
+	%[2]s = %[3]s
+}`, value, target, wrapPointer(fmt.Sprintf("r.expand%s(%s[0])", p.nested.sdkType, value), p.sdkPointer)))
+		case kindBlockList:
+			output = append(output, fmt.Sprintf(`%[1]s := make([]%[2]s.%[3]s, 0)
+for _, v := range %[4]s {
+	%[1]s = append(%[1]s, r.expand%[3]s(v))
+}
+%[5]s = %[6]s`, items, r.sdk.name, p.nested.sdkType, value, target, wrapAddress(items, p.sdkPointer)))
+		case kindLocation:
+			output = append(output, fmt.Sprintf("%s = %s", target, wrapPointer(fmt.Sprintf("location.Normalize(%s)", value), p.sdkPointer)))
+		case kindTags:
+			output = append(output, fmt.Sprintf("%s = tags.Expand(%s)", target, value))
+		case kindIdentity:
+			output = append(output, fmt.Sprintf(`expandedIdentity, err := identity.%[1]s(%[2]s)
+if err != nil {
+	return output, fmt.Errorf("expanding %[3]s: %%+v", err)
+}
+%[4]s = expandedIdentity`, p.identity.expandFunc, value, "`identity`", target))
+		}
+	}
+	return strings.Join(output, "\n")
+}
+
+// flattenStatements returns the statements assigning the properties from the SDK model (in) to the Schema model (out)
+func (r resourceDefinition) flattenStatements(properties []*property, in, out string) string {
+	output := make([]string, 0)
+	for _, p := range properties {
+		value := fmt.Sprintf("%s.%s", in, p.sdkName)
+		target := fmt.Sprintf("%s.%s", out, p.fieldName)
+		unwrapped := value
+		if p.sdkPointer {
+			unwrapped = fmt.Sprintf("pointer.From(%s)", value)
+		}
+
+		switch p.kind {
+		case kindScalar, kindList, kindMap:
+			output = append(output, fmt.Sprintf("%s = %s", target, unwrapped))
+		case kindEnum:
+			output = append(output, fmt.Sprintf("%s = string(%s)", target, unwrapped))
+		case kindEnumList:
+			output = append(output, fmt.Sprintf(`for _, v := range %[1]s {
+	%[2]s = append(%[2]s, string(v))
+}`, unwrapped, target))
+		case kindBlock:
+			if p.sdkPointer {
+				output = append(output, fmt.Sprintf(`if v := %[1]s; v != nil {
+	%[2]s = []%[3]s{r.flatten%[4]s(*v)}
+}`, value, target, p.nested.name, p.nested.sdkType))
+			} else {
+				output = append(output, fmt.Sprintf("%s = []%s{r.flatten%s(%s)}", target, p.nested.name, p.nested.sdkType, value))
+			}
+		case kindBlockList:
+			output = append(output, fmt.Sprintf(`for _, v := range %[1]s {
+	%[2]s = append(%[2]s, r.flatten%[3]s(v))
+}`, unwrapped, target, p.nested.sdkType))
+		case kindLocation:
+			if p.sdkPointer {
+				output = append(output, fmt.Sprintf("%s = location.NormalizeNilable(%s)", target, value))
+			} else {
+				output = append(output, fmt.Sprintf("%s = location.Normalize(%s)", target, value))
+			}
+		case kindTags:
+			output = append(output, fmt.Sprintf("%s = tags.Flatten(%s)", target, value))
+		case kindIdentity:
+			flattened := "flattenedIdentity"
+			if p.identity.flattenReturnsPointer {
+				flattened = "pointer.From(flattenedIdentity)"
+			}
+			if p.identity.flattenReturnsError {
+				output = append(output, fmt.Sprintf(`flattenedIdentity, err := identity.%[1]s(%[2]s)
+if err != nil {
+	return fmt.Errorf("flattening %[3]s: %%+v", err)
+}
+%[4]s = %[5]s`, p.identity.flattenFunc, value, "`identity`", target, flattened))
+			} else {
+				output = append(output, fmt.Sprintf("%s = identity.%s(%s)", target, p.identity.flattenFunc, value))
+			}
+		}
+	}
+	return strings.Join(output, "\n")
+}
+
+func wrapPointer(value string, isPointer bool) string {
+	if isPointer {
+		return fmt.Sprintf("pointer.To(%s)", value)
+	}
+	return value
+}
+
+func wrapAddress(value string, isPointer bool) string {
+	if isPointer {
+		return "&" + value
+	}
+	return value
+}
+
+func lowerFirst(input string) string {
+	if input == "" {
+		return input
+	}
+	return strings.ToLower(input[:1]) + input[1:]
+}
+
+// TestCode returns the code for the Acceptance Tests for the Typed Resource
+func (r resourceDefinition) TestCode(packageName string) (string, error) {
+	arguments := [][2]string{
+		{"name", `"acctest-%[1]d"`},
+	}
+	importArguments := make([][2]string, 0)
+	for _, p := range r.idProperties() {
+		importArguments = append(importArguments, [2]string{p.schemaName, fmt.Sprintf("%s.test.%s", r.resourceType, p.schemaName)})
+		switch p.schemaName {
+		case "name":
+		case "resource_group_name":
+			arguments = append(arguments, [2]string{p.schemaName, "azurerm_resource_group.test.name"})
+		default:
+			arguments = append(arguments, [2]string{p.schemaName, "# TODO"})
+		}
+	}
+	for _, p := range r.properties {
+		switch {
+		case p.kind == kindLocation:
+			arguments = append(arguments, [2]string{p.schemaName, "azurerm_resource_group.test.location"})
+		case p.required:
+			arguments = append(arguments, [2]string{p.schemaName, "# TODO"})
+		default:
+			continue
+		}
+		importArguments = append(importArguments, [2]string{p.schemaName, fmt.Sprintf("%s.test.%s", r.resourceType, p.schemaName)})
+	}
+
+	exists := "clients." + r.client
+	code := fmt.Sprintf(`
+type %[1]sResource struct{}
+
+func TestAcc%[1]s_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, %[2]q, "test")
+	r := %[1]sResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAcc%[1]s_requiresImport(t *testing.T) {
+	data := acceptance.BuildTestData(t, %[2]q, "test")
+	r := %[1]sResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.RequiresImportErrorStep(r.requiresImport),
+	})
+}
+
+func (r %[1]sResource) Exists(ctx context.Context, clients *clients.Client, state *pluginsdk.InstanceState) (*bool, error) {
+	id, err := %[3]s(state.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := %[4]s.Get(%[5]s)
+	if err != nil {
+		return nil, fmt.Errorf("retrieving %%s: %%+v", *id, err)
+	}
+
+	return pointer.To(resp.Model != nil), nil
+}
+
+func (r %[1]sResource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`+"`"+`
+%%[2]s
+
+resource %[2]q "test" {
+  %[6]s
+}
+`+"`"+`, data.RandomInteger, r.template(data))
+}
+
+func (r %[1]sResource) requiresImport(data acceptance.TestData) string {
+	return fmt.Sprintf(`+"`"+`
+%%s
+
+resource %[2]q "import" {
+  %[7]s
+}
+`+"`"+`, r.basic(data))
+}
+
+func (r %[1]sResource) template(data acceptance.TestData) string {
+	return fmt.Sprintf(`+"`"+`
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-%%[1]d"
+  location = %%[2]q
+}
+`+"`"+`, data.RandomInteger, data.Locations.Primary)
+}
+`, r.name, r.resourceType, r.id.function("Parse"), exists, r.getArgs("*id"), hclArguments(arguments), hclArguments(importArguments))
+
+	return r.withImports(packageName+"_test", code)
+}
+
+// hclArguments returns the arguments for a block in the Terraform Configuration, aligned as `terraform fmt` would, where
+// arguments which need to be completed are output as comments
+func hclArguments(input [][2]string) string {
+	width := 0
+	for _, v := range input {
+		width = max(width, len(v[0]))
+	}
+
+	output := make([]string, 0)
+	for _, v := range input {
+		if strings.HasPrefix(v[1], "#") {
+			output = append(output, fmt.Sprintf("%s %s", v[1], v[0]))
+			continue
+		}
+		output = append(output, fmt.Sprintf("%-*s = %s", width, v[0], v[1]))
+	}
+	return strings.Join(output, "\n  ")
+}
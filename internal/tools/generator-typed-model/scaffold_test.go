@@ -0,0 +1,143 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestConvertToSnakeCase(t *testing.T) {
+	cases := map[string]string{
+		"Description":          "description",
+		"DataPlaneURI":         "data_plane_uri",
+		"KeyUrl":               "key_url",
+		"IPAddress":            "ip_address",
+		"ResourceGroupName":    "resource_group_name",
+		"SqlServerId":          "sql_server_id",
+		"Ipv4Address":          "ipv4_address",
+		"MaxSizeBytes":         "max_size_bytes",
+		"HighAvailabilityMode": "high_availability_mode",
+	}
+
+	for input, expected := range cases {
+		if actual := convertToSnakeCase(input); actual != expected {
+			t.Fatalf("expected %q to be %q but got %q", input, expected, actual)
+		}
+	}
+}
+
+func TestScaffold_loadTest(t *testing.T) {
+	pkg, err := loadSdkPackage("github.com/hashicorp/go-azure-sdk/resource-manager/loadtestservice/2022-12-01/loadtests")
+	if err != nil {
+		t.Fatalf("loading the SDK package: %+v", err)
+	}
+	commonIds, err := loadSdkPackage(commonIdsImportPath)
+	if err != nil {
+		t.Fatalf("loading commonids: %+v", err)
+	}
+
+	definition, err := buildResourceDefinition(pkg, commonIds, "azurerm_load_test_example", "LoadTestService.V20221201.LoadTests")
+	if err != nil {
+		t.Fatalf("building the definition: %+v", err)
+	}
+
+	if definition.updateModel != "LoadTestResourceUpdate" {
+		t.Fatalf("expected the Update Model to be %q but got %q", "LoadTestResourceUpdate", definition.updateModel)
+	}
+
+	// the properties which aren't within the Update Model must be ForceNew
+	forceNew := map[string]bool{
+		"data_plane_uri": true,
+		"description":    false,
+		"encryption":     false,
+		"identity":       false,
+		"location":       false,
+		"tags":           false,
+	}
+	for _, p := range definition.properties {
+		expected, ok := forceNew[p.schemaName]
+		if !ok {
+			t.Fatalf("unexpected property %q", p.schemaName)
+		}
+		if p.kind != kindLocation && p.forceNew != expected {
+			t.Fatalf("expected ForceNew for %q to be %t but got %t", p.schemaName, expected, p.forceNew)
+		}
+		delete(forceNew, p.schemaName)
+	}
+	if len(forceNew) > 0 {
+		t.Fatalf("expected the properties %+v to be mapped", forceNew)
+	}
+
+	code, err := definition.ResourceCode("loadtestservice")
+	if err != nil {
+		t.Fatalf("generating the resource: %+v", err)
+	}
+	for _, expected := range []string{
+		"type LoadTestExampleModel struct",
+		"type LoadTestExampleEncryptionPropertiesIdentityModel struct",
+		"return loadtests.ValidateLoadTestID",
+		"id := loadtests.NewLoadTestID(subscriptionId, config.ResourceGroupName, config.Name)",
+		"client.CreateOrUpdateThenPoll(ctx, id, payload)",
+		"client.UpdateThenPoll(ctx, *id, payload)",
+		"client.DeleteThenPoll(ctx, *id)",
+		"validation.StringInSlice(loadtests.PossibleValuesForType(), false)",
+		"identity.ExpandLegacySystemAndUserAssignedMapFromModel(input.Identity)",
+	} {
+		if !strings.Contains(code, expected) {
+			t.Fatalf("expected the generated resource to contain %q:\n\n%s", expected, code)
+		}
+	}
+
+	testCode, err := definition.TestCode("loadtestservice")
+	if err != nil {
+		t.Fatalf("generating the tests: %+v", err)
+	}
+	for _, expected := range []string{
+		"package loadtestservice_test",
+		"func TestAccLoadTestExample_basic(t *testing.T)",
+		"clients.LoadTestService.V20221201.LoadTests.Get(ctx, *id)",
+		"resource_group_name = azurerm_resource_group.test.name",
+	} {
+		if !strings.Contains(testCode, expected) {
+			t.Fatalf("expected the generated tests to contain %q:\n\n%s", expected, testCode)
+		}
+	}
+}
+
+func TestScaffold_parentId(t *testing.T) {
+	pkg, err := loadSdkPackage("github.com/hashicorp/go-azure-sdk/resource-manager/sql/2023-02-01-preview/databases")
+	if err != nil {
+		t.Fatalf("loading the SDK package: %+v", err)
+	}
+	commonIds, err := loadSdkPackage(commonIdsImportPath)
+	if err != nil {
+		t.Fatalf("loading commonids: %+v", err)
+	}
+
+	definition, err := buildResourceDefinition(pkg, commonIds, "azurerm_mssql_database_example", "MSSQL.DatabasesClient")
+	if err != nil {
+		t.Fatalf("building the definition: %+v", err)
+	}
+
+	if definition.parentId == nil || definition.parentId.name != "SqlServerId" {
+		t.Fatalf("expected the parent ID to be %q but got %+v", "SqlServerId", definition.parentId)
+	}
+
+	code, err := definition.ResourceCode("mssql")
+	if err != nil {
+		t.Fatalf("generating the resource: %+v", err)
+	}
+	for _, expected := range []string{
+		`"sql_server_id": {`,
+		"ValidateFunc: commonids.ValidateSqlServerID",
+		"id := commonids.NewSqlDatabaseID(parentId.SubscriptionId, parentId.ResourceGroupName, parentId.ServerName, config.Name)",
+		"SqlServerId: commonids.NewSqlServerID(id.SubscriptionId, id.ResourceGroupName, id.ServerName).ID(),",
+		"client.Get(ctx, *id, databases.DefaultGetOperationOptions())",
+	} {
+		if !strings.Contains(code, expected) {
+			t.Fatalf("expected the generated resource to contain %q:\n\n%s", expected, code)
+		}
+	}
+}
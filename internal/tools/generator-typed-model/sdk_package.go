@@ -0,0 +1,232 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os/exec"
+	"path"
+	"strings"
+)
+
+const commonIdsImportPath = "github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+
+// sdkPackage is a (parsed) go-azure-sdk package for a single API Resource, e.g. `loadtestservice/2022-12-01/loadtests`
+type sdkPackage struct {
+	importPath string
+	name       string
+
+	// structs are the Models (and Resource IDs) defined in this package, keyed by name
+	structs map[string]*ast.StructType
+
+	// constants are the types which define a set of possible values, keyed by name
+	constants map[string]struct{}
+
+	// functions are the top-level functions in this package, keyed by name
+	functions map[string]*ast.FuncDecl
+
+	// clientName is the name of the Client used to call the API Operations
+	clientName string
+
+	// operations are the methods on the Client, keyed by name
+	operations map[string]*ast.FuncDecl
+}
+
+// loadSdkPackage locates the source for the package (using `go list`, so that the vendored package is used where
+// present) and parses it
+func loadSdkPackage(importPath string) (*sdkPackage, error) {
+	out, err := exec.Command("go", "list", "-f", "{{.Dir}}", importPath).Output()
+	if err != nil {
+		return nil, fmt.Errorf("locating the source for %q: %+v", importPath, err)
+	}
+
+	return parseSdkPackage(importPath, strings.TrimSpace(string(out)))
+}
+
+func parseSdkPackage(importPath, directory string) (*sdkPackage, error) {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, directory, nil, parser.SkipObjectResolution)
+	if err != nil {
+		return nil, fmt.Errorf("parsing %q: %+v", directory, err)
+	}
+
+	pkg := sdkPackage{
+		importPath: importPath,
+		name:       path.Base(importPath),
+		structs:    make(map[string]*ast.StructType),
+		constants:  make(map[string]struct{}),
+		functions:  make(map[string]*ast.FuncDecl),
+		operations: make(map[string]*ast.FuncDecl),
+	}
+
+	methods := make(map[string][]*ast.FuncDecl)
+	for name, p := range pkgs {
+		if strings.HasSuffix(name, "_test") {
+			continue
+		}
+		pkg.name = name
+
+		for _, file := range p.Files {
+			for _, decl := range file.Decls {
+				switch v := decl.(type) {
+				case *ast.GenDecl:
+					for _, spec := range v.Specs {
+						typeSpec, ok := spec.(*ast.TypeSpec)
+						if !ok {
+							continue
+						}
+						if s, ok := typeSpec.Type.(*ast.StructType); ok {
+							pkg.structs[typeSpec.Name.Name] = s
+						}
+					}
+
+				case *ast.FuncDecl:
+					if v.Recv == nil {
+						pkg.functions[v.Name.Name] = v
+						continue
+					}
+					if receiver := identName(v.Recv.List[0].Type); receiver != "" {
+						methods[receiver] = append(methods[receiver], v)
+					}
+				}
+			}
+		}
+	}
+
+	for name := range pkg.functions {
+		if constant, ok := strings.CutPrefix(name, "PossibleValuesFor"); ok {
+			pkg.constants[constant] = struct{}{}
+		}
+	}
+
+	// the Client (if any) is the only type wrapping a base layer client, e.g. `Client *resourcemanager.Client`
+	for name, s := range pkg.structs {
+		if strings.HasSuffix(name, "Client") && len(s.Fields.List) == 1 && identName(s.Fields.List[0].Type) == "Client" {
+			pkg.clientName = name
+		}
+	}
+	for _, method := range methods[pkg.clientName] {
+		pkg.operations[method.Name.Name] = method
+	}
+
+	return &pkg, nil
+}
+
+// sdkOperation is an API Operation on the Client which is used by the Resource
+type sdkOperation struct {
+	name string
+
+	// payloadType is the name of the Model sent in the request body, if any
+	payloadType string
+
+	// hasOptions specifies whether the Operation accepts an Options struct
+	hasOptions bool
+
+	// longRunning specifies whether the Operation has a `ThenPoll` variant which should be used
+	longRunning bool
+}
+
+// operation returns the first Operation defined on the Client matching one of the names, or nil if none are
+func (p sdkPackage) operation(names ...string) *sdkOperation {
+	for _, name := range names {
+		method, ok := p.operations[name]
+		if !ok {
+			continue
+		}
+
+		op := sdkOperation{
+			name: name,
+		}
+		_, op.longRunning = p.operations[name+"ThenPoll"]
+
+		// the arguments are `ctx`, `id`, followed by the optional `input` and `options`
+		params := make([]ast.Expr, 0)
+		for _, field := range method.Type.Params.List {
+			for i := 0; i < max(len(field.Names), 1); i++ {
+				params = append(params, field.Type)
+			}
+		}
+		for _, param := range params[min(len(params), 2):] {
+			typeName := identName(param)
+			if typeName == name+"OperationOptions" {
+				op.hasOptions = true
+				continue
+			}
+			op.payloadType = typeName
+		}
+
+		return &op
+	}
+
+	return nil
+}
+
+// idType returns the Resource ID accepted by the Operation, which is either defined in this package or in `commonids`
+func (p sdkPackage) idType(operation string) (importPath, name string, err error) {
+	method, ok := p.operations[operation]
+	if !ok {
+		return "", "", fmt.Errorf("the Operation %q was not found on %q", operation, p.clientName)
+	}
+
+	params := method.Type.Params.List
+	if len(params) < 2 {
+		return "", "", fmt.Errorf("the Operation %q doesn't accept a Resource ID", operation)
+	}
+
+	switch v := params[1].Type.(type) {
+	case *ast.Ident:
+		return p.importPath, v.Name, nil
+	case *ast.SelectorExpr:
+		if identName(v.X) == "commonids" {
+			return commonIdsImportPath, v.Sel.Name, nil
+		}
+	}
+
+	return "", "", fmt.Errorf("the Resource ID accepted by %q isn't supported", operation)
+}
+
+// responseModel returns the name of the Model returned from the Operation
+func (p sdkPackage) responseModel(operation string) (string, error) {
+	response, ok := p.structs[operation+"OperationResponse"]
+	if !ok {
+		return "", fmt.Errorf("the response for %q was not found", operation)
+	}
+
+	for _, field := range response.Fields.List {
+		if len(field.Names) == 1 && field.Names[0].Name == "Model" {
+			if name := identName(field.Type); name != "" {
+				return name, nil
+			}
+		}
+	}
+
+	return "", fmt.Errorf("the response for %q doesn't contain a Model", operation)
+}
+
+// fieldNames returns the names of the fields within the struct, in the order they're defined
+func fieldNames(s *ast.StructType) []string {
+	output := make([]string, 0)
+	for _, field := range s.Fields.List {
+		for _, name := range field.Names {
+			output = append(output, name.Name)
+		}
+	}
+	return output
+}
+
+// identName returns the name of the type, ignoring any pointers, e.g. `Foo` for `*Foo`
+func identName(expr ast.Expr) string {
+	switch v := expr.(type) {
+	case *ast.StarExpr:
+		return identName(v.X)
+	case *ast.Ident:
+		return v.Name
+	case *ast.SelectorExpr:
+		return v.Sel.Name
+	}
+	return ""
+}
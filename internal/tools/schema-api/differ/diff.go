@@ -5,6 +5,7 @@ package differ
 
 import (
 	"fmt"
+	"sort"
 
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/schema-api/providerjson"
 	schema_rules "github.com/hashicorp/terraform-provider-azurerm/internal/tools/schema-api/schema-rules"
@@ -15,55 +16,87 @@ type Differ struct {
 	current *providerjson.ProviderWrapper
 }
 
-func (d *Differ) Diff(fileName string, providerName string) []string {
+// Violation is a breaking change for a Resource or Data Source
+type Violation struct {
+	ResourceType string
+	IsDataSource bool
+	Message      string
+}
+
+func (v Violation) String() string {
+	if v.IsDataSource {
+		return fmt.Sprintf("Data Source %q: %s", v.ResourceType, v.Message)
+	}
+	return fmt.Sprintf("Resource %q: %s", v.ResourceType, v.Message)
+}
+
+func (d *Differ) Diff(fileName string, providerName string) ([]Violation, error) {
 	if err := d.loadFromProvider(providerjson.LoadData(), providerName); err != nil {
-		return []string{err.Error()}
+		return nil, err
 	}
 
 	if err := d.loadFromFile(fileName); err != nil {
-		return []string{err.Error()}
+		return nil, err
 	}
 
 	if d.base.ProviderName != d.current.ProviderName {
-		return []string{fmt.Sprintf("provider name mismatch, expected %q, got %q", d.base.ProviderName, d.current.ProviderName)}
+		return nil, fmt.Errorf("provider name mismatch, expected %q, got %q", d.base.ProviderName, d.current.ProviderName)
+	}
+
+	// the validation for each property is only included in v2 of the schema, so can't be compared with older schemas
+	if d.base.SchemaVersion < providerjson.SchemaVersionWithValidation {
+		removeValidation(d.current.ProviderSchema.ResourcesMap)
+		removeValidation(d.current.ProviderSchema.DataSourcesMap)
 	}
 
-	violations := make([]string, 0)
+	violations := make([]Violation, 0)
+	violations = append(violations, compareResources(d.base.ProviderSchema.ResourcesMap, d.current.ProviderSchema.ResourcesMap, false)...)
+	violations = append(violations, compareResources(d.base.ProviderSchema.DataSourcesMap, d.current.ProviderSchema.DataSourcesMap, true)...)
+
+	return violations, nil
+}
+
+func compareResources(base map[string]providerjson.ResourceJSON, current map[string]providerjson.ResourceJSON, isDataSource bool) []Violation {
+	resourceRules := schema_rules.ResourceBreakingChangeRules
+	if isDataSource {
+		resourceRules = schema_rules.ResourceBreakingChangeRulesDataSource
+	}
 
-	for resource, rs := range d.current.ProviderSchema.ResourcesMap {
-		_, ok := d.base.ProviderSchema.ResourcesMap[resource]
-		if !ok {
+	violations := make([]Violation, 0)
+	for _, resourceType := range sortedKeys(base, current) {
+		baseResource, inBase := base[resourceType]
+		currentResource, inCurrent := current[resourceType]
+		if !inBase {
 			// New resource, no breaking changes to worry about
 			continue
 		}
-		for propertyName, propertySchema := range rs.Schema {
-			// Get the same from the base (released) json
-			baseItem, ok := d.base.ProviderSchema.ResourcesMap[resource].Schema[propertyName]
-			if !ok {
-				// New property, could be breaking - Required etc
-				baseItem = providerjson.SchemaJSON{}
-			}
-			if errs := compareNodeResource(baseItem, propertySchema, propertyName); errs != nil {
-				violations = append(violations, errs...)
+
+		var currentResourcePtr *providerjson.ResourceJSON
+		if inCurrent {
+			currentResourcePtr = &currentResource
+		}
+		for _, rule := range resourceRules {
+			if err := rule.Check(&baseResource, currentResourcePtr); err != nil {
+				violations = append(violations, Violation{
+					ResourceType: resourceType,
+					IsDataSource: isDataSource,
+					Message:      *err,
+				})
 			}
 		}
-	}
-
-	for dataSource, ds := range d.current.ProviderSchema.DataSourcesMap {
-		_, ok := d.base.ProviderSchema.DataSourcesMap[dataSource]
-		if !ok {
-			// New data source, no breaking changes to worry about
+		if !inCurrent {
+			// the removal of the properties is covered by the removal of the resource
 			continue
 		}
-		for propertyName, propertySchema := range ds.Schema {
-			// Get the same from the base (released) json
-			baseItem, ok := d.base.ProviderSchema.DataSourcesMap[dataSource].Schema[propertyName]
-			if !ok {
-				// New property, could be breaking - Required etc
-				baseItem = providerjson.SchemaJSON{}
-			}
-			if errs := compareNodeDataSource(baseItem, propertySchema, propertyName); errs != nil {
-				violations = append(violations, errs...)
+
+		for _, propertyName := range sortedKeys(baseResource.Schema, currentResource.Schema) {
+			// New properties are compared to an empty schema, since these could be breaking - Required etc
+			for _, err := range compareNode(baseResource.Schema[propertyName], currentResource.Schema[propertyName], propertyName, isDataSource) {
+				violations = append(violations, Violation{
+					ResourceType: resourceType,
+					IsDataSource: isDataSource,
+					Message:      err,
+				})
 			}
 		}
 	}
@@ -71,16 +104,20 @@ func (d *Differ) Diff(fileName string, providerName string) []string {
 	return violations
 }
 
-func compareNodeResource(base providerjson.SchemaJSON, current providerjson.SchemaJSON, nodeName string) (errs []string) {
-	if nodeIsBlock(base) {
-		newBaseRaw := base.Elem.(providerjson.ResourceJSON).Schema
-		newCurrent := current.Elem.(*providerjson.ResourceJSON).Schema
-		for k, newBase := range newBaseRaw {
-			errs = append(errs, compareNodeResource(newBase, newCurrent[k], k)...)
+func compareNode(base providerjson.SchemaJSON, current providerjson.SchemaJSON, nodeName string, isDataSource bool) (errs []string) {
+	if baseBlock := blockSchema(base); baseBlock != nil {
+		currentBlock := blockSchema(current)
+		for _, k := range sortedKeys(baseBlock, nil) {
+			// when the block has been removed (or is no longer a block) the nested properties are compared against an empty schema
+			errs = append(errs, compareNode(baseBlock[k], currentBlock[k], fmt.Sprintf("%s.%s", nodeName, k), isDataSource)...)
 		}
 	}
 
-	for _, v := range schema_rules.BreakingChangeRules {
+	rules := schema_rules.BreakingChangeRules
+	if isDataSource {
+		rules = schema_rules.BreakingChangeRulesDataSource
+	}
+	for _, v := range rules {
 		if err := v.Check(base, current, nodeName); err != nil {
 			errs = append(errs, *err)
 		}
@@ -89,30 +126,57 @@ func compareNodeResource(base providerjson.SchemaJSON, current providerjson.Sche
 	return
 }
 
-func compareNodeDataSource(base providerjson.SchemaJSON, current providerjson.SchemaJSON, nodeName string) (errs []string) {
-	if nodeIsBlock(base) {
-		newBaseRaw := base.Elem.(providerjson.ResourceJSON).Schema
-		newCurrent := current.Elem.(*providerjson.ResourceJSON).Schema
-		for k, newBase := range newBaseRaw {
-			errs = append(errs, compareNodeDataSource(newBase, newCurrent[k], k)...)
-		}
+// blockSchema returns the schema for the nested block, if this property is a block
+func blockSchema(input providerjson.SchemaJSON) map[string]providerjson.SchemaJSON {
+	if input.Type != providerjson.SchemaTypeList && input.Type != providerjson.SchemaTypeSet {
+		return nil
 	}
 
-	for _, v := range schema_rules.BreakingChangeRulesDataSource {
-		if err := v.Check(base, current, nodeName); err != nil {
-			errs = append(errs, *err)
+	// the schema loaded from the file contains values, whereas the schema loaded from the provider contains pointers
+	switch elem := input.Elem.(type) {
+	case providerjson.ResourceJSON:
+		return elem.Schema
+	case *providerjson.ResourceJSON:
+		if elem != nil {
+			return elem.Schema
 		}
 	}
 
-	return
+	return nil
+}
+
+// removeValidation removes the validation for each property, recursing into any nested blocks
+func removeValidation(resources map[string]providerjson.ResourceJSON) {
+	for _, resource := range resources {
+		removeValidationFromSchema(resource.Schema)
+	}
 }
 
-func nodeIsBlock(input providerjson.SchemaJSON) bool {
-	if input.Type == providerjson.SchemaTypeList || input.Type == providerjson.SchemaTypeSet {
-		if _, ok := input.Elem.(providerjson.ResourceJSON); ok {
-			return true
+func removeValidationFromSchema(input map[string]providerjson.SchemaJSON) {
+	for k, v := range input {
+		v.Validation = nil
+		input[k] = v
+
+		if block := blockSchema(v); block != nil {
+			removeValidationFromSchema(block)
 		}
 	}
+}
+
+// sortedKeys returns the union of the keys from both maps, in a consistent order
+func sortedKeys[T any](first map[string]T, second map[string]T) []string {
+	keys := make(map[string]struct{})
+	for k := range first {
+		keys[k] = struct{}{}
+	}
+	for k := range second {
+		keys[k] = struct{}{}
+	}
 
-	return false
+	output := make([]string, 0, len(keys))
+	for k := range keys {
+		output = append(output, k)
+	}
+	sort.Strings(output)
+	return output
 }
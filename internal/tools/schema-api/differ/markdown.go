@@ -0,0 +1,60 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package differ
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Markdown renders the violations as a CHANGELOG section, grouped by Resource (then Data Source) in alphabetical order
+func Markdown(violations []Violation) string {
+	if len(violations) == 0 {
+		return ""
+	}
+
+	sorted := make([]Violation, len(violations))
+	copy(sorted, violations)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].IsDataSource != sorted[j].IsDataSource {
+			return !sorted[i].IsDataSource
+		}
+		return sorted[i].ResourceType < sorted[j].ResourceType
+	})
+
+	lines := []string{
+		"BREAKING CHANGES:",
+		"",
+	}
+	for _, v := range sorted {
+		prefix := ""
+		if v.IsDataSource {
+			prefix = "Data Source: "
+		}
+		lines = append(lines, fmt.Sprintf("* %s`%s` - %s", prefix, v.ResourceType, changelogMessage(v.Message)))
+	}
+
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// changelogMessage formats the message for use in the CHANGELOG, where property names are formatted as code
+func changelogMessage(input string) string {
+	output := make([]string, 0)
+	for i, part := range strings.Split(input, `"`) {
+		// every other part is within double-quotes
+		if i%2 == 1 && !strings.ContainsAny(part, " [") {
+			part = fmt.Sprintf("`%s`", part)
+		} else if i%2 == 1 {
+			part = fmt.Sprintf("%q", part)
+		}
+		output = append(output, part)
+	}
+
+	message := strings.Join(output, "")
+	if message != "" {
+		message = strings.ToLower(message[:1]) + message[1:]
+	}
+	return message
+}
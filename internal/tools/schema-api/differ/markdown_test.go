@@ -0,0 +1,40 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package differ
+
+import (
+	"testing"
+)
+
+func TestMarkdown(t *testing.T) {
+	violations := []Violation{
+		{
+			ResourceType: "azurerm_storage_account",
+			IsDataSource: true,
+			Message:      `property "sku" has been removed without first being deprecated`,
+		},
+		{
+			ResourceType: "azurerm_storage_account",
+			Message:      `the validation for property "account_tier" has become stricter, it now no longer supports the values ["Basic"]`,
+		},
+		{
+			ResourceType: "azurerm_resource_group",
+			Message:      `property "managed_by" has become ForceNew, meaning that changing it will recreate the resource`,
+		},
+	}
+
+	expected := "BREAKING CHANGES:\n" +
+		"\n" +
+		"* `azurerm_resource_group` - property `managed_by` has become ForceNew, meaning that changing it will recreate the resource\n" +
+		"* `azurerm_storage_account` - the validation for property `account_tier` has become stricter, it now no longer supports the values [`Basic`]\n" +
+		"* Data Source: `azurerm_storage_account` - property `sku` has been removed without first being deprecated\n"
+
+	if actual := Markdown(violations); actual != expected {
+		t.Fatalf("expected:\n%s\ngot:\n%s", expected, actual)
+	}
+
+	if actual := Markdown(nil); actual != "" {
+		t.Fatalf("expected no output for no violations but got %q", actual)
+	}
+}
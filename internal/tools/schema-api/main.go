@@ -26,6 +26,7 @@ func main() {
 	exportSchema := f.String("export", "", "export the schema to the given path/filename. Intended for use in the release process")
 	detectBreakingChanges := f.String("detect", "", "compare current schema to named dump.")
 	errorOnBreakingChange := f.Bool("error-on-violation", false, "should the detect mode exit with a non-zero error code. Defaults to `false`")
+	outputFormat := f.String("output", "text", "the format used to output the violations in detect mode, either `text` or `markdown` (a CHANGELOG section grouped by resource). Defaults to `text`")
 
 	if err := f.Parse(os.Args[1:]); err != nil {
 		fmt.Printf("error parsing args: %+v", err)
@@ -41,7 +42,7 @@ func main() {
 			log.Printf("dumping schema for '%s'", *providerName)
 			wrappedProvider := &providerjson.ProviderWrapper{
 				ProviderName:  *providerName,
-				SchemaVersion: "1",
+				SchemaVersion: providerjson.SchemaVersionWithValidation,
 			}
 			if err := providerjson.DumpWithWrapper(wrappedProvider, data); err != nil {
 				log.Fatalf("error dumping provider: %+v", err)
@@ -53,9 +54,18 @@ func main() {
 	case pointer.From(detectBreakingChanges) != "":
 		{
 			d := differ.Differ{}
-			if violations := d.Diff(*detectBreakingChanges, *providerName); violations != nil {
-				for _, v := range violations {
-					log.Println(v)
+			violations, err := d.Diff(*detectBreakingChanges, *providerName)
+			if err != nil {
+				log.Fatalf("error detecting breaking changes: %+v", err)
+			}
+			if len(violations) > 0 {
+				switch pointer.From(outputFormat) {
+				case "markdown":
+					fmt.Print(differ.Markdown(violations))
+				default:
+					for _, v := range violations {
+						log.Println(v)
+					}
 				}
 				if pointer.From(errorOnBreakingChange) {
 					os.Exit(1)
@@ -70,7 +80,7 @@ func main() {
 			log.Printf("dumping schema for '%s'", *providerName)
 			wrappedProvider := &providerjson.ProviderWrapper{
 				ProviderName:  *providerName,
-				SchemaVersion: "1",
+				SchemaVersion: providerjson.SchemaVersionWithValidation,
 			}
 			if err := providerjson.WriteWithWrapper(wrappedProvider, data, *exportSchema); err != nil {
 				log.Fatalf("error writing provider schema for %q to %q: %+v", *providerName, *exportSchema, err)
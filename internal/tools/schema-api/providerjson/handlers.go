@@ -40,6 +40,9 @@ func (p *ProviderJSON) ResourcesHandler(w http.ResponseWriter, req *http.Request
 	dsRaw := strings.Split(req.URL.RequestURI(), ResourcesPath)
 	ds := strings.Split(dsRaw[1], "/")[0]
 	data, err := resourceFromRaw(p.ResourcesMap[ds])
+	if data != nil {
+		data.IDFormat = resourceIdFormats()[ds]
+	}
 	if err != nil {
 		w.WriteHeader(http.StatusNotFound)
 		log.Println(w.Write([]byte(fmt.Sprintf("[{\"error\": \"Could not process ProviderSchema for %q from provider: %+v\"}]", ds, err))))
@@ -19,6 +19,9 @@ const (
 	SchemaTypeFloat  = "Float"
 )
 
+// SchemaVersionWithValidation is the version of the schema which includes the validation for each property
+const SchemaVersionWithValidation = "2"
+
 type ProviderJSON schema.Provider
 
 type SchemaJSON struct {
@@ -33,6 +36,9 @@ type SchemaJSON struct {
 	Elem        interface{} `json:"elem,omitempty"`
 	MaxItems    int         `json:"maxItems,omitempty"`
 	MinItems    int         `json:"minItems,omitempty"`
+	Deprecated  string      `json:"deprecated,omitempty"`
+
+	Validation *ValidationJSON `json:"validation,omitempty"`
 }
 
 func (b *SchemaJSON) UnmarshalJSON(body []byte) error {
@@ -52,8 +58,10 @@ func (b *SchemaJSON) UnmarshalJSON(body []byte) error {
 		b.MaxItems = int(max)
 	}
 	if min, ok := m["minItems"].(float64); ok {
-		b.MaxItems = int(min)
+		b.MinItems = int(min)
 	}
+	b.Deprecated, _ = m["deprecated"].(string)
+	b.Validation = validationFromMap(m["validation"])
 
 	if def, ok := m["default"]; ok && def != nil {
 		switch def.(type) {
@@ -86,6 +94,13 @@ func (b *SchemaJSON) UnmarshalJSON(body []byte) error {
 type ResourceJSON struct {
 	Schema   map[string]SchemaJSON `json:"schema"`
 	Timeouts *ResourceTimeoutJSON  `json:"timeouts,omitempty"`
+
+	// Deprecated is the deprecation message for this Resource, if it's deprecated
+	Deprecated string `json:"deprecated,omitempty"`
+
+	// IDFormat is the format of the Resource ID expected when importing this Resource, where this can be determined
+	// from the `IDValidationFunc` of a Typed Resource
+	IDFormat string `json:"idFormat,omitempty"`
 }
 
 type ResourceTimeoutJSON struct {
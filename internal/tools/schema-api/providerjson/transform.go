@@ -21,6 +21,7 @@ func resourceFromRaw(input *schema.Resource) (*ResourceJSON, error) {
 		translatedSchema[k] = schemaFromRaw(s)
 	}
 	result.Schema = translatedSchema
+	result.Deprecated = input.DeprecationMessage
 
 	if input.Timeouts != nil {
 		timeouts := &ResourceTimeoutJSON{}
@@ -57,6 +58,8 @@ func schemaFromRaw(input *schema.Schema) SchemaJSON {
 		Elem:        decodeElem(input.Elem),
 		MaxItems:    input.MaxItems,
 		MinItems:    input.MinItems,
+		Deprecated:  input.Deprecated,
+		Validation:  validationFromRaw(input),
 	}
 }
 
@@ -94,10 +97,12 @@ func SchemaFromMap(input map[string]interface{}) SchemaJSON {
 		result.ForceNew = t.(bool)
 	}
 
-	if t, ok := input["forceNew"]; ok {
-		result.ForceNew = t.(bool)
+	if t, ok := input["deprecated"]; ok {
+		result.Deprecated = t.(string)
 	}
 
+	result.Validation = validationFromMap(input["validation"])
+
 	if t, ok := input["elem"]; ok {
 		result.Elem = decodeElem(t)
 	}
@@ -169,11 +174,13 @@ func ProviderFromRaw(input *ProviderJSON) (*ProviderSchemaJSON, error) {
 		providerSchema[k] = schemaFromRaw(v)
 	}
 
+	idFormats := resourceIdFormats()
 	for k, v := range input.ResourcesMap {
 		resource, err := resourceFromRaw(v)
 		if err != nil {
 			return nil, err
 		}
+		resource.IDFormat = idFormats[k]
 		resourceSchemas[k] = *resource
 	}
 
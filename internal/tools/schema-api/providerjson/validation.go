@@ -0,0 +1,250 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package providerjson
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/provider"
+)
+
+// ValidationJSON describes the constraints applied by the ValidateFunc for a property. Since validation functions
+// can't be inspected, these are determined by calling the ValidateFunc with values which are expected to be invalid
+// and parsing the errors returned from the common validation functions (such as `validation.StringInSlice`)
+type ValidationJSON struct {
+	AllowedValues []string `json:"allowedValues,omitempty"`
+	MinLength     *int     `json:"minLength,omitempty"`
+	MaxLength     *int     `json:"maxLength,omitempty"`
+	MinValue      *int     `json:"minValue,omitempty"`
+	MaxValue      *int     `json:"maxValue,omitempty"`
+}
+
+var (
+	allowedValuesRegex = regexp.MustCompile(`to be one of \[(.*)\], got`)
+	lengthRangeRegex   = regexp.MustCompile(`expected length of .+ to be in the range \((\d+) - (\d+)\)`)
+	valueRangeRegex    = regexp.MustCompile(`expected .+ to be in the range \((-?\d+) - (-?\d+)\)`)
+	valueAtLeastRegex  = regexp.MustCompile(`expected .+ to be at least \((-?\d+)\)`)
+	valueAtMostRegex   = regexp.MustCompile(`expected .+ to be at most \((-?\d+)\)`)
+)
+
+// validationFromRaw returns the constraints for the ValidateFunc of the schema, or nil if there isn't one
+func validationFromRaw(input *schema.Schema) *ValidationJSON {
+	if input.ValidateFunc == nil {
+		return nil
+	}
+
+	var probes []interface{}
+	switch input.Type {
+	case schema.TypeString:
+		// an empty string, an (unlikely) enum value and a value longer than any name
+		probes = []interface{}{"", "\x00schema-api", strings.Repeat("a", 100000)}
+	case schema.TypeInt:
+		probes = []interface{}{math.MinInt32, math.MaxInt32}
+	default:
+		return nil
+	}
+
+	output := &ValidationJSON{}
+	for _, probe := range probes {
+		for _, message := range validationErrors(input.ValidateFunc, probe) {
+			output.parse(message)
+		}
+	}
+
+	return output
+}
+
+// validationFromMap returns the constraints from the JSON representation of the ValidationJSON, or nil if there aren't any
+func validationFromMap(input interface{}) *ValidationJSON {
+	raw, ok := input.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	output := &ValidationJSON{}
+	if values, ok := raw["allowedValues"].([]interface{}); ok {
+		output.AllowedValues = make([]string, 0, len(values))
+		for _, v := range values {
+			if value, ok := v.(string); ok {
+				output.AllowedValues = append(output.AllowedValues, value)
+			}
+		}
+	}
+
+	intFromMap := func(key string) *int {
+		if v, ok := raw[key].(float64); ok {
+			value := int(v)
+			return &value
+		}
+		return nil
+	}
+	output.MinLength = intFromMap("minLength")
+	output.MaxLength = intFromMap("maxLength")
+	output.MinValue = intFromMap("minValue")
+	output.MaxValue = intFromMap("maxValue")
+
+	return output
+}
+
+// validationErrors returns the error messages from calling the ValidateFunc, recovering from any panics since
+// these are only expected to be called with a value of the correct type
+func validationErrors(validateFunc schema.SchemaValidateFunc, value interface{}) (messages []string) {
+	defer func() {
+		if r := recover(); r != nil {
+			messages = nil
+		}
+	}()
+
+	_, errs := validateFunc(value, "probe")
+	for _, err := range errs {
+		messages = append(messages, err.Error())
+	}
+	return messages
+}
+
+func (v *ValidationJSON) parse(message string) {
+	if match := allowedValuesRegex.FindStringSubmatch(message); match != nil && v.AllowedValues == nil {
+		v.AllowedValues = parseValues(match[1])
+		return
+	}
+
+	if match := lengthRangeRegex.FindStringSubmatch(message); match != nil {
+		v.MinLength = parseInt(match[1])
+		v.MaxLength = parseInt(match[2])
+		return
+	}
+
+	if match := valueRangeRegex.FindStringSubmatch(message); match != nil {
+		v.MinValue = parseInt(match[1])
+		v.MaxValue = parseInt(match[2])
+		return
+	}
+
+	if match := valueAtLeastRegex.FindStringSubmatch(message); match != nil {
+		v.MinValue = parseInt(match[1])
+	}
+
+	if match := valueAtMostRegex.FindStringSubmatch(message); match != nil {
+		v.MaxValue = parseInt(match[1])
+	}
+}
+
+// parseValues parses the allowed values from `%q` (e.g. `"a" "b"`) or `%v` (e.g. `1 2`) formatted slices
+func parseValues(input string) []string {
+	output := make([]string, 0)
+
+	remaining := strings.TrimSpace(input)
+	for remaining != "" {
+		if strings.HasPrefix(remaining, `"`) {
+			quoted, err := strconv.QuotedPrefix(remaining)
+			if err != nil {
+				break
+			}
+			value, err := strconv.Unquote(quoted)
+			if err != nil {
+				break
+			}
+			output = append(output, value)
+			remaining = strings.TrimSpace(strings.TrimPrefix(remaining, quoted))
+			continue
+		}
+
+		value, rest, _ := strings.Cut(remaining, " ")
+		output = append(output, value)
+		remaining = strings.TrimSpace(rest)
+	}
+
+	sort.Strings(output)
+	return output
+}
+
+func parseInt(input string) *int {
+	v, err := strconv.Atoi(input)
+	if err != nil {
+		return nil
+	}
+	return &v
+}
+
+// resourceIdFormats returns the format of the Resource ID expected when importing each Typed Resource, keyed by
+// the name of the Resource - Untyped Resources don't expose the ID Parser used by their Importer, so are omitted
+func resourceIdFormats() map[string]string {
+	output := make(map[string]string)
+	for _, service := range provider.SupportedTypedServices() {
+		for _, resource := range service.Resources() {
+			if format := idFormatFromValidateFunc(resource.IDValidationFunc()); format != "" {
+				output[resource.ResourceType()] = format
+			}
+		}
+	}
+	return output
+}
+
+// idFormatFromValidateFunc returns the format of the Resource ID expected by the validation function, which is
+// determined by validating an invalid ID and parsing the expected format from the error returned by the ID parser
+func idFormatFromValidateFunc(validateFunc schema.SchemaValidateFunc) string {
+	if validateFunc == nil {
+		return ""
+	}
+
+	_, errs := validateFunc("/schema-api", "id")
+	for _, err := range errs {
+		if format := parseIdFormat(err.Error()); format != "" {
+			return format
+		}
+	}
+	return ""
+}
+
+// parseIdFormat parses the expected Resource ID from the errors returned from the `resourceids` package, e.g.
+//
+//	Expected a Resource Group ID that matched:
+//
+//	> /subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/example-resource-group
+func parseIdFormat(message string) string {
+	lines := strings.Split(message, "\n")
+	for i, line := range lines {
+		if !strings.HasPrefix(line, "Expected a") {
+			continue
+		}
+
+		for _, next := range lines[i+1:] {
+			if strings.HasPrefix(next, "> ") {
+				return strings.TrimPrefix(next, "> ")
+			}
+		}
+	}
+
+	return ""
+}
+
+func (v ValidationJSON) String() string {
+	parts := make([]string, 0)
+	if len(v.AllowedValues) > 0 {
+		parts = append(parts, fmt.Sprintf("one of %q", v.AllowedValues))
+	}
+	if v.MinLength != nil || v.MaxLength != nil {
+		parts = append(parts, fmt.Sprintf("a length between %s and %s", formatBound(v.MinLength), formatBound(v.MaxLength)))
+	}
+	if v.MinValue != nil || v.MaxValue != nil {
+		parts = append(parts, fmt.Sprintf("a value between %s and %s", formatBound(v.MinValue), formatBound(v.MaxValue)))
+	}
+	if len(parts) == 0 {
+		return "unrestricted"
+	}
+	return strings.Join(parts, ", ")
+}
+
+func formatBound(input *int) string {
+	if input == nil {
+		return "unbounded"
+	}
+	return strconv.Itoa(*input)
+}
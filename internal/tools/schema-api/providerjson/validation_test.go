@@ -0,0 +1,140 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package providerjson
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func TestValidationFromRaw(t *testing.T) {
+	testData := []struct {
+		Name     string
+		Input    *schema.Schema
+		Expected *ValidationJSON
+	}{
+		{
+			Name: "no validation",
+			Input: &schema.Schema{
+				Type: schema.TypeString,
+			},
+			Expected: nil,
+		},
+		{
+			Name: "string in slice",
+			Input: &schema.Schema{
+				Type:         schema.TypeString,
+				ValidateFunc: validation.StringInSlice([]string{"Standard", "Basic"}, false),
+			},
+			Expected: &ValidationJSON{
+				AllowedValues: []string{"Basic", "Standard"},
+			},
+		},
+		{
+			Name: "string length",
+			Input: &schema.Schema{
+				Type:         schema.TypeString,
+				ValidateFunc: validation.StringLenBetween(3, 24),
+			},
+			Expected: &ValidationJSON{
+				MinLength: pointer.To(3),
+				MaxLength: pointer.To(24),
+			},
+		},
+		{
+			Name: "all of",
+			Input: &schema.Schema{
+				Type: schema.TypeString,
+				ValidateFunc: validation.All(
+					validation.StringIsNotEmpty,
+					validation.StringLenBetween(1, 64),
+				),
+			},
+			Expected: &ValidationJSON{
+				MinLength: pointer.To(1),
+				MaxLength: pointer.To(64),
+			},
+		},
+		{
+			Name: "int between",
+			Input: &schema.Schema{
+				Type:         schema.TypeInt,
+				ValidateFunc: validation.IntBetween(1, 100),
+			},
+			Expected: &ValidationJSON{
+				MinValue: pointer.To(1),
+				MaxValue: pointer.To(100),
+			},
+		},
+		{
+			Name: "int at least",
+			Input: &schema.Schema{
+				Type:         schema.TypeInt,
+				ValidateFunc: validation.IntAtLeast(7),
+			},
+			Expected: &ValidationJSON{
+				MinValue: pointer.To(7),
+			},
+		},
+		{
+			Name: "int in slice",
+			Input: &schema.Schema{
+				Type:         schema.TypeInt,
+				ValidateFunc: validation.IntInSlice([]int{30, 7, 90}),
+			},
+			Expected: &ValidationJSON{
+				AllowedValues: []string{"30", "7", "90"},
+			},
+		},
+		{
+			Name: "unsupported validation",
+			Input: &schema.Schema{
+				Type:         schema.TypeString,
+				ValidateFunc: validation.IsUUID,
+			},
+			Expected: &ValidationJSON{},
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Name)
+
+		actual := validationFromRaw(v.Input)
+		if !reflect.DeepEqual(actual, v.Expected) {
+			t.Fatalf("expected %+v but got %+v", pointer.From(v.Expected), pointer.From(actual))
+		}
+	}
+}
+
+func TestParseIdFormat(t *testing.T) {
+	_, err := commonids.ParseResourceGroupID("/schema-api")
+	if err == nil {
+		t.Fatalf("expected an error parsing an invalid Resource Group ID")
+	}
+
+	expected := "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/example-resource-group"
+	if actual := parseIdFormat(err.Error()); actual != expected {
+		t.Fatalf("expected %q but got %q", expected, actual)
+	}
+
+	if actual := parseIdFormat("some other error"); actual != "" {
+		t.Fatalf("expected no format but got %q", actual)
+	}
+}
+
+func TestIdFormatFromValidateFunc(t *testing.T) {
+	expected := "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/example-resource-group"
+	if actual := idFormatFromValidateFunc(commonids.ValidateResourceGroupID); actual != expected {
+		t.Fatalf("expected %q but got %q", expected, actual)
+	}
+
+	if actual := idFormatFromValidateFunc(nil); actual != "" {
+		t.Fatalf("expected no format but got %q", actual)
+	}
+}
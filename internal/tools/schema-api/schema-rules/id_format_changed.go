@@ -0,0 +1,29 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package schema_rules
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/schema-api/providerjson"
+)
+
+var _ ResourceBreakingChangeRule = idFormatChanged{}
+
+type idFormatChanged struct{}
+
+// Check - Checks that the format of the Resource ID hasn't changed, since existing resources would need to be migrated
+// using a State Upgrader and the ID for any resources being imported would need to change
+func (idFormatChanged) Check(base *providerjson.ResourceJSON, current *providerjson.ResourceJSON) *string {
+	if base == nil || current == nil || base.IDFormat == "" || current.IDFormat == "" {
+		return nil
+	}
+
+	if base.IDFormat != current.IDFormat {
+		return pointer.To(fmt.Sprintf("the format of the Resource ID has changed from %q to %q", base.IDFormat, current.IDFormat))
+	}
+
+	return nil
+}
@@ -0,0 +1,34 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package schema_rules
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/schema-api/providerjson"
+)
+
+func TestIdFormatChanged_Check(t *testing.T) {
+	data := idFormatChanged{}
+	base := &providerjson.ResourceJSON{
+		IDFormat: "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/example-resource-group",
+	}
+	unknown := &providerjson.ResourceJSON{}
+	violates := &providerjson.ResourceJSON{
+		IDFormat: "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/example-resource-group/providers/Microsoft.Example/things/thing1",
+	}
+
+	if res := data.Check(base, base); res != nil {
+		t.Errorf("expected no violation, got %+v", *res)
+	}
+	if res := data.Check(base, unknown); res != nil {
+		t.Errorf("expected no violation when the format can't be determined, got %+v", *res)
+	}
+	if res := data.Check(base, nil); res != nil {
+		t.Errorf("expected no violation for a removed resource, got %+v", *res)
+	}
+	if res := data.Check(base, violates); res == nil {
+		t.Errorf("expected violation, but didn't get one")
+	}
+}
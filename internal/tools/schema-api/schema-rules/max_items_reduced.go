@@ -0,0 +1,32 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package schema_rules
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/schema-api/providerjson"
+)
+
+var _ BreakingChangeRule = maxItemsReduced{}
+
+type maxItemsReduced struct{}
+
+// Check - Checks that the MaxItems for a property isn't reduced (or introduced), since existing configurations may exceed it
+func (maxItemsReduced) Check(base providerjson.SchemaJSON, current providerjson.SchemaJSON, propertyName string) *string {
+	if base.Type == "" || current.MaxItems == 0 {
+		return nil
+	}
+
+	if base.MaxItems == 0 {
+		return pointer.To(fmt.Sprintf("property %q is now limited to %d items", propertyName, current.MaxItems))
+	}
+
+	if current.MaxItems < base.MaxItems {
+		return pointer.To(fmt.Sprintf("the MaxItems for property %q has been reduced from %d to %d", propertyName, base.MaxItems, current.MaxItems))
+	}
+
+	return nil
+}
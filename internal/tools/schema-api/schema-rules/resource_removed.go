@@ -0,0 +1,22 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package schema_rules
+
+import (
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/schema-api/providerjson"
+)
+
+var _ ResourceBreakingChangeRule = resourceRemoved{}
+
+type resourceRemoved struct{}
+
+// Check - Checks that a Resource or Data Source is only removed once it's been deprecated in a previous release
+func (resourceRemoved) Check(base *providerjson.ResourceJSON, current *providerjson.ResourceJSON) *string {
+	if base != nil && current == nil && base.Deprecated == "" {
+		return pointer.To("has been removed without first being deprecated")
+	}
+
+	return nil
+}
@@ -0,0 +1,28 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package schema_rules
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/schema-api/providerjson"
+)
+
+func TestResourceRemoved_Check(t *testing.T) {
+	data := resourceRemoved{}
+	base := &providerjson.ResourceJSON{}
+	deprecated := &providerjson.ResourceJSON{
+		Deprecated: "The `azurerm_example` resource has been superseded by `azurerm_other_example`",
+	}
+
+	if res := data.Check(base, &providerjson.ResourceJSON{}); res != nil {
+		t.Errorf("expected no violation, got %+v", *res)
+	}
+	if res := data.Check(deprecated, nil); res != nil {
+		t.Errorf("expected no violation for a deprecated resource, got %+v", *res)
+	}
+	if res := data.Check(base, nil); res == nil {
+		t.Errorf("expected violation, but didn't get one")
+	}
+}
@@ -11,12 +11,33 @@ type BreakingChangeRule interface {
 
 var BreakingChangeRules = []BreakingChangeRule{
 	becomeComputedOnly{},
+	forceNewAdded{},
+	maxItemsReduced{},
 	newRequiredPropertyExistingResource{},
 	optionalRemoveComputed{},
 	optionalToRequired{},
+	propertyRemoved{},
 	propertyType{},
+	stricterValidation{},
 }
 
 var BreakingChangeRulesDataSource = []BreakingChangeRule{
+	propertyRemoved{},
 	propertyType{},
+	stricterValidation{},
+}
+
+// ResourceBreakingChangeRule is a rule which compares a Resource or Data Source as a whole, where either `base`
+// (for a new Resource) or `current` (for a removed Resource) can be nil
+type ResourceBreakingChangeRule interface {
+	Check(base *providerjson.ResourceJSON, current *providerjson.ResourceJSON) *string
+}
+
+var ResourceBreakingChangeRules = []ResourceBreakingChangeRule{
+	idFormatChanged{},
+	resourceRemoved{},
+}
+
+var ResourceBreakingChangeRulesDataSource = []ResourceBreakingChangeRule{
+	resourceRemoved{},
 }
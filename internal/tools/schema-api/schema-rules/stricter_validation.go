@@ -0,0 +1,86 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package schema_rules
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/schema-api/providerjson"
+)
+
+var _ BreakingChangeRule = stricterValidation{}
+
+type stricterValidation struct{}
+
+// Check - Checks that the validation for an existing property doesn't become stricter, since existing configurations
+// may no longer be valid - for example removing a possible value, or reducing the maximum length
+func (stricterValidation) Check(base providerjson.SchemaJSON, current providerjson.SchemaJSON, propertyName string) *string {
+	if base.Type == "" || current.Validation == nil {
+		return nil
+	}
+
+	baseValidation := pointer.From(base.Validation)
+	currentValidation := pointer.From(current.Validation)
+	reasons := make([]string, 0)
+
+	if len(currentValidation.AllowedValues) > 0 {
+		if len(baseValidation.AllowedValues) == 0 {
+			reasons = append(reasons, fmt.Sprintf("is now limited to the values %q", currentValidation.AllowedValues))
+		} else if removed := removedValues(baseValidation.AllowedValues, currentValidation.AllowedValues); len(removed) > 0 {
+			reasons = append(reasons, fmt.Sprintf("no longer supports the values %q", removed))
+		}
+	}
+
+	if lowerBoundIncreased(baseValidation.MinLength, currentValidation.MinLength, 0) {
+		reasons = append(reasons, fmt.Sprintf("has a minimum length of %d", *currentValidation.MinLength))
+	}
+	if upperBoundDecreased(baseValidation.MaxLength, currentValidation.MaxLength) {
+		reasons = append(reasons, fmt.Sprintf("has a maximum length of %d", *currentValidation.MaxLength))
+	}
+	if lowerBoundIncreased(baseValidation.MinValue, currentValidation.MinValue, math.MinInt) {
+		reasons = append(reasons, fmt.Sprintf("has a minimum value of %d", *currentValidation.MinValue))
+	}
+	if upperBoundDecreased(baseValidation.MaxValue, currentValidation.MaxValue) {
+		reasons = append(reasons, fmt.Sprintf("has a maximum value of %d", *currentValidation.MaxValue))
+	}
+
+	if len(reasons) > 0 {
+		return pointer.To(fmt.Sprintf("the validation for property %q has become stricter, it now %s", propertyName, strings.Join(reasons, " and ")))
+	}
+
+	return nil
+}
+
+func removedValues(base []string, current []string) []string {
+	remaining := make(map[string]struct{}, len(current))
+	for _, v := range current {
+		remaining[strings.ToLower(v)] = struct{}{}
+	}
+
+	output := make([]string, 0)
+	for _, v := range base {
+		if _, ok := remaining[strings.ToLower(v)]; !ok {
+			output = append(output, v)
+		}
+	}
+	return output
+}
+
+// lowerBoundIncreased returns whether the lower bound has increased, where `floor` is the implicit lower bound when unset
+func lowerBoundIncreased(base *int, current *int, floor int) bool {
+	if current == nil {
+		return false
+	}
+	if base != nil {
+		floor = *base
+	}
+	return *current > floor
+}
+
+func upperBoundDecreased(base *int, current *int) bool {
+	return current != nil && (base == nil || *current < *base)
+}
@@ -0,0 +1,107 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package schema_rules
+
+import (
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/schema-api/providerjson"
+)
+
+func TestStricterValidation_Check(t *testing.T) {
+	testData := []struct {
+		Name      string
+		Base      *providerjson.ValidationJSON
+		Current   *providerjson.ValidationJSON
+		Violation bool
+	}{
+		{
+			Name:    "no validation",
+			Base:    nil,
+			Current: nil,
+		},
+		{
+			Name: "validation removed",
+			Base: &providerjson.ValidationJSON{
+				AllowedValues: []string{"Basic", "Standard"},
+			},
+			Current: nil,
+		},
+		{
+			Name:      "allowed values added",
+			Base:      nil,
+			Current:   &providerjson.ValidationJSON{AllowedValues: []string{"Basic", "Standard"}},
+			Violation: true,
+		},
+		{
+			Name:    "allowed value added",
+			Base:    &providerjson.ValidationJSON{AllowedValues: []string{"Basic", "Standard"}},
+			Current: &providerjson.ValidationJSON{AllowedValues: []string{"Basic", "Premium", "Standard"}},
+		},
+		{
+			Name:    "allowed value casing changed",
+			Base:    &providerjson.ValidationJSON{AllowedValues: []string{"Basic", "Standard"}},
+			Current: &providerjson.ValidationJSON{AllowedValues: []string{"basic", "standard"}},
+		},
+		{
+			Name:      "allowed value removed",
+			Base:      &providerjson.ValidationJSON{AllowedValues: []string{"Basic", "Standard"}},
+			Current:   &providerjson.ValidationJSON{AllowedValues: []string{"Standard"}},
+			Violation: true,
+		},
+		{
+			Name:    "length range widened",
+			Base:    &providerjson.ValidationJSON{MinLength: pointer.To(3), MaxLength: pointer.To(24)},
+			Current: &providerjson.ValidationJSON{MinLength: pointer.To(1), MaxLength: pointer.To(64)},
+		},
+		{
+			Name:      "minimum length increased",
+			Base:      &providerjson.ValidationJSON{MinLength: pointer.To(1), MaxLength: pointer.To(24)},
+			Current:   &providerjson.ValidationJSON{MinLength: pointer.To(3), MaxLength: pointer.To(24)},
+			Violation: true,
+		},
+		{
+			Name:      "maximum length decreased",
+			Base:      &providerjson.ValidationJSON{MinLength: pointer.To(1), MaxLength: pointer.To(64)},
+			Current:   &providerjson.ValidationJSON{MinLength: pointer.To(1), MaxLength: pointer.To(24)},
+			Violation: true,
+		},
+		{
+			Name:      "maximum value added",
+			Base:      &providerjson.ValidationJSON{MinValue: pointer.To(1)},
+			Current:   &providerjson.ValidationJSON{MinValue: pointer.To(1), MaxValue: pointer.To(100)},
+			Violation: true,
+		},
+		{
+			Name:    "minimum value decreased",
+			Base:    &providerjson.ValidationJSON{MinValue: pointer.To(1), MaxValue: pointer.To(100)},
+			Current: &providerjson.ValidationJSON{MinValue: pointer.To(0), MaxValue: pointer.To(100)},
+		},
+	}
+
+	data := stricterValidation{}
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Name)
+
+		base := providerjson.SchemaJSON{
+			Type:       providerjson.SchemaTypeString,
+			Optional:   true,
+			Validation: v.Base,
+		}
+		current := providerjson.SchemaJSON{
+			Type:       providerjson.SchemaTypeString,
+			Optional:   true,
+			Validation: v.Current,
+		}
+
+		res := data.Check(base, current, "sku")
+		if v.Violation && res == nil {
+			t.Errorf("expected violation for %q, but didn't get one", v.Name)
+		}
+		if !v.Violation && res != nil {
+			t.Errorf("expected no violation for %q, got %+v", v.Name, *res)
+		}
+	}
+}
@@ -4,17 +4,27 @@
 package main
 
 import (
+	"encoding/json"
+	"errors"
 	"flag"
+	"fmt"
 	"log"
 	"os"
 	"slices"
+	"sort"
 	"strings"
 
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/static-analysis/rules"
 )
 
 var allRules = map[string]rules.Rule{
-	rules.TypedSDKBitCheck{}.Name(): rules.TypedSDKBitCheck{},
+	rules.TypedSDKBitCheck{}.Name():     rules.TypedSDKBitCheck{},
+	rules.ReadMarksAsGone{}.Name():      rules.ReadMarksAsGone{},
+	rules.CreateRequiresImport{}.Name(): rules.CreateRequiresImport{},
+	rules.CheckSetErrors{}.Name():       rules.CheckSetErrors{},
+	rules.ParseResourceIds{}.Name():     rules.ParseResourceIds{},
+	rules.ForceNewInUpdate{}.Name():     rules.ForceNewInUpdate{},
+	rules.LockChildResources{}.Name():   rules.LockChildResources{},
 }
 
 func main() {
@@ -22,6 +32,8 @@ func main() {
 
 	rulesToCheck := f.String("rules", "all", "Comma separated list of rules to run. Defaults to all. ")
 	failOnError := f.Bool("fail-on-error", true, "If set to true will fail on error, otherwise will only log. Defaults to true.")
+	output := f.String("output", "text", "The format used to output the errors, either text or json. Defaults to text.")
+	servicesPath := f.String("services-path", rules.ServicesDirectory, "The path to the directory containing the Service Packages. Defaults to ./internal/services.")
 
 	if err := f.Parse(os.Args[1:]); err != nil {
 		log.Fatalf("failed to parse flags: %v", err)
@@ -30,31 +42,55 @@ func main() {
 	if len(*rulesToCheck) == 0 {
 		log.Fatalf("no rules specified")
 	}
+	if *output != "text" && *output != "json" {
+		log.Fatalf("unsupported output %q, expected `text` or `json`", *output)
+	}
+	rules.ServicesDirectory = *servicesPath
 	specifiedRules := strings.Split(*rulesToCheck, ",")
 
-	// If `all` is in the list, just reset it to `all`
+	// If `all` is in the list, run every rule in a consistent order
 	if slices.Contains(specifiedRules, "all") {
-		specifiedRules = []string{"all"}
+		specifiedRules = make([]string, 0, len(allRules))
+		for name := range allRules {
+			specifiedRules = append(specifiedRules, name)
+		}
+		sort.Strings(specifiedRules)
 	}
 
-	errors := make([]error, 0)
+	violations := make([]rules.Violation, 0)
 	for _, rule := range specifiedRules {
-		if strings.EqualFold(rule, "all") {
-			for _, r := range allRules {
-				errors = append(errors, r.Run()...)
+		r, ok := allRules[rule]
+		if !ok {
+			log.Fatalf("unknown rule %q", rule)
+		}
+
+		for _, err := range r.Run() {
+			violation := rules.Violation{
+				Rule:    r.Name(),
+				Message: strings.TrimSpace(err.Error()),
 			}
+			errors.As(err, &violation)
+			violations = append(violations, violation)
 		}
+	}
 
-		if r, ok := allRules[rule]; ok {
-			errors = append(errors, r.Run()...)
+	if *output == "json" {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(violations); err != nil {
+			log.Fatalf("failed to output the errors: %v", err)
+		}
+	} else {
+		for _, violation := range violations {
+			fmt.Println(violation.Error())
 		}
 	}
 
-	if len(errors) > 0 {
+	if len(violations) > 0 {
 		if *failOnError {
-			log.Fatalf("failed to run rules: %v", errors)
+			log.Fatalf("failed to run rules: found %d error(s)", len(violations))
 		} else {
-			log.Printf("failed to run rules: %v", errors)
+			log.Printf("failed to run rules: found %d error(s)", len(violations))
 			os.Exit(0)
 		}
 	}
@@ -0,0 +1,32 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package rules
+
+// actionResources are the Resources which perform an action (or generate a value) when they're created, rather than
+// managing the lifecycle of a resource within Azure - as such there's no existing resource to check for during
+// creation, and no resource to remove from the state when it's been deleted outside of Terraform.
+var actionResources = map[string]bool{
+	// swaps the Production Slot for an App Service with the specified Slot
+	"azurerm_app_service_active_slot":  true,
+	"azurerm_function_app_active_slot": true,
+	"azurerm_web_app_active_slot":      true,
+
+	// starts an Execution of a Chaos Studio Experiment
+	"azurerm_chaos_studio_experiment_execution": true,
+
+	// triggers a run of the Task for a Container Registry
+	"azurerm_container_registry_task_schedule_run_now": true,
+
+	// triggers a (full) backup of a Managed HSM to a Storage Container
+	"azurerm_key_vault_managed_hardware_security_module_backup": true,
+
+	// generates a SAS Token to export a Managed Disk
+	"azurerm_managed_disk_sas_token": true,
+
+	// uploads the files within a local directory to a Storage Container
+	"azurerm_storage_blob_directory_sync": true,
+
+	// generates a Registration Token for a Virtual Desktop Host Pool
+	"azurerm_virtual_desktop_host_pool_registration_info": true,
+}
@@ -0,0 +1,105 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package rules
+
+import (
+	"fmt"
+	"go/ast"
+	"strings"
+)
+
+var _ Rule = CheckSetErrors{}
+
+type CheckSetErrors struct{}
+
+func (r CheckSetErrors) Run() []error {
+	packages, err := loadServicePackages()
+	if err != nil {
+		return []error{err}
+	}
+	return r.check(packages)
+}
+
+func (r CheckSetErrors) check(packages []servicePackage) (errors []error) {
+	for _, pkg := range packages {
+		for _, file := range pkg.files {
+			ast.Inspect(file, func(node ast.Node) bool {
+				var call *ast.CallExpr
+
+				switch stmt := node.(type) {
+				case *ast.ExprStmt:
+					call, _ = stmt.X.(*ast.CallExpr)
+
+				case *ast.AssignStmt:
+					// e.g. `_ = d.Set("name", id.Name)`
+					if len(stmt.Rhs) == 1 && len(stmt.Lhs) == 1 {
+						if ident, ok := stmt.Lhs[0].(*ast.Ident); ok && ident.Name == "_" {
+							call, _ = stmt.Rhs[0].(*ast.CallExpr)
+						}
+					}
+				}
+
+				if call != nil && isResourceDataSet(call) && isComplexValue(call.Args[1]) {
+					key := "a value"
+					if len(call.Args) > 0 {
+						if value, ok := stringLiteral(call.Args[0]); ok {
+							key = fmt.Sprintf("%q", value)
+						}
+					}
+					errors = append(errors, pkg.violation(r.Name(), call, "", "the error returned when setting %s into the state is ignored", key))
+				}
+
+				return true
+			})
+		}
+	}
+
+	return
+}
+
+// isResourceDataSet returns whether this is a call to `d.Set(...)` or `metadata.ResourceData.Set(...)`
+func isResourceDataSet(call *ast.CallExpr) bool {
+	selector, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || selector.Sel.Name != "Set" || len(call.Args) != 2 {
+		return false
+	}
+
+	switch x := selector.X.(type) {
+	case *ast.Ident:
+		return x.Name == "d"
+	case *ast.SelectorExpr:
+		return x.Sel.Name == "ResourceData"
+	}
+	return false
+}
+
+// isComplexValue returns whether the value being set is a list, set or map - since errors are only returned from
+// `d.Set` when the value doesn't match the schema, the repository convention is only to check these for complex values
+func isComplexValue(expr ast.Expr) bool {
+	switch value := expr.(type) {
+	case *ast.CallExpr:
+		// e.g. `flattenIpRules(props.IpRules)` or `pluginsdk.NewSet(...)`
+		name := callName(value)
+		return strings.HasPrefix(name, "flatten") || strings.HasPrefix(name, "Flatten") || name == "NewSet"
+
+	case *ast.CompositeLit:
+		switch value.Type.(type) {
+		case *ast.ArrayType, *ast.MapType:
+			return true
+		}
+	}
+	return false
+}
+
+func (r CheckSetErrors) Name() string {
+	return "checkSetErrors"
+}
+
+func (r CheckSetErrors) Description() string {
+	return fmt.Sprintf(`
+The '%s' check is used to check that the errors returned from 'd.Set' are handled when setting a list, set or map
+(e.g. the result of a 'flatten' function), since these are returned when the value doesn't match the schema - in
+which case the value is silently omitted from the state.
+`, r.Name())
+}
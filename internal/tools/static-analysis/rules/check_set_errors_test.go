@@ -0,0 +1,25 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package rules
+
+import (
+	"testing"
+)
+
+func TestCheckSetErrors(t *testing.T) {
+	source := `package example
+
+func resourceExampleRead(d *pluginsdk.ResourceData, meta interface{}) error {
+	d.Set("name", id.Name)
+	if err := d.Set("ip_rules", flattenIpRules(props.IpRules)); err != nil {
+		return err
+	}
+	d.Set("network_rules", flattenNetworkRules(props.NetworkRules))
+	_ = d.Set("tags", map[string]interface{}{})
+	metadata.ResourceData.Set("zones", []string{})
+	return nil
+}
+`
+	expectViolations(t, CheckSetErrors{}.check(parseTestPackage(t, source)), 8, 9, 10)
+}
@@ -0,0 +1,54 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package rules
+
+import (
+	"fmt"
+)
+
+var _ Rule = CreateRequiresImport{}
+
+type CreateRequiresImport struct{}
+
+func (r CreateRequiresImport) Run() []error {
+	packages, err := loadServicePackages()
+	if err != nil {
+		return []error{err}
+	}
+	return r.check(packages)
+}
+
+func (r CreateRequiresImport) check(packages []servicePackage) (errors []error) {
+	for _, pkg := range packages {
+		for _, resource := range pkg.resources() {
+			if resource.create == nil || resource.create.Body == nil || actionResources[resource.resourceType] {
+				continue
+			}
+			body := pkg.withCallees(resource.create)
+
+			// Typed Resources which share a base resource with Untyped Resources can also use `tf.ImportAsExistsError`
+			if resource.typed && !callsAny(body, "ResourceRequiresImport", "ImportAsExistsError") {
+				errors = append(errors, pkg.violation(r.Name(), resource.create, resource.name, "the Create function should check for an existing resource and return `metadata.ResourceRequiresImport`"))
+			}
+
+			if !resource.typed && !callsAny(body, "ImportAsExistsError") {
+				errors = append(errors, pkg.violation(r.Name(), resource.create, resource.name, "the Create function should check for an existing resource and return `tf.ImportAsExistsError`"))
+			}
+		}
+	}
+
+	return
+}
+
+func (r CreateRequiresImport) Name() string {
+	return "createRequiresImport"
+}
+
+func (r CreateRequiresImport) Description() string {
+	return fmt.Sprintf(`
+The '%s' check is used to check that the Create function for a Resource checks whether the resource already exists,
+and if so returns an error stating that it needs to be imported - rather than silently adopting the existing resource.
+Resources which perform an action rather than managing a resource (such as 'azurerm_managed_disk_sas_token') are skipped.
+`, r.Name())
+}
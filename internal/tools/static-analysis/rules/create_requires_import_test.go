@@ -0,0 +1,77 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package rules
+
+import (
+	"testing"
+)
+
+func TestCreateRequiresImport(t *testing.T) {
+	source := `package example
+
+type PassesResource struct{}
+
+func (r PassesResource) Create() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			existing, err := client.Get(ctx, id)
+			if !response.WasNotFound(existing.HttpResponse) {
+				return metadata.ResourceRequiresImport(r.ResourceType(), id)
+			}
+			return nil
+		},
+	}
+}
+
+type ViolatesResource struct{}
+
+func (r ViolatesResource) Create() sdk.ResourceFunc {
+	return sdk.ResourceFunc{}
+}
+
+func resourceExample() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		Create: resourceExampleCreate,
+	}
+}
+
+func resourceExampleCreate(d *pluginsdk.ResourceData, meta interface{}) error {
+	return nil
+}
+
+type HelperResource struct {
+	base exampleBaseResource
+}
+
+func (r HelperResource) Create() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			return r.create(ctx, metadata)
+		},
+	}
+}
+
+func (r HelperResource) create(ctx context.Context, metadata sdk.ResourceMetaData) error {
+	return r.base.checkForExisting(ctx, metadata)
+}
+
+type exampleBaseResource struct{}
+
+func (br exampleBaseResource) checkForExisting(ctx context.Context, metadata sdk.ResourceMetaData) error {
+	return tf.ImportAsExistsError("azurerm_example", id.ID())
+}
+
+type ActionResource struct{}
+
+func (r ActionResource) ResourceType() string {
+	return "azurerm_managed_disk_sas_token"
+}
+
+func (r ActionResource) Create() sdk.ResourceFunc {
+	return sdk.ResourceFunc{}
+}
+`
+	// HelperResource checks within a helper method on its base resource, and ActionResource is skipped
+	expectViolations(t, CreateRequiresImport{}.check(parseTestPackage(t, source)), 19, 29)
+}
@@ -0,0 +1,131 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package rules
+
+import (
+	"fmt"
+	"go/ast"
+)
+
+var _ Rule = ForceNewInUpdate{}
+
+type ForceNewInUpdate struct{}
+
+func (r ForceNewInUpdate) Run() []error {
+	packages, err := loadServicePackages()
+	if err != nil {
+		return []error{err}
+	}
+	return r.check(packages)
+}
+
+func (r ForceNewInUpdate) check(packages []servicePackage) (errors []error) {
+	for _, pkg := range packages {
+		for _, resource := range pkg.resources() {
+			if !resource.typed || resource.update == nil || resource.arguments == nil {
+				continue
+			}
+
+			forceNew := forceNewArguments(resource.arguments)
+			if len(forceNew) == 0 {
+				continue
+			}
+
+			ast.Inspect(resource.update.Body, func(node ast.Node) bool {
+				call, ok := node.(*ast.CallExpr)
+				if !ok {
+					return true
+				}
+				if name := callName(call); name != "HasChange" && name != "HasChanges" {
+					return true
+				}
+
+				for _, arg := range call.Args {
+					if key, ok := stringLiteral(arg); ok && forceNew[key] {
+						errors = append(errors, pkg.violation(r.Name(), call, resource.name, "the argument %q is ForceNew so can't be changed in the Update function", key))
+					}
+				}
+				return true
+			})
+		}
+	}
+
+	return
+}
+
+// forceNewArguments returns the top-level keys within the schema returned from the Arguments method which are ForceNew
+func forceNewArguments(fn *ast.FuncDecl) map[string]bool {
+	output := make(map[string]bool)
+
+	ast.Inspect(fn.Body, func(node ast.Node) bool {
+		lit, ok := node.(*ast.CompositeLit)
+		if !ok {
+			return true
+		}
+		if _, ok := lit.Type.(*ast.MapType); !ok {
+			return true
+		}
+
+		for _, elt := range lit.Elts {
+			kv, ok := elt.(*ast.KeyValueExpr)
+			if !ok {
+				continue
+			}
+			key, ok := stringLiteral(kv.Key)
+			if !ok {
+				continue
+			}
+
+			if isForceNewSchema(kv.Value) {
+				output[key] = true
+			}
+		}
+
+		// nested schemas are within an `Elem`, which can be updated independently
+		return false
+	})
+
+	return output
+}
+
+func isForceNewSchema(expr ast.Expr) bool {
+	if unary, ok := expr.(*ast.UnaryExpr); ok {
+		expr = unary.X
+	}
+
+	switch value := expr.(type) {
+	case *ast.CompositeLit:
+		for _, elt := range value.Elts {
+			kv, ok := elt.(*ast.KeyValueExpr)
+			if !ok {
+				continue
+			}
+			key, ok := kv.Key.(*ast.Ident)
+			if !ok || key.Name != "ForceNew" {
+				continue
+			}
+			if ident, ok := kv.Value.(*ast.Ident); ok && ident.Name == "true" {
+				return true
+			}
+		}
+
+	case *ast.CallExpr:
+		// the common schemas which are always ForceNew
+		return isSelector(value.Fun, "commonschema", "Location") || isSelector(value.Fun, "commonschema", "ResourceGroupName")
+	}
+
+	return false
+}
+
+func (r ForceNewInUpdate) Name() string {
+	return "forceNewInUpdate"
+}
+
+func (r ForceNewInUpdate) Description() string {
+	return fmt.Sprintf(`
+The '%s' check is used to check that the Update function for a Typed Resource doesn't handle changes to
+arguments which are ForceNew, since changing these recreates the resource - meaning this is either dead code
+or the argument shouldn't be ForceNew.
+`, r.Name())
+}
@@ -0,0 +1,49 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package rules
+
+import (
+	"testing"
+)
+
+func TestForceNewInUpdate(t *testing.T) {
+	source := `package example
+
+type ExampleResource struct{}
+
+func (r ExampleResource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"name": {
+			Type:     pluginsdk.TypeString,
+			Required: true,
+			ForceNew: true,
+		},
+
+		"location": commonschema.Location(),
+
+		"sku": {
+			Type:     pluginsdk.TypeString,
+			Optional: true,
+		},
+	}
+}
+
+func (r ExampleResource) Create() sdk.ResourceFunc { return sdk.ResourceFunc{} }
+
+func (r ExampleResource) Update() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			if metadata.ResourceData.HasChange("sku") {
+			}
+			if metadata.ResourceData.HasChange("name") {
+			}
+			if metadata.ResourceData.HasChanges("sku", "location") {
+			}
+			return nil
+		},
+	}
+}
+`
+	expectViolations(t, ForceNewInUpdate{}.check(parseTestPackage(t, source)), 29, 31)
+}
@@ -0,0 +1,272 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package rules
+
+import (
+	"fmt"
+	"go/ast"
+	"sort"
+	"strings"
+)
+
+var _ Rule = LockChildResources{}
+
+type LockChildResources struct{}
+
+func (r LockChildResources) Run() []error {
+	packages, err := loadServicePackages()
+	if err != nil {
+		return []error{err}
+	}
+	return r.check(packages)
+}
+
+func (r LockChildResources) check(packages []servicePackage) (errors []error) {
+	for _, pkg := range packages {
+		resources := pkg.resources()
+
+		// parentLocks is a map of the ID field for a parent resource (e.g. `IotHubName`) to the resource
+		// name used to lock it (e.g. `IothubResourceName`), for the resources in this package which lock on a parent
+		parentLocks := make(map[string]string)
+
+		// parentClients is a map of the ID field for a parent resource to the clients which are used to update
+		// it (e.g. `IoTHub.ResourceClient`) by the resources in this package which lock on it
+		parentClients := make(map[string]map[string]bool)
+
+		for _, resource := range resources {
+			locking := make([]string, 0)
+			for _, fn := range resource.mutatingFuncs() {
+				body := pkg.withCallees(fn)
+				locks := lockCalls(body)
+				if len(locks) == 0 {
+					continue
+				}
+				locking = append(locking, fn.Name.Name)
+
+				if unlocks := unlockCalls(body); unlocks < len(locks) {
+					errors = append(errors, pkg.violation(r.Name(), fn, resource.name, "the %s function acquires %d lock(s) but only releases %d, locks should be released using `defer locks.UnlockByID`/`defer locks.UnlockByName`", fn.Name.Name, len(locks), unlocks))
+				}
+
+				for _, call := range locks {
+					if field, name, ok := parentLock(call); ok {
+						parentLocks[field] = name
+						if _, ok := parentClients[field]; !ok {
+							parentClients[field] = make(map[string]bool)
+						}
+						for client := range updatedClients(body) {
+							parentClients[field][client] = true
+						}
+					}
+				}
+			}
+
+			// if any of the Create/Update/Delete functions lock then they all should, since each could modify the same parent
+			if len(locking) > 0 {
+				for _, fn := range resource.mutatingFuncs() {
+					if len(lockCalls(pkg.withCallees(fn))) == 0 {
+						errors = append(errors, pkg.violation(r.Name(), fn, resource.name, "the %s function doesn't acquire a lock, however the %s function(s) do", fn.Name.Name, locking))
+					}
+				}
+			}
+		}
+
+		if len(parentLocks) == 0 {
+			continue
+		}
+
+		fields := make([]string, 0, len(parentLocks))
+		for field := range parentLocks {
+			fields = append(fields, field)
+		}
+		sort.Strings(fields)
+
+		// sibling resources which update the same parent must also lock on it, else they can modify it concurrently -
+		// resources which are only nested within the parent (and are updated using their own API) don't need to
+		for _, resource := range resources {
+			for _, fn := range resource.mutatingFuncs() {
+				body := pkg.withCallees(fn)
+				if len(lockCalls(body)) > 0 {
+					continue
+				}
+
+				clients := updatedClients(body)
+				for _, field := range fields {
+					if referencesField(body, field) && updatesAnyClient(clients, parentClients[field]) {
+						errors = append(errors, pkg.violation(r.Name(), fn, resource.name, "the %s function updates the parent resource without acquiring a lock on it, however sibling resources lock on it using `locks.ByName(<id>.%s, %s)`", fn.Name.Name, field, parentLocks[field]))
+						break
+					}
+				}
+			}
+		}
+	}
+
+	return
+}
+
+// updatedClients returns the clients (e.g. `IoTHub.ResourceClient`) which are used to create or update a resource
+// within the node, either directly or via a local variable such as `client := meta.(*clients.Client).IoTHub.ResourceClient`
+func updatedClients(node ast.Node) map[string]bool {
+	variables := make(map[string]string)
+	ast.Inspect(node, func(n ast.Node) bool {
+		if assign, ok := n.(*ast.AssignStmt); ok && len(assign.Lhs) == len(assign.Rhs) {
+			for i, lhs := range assign.Lhs {
+				ident, ok := lhs.(*ast.Ident)
+				if !ok {
+					continue
+				}
+				if client, ok := clientPath(assign.Rhs[i]); ok {
+					variables[ident.Name] = client
+				}
+			}
+		}
+		return true
+	})
+
+	output := make(map[string]bool)
+	ast.Inspect(node, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		selector, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok || !isUpdateMethod(selector.Sel.Name) {
+			return true
+		}
+
+		if ident, ok := selector.X.(*ast.Ident); ok {
+			if client, ok := variables[ident.Name]; ok {
+				output[client] = true
+			}
+		} else if client, ok := clientPath(selector.X); ok {
+			output[client] = true
+		}
+		return true
+	})
+	return output
+}
+
+// clientPath returns the path to a client within `clients.Client`, e.g. `IoTHub.ResourceClient` for both
+// `metadata.Client.IoTHub.ResourceClient` and `meta.(*clients.Client).IoTHub.ResourceClient`
+func clientPath(expr ast.Expr) (string, bool) {
+	segments := make([]string, 0)
+	for {
+		switch e := expr.(type) {
+		case *ast.SelectorExpr:
+			if e.Sel.Name == "Client" {
+				if _, ok := e.X.(*ast.Ident); ok && len(segments) > 0 {
+					return strings.Join(segments, "."), true
+				}
+			}
+			segments = append([]string{e.Sel.Name}, segments...)
+			expr = e.X
+		case *ast.ParenExpr:
+			expr = e.X
+		case *ast.TypeAssertExpr:
+			if len(segments) > 0 && isSelector(starred(e.Type), "clients", "Client") {
+				return strings.Join(segments, "."), true
+			}
+			return "", false
+		default:
+			return "", false
+		}
+	}
+}
+
+// isUpdateMethod returns whether the name of the method is one used to create or update a resource
+// e.g. `CreateOrUpdate`, `CreateOrUpdateThenPoll`, `Update` and `Patch`
+func isUpdateMethod(name string) bool {
+	for _, prefix := range []string{"Create", "Update", "Patch", "Put"} {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func updatesAnyClient(clients map[string]bool, parentClients map[string]bool) bool {
+	for client := range clients {
+		if parentClients[client] {
+			return true
+		}
+	}
+	return false
+}
+
+// lockCalls returns the calls to `locks.ByID`, `locks.ByName` and `locks.MultipleByName` within the node
+func lockCalls(node ast.Node) []*ast.CallExpr {
+	output := make([]*ast.CallExpr, 0)
+	ast.Inspect(node, func(n ast.Node) bool {
+		if call, ok := n.(*ast.CallExpr); ok {
+			if isSelector(call.Fun, "locks", "ByID") || isSelector(call.Fun, "locks", "ByName") || isSelector(call.Fun, "locks", "MultipleByName") {
+				output = append(output, call)
+			}
+		}
+		return true
+	})
+	return output
+}
+
+// unlockCalls returns the number of calls to release a lock within the node
+func unlockCalls(node ast.Node) int {
+	count := 0
+	ast.Inspect(node, func(n ast.Node) bool {
+		if call, ok := n.(*ast.CallExpr); ok {
+			if isSelector(call.Fun, "locks", "UnlockByID") || isSelector(call.Fun, "locks", "UnlockByName") || isSelector(call.Fun, "locks", "UnlockMultipleByName") {
+				count++
+			}
+		}
+		return true
+	})
+	return count
+}
+
+// parentLock returns the ID field and resource name for a call in the form `locks.ByName(id.ParentName, ParentResourceName)`
+func parentLock(call *ast.CallExpr) (field string, name string, ok bool) {
+	if !isSelector(call.Fun, "locks", "ByName") || len(call.Args) != 2 {
+		return "", "", false
+	}
+
+	selector, ok := call.Args[0].(*ast.SelectorExpr)
+	if !ok {
+		return "", "", false
+	}
+
+	// these are shared by every resource, rather than identifying a parent resource
+	switch selector.Sel.Name {
+	case "Name", "ResourceGroup", "ResourceGroupName", "SubscriptionId":
+		return "", "", false
+	}
+
+	resourceName, ok := call.Args[1].(*ast.Ident)
+	if !ok {
+		return "", "", false
+	}
+
+	return selector.Sel.Name, resourceName.Name, true
+}
+
+// referencesField returns whether the node contains a selector for the specified field, e.g. `id.IotHubName`
+func referencesField(node ast.Node, field string) bool {
+	found := false
+	ast.Inspect(node, func(n ast.Node) bool {
+		if selector, ok := n.(*ast.SelectorExpr); ok && selector.Sel.Name == field {
+			found = true
+		}
+		return !found
+	})
+	return found
+}
+
+func (r LockChildResources) Name() string {
+	return "lockChildResources"
+}
+
+func (r LockChildResources) Description() string {
+	return fmt.Sprintf(`
+The '%s' check is used to check that resources which are nested within a parent resource (and which update the
+parent when they're modified) acquire a lock on the parent using 'locks.ByID'/'locks.ByName' - consistently across
+the Create, Update and Delete functions and their sibling resources - and that these locks are released. Sibling
+resources are only expected to lock on the parent when they update it using the same client as the resources which lock.
+`, r.Name())
+}
@@ -0,0 +1,79 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package rules
+
+import (
+	"testing"
+)
+
+func TestLockChildResources(t *testing.T) {
+	source := `package example
+
+func resourceRoute() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		Create: resourceRouteCreateUpdate,
+		Update: resourceRouteCreateUpdate,
+		Delete: resourceRouteDelete,
+	}
+}
+
+func resourceRouteCreateUpdate(d *pluginsdk.ResourceData, meta interface{}) error {
+	locks.ByName(id.IotHubName, IothubResourceName)
+	defer locks.UnlockByName(id.IotHubName, IothubResourceName)
+	return updateIotHub(d, meta)
+}
+
+func updateIotHub(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).IoTHub.ResourceClient
+	return client.CreateOrUpdate(ctx, id.ResourceGroup, id.IotHubName, iothub, "")
+}
+
+func resourceRouteDelete(d *pluginsdk.ResourceData, meta interface{}) error {
+	locks.ByName(id.IotHubName, IothubResourceName)
+	return nil
+}
+
+func resourceEndpoint() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		Create: resourceEndpointCreate,
+		Delete: resourceEndpointDelete,
+	}
+}
+
+func resourceEndpointCreate(d *pluginsdk.ResourceData, meta interface{}) error {
+	locks.ByID(id.ID())
+	defer locks.UnlockByID(id.ID())
+	return nil
+}
+
+func resourceEndpointDelete(d *pluginsdk.ResourceData, meta interface{}) error {
+	return nil
+}
+
+func resourceSibling() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		Create: resourceSiblingCreate,
+	}
+}
+
+func resourceSiblingCreate(d *pluginsdk.ResourceData, meta interface{}) error {
+	return meta.(*clients.Client).IoTHub.ResourceClient.CreateOrUpdate(ctx, id.ResourceGroup, id.IotHubName, iothub, "")
+}
+
+func resourceNested() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		Create: resourceNestedCreate,
+	}
+}
+
+func resourceNestedCreate(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).IoTHub.NestedClient
+	id := parse.NewNestedID(subscriptionId, resourceGroup, hubId.IotHubName, name)
+	return client.CreateOrUpdate(ctx, id)
+}
+`
+	// the unlocked resourceEndpointDelete, the unreleased lock in resourceRouteDelete and the unlocked sibling which
+	// updates the parent - but not resourceNested, which uses its own API
+	expectViolations(t, LockChildResources{}.check(parseTestPackage(t, source)), 40, 22, 50)
+}
@@ -0,0 +1,86 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package rules
+
+import (
+	"fmt"
+	"go/ast"
+	"strings"
+)
+
+var _ Rule = ParseResourceIds{}
+
+type ParseResourceIds struct{}
+
+func (r ParseResourceIds) Run() []error {
+	packages, err := loadServicePackages()
+	if err != nil {
+		return []error{err}
+	}
+	return r.check(packages)
+}
+
+func (r ParseResourceIds) check(packages []servicePackage) (errors []error) {
+	for _, pkg := range packages {
+		for _, file := range pkg.files {
+			ast.Inspect(file, func(node ast.Node) bool {
+				call, ok := node.(*ast.CallExpr)
+				if !ok || len(call.Args) < 2 {
+					return true
+				}
+
+				selector, ok := call.Fun.(*ast.SelectorExpr)
+				if !ok || !isSelector(selector, "strings", selector.Sel.Name) {
+					return true
+				}
+				switch selector.Sel.Name {
+				case "Split", "SplitN", "SplitAfter", "SplitAfterN":
+				default:
+					return true
+				}
+
+				if separator, ok := stringLiteral(call.Args[1]); ok && separator == "/" && referencesId(call.Args[0]) {
+					errors = append(errors, pkg.violation(r.Name(), call, "", "Resource IDs should be parsed using the `parse` package or the `Parse...ID` functions from the SDK, rather than `strings.%s`", selector.Sel.Name))
+				}
+
+				return true
+			})
+		}
+	}
+
+	return
+}
+
+// referencesId returns whether the expression appears to be a Resource ID, e.g. `d.Id()`, `id` or `model.ServerId`
+func referencesId(expr ast.Expr) bool {
+	found := false
+	ast.Inspect(expr, func(node ast.Node) bool {
+		var name string
+		switch n := node.(type) {
+		case *ast.Ident:
+			name = n.Name
+		case *ast.SelectorExpr:
+			name = n.Sel.Name
+		default:
+			return true
+		}
+
+		if name == "id" || strings.HasSuffix(name, "Id") || strings.HasSuffix(name, "ID") {
+			found = true
+		}
+		return !found
+	})
+	return found
+}
+
+func (r ParseResourceIds) Name() string {
+	return "parseResourceIds"
+}
+
+func (r ParseResourceIds) Description() string {
+	return fmt.Sprintf(`
+The '%s' check is used to check that Resource IDs are parsed using the Resource ID parsers (which validate the
+segments and handle casing), rather than by splitting the ID on '/'.
+`, r.Name())
+}
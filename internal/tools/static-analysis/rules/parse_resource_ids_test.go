@@ -0,0 +1,23 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package rules
+
+import (
+	"testing"
+)
+
+func TestParseResourceIds(t *testing.T) {
+	source := `package example
+
+func example(d *pluginsdk.ResourceData) {
+	strings.Split(d.Id(), "/")
+	strings.Split(model.ServerId, "/")
+	strings.SplitN(serverID, "/", 3)
+	strings.Split(d.Id(), ";")
+	strings.Split(path, "/")
+	strings.Split(valid, "/")
+}
+`
+	expectViolations(t, ParseResourceIds{}.check(parseTestPackage(t, source)), 4, 5, 6)
+}
@@ -0,0 +1,68 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package rules
+
+import (
+	"fmt"
+	"go/ast"
+)
+
+var _ Rule = ReadMarksAsGone{}
+
+type ReadMarksAsGone struct{}
+
+func (r ReadMarksAsGone) Run() []error {
+	packages, err := loadServicePackages()
+	if err != nil {
+		return []error{err}
+	}
+	return r.check(packages)
+}
+
+func (r ReadMarksAsGone) check(packages []servicePackage) (errors []error) {
+	for _, pkg := range packages {
+		for _, resource := range pkg.resources() {
+			if resource.read == nil || resource.read.Body == nil || actionResources[resource.resourceType] {
+				continue
+			}
+			body := pkg.withCallees(resource.read)
+
+			if resource.typed && !callsAny(body, "MarkAsGone") {
+				errors = append(errors, pkg.violation(r.Name(), resource.read, resource.name, "the Read function should call `metadata.MarkAsGone` when the resource returns a 404"))
+			}
+
+			if !resource.typed && !setsEmptyId(body) {
+				errors = append(errors, pkg.violation(r.Name(), resource.read, resource.name, "the Read function should call `d.SetId(\"\")` when the resource returns a 404"))
+			}
+		}
+	}
+
+	return
+}
+
+func (r ReadMarksAsGone) Name() string {
+	return "readMarksAsGone"
+}
+
+func (r ReadMarksAsGone) Description() string {
+	return fmt.Sprintf(`
+The '%s' check is used to check that the Read function for a Resource removes it from the state when it's been
+deleted outside of Terraform, by calling 'metadata.MarkAsGone' (Typed Resources) or 'd.SetId("")' (Untyped Resources).
+Resources which perform an action rather than managing a resource (such as 'azurerm_managed_disk_sas_token') are skipped.
+`, r.Name())
+}
+
+// setsEmptyId returns whether the node contains a call to `SetId("")`
+func setsEmptyId(node ast.Node) bool {
+	found := false
+	ast.Inspect(node, func(n ast.Node) bool {
+		if call, ok := n.(*ast.CallExpr); ok && callName(call) == "SetId" && len(call.Args) == 1 {
+			if value, ok := stringLiteral(call.Args[0]); ok && value == "" {
+				found = true
+			}
+		}
+		return !found
+	})
+	return found
+}
@@ -0,0 +1,95 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package rules
+
+import (
+	"testing"
+)
+
+func TestReadMarksAsGone(t *testing.T) {
+	source := `package example
+
+type PassesResource struct{}
+
+func (r PassesResource) Create() sdk.ResourceFunc { return sdk.ResourceFunc{} }
+
+func (r PassesResource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			resp, err := client.Get(ctx, *id)
+			if err != nil {
+				if response.WasNotFound(resp.HttpResponse) {
+					return metadata.MarkAsGone(id)
+				}
+				return err
+			}
+			return nil
+		},
+	}
+}
+
+type ViolatesResource struct{}
+
+func (r ViolatesResource) Create() sdk.ResourceFunc { return sdk.ResourceFunc{} }
+
+func (r ViolatesResource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			_, err := client.Get(ctx, *id)
+			return err
+		},
+	}
+}
+
+func resourcePasses() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		Create: resourcePassesCreate,
+		Read:   resourcePassesRead,
+	}
+}
+
+func resourcePassesRead(d *pluginsdk.ResourceData, meta interface{}) error {
+	return sharedRead(d, meta)
+}
+
+func sharedRead(d *pluginsdk.ResourceData, meta interface{}) error {
+	if utils.ResponseWasNotFound(resp.Response) {
+		d.SetId("")
+		return nil
+	}
+	return nil
+}
+
+func resourceViolates() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		Create: resourceViolatesCreate,
+		Read:   resourceViolatesRead,
+	}
+}
+
+func resourceViolatesRead(d *pluginsdk.ResourceData, meta interface{}) error {
+	d.SetId("example")
+	return nil
+}
+
+func (r Registration) SupportedResources() map[string]*pluginsdk.Resource {
+	return map[string]*pluginsdk.Resource{
+		"azurerm_managed_disk_sas_token": resourceAction(),
+	}
+}
+
+func resourceAction() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		Create: resourceActionCreate,
+		Read:   resourceActionRead,
+	}
+}
+
+func resourceActionRead(d *pluginsdk.ResourceData, meta interface{}) error {
+	return nil
+}
+`
+	// resourceAction is skipped since it performs an action
+	expectViolations(t, ReadMarksAsGone{}.check(parseTestPackage(t, source)), 26, 61)
+}
@@ -0,0 +1,499 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package rules
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// ServicesDirectory is the directory containing the Service Packages which are checked by the source-based rules
+var ServicesDirectory = "./internal/services"
+
+// servicePackage is a parsed (non-test) Go package within the Services Directory
+type servicePackage struct {
+	dir   string
+	fset  *token.FileSet
+	files []*ast.File
+
+	// funcs are the top-level functions (and methods) in this package, keyed by name for functions
+	// and `Type.Method` for methods
+	funcs map[string]*ast.FuncDecl
+
+	// fieldTypes are the names of the types of the fields on the structs in this package which are defined in this
+	// package, keyed by `Type.Field` - for example `ResourceAssignmentResource.base` is `assignmentBaseResource`
+	fieldTypes map[string]string
+}
+
+var (
+	servicePackagesOnce  sync.Once
+	servicePackagesCache []servicePackage
+	servicePackagesErr   error
+)
+
+// loadServicePackages parses the Service Packages once, since these are shared between the rules
+func loadServicePackages() ([]servicePackage, error) {
+	servicePackagesOnce.Do(func() {
+		servicePackagesCache, servicePackagesErr = parseServicePackages(ServicesDirectory)
+	})
+	return servicePackagesCache, servicePackagesErr
+}
+
+func parseServicePackages(root string) ([]servicePackage, error) {
+	fset := token.NewFileSet()
+	filesByDir := make(map[string][]*ast.File)
+
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() {
+			// the embedded SDKs are generated, so are excluded
+			if d.Name() == "sdk" || d.Name() == "testdata" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if !strings.HasSuffix(path, ".go") || strings.HasSuffix(path, "_test.go") {
+			return nil
+		}
+
+		file, err := parser.ParseFile(fset, path, nil, parser.SkipObjectResolution)
+		if err != nil {
+			return fmt.Errorf("parsing %q: %+v", path, err)
+		}
+		dir := filepath.Dir(path)
+		filesByDir[dir] = append(filesByDir[dir], file)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	dirs := make([]string, 0, len(filesByDir))
+	for dir := range filesByDir {
+		dirs = append(dirs, dir)
+	}
+	sort.Strings(dirs)
+
+	output := make([]servicePackage, 0, len(dirs))
+	for _, dir := range dirs {
+		output = append(output, newServicePackage(dir, fset, filesByDir[dir]))
+	}
+	return output, nil
+}
+
+func newServicePackage(dir string, fset *token.FileSet, files []*ast.File) servicePackage {
+	pkg := servicePackage{
+		dir:   dir,
+		fset:  fset,
+		files: files,
+		funcs: make(map[string]*ast.FuncDecl),
+
+		fieldTypes: make(map[string]string),
+	}
+
+	for _, file := range files {
+		for _, decl := range file.Decls {
+			switch decl := decl.(type) {
+			case *ast.FuncDecl:
+				name := decl.Name.Name
+				if receiver := receiverTypeName(decl); receiver != "" {
+					name = receiver + "." + name
+				}
+				pkg.funcs[name] = decl
+
+			case *ast.GenDecl:
+				for _, spec := range decl.Specs {
+					typeSpec, ok := spec.(*ast.TypeSpec)
+					if !ok {
+						continue
+					}
+					structType, ok := typeSpec.Type.(*ast.StructType)
+					if !ok {
+						continue
+					}
+					for _, field := range structType.Fields.List {
+						fieldType, ok := starred(field.Type).(*ast.Ident)
+						if !ok {
+							continue
+						}
+						for _, fieldName := range field.Names {
+							pkg.fieldTypes[typeSpec.Name.Name+"."+fieldName.Name] = fieldType.Name
+						}
+					}
+				}
+			}
+		}
+	}
+
+	return pkg
+}
+
+// violation returns a Violation for the specified node
+func (p servicePackage) violation(rule string, node ast.Node, resource string, message string, args ...interface{}) Violation {
+	position := p.fset.Position(node.Pos())
+	return Violation{
+		Rule:     rule,
+		File:     position.Filename,
+		Line:     position.Line,
+		Resource: resource,
+		Message:  fmt.Sprintf(message, args...),
+	}
+}
+
+// withCallees returns the body of the function alongside the bodies of any functions within this package which it
+// calls (directly or via other functions in this package), since the logic for similar resources is commonly shared
+// in a helper function - such as `resourceExampleCreateUpdate(d, meta)` or `r.readProtectedItem(ctx, metadata)`
+func (p servicePackage) withCallees(fn *ast.FuncDecl) *ast.BlockStmt {
+	output := &ast.BlockStmt{
+		List: make([]ast.Stmt, 0),
+	}
+	if fn == nil || fn.Body == nil {
+		return output
+	}
+
+	seen := map[*ast.FuncDecl]bool{
+		fn: true,
+	}
+	pending := []*ast.FuncDecl{fn}
+	for len(pending) > 0 {
+		current := pending[0]
+		pending = pending[1:]
+		output.List = append(output.List, current.Body)
+
+		ast.Inspect(current.Body, func(node ast.Node) bool {
+			call, ok := node.(*ast.CallExpr)
+			if !ok {
+				return true
+			}
+
+			if callee := p.callee(call, current); callee != nil && callee.Body != nil && !seen[callee] {
+				seen[callee] = true
+				pending = append(pending, callee)
+			}
+			return true
+		})
+	}
+
+	return output
+}
+
+// callee returns the function or method within this package which is being called from the caller
+func (p servicePackage) callee(call *ast.CallExpr, caller *ast.FuncDecl) *ast.FuncDecl {
+	switch fun := call.Fun.(type) {
+	case *ast.Ident:
+		return p.funcs[fun.Name]
+
+	case *ast.SelectorExpr:
+		switch x := fun.X.(type) {
+		case *ast.Ident:
+			// `r.helper()` where `r` is the receiver of the calling method
+			if x.Name == receiverName(caller) {
+				return p.funcs[receiverTypeName(caller)+"."+fun.Sel.Name]
+			}
+		case *ast.SelectorExpr:
+			// `r.base.helper()` where `base` is a field on the receiver of the calling method
+			if ident, ok := x.X.(*ast.Ident); ok && ident.Name == receiverName(caller) {
+				if fieldType, ok := p.fieldTypes[receiverTypeName(caller)+"."+x.Sel.Name]; ok {
+					return p.funcs[fieldType+"."+fun.Sel.Name]
+				}
+			}
+		case *ast.CompositeLit:
+			// `ExampleResource{}.helper()`
+			if ident, ok := x.Type.(*ast.Ident); ok {
+				return p.funcs[ident.Name+"."+fun.Sel.Name]
+			}
+		}
+	}
+
+	return nil
+}
+
+// resourceImplementation is a Resource (rather than a Data Source) defined within a Service Package
+type resourceImplementation struct {
+	// name is the name of the struct implementing sdk.Resource for Typed Resources, or the name of the
+	// function returning the *pluginsdk.Resource for Untyped Resources
+	name  string
+	typed bool
+
+	// resourceType is the name of the Resource within Terraform (e.g. `azurerm_resource_group`), where this can be
+	// determined from the `ResourceType` method for Typed Resources or the Service Registration for Untyped Resources
+	resourceType string
+
+	create *ast.FuncDecl
+	read   *ast.FuncDecl
+	update *ast.FuncDecl
+	delete *ast.FuncDecl
+
+	// arguments is the Arguments method for Typed Resources
+	arguments *ast.FuncDecl
+}
+
+// mutatingFuncs returns the Create, Update and Delete functions for this Resource, where defined
+func (r resourceImplementation) mutatingFuncs() []*ast.FuncDecl {
+	output := make([]*ast.FuncDecl, 0)
+	seen := make(map[*ast.FuncDecl]bool)
+	for _, fn := range []*ast.FuncDecl{r.create, r.update, r.delete} {
+		// Untyped Resources commonly share a function for Create and Update
+		if fn != nil && !seen[fn] {
+			output = append(output, fn)
+			seen[fn] = true
+		}
+	}
+	return output
+}
+
+// resources returns the Typed and Untyped Resources defined within this Service Package
+func (p servicePackage) resources() []resourceImplementation {
+	output := make([]resourceImplementation, 0)
+
+	// Typed Resources are the types with a Create method returning an sdk.ResourceFunc
+	typeNames := make([]string, 0)
+	for _, fn := range p.funcs {
+		if receiver := receiverTypeName(fn); receiver != "" && fn.Name.Name == "Create" && returnsSelector(fn, "sdk", "ResourceFunc") {
+			typeNames = append(typeNames, receiver)
+		}
+	}
+	sort.Strings(typeNames)
+	for _, typeName := range typeNames {
+		resourceType := ""
+		if fn, ok := p.funcs[typeName+".ResourceType"]; ok {
+			resourceType = returnedString(fn)
+		}
+
+		output = append(output, resourceImplementation{
+			name:         typeName,
+			typed:        true,
+			resourceType: resourceType,
+			create:       p.funcs[typeName+".Create"],
+			read:         p.funcs[typeName+".Read"],
+			update:       p.funcs[typeName+".Update"],
+			delete:       p.funcs[typeName+".Delete"],
+			arguments:    p.funcs[typeName+".Arguments"],
+		})
+	}
+
+	// Untyped Resources are functions returning a *pluginsdk.Resource which defines a Create function
+	funcNames := make([]string, 0)
+	for name, fn := range p.funcs {
+		if fn.Recv == nil && returnsSelector(fn, "pluginsdk", "Resource") {
+			funcNames = append(funcNames, name)
+		}
+	}
+	sort.Strings(funcNames)
+	registrations := p.untypedResourceTypes()
+	for _, funcName := range funcNames {
+		fields := resourceFields(p.funcs[funcName])
+		if fields["Create"] == "" {
+			continue
+		}
+
+		output = append(output, resourceImplementation{
+			name:         funcName,
+			resourceType: registrations[funcName],
+			create:       p.funcs[fields["Create"]],
+			read:         p.funcs[fields["Read"]],
+			update:       p.funcs[fields["Update"]],
+			delete:       p.funcs[fields["Delete"]],
+		})
+	}
+
+	return output
+}
+
+// untypedResourceTypes returns a map of the function returning an Untyped Resource to the name of that Resource, from
+// the Service Registration - which is either `"azurerm_example": resourceExample()` or
+// `resources["azurerm_example"] = resourceExample()`
+func (p servicePackage) untypedResourceTypes() map[string]string {
+	output := make(map[string]string)
+	register := func(key ast.Expr, value ast.Expr) {
+		call, ok := value.(*ast.CallExpr)
+		if !ok {
+			return
+		}
+		funcName, ok := call.Fun.(*ast.Ident)
+		if !ok {
+			return
+		}
+		if name, ok := stringLiteral(key); ok && strings.HasPrefix(name, "azurerm_") {
+			output[funcName.Name] = name
+		}
+	}
+
+	for _, file := range p.files {
+		ast.Inspect(file, func(node ast.Node) bool {
+			switch n := node.(type) {
+			case *ast.KeyValueExpr:
+				register(n.Key, n.Value)
+			case *ast.AssignStmt:
+				if len(n.Lhs) == 1 && len(n.Rhs) == 1 {
+					if index, ok := n.Lhs[0].(*ast.IndexExpr); ok {
+						register(index.Index, n.Rhs[0])
+					}
+				}
+			}
+			return true
+		})
+	}
+
+	return output
+}
+
+// returnedString returns the value of the string literal returned from the function, e.g. from `ResourceType`
+func returnedString(fn *ast.FuncDecl) string {
+	if fn.Body == nil || len(fn.Body.List) != 1 {
+		return ""
+	}
+	ret, ok := fn.Body.List[0].(*ast.ReturnStmt)
+	if !ok || len(ret.Results) != 1 {
+		return ""
+	}
+	value, _ := stringLiteral(ret.Results[0])
+	return value
+}
+
+// resourceFields returns the names of the functions assigned to the `Create`, `Read`, `Update` and `Delete`
+// fields of the `pluginsdk.Resource` returned from the function
+func resourceFields(fn *ast.FuncDecl) map[string]string {
+	output := make(map[string]string)
+	if fn.Body == nil {
+		return output
+	}
+
+	ast.Inspect(fn.Body, func(node ast.Node) bool {
+		lit, ok := node.(*ast.CompositeLit)
+		if !ok || !isSelector(lit.Type, "pluginsdk", "Resource") {
+			return true
+		}
+
+		for _, elt := range lit.Elts {
+			kv, ok := elt.(*ast.KeyValueExpr)
+			if !ok {
+				continue
+			}
+			key, ok := kv.Key.(*ast.Ident)
+			if !ok {
+				continue
+			}
+			if value, ok := kv.Value.(*ast.Ident); ok {
+				output[key.Name] = value.Name
+			}
+		}
+
+		// nested Resources are schema blocks, which don't define any functions
+		return false
+	})
+
+	return output
+}
+
+// receiverName returns the name of the receiver for a method, e.g. `r` for `func (r ExampleResource) Create()`
+func receiverName(fn *ast.FuncDecl) string {
+	if fn.Recv == nil || len(fn.Recv.List) == 0 || len(fn.Recv.List[0].Names) == 0 {
+		return ""
+	}
+	if name := fn.Recv.List[0].Names[0].Name; name != "_" {
+		return name
+	}
+	return ""
+}
+
+func receiverTypeName(fn *ast.FuncDecl) string {
+	if fn.Recv == nil || len(fn.Recv.List) == 0 {
+		return ""
+	}
+
+	expr := fn.Recv.List[0].Type
+	if star, ok := expr.(*ast.StarExpr); ok {
+		expr = star.X
+	}
+	if ident, ok := expr.(*ast.Ident); ok {
+		return ident.Name
+	}
+	return ""
+}
+
+// returnsSelector returns whether the function returns a single `pkg.Name` or `*pkg.Name`
+func returnsSelector(fn *ast.FuncDecl, pkg, name string) bool {
+	if fn.Type.Results == nil || len(fn.Type.Results.List) != 1 {
+		return false
+	}
+
+	expr := fn.Type.Results.List[0].Type
+	if star, ok := expr.(*ast.StarExpr); ok {
+		expr = star.X
+	}
+	return isSelector(expr, pkg, name)
+}
+
+// starred returns the type being pointed to when the expression is a pointer, e.g. `Example` for `*Example`
+func starred(expr ast.Expr) ast.Expr {
+	if star, ok := expr.(*ast.StarExpr); ok {
+		return star.X
+	}
+	return expr
+}
+
+func isSelector(expr ast.Expr, pkg, name string) bool {
+	selector, ok := expr.(*ast.SelectorExpr)
+	if !ok {
+		return false
+	}
+	ident, ok := selector.X.(*ast.Ident)
+	return ok && ident.Name == pkg && selector.Sel.Name == name
+}
+
+// callName returns the name of the function or method being called, e.g. `MarkAsGone` for `metadata.MarkAsGone(id)`
+func callName(call *ast.CallExpr) string {
+	switch fun := call.Fun.(type) {
+	case *ast.Ident:
+		return fun.Name
+	case *ast.SelectorExpr:
+		return fun.Sel.Name
+	}
+	return ""
+}
+
+// callsAny returns whether the node contains a call to any function or method with one of the specified names
+func callsAny(node ast.Node, names ...string) bool {
+	if node == nil {
+		return false
+	}
+
+	found := false
+	ast.Inspect(node, func(n ast.Node) bool {
+		if found {
+			return false
+		}
+		if call, ok := n.(*ast.CallExpr); ok {
+			for _, name := range names {
+				if callName(call) == name {
+					found = true
+					return false
+				}
+			}
+		}
+		return true
+	})
+	return found
+}
+
+// stringLiteral returns the value of the expression when it's a string literal
+func stringLiteral(expr ast.Expr) (string, bool) {
+	lit, ok := expr.(*ast.BasicLit)
+	if !ok || lit.Kind != token.STRING {
+		return "", false
+	}
+	return strings.Trim(lit.Value, "`\""), true
+}
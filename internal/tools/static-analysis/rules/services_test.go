@@ -0,0 +1,94 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package rules
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"testing"
+)
+
+// parseTestPackage parses the source code as a single Service Package
+func parseTestPackage(t *testing.T, source string) []servicePackage {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "example_resource.go", source, parser.SkipObjectResolution)
+	if err != nil {
+		t.Fatalf("parsing source: %+v", err)
+	}
+
+	return []servicePackage{
+		newServicePackage("example", fset, []*ast.File{file}),
+	}
+}
+
+// expectViolations checks that the errors are Violations on the specified lines
+func expectViolations(t *testing.T, errors []error, lines ...int) {
+	if len(errors) != len(lines) {
+		t.Fatalf("expected %d violation(s) but got %d: %+v", len(lines), len(errors), errors)
+	}
+
+	for i, err := range errors {
+		violation, ok := err.(Violation)
+		if !ok {
+			t.Fatalf("expected a Violation but got %T: %+v", err, err)
+		}
+		if violation.Line != lines[i] {
+			t.Errorf("expected violation %d to be on line %d but got %d: %s", i, lines[i], violation.Line, violation.Message)
+		}
+	}
+}
+
+func TestResources(t *testing.T) {
+	source := `package example
+
+type ExampleResource struct{}
+
+func (r ExampleResource) Create() sdk.ResourceFunc { return sdk.ResourceFunc{} }
+
+func (r ExampleResource) Read() sdk.ResourceFunc { return sdk.ResourceFunc{} }
+
+type ExampleDataSource struct{}
+
+func (r ExampleDataSource) Read() sdk.ResourceFunc { return sdk.ResourceFunc{} }
+
+func resourceExample() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		Create: resourceExampleCreateUpdate,
+		Read:   resourceExampleRead,
+		Update: resourceExampleCreateUpdate,
+		Delete: resourceExampleDelete,
+	}
+}
+
+func dataSourceExample() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		Read: dataSourceExampleRead,
+	}
+}
+
+func resourceExampleCreateUpdate(d *pluginsdk.ResourceData, meta interface{}) error { return nil }
+
+func resourceExampleRead(d *pluginsdk.ResourceData, meta interface{}) error { return nil }
+
+func resourceExampleDelete(d *pluginsdk.ResourceData, meta interface{}) error { return nil }
+`
+	resources := parseTestPackage(t, source)[0].resources()
+	if len(resources) != 2 {
+		t.Fatalf("expected 2 resources but got %d", len(resources))
+	}
+
+	typed := resources[0]
+	if typed.name != "ExampleResource" || !typed.typed || typed.create == nil || typed.read == nil || typed.update != nil {
+		t.Fatalf("unexpected typed resource %+v", typed)
+	}
+
+	untyped := resources[1]
+	if untyped.name != "resourceExample" || untyped.typed || untyped.create == nil || untyped.read == nil || untyped.delete == nil {
+		t.Fatalf("unexpected untyped resource %+v", untyped)
+	}
+	if funcs := untyped.mutatingFuncs(); len(funcs) != 2 {
+		t.Fatalf("expected the shared Create/Update function to only be returned once but got %d functions", len(funcs))
+	}
+}
@@ -6,7 +6,8 @@
 function runStaticAnalysis {
 # This tool checks for code conformity within the provider e.g. are the correct Go types used in TypedSDK structs.
 # Currently will not fail GHA's etc as we have existing violations in `main`. -fail-on-error=false can be removed when
-# these are resolved to prevent PRs introducing this in future.
+# these are resolved to prevent PRs introducing this in future. Specific rules can be run using `-rules` (e.g.
+# `-rules=readMarksAsGone,checkSetErrors`) and the errors can be output as JSON using `-output=json`.
   go run internal/tools/static-analysis/main.go -fail-on-error=false
 }
 